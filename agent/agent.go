@@ -608,6 +608,9 @@ func (a *Agent) runTask(ctx context.Context, tcInput *taskContext, nt *apimodels
 		defer shutdown(ctx)
 	}
 
+	tc.resourceUsage = newResourceUsageCollector(tc.task.ID, defaultResourceUsageInterval)
+	tc.resourceUsage.start(tskCtx)
+
 	defer func() {
 		tc.logger.Execution().Error(errors.Wrap(a.uploadTraces(tskCtx, tc.taskConfig.WorkDir), "uploading traces"))
 	}()
@@ -965,6 +968,8 @@ func (a *Agent) finishTask(ctx context.Context, tc *taskContext, status string,
 
 	a.killProcs(ctx, tc, false, "task is ending")
 
+	a.sendResourceUsage(ctx, tc)
+
 	if tc.logger != nil {
 		tc.logger.Execution().Infof("Sending final task status: '%s'.", detail.Status)
 		flushCtx, cancel := context.WithTimeout(ctx, time.Minute)
@@ -987,6 +992,32 @@ func (a *Agent) finishTask(ctx context.Context, tc *taskContext, status string,
 	return resp, nil
 }
 
+// sendResourceUsage stops collecting the task's resource usage and sends the
+// summary to the API server. It warns in the task logs if the task came close
+// to using all of the host's memory.
+func (a *Agent) sendResourceUsage(ctx context.Context, tc *taskContext) {
+	if tc.resourceUsage == nil {
+		return
+	}
+	usage := tc.resourceUsage.stop()
+	tc.resourceUsage = nil
+	if usage == nil {
+		return
+	}
+
+	if usage.ExceededMemory() && tc.logger != nil {
+		var distroID string
+		if tc.taskConfig != nil {
+			distroID = tc.taskConfig.Task.DistroId
+		}
+		tc.logger.Task().Warningf("Task used %d of %d bytes of memory on distro '%s' at its peak. Consider running it on a distro with more memory.", usage.MaxMemoryBytes, usage.TotalMemoryBytes, distroID)
+	}
+
+	if err := a.comm.SetResourceUsage(ctx, tc.task, usage); err != nil && tc.logger != nil {
+		tc.logger.Execution().Error(errors.Wrap(err, "sending task resource usage"))
+	}
+}
+
 func (a *Agent) endTaskResponse(ctx context.Context, tc *taskContext, status string, systemFailureDescription string) *apimodels.TaskEndDetail {
 	highestPriorityDescription := systemFailureDescription
 	var userDefinedFailureType string
@@ -167,6 +167,11 @@ func (a *Agent) runCommandOrFunc(ctx context.Context, tc *taskContext, commandIn
 
 	var err error
 	var logger client.LoggerProducer
+	if commandInfo.Function != "" {
+		if fn := tc.taskConfig.Project.Functions[commandInfo.Function]; fn != nil {
+			commandInfo.Vars = fn.ResolveVars(commandInfo.Vars)
+		}
+	}
 	// if there is a command-specific logger, make it here otherwise use the task-level logger
 	if commandInfo.Loggers == nil {
 		logger = tc.logger
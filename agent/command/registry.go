@@ -22,6 +22,7 @@ func init() {
 		"archive.zip_pack":                      zipArchiveCreateFactory,
 		"archive.zip_extract":                   zipExtractFactory,
 		"archive.auto_extract":                  autoExtractFactory,
+		"cucumber.parse_json":                   cucumberResultsFactory,
 		evergreen.AttachResultsCommandName:      attachResultsFactory,
 		evergreen.AttachXUnitResultsCommandName: xunitResultsFactory,
 		evergreen.AttachArtifactsCommandName:    attachArtifactsFactory,
@@ -40,6 +41,7 @@ func init() {
 		"mac.sign":                              macSignFactory,
 		"manifest.load":                         manifestLoadFactory,
 		"perf.send":                             perfSendFactory,
+		"pytest.parse_json":                     pytestResultsFactory,
 		"downstream_expansions.set":             setExpansionsFactory,
 		"s3.get":                                s3GetFactory,
 		"s3.put":                                s3PutFactory,
@@ -49,7 +51,9 @@ func init() {
 		evergreen.ShellExecCommandName:          shellExecFactory,
 		"subprocess.exec":                       subprocessExecFactory,
 		"setup.initial":                         initialSetupFactory,
+		"tap.parse_files":                       tapResultsFactory,
 		"timeout.update":                        timeoutUpdateFactory,
+		"trx.parse_files":                       trxResultsFactory,
 	}
 
 	for name, factory := range cmds {
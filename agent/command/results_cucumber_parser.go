@@ -0,0 +1,140 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	cucumberStatusPassed = "passed"
+	cucumberStatusFailed = "failed"
+
+	cucumberBackgroundType = "background"
+)
+
+// cucumberFeature is a feature in the Cucumber JSON report format.
+type cucumberFeature struct {
+	URI      string            `json:"uri"`
+	Name     string            `json:"name"`
+	Elements []cucumberElement `json:"elements"`
+}
+
+// cucumberElement is a scenario or background of a feature.
+type cucumberElement struct {
+	Name    string         `json:"name"`
+	Keyword string         `json:"keyword"`
+	Type    string         `json:"type"`
+	Steps   []cucumberStep `json:"steps"`
+}
+
+type cucumberStep struct {
+	Keyword string             `json:"keyword"`
+	Name    string             `json:"name"`
+	Result  cucumberStepResult `json:"result"`
+	Output  []string           `json:"output"`
+}
+
+type cucumberStepResult struct {
+	Status string `json:"status"`
+	// Duration is in nanoseconds.
+	Duration     int64  `json:"duration"`
+	ErrorMessage string `json:"error_message"`
+}
+
+func parseCucumberJSON(r io.Reader) ([]cucumberFeature, error) {
+	var features []cucumberFeature
+	if err := json.NewDecoder(r).Decode(&features); err != nil {
+		return nil, errors.Wrap(err, "decoding Cucumber JSON report")
+	}
+
+	return features, nil
+}
+
+// parseCucumberResults parses a Cucumber JSON report into test results, one
+// per scenario. Each scenario's steps are written to its own test log, and
+// failed scenarios point to the log line of the step that failed. Background
+// steps are considered part of the scenario that follows them.
+func parseCucumberResults(conf *internal.TaskConfig, _ client.LoggerProducer, r io.Reader, _ string) ([]testresult.TestResult, []model.TestLog, error) {
+	features, err := parseCucumberJSON(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		results []testresult.TestResult
+		logs    []model.TestLog
+	)
+	for _, feature := range features {
+		var background []cucumberStep
+		for _, element := range feature.Elements {
+			if element.Type == cucumberBackgroundType {
+				background = append(background, element.Steps...)
+				continue
+			}
+
+			steps := append(append([]cucumberStep{}, background...), element.Steps...)
+			background = nil
+
+			res, log := element.toModelTestResultAndLog(conf, feature.Name, steps)
+			results = append(results, res)
+			logs = append(logs, log)
+		}
+	}
+	if len(results) == 0 {
+		return nil, nil, errors.New("no results found")
+	}
+
+	return results, logs, nil
+}
+
+func (e cucumberElement) toModelTestResultAndLog(conf *internal.TaskConfig, featureName string, steps []cucumberStep) (testresult.TestResult, model.TestLog) {
+	res := testresult.TestResult{
+		TestName: util.CleanForPath(fmt.Sprintf("%s.%s", featureName, e.Name)),
+		Status:   evergreen.TestSucceededStatus,
+	}
+
+	lines := []string{fmt.Sprintf("%s: %s", strings.TrimSpace(e.Keyword), e.Name)}
+	var duration time.Duration
+	for _, step := range steps {
+		duration += time.Duration(step.Result.Duration)
+
+		switch step.Result.Status {
+		case cucumberStatusPassed:
+		case cucumberStatusFailed:
+			if res.Status != evergreen.TestFailedStatus {
+				res.Status = evergreen.TestFailedStatus
+				res.LineNum = len(lines)
+			}
+		default:
+			// Skipped, pending, and undefined steps mean that the
+			// scenario did not run to completion.
+			if res.Status == evergreen.TestSucceededStatus {
+				res.Status = evergreen.TestSkippedStatus
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s ... %s", step.Keyword, step.Name, step.Result.Status))
+		lines = append(lines, splitLogLines(step.Result.ErrorMessage)...)
+		for _, output := range step.Output {
+			lines = append(lines, splitLogLines(output)...)
+		}
+	}
+
+	res.TestStartTime = time.Now()
+	res.TestEndTime = res.TestStartTime.Add(duration)
+
+	log := newResultsTestLog(conf, &res, lines)
+
+	return res, log
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCucumberResults(t *testing.T) {
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task", Execution: 1}}
+
+	t.Run("ConvertsScenarios", func(t *testing.T) {
+		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "cucumber", "report.json"))
+		require.NoError(t, err)
+		defer file.Close()
+
+		results, logs, err := parseCucumberResults(conf, nil, file, "report")
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.Len(t, logs, 3)
+
+		for i, log := range logs {
+			assert.Equal(t, conf.Task.Id, log.Task)
+			assert.Equal(t, conf.Task.Execution, log.TaskExecution)
+			assert.Equal(t, log.Name, results[i].LogTestName)
+		}
+
+		assert.Equal(t, "Login.Successful_login", results[0].TestName)
+		assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+		assert.Equal(t, 6*time.Millisecond, results[0].Duration())
+		assert.Zero(t, results[0].LineNum)
+		assert.Equal(t, []string{
+			"Scenario: Successful login",
+			"Given the login page is open ... passed",
+			"When I log in as \"admin\" ... passed",
+			"Then I see the dashboard ... passed",
+			"dashboard loaded",
+		}, logs[0].Lines)
+
+		assert.Equal(t, "Login.Wrong_password", results[1].TestName)
+		assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+		assert.Equal(t, 5*time.Millisecond, results[1].Duration())
+		assert.Equal(t, 2, results[1].LineNum)
+		assert.Equal(t, "When I log in with a wrong password ... failed", logs[1].Lines[results[1].LineNum])
+		assert.Equal(t, "expected error message", logs[1].Lines[results[1].LineNum+1])
+
+		assert.Equal(t, "Logout.Logout", results[2].TestName)
+		assert.Equal(t, evergreen.TestSkippedStatus, results[2].Status)
+	})
+	t.Run("NoScenarios", func(t *testing.T) {
+		_, _, err := parseCucumberResults(conf, nil, strings.NewReader("[]"), "report")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidJSON", func(t *testing.T) {
+		_, _, err := parseCucumberResults(conf, nil, strings.NewReader("{"), "report")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,227 @@
+package command
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+)
+
+// resultsFileParser parses the contents of a single test results file into
+// test results and the test logs that they reference. The suite name is the
+// file's base name without its extension.
+type resultsFileParser func(conf *internal.TaskConfig, logger client.LoggerProducer, r io.Reader, suiteName string) ([]testresult.TestResult, []model.TestLog, error)
+
+// parseResultsFiles is a command that parses files containing test results in
+// a particular format and sends the results and their test logs to the
+// server. It backs the commands for the test result formats that do not need
+// any format-specific parameters.
+type parseResultsFiles struct {
+	// Files is a list of file patterns to parse, relative to the task's
+	// working directory (e.g. "results/*.tap").
+	Files []string `mapstructure:"files" plugin:"expand"`
+
+	// OptionalOutput, when set to true, causes this command to be skipped
+	// over without an error when no files are found to be parsed.
+	OptionalOutput   string `mapstructure:"optional_output" plugin:"expand"`
+	outputIsOptional bool
+
+	name   string
+	parser resultsFileParser
+	base
+}
+
+func tapResultsFactory() Command {
+	return &parseResultsFiles{name: "tap.parse_files", parser: parseTAPResults}
+}
+
+func cucumberResultsFactory() Command {
+	return &parseResultsFiles{name: "cucumber.parse_json", parser: parseCucumberResults}
+}
+
+func trxResultsFactory() Command {
+	return &parseResultsFiles{name: "trx.parse_files", parser: parseTRXResults}
+}
+
+func pytestResultsFactory() Command {
+	return &parseResultsFiles{name: "pytest.parse_json", parser: parsePytestResults}
+}
+
+func (c *parseResultsFiles) Name() string { return c.name }
+
+// ParseParams reads the specified map of parameters into the command and
+// validates that at least one file pattern is specified.
+func (c *parseResultsFiles) ParseParams(params map[string]interface{}) error {
+	var err error
+	if err = mapstructure.Decode(params, c); err != nil {
+		return errors.Wrap(err, "decoding mapstructure params")
+	}
+
+	if c.OptionalOutput != "" {
+		c.outputIsOptional, err = strconv.ParseBool(c.OptionalOutput)
+		if err != nil {
+			return errors.Wrap(err, "parsing optional output parameter as a boolean")
+		}
+	}
+
+	if len(c.Files) == 0 {
+		return errors.New("must specify at least one file pattern to parse")
+	}
+
+	return nil
+}
+
+// Execute parses the specified results files and sends the test results and
+// test logs found in them to the server.
+func (c *parseResultsFiles) Execute(ctx context.Context,
+	comm client.Communicator, logger client.LoggerProducer, conf *internal.TaskConfig) error {
+
+	if err := util.ExpandValues(c, &conf.Expansions); err != nil {
+		return errors.Wrap(err, "applying expansions")
+	}
+
+	// All file patterns should be relative to the task's working directory.
+	for i, file := range c.Files {
+		c.Files[i] = getWorkingDirectory(conf, file)
+	}
+
+	resultsFiles, err := globFiles(c.Files...)
+	if err != nil {
+		return errors.Wrap(err, "obtaining names of results files")
+	}
+	if len(resultsFiles) == 0 {
+		if c.outputIsOptional {
+			return nil
+		}
+		return errors.New("no files found to be parsed")
+	}
+
+	results, logs, err := c.parseFiles(ctx, conf, logger, resultsFiles)
+	if err != nil {
+		return errors.Wrap(err, "parsing results files")
+	}
+
+	logger.Task().Info("Posting test logs...")
+	for i := range logs {
+		if err := ctx.Err(); err != nil {
+			return errors.Wrap(err, "canceled while sending test logs")
+		}
+
+		if err := sendTestLog(ctx, comm, conf, &logs[i]); err != nil {
+			// Continue on error to let the other logs be posted.
+			logger.Task().Error(errors.Wrap(err, "sending test log"))
+		}
+	}
+	logger.Task().Info("Finished posting test logs.")
+
+	return errors.Wrap(sendTestResults(ctx, comm, logger, conf, results), "sending test results")
+}
+
+// parseFiles parses all of the given results files and returns the test
+// results and test logs found within.
+func (c *parseResultsFiles) parseFiles(ctx context.Context, conf *internal.TaskConfig,
+	logger client.LoggerProducer, resultsFiles []string) ([]testresult.TestResult, []model.TestLog, error) {
+
+	var (
+		results []testresult.TestResult
+		logs    []model.TestLog
+	)
+	for _, resultsFile := range resultsFiles {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, errors.Wrap(err, "canceled while parsing results files")
+		}
+
+		fileResults, fileLogs, err := c.parseFile(conf, logger, resultsFile)
+		if err != nil {
+			// Don't bomb out on a single bad file.
+			logger.Task().Error(errors.Wrapf(err, "parsing file '%s'", resultsFile))
+			continue
+		}
+
+		results = append(results, fileResults...)
+		logs = append(logs, fileLogs...)
+	}
+
+	if len(results) == 0 {
+		return nil, nil, errors.New("results files contained no results")
+	}
+
+	return results, logs, nil
+}
+
+func (c *parseResultsFiles) parseFile(conf *internal.TaskConfig, logger client.LoggerProducer, resultsFile string) ([]testresult.TestResult, []model.TestLog, error) {
+	f, err := os.Open(resultsFile)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "opening file")
+	}
+	defer f.Close()
+
+	return c.parser(conf, logger, f, getResultsSuiteName(conf, resultsFile))
+}
+
+// getResultsSuiteName returns the suite name of the given results file, which
+// is the file's path relative to the task's working directory without its
+// extension, with path separators replaced by underscores. Using the relative
+// path rather than the base name keeps files with the same name in different
+// directories from sharing a suite name. Files outside of the working
+// directory fall back to their base name.
+func getResultsSuiteName(conf *internal.TaskConfig, resultsFile string) string {
+	suiteName, err := filepath.Rel(conf.WorkDir, resultsFile)
+	if err != nil || strings.HasPrefix(suiteName, "..") {
+		suiteName = filepath.Base(resultsFile)
+	}
+	suiteName = strings.TrimSuffix(suiteName, filepath.Ext(suiteName))
+
+	return strings.ReplaceAll(filepath.ToSlash(suiteName), "/", "_")
+}
+
+// newResultsTestLog returns a test log for a single test result with the
+// given lines. Since there may be duplicate test names, the log is given a
+// unique name.
+func newResultsTestLog(conf *internal.TaskConfig, res *testresult.TestResult, lines []string) model.TestLog {
+	log := model.TestLog{
+		Name:          utility.RandomString(),
+		Task:          conf.Task.Id,
+		TaskExecution: conf.Task.Execution,
+		Lines:         lines,
+	}
+	res.LogTestName = log.Name
+
+	return log
+}
+
+// splitLogLines splits the text into log lines, ignoring leading and trailing
+// blank lines.
+func splitLogLines(text string) []string {
+	text = strings.Trim(text, "\r\n")
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// constructSystemLogLines is like constructSystemLogs, but splits the output
+// into individual log lines.
+func constructSystemLogLines(sysOut, sysErr string) []string {
+	var lines []string
+	if sysOutLines := splitLogLines(sysOut); len(sysOutLines) > 0 {
+		lines = append(append(lines, systemOut), sysOutLines...)
+	}
+	if sysErrLines := splitLogLines(sysErr); len(sysErrLines) > 0 {
+		lines = append(append(lines, systemErr), sysErrLines...)
+	}
+
+	return lines
+}
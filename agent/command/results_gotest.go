@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"os"
@@ -123,16 +124,27 @@ func globFiles(patterns ...string) ([]string, error) {
 	return matchedFiles, nil
 }
 
-// parseTestOutput parses the test results and logs from a single output source.
-func parseTestOutput(ctx context.Context, conf *internal.TaskConfig, report io.Reader, suiteName string) (model.TestLog, []testresult.TestResult, error) {
+// parseTestOutput parses the test results and logs from a single output
+// source. Text output from `go test -v` is written to a single test log,
+// whereas the output of each test in `go test -json` output is written to its
+// own test log. The test results correspond to the log at the same index.
+func parseTestOutput(ctx context.Context, conf *internal.TaskConfig, report io.Reader, suiteName string) ([]model.TestLog, [][]testresult.TestResult, error) {
+	output, err := io.ReadAll(report)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "reading file")
+	}
+	if isGoTestJSON(output) {
+		return parseTestJSONOutput(conf, bytes.NewReader(output))
+	}
+
 	// parse the output logs
 	parser := &goTestParser{}
-	if err := parser.Parse(report); err != nil {
-		return model.TestLog{}, nil, errors.Wrap(err, "parsing file")
+	if err := parser.Parse(bytes.NewReader(output)); err != nil {
+		return nil, nil, errors.Wrap(err, "parsing file")
 	}
 
 	if len(parser.order) == 0 && len(parser.logs) == 0 {
-		return model.TestLog{}, nil, errors.New("no results found")
+		return nil, nil, errors.New("no results found")
 	}
 
 	// build up the test logs
@@ -144,7 +156,7 @@ func parseTestOutput(ctx context.Context, conf *internal.TaskConfig, report io.R
 		Lines:         logLines,
 	}
 
-	return testLog, ToModelTestResults(parser.Results(), suiteName), nil
+	return []model.TestLog{testLog}, [][]testresult.TestResult{ToModelTestResults(parser.Results(), suiteName)}, nil
 }
 
 // parseTestOutputFiles parses all of the files that are passed in, and returns
@@ -173,7 +185,7 @@ func parseTestOutputFiles(ctx context.Context, logger client.LoggerProducer,
 		}
 		defer fileReader.Close() //nolint: evg-lint
 
-		log, result, err := parseTestOutput(ctx, conf, fileReader, suiteName)
+		fileLogs, fileResults, err := parseTestOutput(ctx, conf, fileReader, suiteName)
 		if err != nil {
 			// continue on error
 			logger.Task().Error(errors.Wrapf(err, "parsing file '%s'", outputFile))
@@ -181,8 +193,8 @@ func parseTestOutputFiles(ctx context.Context, logger client.LoggerProducer,
 		}
 
 		// save the results
-		results = append(results, result)
-		logs = append(logs, log)
+		results = append(results, fileResults...)
+		logs = append(logs, fileLogs...)
 	}
 
 	if len(results) == 0 && len(logs) == 0 {
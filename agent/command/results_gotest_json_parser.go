@@ -0,0 +1,342 @@
+package command
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+)
+
+// Actions of the events emitted by `go test -json`. See `go doc test2json`
+// for details.
+const (
+	goTestActionRun    = "run"
+	goTestActionPass   = "pass"
+	goTestActionFail   = "fail"
+	goTestActionSkip   = "skip"
+	goTestActionBench  = "bench"
+	goTestActionOutput = "output"
+)
+
+// goTestEvent is a single event emitted by `go test -json` or test2json.
+type goTestEvent struct {
+	Time    time.Time `json:"Time"`
+	Action  string    `json:"Action"`
+	Package string    `json:"Package"`
+	Test    string    `json:"Test"`
+	// Elapsed is in seconds.
+	Elapsed float64 `json:"Elapsed"`
+	Output  string  `json:"Output"`
+}
+
+// goTestJSONResult is a test, or a package's test binary, parsed from a
+// stream of test2json events.
+type goTestJSONResult struct {
+	Name    string
+	Package string
+	// Parent is the test that ran this test as a subtest, if any.
+	Parent *goTestJSONResult
+	// Status is one of PASS, FAIL, or SKIP.
+	Status    string
+	StartTime time.Time
+	RunTime   time.Duration
+	// Lines are the output lines of the test, including the output of its
+	// subtests.
+	Lines []string
+	// SkipReason is the last line of output logged by the test itself,
+	// which is the reason that it was skipped if it was skipped.
+	// SkipReasonLine is its index in Lines.
+	SkipReason     string
+	SkipReasonLine int
+
+	partialLine string
+}
+
+// goTestJSONParser parses the stream of events emitted by `go test -json` or
+// test2json. Unlike the text output of `go test -v`, the events attribute each
+// line of output to the test that wrote it, so the output of parallel tests
+// and subtests is not interleaved. Lines that are not test2json events, such
+// as compiler output written to standard error, are ignored.
+type goTestJSONParser struct {
+	// tests holds the most recent run of each test by package and test
+	// name.
+	tests    map[string]map[string]*goTestJSONResult
+	packages map[string]*goTestJSONResult
+	order    []*goTestJSONResult
+	pkgOrder []*goTestJSONResult
+}
+
+// isGoTestJSON returns whether the test output is a stream of test2json
+// events. Since the events may be preceded by output that the go command
+// wrote to standard error, such as build errors, the output is considered
+// test2json events if an event appears before any text test output does.
+func isGoTestJSON(output []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) > 0 && line[0] == '{' {
+			var event goTestEvent
+			if err := json.Unmarshal(line, &event); err == nil && event.Action != "" {
+				return true
+			}
+		}
+		if startRegex.Match(line) || gocheckStartRegex.Match(line) {
+			return false
+		}
+	}
+
+	return false
+}
+
+// Parse reads in the test2json events and stores the results and their
+// output.
+func (p *goTestJSONParser) Parse(testOutput io.Reader) error {
+	p.tests = map[string]map[string]*goTestJSONResult{}
+	p.packages = map[string]*goTestJSONResult{}
+
+	scanner := bufio.NewScanner(testOutput)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+
+		var event goTestEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			continue
+		}
+		p.handleEvent(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "reading test output")
+	}
+
+	for _, res := range append(p.order, p.pkgOrder...) {
+		if res.partialLine != "" {
+			res.appendLine(res.partialLine)
+			res.partialLine = ""
+		}
+	}
+
+	return nil
+}
+
+func (p *goTestJSONParser) handleEvent(event goTestEvent) {
+	if event.Test == "" {
+		p.handlePackageEvent(event)
+		return
+	}
+
+	if event.Action == goTestActionRun {
+		p.startTest(event)
+		return
+	}
+
+	res := p.tests[event.Package][event.Test]
+	if res == nil {
+		// The run event may be missing if the stream was truncated,
+		// so stub out the test.
+		res = p.startTest(event)
+	}
+
+	switch event.Action {
+	case goTestActionOutput:
+		for _, line := range res.appendOutput(event.Output) {
+			res.appendLine(line)
+			if !isGoTestFramingLine(line) && strings.TrimSpace(line) != "" {
+				res.SkipReason = strings.TrimSpace(line)
+				res.SkipReasonLine = len(res.Lines) - 1
+			}
+		}
+	case goTestActionPass, goTestActionBench:
+		res.finish(PASS, event.Elapsed)
+	case goTestActionFail:
+		res.finish(FAIL, event.Elapsed)
+	case goTestActionSkip:
+		res.finish(SKIP, event.Elapsed)
+	}
+}
+
+// startTest records a new run of a test. Tests start out failed unless they
+// are marked passing or skipped, so that tests which never finish, such as
+// those that panic or time out, are failures.
+func (p *goTestJSONParser) startTest(event goTestEvent) *goTestJSONResult {
+	if p.tests[event.Package] == nil {
+		p.tests[event.Package] = map[string]*goTestJSONResult{}
+	}
+
+	res := &goTestJSONResult{
+		Name:      event.Test,
+		Package:   event.Package,
+		Status:    FAIL,
+		StartTime: event.Time,
+	}
+	if idx := strings.LastIndex(event.Test, "/"); idx > 0 {
+		res.Parent = p.tests[event.Package][event.Test[:idx]]
+	}
+	p.tests[event.Package][event.Test] = res
+	p.order = append(p.order, res)
+
+	return res
+}
+
+func (p *goTestJSONParser) handlePackageEvent(event goTestEvent) {
+	pkg := p.packages[event.Package]
+	if pkg == nil {
+		pkg = &goTestJSONResult{
+			Name:      event.Package,
+			Package:   event.Package,
+			StartTime: event.Time,
+		}
+		p.packages[event.Package] = pkg
+		p.pkgOrder = append(p.pkgOrder, pkg)
+	}
+
+	switch event.Action {
+	case goTestActionOutput:
+		for _, line := range pkg.appendOutput(event.Output) {
+			pkg.appendLine(line)
+		}
+	case goTestActionPass:
+		pkg.finish(PASS, event.Elapsed)
+	case goTestActionFail:
+		pkg.finish(FAIL, event.Elapsed)
+	case goTestActionSkip:
+		pkg.finish(SKIP, event.Elapsed)
+	}
+}
+
+// appendOutput adds the output to the test's partial line, if any, and
+// returns the complete lines. Output is usually one line per event, but long
+// lines and output without a trailing newline can be split across events.
+func (r *goTestJSONResult) appendOutput(output string) []string {
+	output = r.partialLine + output
+	lines := strings.Split(output, "\n")
+	r.partialLine = lines[len(lines)-1]
+
+	return lines[:len(lines)-1]
+}
+
+// appendLine appends the line to the output of the test and of the tests
+// that it is a subtest of.
+func (r *goTestJSONResult) appendLine(line string) {
+	for res := r; res != nil; res = res.Parent {
+		res.Lines = append(res.Lines, line)
+	}
+}
+
+func (r *goTestJSONResult) finish(status string, elapsed float64) {
+	r.Status = status
+	r.RunTime = time.Duration(elapsed * float64(time.Second))
+}
+
+// isGoTestFramingLine returns whether the line of test output is written by
+// the testing package to mark a test's progress rather than by the test
+// itself.
+func isGoTestFramingLine(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"=== RUN", "=== PAUSE", "=== CONT", "=== NAME", "--- PASS", "--- FAIL", "--- SKIP"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Results returns the results of the tests, followed by a result for each
+// package whose test binary failed without any of its tests failing, such
+// as when it fails to build or panics outside of a test.
+func (p *goTestJSONParser) Results() []*goTestJSONResult {
+	results := append([]*goTestJSONResult{}, p.order...)
+
+	failedPackages := map[string]bool{}
+	for _, res := range p.order {
+		if res.Status == FAIL {
+			failedPackages[res.Package] = true
+		}
+	}
+	for _, pkg := range p.pkgOrder {
+		if pkg.Status == FAIL && !failedPackages[pkg.Package] {
+			results = append(results, pkg)
+		}
+	}
+
+	return results
+}
+
+// parseTestJSONOutput parses test2json events into test results. The output
+// of each test is written to its own test log, so each result corresponds to
+// the log at the same index.
+func parseTestJSONOutput(conf *internal.TaskConfig, report io.Reader) ([]model.TestLog, [][]testresult.TestResult, error) {
+	parser := &goTestJSONParser{}
+	if err := parser.Parse(report); err != nil {
+		return nil, nil, errors.Wrap(err, "parsing test2json output")
+	}
+
+	parsed := parser.Results()
+	if len(parsed) == 0 {
+		return nil, nil, errors.New("no results found")
+	}
+
+	logs := make([]model.TestLog, 0, len(parsed))
+	results := make([][]testresult.TestResult, 0, len(parsed))
+	for _, res := range parsed {
+		modelResult := res.toModelTestResult()
+		logs = append(logs, newResultsTestLog(conf, &modelResult, res.Lines))
+		results = append(results, []testresult.TestResult{modelResult})
+	}
+
+	return logs, results, nil
+}
+
+// toModelTestResult converts the parsed result into a test result. Since the
+// events of multiple packages can share a stream, test names are qualified by
+// their package so that tests with the same name in different packages are
+// reported separately.
+func (r *goTestJSONResult) toModelTestResult() testresult.TestResult {
+	start := r.StartTime
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	res := testresult.TestResult{
+		TestName:      r.qualifiedName(),
+		TestStartTime: start,
+		TestEndTime:   start.Add(r.RunTime),
+	}
+	switch r.Status {
+	case PASS:
+		res.Status = evergreen.TestSucceededStatus
+	case SKIP:
+		res.Status = evergreen.TestSkippedStatus
+		// Point to the reason that the test was skipped.
+		res.LineNum = r.SkipReasonLine
+	default:
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	return res
+}
+
+// qualifiedName returns the name of the test prefixed by its package, e.g.
+// "example.com/pkg.TestName". Results for a package's test binary are
+// already named after the package.
+func (r *goTestJSONResult) qualifiedName() string {
+	if r.Package == "" || r.Name == r.Package {
+		return r.Name
+	}
+
+	return fmt.Sprintf("%s.%s", r.Package, r.Name)
+}
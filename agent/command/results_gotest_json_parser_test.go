@@ -0,0 +1,202 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsGoTestJSON(t *testing.T) {
+	cwd := testutil.GetDirectoryOfFile()
+	for file, expected := range map[string]bool{
+		filepath.Join("testdata", "test2json.json"):                    true,
+		filepath.Join("testdata", "gotest", "test2json_subtests.json"): true,
+		filepath.Join("testdata", "gotest", "1_simple.log"):            false,
+		filepath.Join("testdata", "gotest", "2_simple.log"):            false,
+		filepath.Join("testdata", "gotest", "4_simple.log"):            false,
+	} {
+		output, err := os.ReadFile(filepath.Join(cwd, file))
+		require.NoError(t, err)
+		assert.Equal(t, expected, isGoTestJSON(output), file)
+	}
+	assert.False(t, isGoTestJSON(nil))
+	assert.False(t, isGoTestJSON([]byte("=== RUN   TestFoo\n{\"Action\":\"run\"}\n")))
+}
+
+func TestGoTestJSONParser(t *testing.T) {
+	cwd := testutil.GetDirectoryOfFile()
+
+	t.Run("SubtestsAndParallelTests", func(t *testing.T) {
+		output, err := os.ReadFile(filepath.Join(cwd, "testdata", "gotest", "test2json_subtests.json"))
+		require.NoError(t, err)
+
+		parser := &goTestJSONParser{}
+		require.NoError(t, parser.Parse(bytes.NewReader(output)))
+		results := parser.Results()
+		require.Len(t, results, 7)
+
+		parent := results[0]
+		assert.Equal(t, "TestParent", parent.Name)
+		assert.Equal(t, "example.com/pkga", parent.Package)
+		assert.Nil(t, parent.Parent)
+		assert.Equal(t, PASS, parent.Status)
+		assert.Equal(t, 1500*time.Millisecond, parent.RunTime)
+		assert.Equal(t, time.Date(2023, 5, 1, 14, 0, 2, 0, time.UTC), parent.StartTime.UTC())
+		assert.Equal(t, []string{
+			"=== RUN   TestParent",
+			"=== RUN   TestParent/sub_pass",
+			"    parent_test.go:12: checking the value",
+			"=== RUN   TestParent/sub_skip",
+			"    parent_test.go:20: not supported on linux",
+			"--- SKIP: TestParent/sub_skip (0.00s)",
+			"--- PASS: TestParent (1.50s)",
+			"    --- PASS: TestParent/sub_pass (1.25s)",
+		}, parent.Lines)
+
+		subPass := results[1]
+		assert.Equal(t, "TestParent/sub_pass", subPass.Name)
+		assert.Equal(t, parent, subPass.Parent)
+		assert.Equal(t, PASS, subPass.Status)
+		assert.Equal(t, 1250*time.Millisecond, subPass.RunTime)
+		assert.Equal(t, []string{
+			"=== RUN   TestParent/sub_pass",
+			"    parent_test.go:12: checking the value",
+			"    --- PASS: TestParent/sub_pass (1.25s)",
+		}, subPass.Lines)
+
+		subSkip := results[2]
+		assert.Equal(t, "TestParent/sub_skip", subSkip.Name)
+		assert.Equal(t, parent, subSkip.Parent)
+		assert.Equal(t, SKIP, subSkip.Status)
+		assert.Equal(t, "parent_test.go:20: not supported on linux", subSkip.SkipReason)
+		assert.Equal(t, 1, subSkip.SkipReasonLine)
+
+		parallelA := results[3]
+		assert.Equal(t, "TestParallelA", parallelA.Name)
+		assert.Equal(t, FAIL, parallelA.Status)
+		assert.Equal(t, 750*time.Millisecond, parallelA.RunTime)
+		assert.Equal(t, []string{
+			"=== RUN   TestParallelA",
+			"=== PAUSE TestParallelA",
+			"=== CONT  TestParallelA",
+			"    parallel_test.go:20: a is running",
+			"    parallel_test.go:21: expected 1, got 2",
+			"--- FAIL: TestParallelA (0.75s)",
+		}, parallelA.Lines)
+
+		parallelB := results[4]
+		assert.Equal(t, "TestParallelB", parallelB.Name)
+		assert.Equal(t, PASS, parallelB.Status)
+		assert.Len(t, parallelB.Lines, 5)
+
+		// Tests that never finish, such as those that panic, are
+		// failures.
+		panics := results[5]
+		assert.Equal(t, "TestPanics", panics.Name)
+		assert.Equal(t, FAIL, panics.Status)
+		assert.Len(t, panics.Lines, 3)
+
+		// Packages that fail without any failed tests get their own
+		// result.
+		buildFailed := results[6]
+		assert.Equal(t, "example.com/pkgb", buildFailed.Name)
+		assert.Equal(t, FAIL, buildFailed.Status)
+		assert.Equal(t, []string{"FAIL\texample.com/pkgb [build failed]"}, buildFailed.Lines)
+	})
+	t.Run("TopLevelTests", func(t *testing.T) {
+		output, err := os.ReadFile(filepath.Join(cwd, "testdata", "test2json.json"))
+		require.NoError(t, err)
+
+		parser := &goTestJSONParser{}
+		require.NoError(t, parser.Parse(bytes.NewReader(output)))
+		results := parser.Results()
+
+		statuses := map[string]string{}
+		for _, res := range results {
+			statuses[res.Name] = res.Status
+		}
+		assert.Equal(t, map[string]string{
+			"TestTestifyPass":                 PASS,
+			"TestTestifyFail":                 FAIL,
+			"TestConveyPass":                  PASS,
+			"TestConveyFail":                  FAIL,
+			"TestNativeTestPass":              PASS,
+			"TestNativeTestFail":              FAIL,
+			"TestPassingButInAnotherFile":     PASS,
+			"TestFailingButInAnotherFile":     FAIL,
+			"TestTestifySuite":                PASS,
+			"TestTestifySuite/TestThings":     PASS,
+			"TestTestifySuiteFail":            FAIL,
+			"TestTestifySuiteFail/TestThings": FAIL,
+			"TestSkippedTestFail":             SKIP,
+		}, statuses)
+
+		for _, res := range results {
+			if res.Name == "TestSkippedTestFail" {
+				assert.Equal(t, "awesome_test.go:77: skipping because reasons", res.SkipReason)
+				assert.Equal(t, "\tawesome_test.go:77: skipping because reasons", res.Lines[res.SkipReasonLine])
+			}
+		}
+	})
+}
+
+func TestParseTestJSONOutput(t *testing.T) {
+	output, err := os.ReadFile(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "gotest", "test2json_subtests.json"))
+	require.NoError(t, err)
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task", Execution: 1}}
+
+	logs, results, err := parseTestOutput(context.Background(), conf, bytes.NewReader(output), "test2json_subtests")
+	require.NoError(t, err)
+	require.Len(t, logs, 7)
+	require.Len(t, results, 7)
+
+	for i, log := range logs {
+		require.Len(t, results[i], 1)
+		assert.Equal(t, log.Name, results[i][0].LogTestName)
+		assert.Equal(t, conf.Task.Id, log.Task)
+		assert.Equal(t, conf.Task.Execution, log.TaskExecution)
+	}
+
+	assert.Equal(t, "example.com/pkga.TestParent", results[0][0].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[0][0].Status)
+	assert.Equal(t, 1500*time.Millisecond, results[0][0].Duration())
+	assert.Len(t, logs[0].Lines, 8)
+
+	assert.Equal(t, "example.com/pkga.TestParent/sub_skip", results[2][0].TestName)
+	assert.Equal(t, evergreen.TestSkippedStatus, results[2][0].Status)
+	assert.Equal(t, "    parent_test.go:20: not supported on linux", logs[2].Lines[results[2][0].LineNum])
+
+	assert.Equal(t, evergreen.TestFailedStatus, results[3][0].Status)
+	assert.Equal(t, evergreen.TestFailedStatus, results[5][0].Status)
+	assert.Equal(t, "example.com/pkgb", results[6][0].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[6][0].Status)
+}
+
+func TestParseTestJSONOutputQualifiesTestNamesByPackage(t *testing.T) {
+	output := strings.Join([]string{
+		`{"Action":"run","Package":"example.com/pkga","Test":"TestShared"}`,
+		`{"Action":"fail","Package":"example.com/pkga","Test":"TestShared","Elapsed":0.1}`,
+		`{"Action":"run","Package":"example.com/pkgb","Test":"TestShared"}`,
+		`{"Action":"pass","Package":"example.com/pkgb","Test":"TestShared","Elapsed":0.1}`,
+	}, "\n")
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task"}}
+
+	_, results, err := parseTestJSONOutput(conf, strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "example.com/pkga.TestShared", results[0][0].TestName)
+	assert.Equal(t, evergreen.TestFailedStatus, results[0][0].Status)
+	assert.Equal(t, "example.com/pkgb.TestShared", results[1][0].TestName)
+	assert.Equal(t, evergreen.TestSucceededStatus, results[1][0].Status)
+}
@@ -0,0 +1,141 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	pytestOutcomePassed  = "passed"
+	pytestOutcomeXPassed = "xpassed"
+	pytestOutcomeSkipped = "skipped"
+	pytestOutcomeXFailed = "xfailed"
+)
+
+// pytestReport is a report produced by the pytest-json-report plugin (i.e.
+// `pytest --json-report`).
+type pytestReport struct {
+	Tests []pytestTest `json:"tests"`
+}
+
+type pytestTest struct {
+	NodeID   string       `json:"nodeid"`
+	Outcome  string       `json:"outcome"`
+	Setup    *pytestStage `json:"setup"`
+	Call     *pytestStage `json:"call"`
+	Teardown *pytestStage `json:"teardown"`
+}
+
+// pytestStage is the result of one of the setup, call, or teardown stages of
+// a test.
+type pytestStage struct {
+	// Duration is in seconds.
+	Duration float64 `json:"duration"`
+	Outcome  string  `json:"outcome"`
+	Longrepr string  `json:"longrepr"`
+	Stdout   string  `json:"stdout"`
+	Stderr   string  `json:"stderr"`
+}
+
+func parsePytestJSON(r io.Reader) (*pytestReport, error) {
+	report := &pytestReport{}
+	if err := json.NewDecoder(r).Decode(report); err != nil {
+		return nil, errors.Wrap(err, "decoding pytest JSON report")
+	}
+
+	return report, nil
+}
+
+// parsePytestResults parses a pytest JSON report into test results. Tests
+// that have output or a failure report get their own test log, and failed
+// tests point to the log line where the failure report of the failing stage
+// begins.
+func parsePytestResults(conf *internal.TaskConfig, _ client.LoggerProducer, r io.Reader, _ string) ([]testresult.TestResult, []model.TestLog, error) {
+	report, err := parsePytestJSON(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(report.Tests) == 0 {
+		return nil, nil, errors.New("no results found")
+	}
+
+	var (
+		results []testresult.TestResult
+		logs    []model.TestLog
+	)
+	for _, test := range report.Tests {
+		res, log := test.toModelTestResultAndLog(conf)
+		results = append(results, res)
+		if log != nil {
+			logs = append(logs, *log)
+		}
+	}
+
+	return results, logs, nil
+}
+
+func (t pytestTest) toModelTestResultAndLog(conf *internal.TaskConfig) (testresult.TestResult, *model.TestLog) {
+	res := testresult.TestResult{
+		// Replace the "::" separators, slashes, etc. with underscores.
+		TestName: util.CleanForPath(t.NodeID),
+	}
+
+	switch t.Outcome {
+	case pytestOutcomePassed, pytestOutcomeXPassed:
+		res.Status = evergreen.TestSucceededStatus
+	case pytestOutcomeSkipped, pytestOutcomeXFailed:
+		res.Status = evergreen.TestSkippedStatus
+	default:
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	var (
+		duration     float64
+		lines        []string
+		foundFailure bool
+	)
+	for _, stage := range []struct {
+		name   string
+		result *pytestStage
+	}{
+		{name: "setup", result: t.Setup},
+		{name: "call", result: t.Call},
+		{name: "teardown", result: t.Teardown},
+	} {
+		if stage.result == nil {
+			continue
+		}
+		duration += stage.result.Duration
+
+		if stage.result.Longrepr != "" {
+			if res.Status == evergreen.TestFailedStatus && !foundFailure && stage.result.Outcome != pytestOutcomePassed {
+				foundFailure = true
+				res.LineNum = len(lines)
+			}
+			lines = append(lines, fmt.Sprintf("%s %s:", stage.name, stage.result.Outcome))
+			lines = append(lines, splitLogLines(stage.result.Longrepr)...)
+		}
+		lines = append(lines, constructSystemLogLines(stage.result.Stdout, stage.result.Stderr)...)
+	}
+
+	res.TestStartTime = time.Now()
+	res.TestEndTime = res.TestStartTime.Add(time.Duration(duration * float64(time.Second)))
+
+	if len(lines) == 0 {
+		return res, nil
+	}
+
+	log := newResultsTestLog(conf, &res, lines)
+
+	return res, &log
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePytestResults(t *testing.T) {
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task", Execution: 3}}
+
+	t.Run("ConvertsTests", func(t *testing.T) {
+		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "pytest", "report.json"))
+		require.NoError(t, err)
+		defer file.Close()
+
+		results, logs, err := parsePytestResults(conf, nil, file, "report")
+		require.NoError(t, err)
+		require.Len(t, results, 4)
+		require.Len(t, logs, 4)
+
+		for i, log := range logs {
+			assert.Equal(t, conf.Task.Id, log.Task)
+			assert.Equal(t, conf.Task.Execution, log.TaskExecution)
+			assert.Equal(t, log.Name, results[i].LogTestName)
+		}
+
+		assert.Equal(t, "tests_test_math.py__test_add", results[0].TestName)
+		assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+		assert.Equal(t, 250300*time.Microsecond, results[0].Duration().Round(time.Microsecond))
+		assert.Equal(t, []string{systemOut, "adding numbers"}, logs[0].Lines)
+
+		assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+		assert.Equal(t, 2, results[1].LineNum)
+		assert.Equal(t, []string{
+			systemOut,
+			"setting up",
+			"call failed:",
+			"def test_divide():",
+			">       assert 1 / 0",
+			"E       ZeroDivisionError: division by zero",
+			"",
+			"tests/test_math.py:10: ZeroDivisionError",
+			systemErr,
+			"warning: dividing by zero",
+		}, logs[1].Lines)
+
+		assert.Equal(t, "tests_test_math.py__test_sqrt", results[2].TestName)
+		assert.Equal(t, evergreen.TestSkippedStatus, results[2].Status)
+		assert.Zero(t, results[2].LineNum)
+
+		assert.Equal(t, evergreen.TestFailedStatus, results[3].Status)
+		assert.Zero(t, results[3].LineNum)
+		assert.Equal(t, []string{"setup failed:", "fixture 'database' not found"}, logs[3].Lines)
+	})
+	t.Run("NoTests", func(t *testing.T) {
+		_, _, err := parsePytestResults(conf, nil, strings.NewReader(`{"tests": []}`), "report")
+		assert.Error(t, err)
+	})
+}
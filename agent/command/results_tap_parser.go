@@ -0,0 +1,175 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	tapVersionRegex   = regexp.MustCompile(`^TAP version \d+`)
+	tapPlanRegex      = regexp.MustCompile(`^\d+\.\.\d+`)
+	tapTestPointRegex = regexp.MustCompile(`^(not )?ok\b\s*(\d+)?\s*(?:-\s*)?(.*)$`)
+	tapDirectiveRegex = regexp.MustCompile(`(?i)^(.*?)(?:^|\s+)#\s*(skip|todo)\S*(?:\s+(.*))?$`)
+	tapDurationRegex  = regexp.MustCompile(`^duration_ms:\s*([0-9.]+)`)
+)
+
+const (
+	tapDirectiveSkip = "skip"
+	tapDirectiveTodo = "todo"
+)
+
+// tapTest is a single test point parsed from TAP output.
+type tapTest struct {
+	Number    int
+	Name      string
+	OK        bool
+	Directive string
+	Reason    string
+	Duration  time.Duration
+	// StartLine and EndLine are the indices of the first and last log
+	// lines that belong to the test. Output preceding a test point and
+	// the YAML diagnostic block following it belong to the test.
+	StartLine int
+	EndLine   int
+}
+
+// tapParser parses the Test Anything Protocol (TAP) output of a test run.
+// Only top-level test points are parsed; indented subtests are part of the
+// log lines of the test point that follows them.
+type tapParser struct {
+	lines     []string
+	tests     []tapTest
+	inYAML    bool
+	nextStart int
+}
+
+func (p *tapParser) parse(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.parseLine(strings.TrimRight(scanner.Text(), "\r"))
+	}
+
+	return errors.Wrap(scanner.Err(), "reading TAP output")
+}
+
+func (p *tapParser) parseLine(line string) {
+	idx := len(p.lines)
+	p.lines = append(p.lines, line)
+	trimmed := strings.TrimSpace(line)
+
+	if p.inYAML {
+		last := &p.tests[len(p.tests)-1]
+		last.EndLine = idx
+		if trimmed == "..." {
+			p.inYAML = false
+			p.nextStart = idx + 1
+		} else if match := tapDurationRegex.FindStringSubmatch(trimmed); match != nil {
+			if ms, err := strconv.ParseFloat(match[1], 64); err == nil {
+				last.Duration = time.Duration(ms * float64(time.Millisecond))
+			}
+		}
+		return
+	}
+
+	if match := tapTestPointRegex.FindStringSubmatch(line); match != nil {
+		test := tapTest{
+			Number:    len(p.tests) + 1,
+			Name:      strings.TrimSpace(match[3]),
+			OK:        match[1] == "",
+			StartLine: p.nextStart,
+			EndLine:   idx,
+		}
+		if match[2] != "" {
+			test.Number, _ = strconv.Atoi(match[2])
+		}
+		if directive := tapDirectiveRegex.FindStringSubmatch(test.Name); directive != nil {
+			test.Name = strings.TrimSpace(directive[1])
+			test.Directive = strings.ToLower(directive[2])
+			test.Reason = strings.TrimSpace(directive[3])
+		}
+
+		p.tests = append(p.tests, test)
+		p.nextStart = idx + 1
+		return
+	}
+
+	// A YAML diagnostic block must immediately follow its test point.
+	if len(p.tests) > 0 && trimmed == "---" && line != trimmed && p.tests[len(p.tests)-1].EndLine == idx-1 {
+		p.inYAML = true
+		p.tests[len(p.tests)-1].EndLine = idx
+		return
+	}
+
+	// The version and a leading plan do not belong to the first test.
+	if len(p.tests) == 0 && p.nextStart == idx && (tapVersionRegex.MatchString(line) || tapPlanRegex.MatchString(line)) {
+		p.nextStart = idx + 1
+	}
+}
+
+// status returns the Evergreen test status of the TAP test point. Failing TODO
+// tests are expected to fail, so they are considered skipped rather than
+// failed.
+func (t tapTest) status() string {
+	switch {
+	case t.Directive == tapDirectiveSkip || (t.Directive == tapDirectiveTodo && !t.OK):
+		return evergreen.TestSkippedStatus
+	case t.OK:
+		return evergreen.TestSucceededStatus
+	default:
+		return evergreen.TestFailedStatus
+	}
+}
+
+// parseTAPResults parses TAP output into test results. The entire output is
+// written as a single test log, and each test result points to the range of
+// log lines that belong to it.
+func parseTAPResults(conf *internal.TaskConfig, _ client.LoggerProducer, r io.Reader, suiteName string) ([]testresult.TestResult, []model.TestLog, error) {
+	p := &tapParser{}
+	if err := p.parse(r); err != nil {
+		return nil, nil, err
+	}
+	if len(p.tests) == 0 {
+		return nil, nil, errors.New("no results found")
+	}
+
+	testLog := model.TestLog{
+		Name:          suiteName,
+		Task:          conf.Task.Id,
+		TaskExecution: conf.Task.Execution,
+		Lines:         p.lines,
+	}
+
+	results := make([]testresult.TestResult, 0, len(p.tests))
+	for _, test := range p.tests {
+		name := test.Name
+		if name == "" {
+			name = fmt.Sprintf("%s_%d", suiteName, test.Number)
+		}
+
+		start := time.Now()
+		results = append(results, testresult.TestResult{
+			TestName:      util.CleanForPath(name),
+			Status:        test.status(),
+			TestStartTime: start,
+			TestEndTime:   start.Add(test.Duration),
+			LogTestName:   testLog.Name,
+			LineNum:       test.StartLine,
+		})
+	}
+
+	return results, []model.TestLog{testLog}, nil
+}
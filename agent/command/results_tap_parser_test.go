@@ -0,0 +1,135 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTAPParser(t *testing.T) {
+	file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "tap", "node.tap"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	p := &tapParser{}
+	require.NoError(t, p.parse(file))
+	require.Len(t, p.lines, 20)
+	require.Len(t, p.tests, 5)
+
+	assert.Equal(t, tapTest{
+		Number:    1,
+		Name:      "connects to the database",
+		OK:        true,
+		Duration:  12500 * time.Microsecond,
+		StartLine: 2,
+		EndLine:   6,
+	}, p.tests[0])
+	assert.Equal(t, tapTest{
+		Number:    2,
+		Name:      "inserts a document",
+		Duration:  250 * time.Millisecond,
+		StartLine: 7,
+		EndLine:   13,
+	}, p.tests[1])
+	assert.Equal(t, tapTest{
+		Number:    3,
+		Name:      "deletes a document",
+		OK:        true,
+		Directive: tapDirectiveSkip,
+		Reason:    "not supported by this driver",
+		StartLine: 14,
+		EndLine:   14,
+	}, p.tests[2])
+	assert.Equal(t, tapTest{
+		Number:    4,
+		Name:      "handles transactions",
+		Directive: tapDirectiveTodo,
+		Reason:    "implement transactions",
+		StartLine: 15,
+		EndLine:   15,
+	}, p.tests[3])
+	assert.Equal(t, tapTest{
+		Number:    5,
+		OK:        true,
+		StartLine: 16,
+		EndLine:   16,
+	}, p.tests[4])
+}
+
+func TestParseTAPResults(t *testing.T) {
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task", Execution: 2}}
+
+	t.Run("ConvertsTestPoints", func(t *testing.T) {
+		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "tap", "node.tap"))
+		require.NoError(t, err)
+		defer file.Close()
+
+		results, logs, err := parseTAPResults(conf, nil, file, "node")
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.Equal(t, "node", logs[0].Name)
+		assert.Equal(t, conf.Task.Id, logs[0].Task)
+		assert.Equal(t, conf.Task.Execution, logs[0].TaskExecution)
+		assert.Len(t, logs[0].Lines, 20)
+
+		require.Len(t, results, 5)
+		for i, expected := range []struct {
+			name     string
+			status   string
+			lineNum  int
+			duration time.Duration
+		}{
+			{name: "connects_to_the_database", status: evergreen.TestSucceededStatus, lineNum: 2, duration: 12500 * time.Microsecond},
+			{name: "inserts_a_document", status: evergreen.TestFailedStatus, lineNum: 7, duration: 250 * time.Millisecond},
+			{name: "deletes_a_document", status: evergreen.TestSkippedStatus, lineNum: 14},
+			{name: "handles_transactions", status: evergreen.TestSkippedStatus, lineNum: 15},
+			{name: "node_5", status: evergreen.TestSucceededStatus, lineNum: 16},
+		} {
+			assert.Equal(t, expected.name, results[i].TestName)
+			assert.Equal(t, expected.status, results[i].Status)
+			assert.Equal(t, expected.lineNum, results[i].LineNum)
+			assert.Equal(t, expected.duration, results[i].Duration())
+			assert.Equal(t, "node", results[i].LogTestName)
+		}
+		assert.True(t, strings.HasPrefix(logs[0].Lines[results[1].LineNum], "# running the queries"))
+	})
+	t.Run("NoTestPoints", func(t *testing.T) {
+		_, _, err := parseTAPResults(conf, nil, strings.NewReader("TAP version 13\n1..0\n"), "empty")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetResultsSuiteName(t *testing.T) {
+	conf := &internal.TaskConfig{WorkDir: filepath.Join("/", "data", "mci")}
+
+	for name, test := range map[string]struct {
+		file     string
+		expected string
+	}{
+		"FileInWorkingDirectory": {
+			file:     filepath.Join(conf.WorkDir, "results.tap"),
+			expected: "results",
+		},
+		"FilesWithSameBaseNameInDifferentDirectories": {
+			file:     filepath.Join(conf.WorkDir, "src", "client", "results.tap"),
+			expected: "src_client_results",
+		},
+		"FileOutsideOfWorkingDirectory": {
+			file:     filepath.Join("/", "tmp", "results.tap"),
+			expected: "results",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, getResultsSuiteName(conf, test.file))
+		})
+	}
+}
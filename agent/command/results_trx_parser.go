@@ -0,0 +1,185 @@
+package command
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/pkg/errors"
+)
+
+// trxDurationRegex matches TRX durations, which have the format
+// "hh:mm:ss.fffffff".
+var trxDurationRegex = regexp.MustCompile(`^(\d+):(\d{2}):(\d{2}(?:\.\d+)?)$`)
+
+const (
+	trxOutcomePassed       = "Passed"
+	trxOutcomeNotExecuted  = "NotExecuted"
+	trxOutcomeInconclusive = "Inconclusive"
+	trxOutcomePending      = "Pending"
+)
+
+// trxTestRun is the root of a Visual Studio test results (TRX) file, as
+// produced by `dotnet test --logger trx`.
+type trxTestRun struct {
+	Results     []trxUnitTestResult `xml:"Results>UnitTestResult"`
+	Definitions []trxUnitTest       `xml:"TestDefinitions>UnitTest"`
+}
+
+type trxUnitTestResult struct {
+	TestID    string    `xml:"testId,attr"`
+	TestName  string    `xml:"testName,attr"`
+	Outcome   string    `xml:"outcome,attr"`
+	Duration  string    `xml:"duration,attr"`
+	StartTime string    `xml:"startTime,attr"`
+	EndTime   string    `xml:"endTime,attr"`
+	Output    trxOutput `xml:"Output"`
+}
+
+type trxOutput struct {
+	StdOut    string        `xml:"StdOut"`
+	StdErr    string        `xml:"StdErr"`
+	ErrorInfo *trxErrorInfo `xml:"ErrorInfo"`
+}
+
+type trxErrorInfo struct {
+	Message    string `xml:"Message"`
+	StackTrace string `xml:"StackTrace"`
+}
+
+type trxUnitTest struct {
+	ID         string        `xml:"id,attr"`
+	Name       string        `xml:"name,attr"`
+	TestMethod trxTestMethod `xml:"TestMethod"`
+}
+
+type trxTestMethod struct {
+	ClassName string `xml:"className,attr"`
+	Name      string `xml:"name,attr"`
+}
+
+func parseTRX(r io.Reader) (*trxTestRun, error) {
+	run := &trxTestRun{}
+	if err := xml.NewDecoder(r).Decode(run); err != nil {
+		return nil, errors.Wrap(err, "decoding TRX XML")
+	}
+
+	return run, nil
+}
+
+// parseTRXResults parses a TRX file into test results. Tests that have output
+// or error information get their own test log, which contains the error
+// message and stack trace followed by the standard output and error.
+func parseTRXResults(conf *internal.TaskConfig, logger client.LoggerProducer, r io.Reader, _ string) ([]testresult.TestResult, []model.TestLog, error) {
+	run, err := parseTRX(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(run.Results) == 0 {
+		return nil, nil, errors.New("no results found")
+	}
+
+	definitions := make(map[string]trxUnitTest, len(run.Definitions))
+	for _, def := range run.Definitions {
+		definitions[def.ID] = def
+	}
+
+	var (
+		results []testresult.TestResult
+		logs    []model.TestLog
+	)
+	for _, utr := range run.Results {
+		res, log := utr.toModelTestResultAndLog(conf, logger, definitions[utr.TestID])
+		results = append(results, res)
+		if log != nil {
+			logs = append(logs, *log)
+		}
+	}
+
+	return results, logs, nil
+}
+
+func (utr trxUnitTestResult) toModelTestResultAndLog(conf *internal.TaskConfig, logger client.LoggerProducer, def trxUnitTest) (testresult.TestResult, *model.TestLog) {
+	res := testresult.TestResult{}
+
+	switch {
+	case def.TestMethod.ClassName != "" && def.TestMethod.Name != "":
+		res.TestName = fmt.Sprintf("%s.%s", def.TestMethod.ClassName, def.TestMethod.Name)
+	case utr.TestName != "":
+		res.TestName = utr.TestName
+	default:
+		res.TestName = def.Name
+	}
+	// Replace spaces, dashes, etc. with underscores.
+	res.TestName = util.CleanForPath(res.TestName)
+
+	switch utr.Outcome {
+	case trxOutcomePassed:
+		res.Status = evergreen.TestSucceededStatus
+	case trxOutcomeNotExecuted, trxOutcomeInconclusive, trxOutcomePending:
+		res.Status = evergreen.TestSkippedStatus
+	default:
+		res.Status = evergreen.TestFailedStatus
+	}
+
+	duration, err := parseTRXDuration(utr.Duration)
+	if err != nil {
+		logger.Task().Errorf("Test '%s' has invalid duration '%s', its calculated duration will be incorrect.", res.TestName, utr.Duration)
+	}
+	res.TestStartTime, err = time.Parse(time.RFC3339, utr.StartTime)
+	if err != nil {
+		res.TestStartTime = time.Now()
+	}
+	res.TestEndTime, err = time.Parse(time.RFC3339, utr.EndTime)
+	if err != nil || res.TestEndTime.Before(res.TestStartTime) {
+		res.TestEndTime = res.TestStartTime.Add(duration)
+	}
+
+	var lines []string
+	if info := utr.Output.ErrorInfo; info != nil {
+		lines = append(lines, splitLogLines(fmt.Sprintf("%s: %s", utr.Outcome, info.Message))...)
+		lines = append(lines, splitLogLines(info.StackTrace)...)
+	}
+	lines = append(lines, constructSystemLogLines(utr.Output.StdOut, utr.Output.StdErr)...)
+	if len(lines) == 0 {
+		return res, nil
+	}
+
+	log := newResultsTestLog(conf, &res, lines)
+
+	return res, &log
+}
+
+func parseTRXDuration(duration string) (time.Duration, error) {
+	if duration == "" {
+		return 0, nil
+	}
+
+	match := trxDurationRegex.FindStringSubmatch(duration)
+	if match == nil {
+		return 0, errors.Errorf("invalid duration '%s'", duration)
+	}
+	hours, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing hours")
+	}
+	minutes, err := strconv.Atoi(match[2])
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing minutes")
+	}
+	seconds, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "parsing seconds")
+	}
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second)), nil
+}
@@ -0,0 +1,83 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/agent/internal"
+	"github.com/evergreen-ci/evergreen/agent/internal/client"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTRXResults(t *testing.T) {
+	conf := &internal.TaskConfig{Task: task.Task{Id: "task", Execution: 0}}
+	logger := client.NewSingleChannelLogHarness("", send.MakeInternalLogger())
+	defer func() {
+		assert.NoError(t, logger.Close())
+	}()
+
+	t.Run("ConvertsUnitTestResults", func(t *testing.T) {
+		file, err := os.Open(filepath.Join(testutil.GetDirectoryOfFile(), "testdata", "trx", "results.trx"))
+		require.NoError(t, err)
+		defer file.Close()
+
+		results, logs, err := parseTRXResults(conf, logger, file, "results")
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		require.Len(t, logs, 2)
+
+		assert.Equal(t, "Calculator.Tests.CalculatorTests.Add_ReturnsSum", results[0].TestName)
+		assert.Equal(t, evergreen.TestSucceededStatus, results[0].Status)
+		assert.Equal(t, 12345*time.Microsecond, results[0].Duration())
+		assert.Equal(t, time.Date(2023, 5, 1, 14, 0, 1, 0, time.UTC), results[0].TestStartTime.UTC())
+		assert.Empty(t, results[0].LogTestName)
+
+		assert.Equal(t, "Calculator.Tests.CalculatorTests.Divide_ByZero_Throws", results[1].TestName)
+		assert.Equal(t, evergreen.TestFailedStatus, results[1].Status)
+		assert.Equal(t, 1500*time.Millisecond, results[1].Duration())
+		assert.Equal(t, logs[0].Name, results[1].LogTestName)
+		assert.Equal(t, conf.Task.Id, logs[0].Task)
+		assert.Equal(t, []string{
+			"Failed: Assert.Throws() Failure",
+			"Expected: typeof(System.DivideByZeroException)",
+			"Actual:   (No exception was thrown)",
+			"   at Calculator.Tests.CalculatorTests.Divide_ByZero_Throws() in /src/CalculatorTests.cs:line 27",
+			systemOut,
+			"dividing 1 by 0",
+		}, logs[0].Lines)
+
+		// Results without a test definition fall back to the test name.
+		assert.Equal(t, "Subtract_Negative", results[2].TestName)
+		assert.Equal(t, evergreen.TestSkippedStatus, results[2].Status)
+		assert.Equal(t, time.Microsecond, results[2].Duration())
+		assert.Equal(t, logs[1].Name, results[2].LogTestName)
+	})
+	t.Run("NoResults", func(t *testing.T) {
+		_, _, err := parseTRXResults(conf, logger, strings.NewReader("<TestRun><Results></Results></TestRun>"), "results")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTRXDuration(t *testing.T) {
+	for duration, expected := range map[string]time.Duration{
+		"":                 0,
+		"00:00:00.0000001": 100 * time.Nanosecond,
+		"00:00:01.5000000": 1500 * time.Millisecond,
+		"01:02:03":         time.Hour + 2*time.Minute + 3*time.Second,
+	} {
+		actual, err := parseTRXDuration(duration)
+		require.NoError(t, err, duration)
+		assert.Equal(t, expected, actual, duration)
+	}
+
+	_, err := parseTRXDuration("1.5s")
+	assert.Error(t, err)
+}
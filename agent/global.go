@@ -36,6 +36,14 @@ const (
 	// to API server
 	defaultStatsInterval = time.Minute
 
+	// defaultResourceUsageInterval is the interval at which the agent samples
+	// the resources used by the task's processes.
+	defaultResourceUsageInterval = 15 * time.Second
+
+	// maxResourceUsageSamples is the maximum number of points in the resource
+	// usage time series that the agent sends for a task.
+	maxResourceUsageSamples = 240
+
 	// defaultCallbackTimeout specifies the duration after when the timeout
 	// block should time out and stop the current command.
 	defaultCallbackTimeout = 15 * time.Minute
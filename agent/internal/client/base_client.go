@@ -727,6 +727,21 @@ func (c *baseCommunicator) SetResultsInfo(ctx context.Context, taskData TaskData
 	return nil
 }
 
+func (c *baseCommunicator) SetResourceUsage(ctx context.Context, taskData TaskData, usage *apimodels.ResourceUsageInfo) error {
+	info := requestInfo{
+		method:   http.MethodPost,
+		taskData: &taskData,
+	}
+	info.path = fmt.Sprintf("task/%s/resource_usage", taskData.ID)
+	resp, err := c.retryRequest(ctx, info, usage)
+	if err != nil {
+		return util.RespErrorf(resp, errors.Wrap(err, "setting resource usage").Error())
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
 func (c *baseCommunicator) NewPush(ctx context.Context, taskData TaskData, req *apimodels.S3CopyRequest) (*model.PushLog, error) {
 	newPushLog := model.PushLog{}
 	info := requestInfo{
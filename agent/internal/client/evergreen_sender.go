@@ -7,6 +7,7 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/level"
 	"github.com/mongodb/grip/message"
@@ -168,11 +169,12 @@ func (s *evergreenLogSender) Flush(_ context.Context) error {
 
 func (s *evergreenLogSender) convertMessage(m message.Composer) apimodels.LogMessage {
 	return apimodels.LogMessage{
-		Type:      s.logChannel,
-		Severity:  priorityToString(m.Priority()),
-		Message:   m.String(),
-		Timestamp: time.Now(),
-		Version:   evergreen.LogmessageCurrentVersion,
+		Type:       s.logChannel,
+		Severity:   priorityToString(m.Priority()),
+		Message:    m.String(),
+		Timestamp:  time.Now(),
+		Version:    evergreen.LogmessageCurrentVersion,
+		Attributes: log.AttributesFromComposer(m),
 	}
 }
 
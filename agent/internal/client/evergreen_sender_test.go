@@ -39,6 +39,29 @@ func TestRestClientLogSenderMessageContents(t *testing.T) {
 	assert.Equal("hello world", m[0].Message)
 }
 
+func TestRestClientLogSenderMessageAttributes(t *testing.T) {
+	assert := assert.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	comm := NewMock("url")
+	td := TaskData{ID: "task", Secret: "secret"}
+	s, ok := newEvergreenLogSender(ctx, comm, "testStream", td, defaultLogBufferSize, defaultLogBufferTime).(*evergreenLogSender)
+	s.setBufferTime(10 * time.Millisecond)
+	assert.True(ok)
+
+	s.Send(message.NewFieldsMessage(level.Info, "structured", message.Fields{"step": "compile"}))
+	s.Send(message.NewDefaultMessage(level.Info, "unstructured"))
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(s.Close())
+
+	m := comm.GetMockMessages()["task"]
+	if assert.Len(m, 2) {
+		assert.Equal(map[string]string{"message": "structured", "step": "compile"}, m[0].Attributes)
+		assert.Nil(m[1].Attributes)
+	}
+}
+
 func TestRestClientLogSenderDoesNotLogInErrorConditions(t *testing.T) {
 	assert := assert.New(t)
 	ctx, cancel := context.WithCancel(context.Background())
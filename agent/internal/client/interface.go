@@ -74,6 +74,8 @@ type SharedCommunicator interface {
 	GetCedarGRPCConn(context.Context) (*grpc.ClientConn, error)
 	// SetResultsInfo sets the test results information in the task.
 	SetResultsInfo(context.Context, TaskData, string, bool) error
+	// SetResourceUsage sends the summary of the resources used by the task.
+	SetResourceUsage(context.Context, TaskData, *apimodels.ResourceUsageInfo) error
 	// GetDataPipesConfig returns the Data-Pipes service configuration.
 	GetDataPipesConfig(context.Context) (*apimodels.DataPipesConfig, error)
 
@@ -69,6 +69,7 @@ type Mock struct {
 	LocalTestResults []testresult.TestResult
 	ResultsService   string
 	ResultsFailed    bool
+	ResourceUsage    *apimodels.ResourceUsageInfo
 	TestLogs         []*serviceModel.TestLog
 	TestLogCount     int
 
@@ -348,11 +349,12 @@ func (c *Mock) GetMockMessages() map[string][]apimodels.LogMessage {
 		out[k] = []apimodels.LogMessage{}
 		for _, i := range v {
 			new := apimodels.LogMessage{
-				Type:      i.Type,
-				Severity:  i.Severity,
-				Message:   i.Message,
-				Timestamp: i.Timestamp,
-				Version:   i.Version,
+				Type:       i.Type,
+				Severity:   i.Severity,
+				Message:    i.Message,
+				Timestamp:  i.Timestamp,
+				Version:    i.Version,
+				Attributes: i.Attributes,
 			}
 			out[k] = append(out[k], new)
 		}
@@ -419,6 +421,14 @@ func (c *Mock) SetResultsInfo(ctx context.Context, _ TaskData, service string, f
 	return nil
 }
 
+func (c *Mock) SetResourceUsage(ctx context.Context, _ TaskData, usage *apimodels.ResourceUsageInfo) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ResourceUsage = usage
+	return nil
+}
+
 // DisableHost signals to the app server that the host should be disabled.
 func (c *Mock) DisableHost(ctx context.Context, hostID string, info apimodels.DisableInfo) error {
 	return nil
@@ -0,0 +1,386 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	agentutil "github.com/evergreen-ci/evergreen/agent/util"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/recovery"
+	"github.com/pkg/errors"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/net"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// resourceUsageCollector samples the CPU, memory and disk I/O used by the
+// processes that the agent spawns for a task, along with the host's network
+// I/O, and summarizes them into a bounded time series.
+type resourceUsageCollector struct {
+	taskID   string
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	// prevProcs is the cumulative usage of each of the task's processes as of
+	// the last sample.
+	prevProcs   map[int32]processUsage
+	prevNetwork networkCounters
+	prevAt      time.Time
+
+	info apimodels.ResourceUsageInfo
+	// samplesPerPoint is the number of samples that are merged into each
+	// point of the time series. It doubles whenever the time series reaches
+	// the maximum number of points.
+	samplesPerPoint int
+	// pending accumulates the samples for the next point in the time series.
+	pending       apimodels.ResourceUsageSample
+	numPending    int
+	numSamples    int
+	cpuPercentSum float64
+	memorySum     float64
+	mu            sync.Mutex
+}
+
+// networkCounters are the host's cumulative network I/O counters.
+type networkCounters struct {
+	sentBytes int64
+	recvBytes int64
+}
+
+// processUsage is the cumulative CPU time and disk I/O of a single process.
+type processUsage struct {
+	cpuSecs        float64
+	diskReadBytes  int64
+	diskWriteBytes int64
+}
+
+// processTreeUsage is the resource usage of a task's processes at a single
+// point in time.
+type processTreeUsage struct {
+	procs       map[int32]processUsage
+	memoryBytes int64
+}
+
+func newResourceUsageCollector(taskID string, interval time.Duration) *resourceUsageCollector {
+	return &resourceUsageCollector{
+		taskID:          taskID,
+		interval:        interval,
+		samplesPerPoint: 1,
+		prevProcs:       map[int32]processUsage{},
+		info: apimodels.ResourceUsageInfo{
+			SampleIntervalSecs: interval.Seconds(),
+			NumCPUs:            runtime.NumCPU(),
+		},
+	}
+}
+
+// start begins sampling resource usage in the background until the context
+// is done or the collector is stopped.
+func (c *resourceUsageCollector) start(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.done = make(chan struct{})
+
+	if memStats, err := mem.VirtualMemoryWithContext(ctx); err == nil {
+		c.info.TotalMemoryBytes = int64(memStats.Total)
+	}
+	c.prevAt = time.Now()
+	c.prevNetwork = readNetworkCounters(ctx)
+	if usage, err := readTaskProcessUsage(ctx, c.taskID); err == nil {
+		c.prevProcs = usage.procs
+	}
+
+	go func() {
+		defer close(c.done)
+		defer recovery.LogStackTraceAndContinue("resource usage collector")
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				usage, err := readTaskProcessUsage(ctx, c.taskID)
+				if err != nil {
+					grip.Debug(message.WrapError(err, message.Fields{
+						"message": "could not sample task process resource usage",
+						"task_id": c.taskID,
+					}))
+					continue
+				}
+				c.addSample(time.Now(), usage, readNetworkCounters(ctx))
+			}
+		}
+	}()
+}
+
+// stop stops sampling and returns the summarized resource usage. It returns
+// nil if no samples were collected.
+func (c *resourceUsageCollector) stop() *apimodels.ResourceUsageInfo {
+	if c.cancel != nil {
+		c.cancel()
+		<-c.done
+	}
+	return c.summary()
+}
+
+// addSample converts the raw usage into a sample relative to the previous one
+// and adds it to the time series.
+func (c *resourceUsageCollector) addSample(at time.Time, usage processTreeUsage, network networkCounters) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elapsedSecs := at.Sub(c.prevAt).Seconds()
+	if elapsedSecs <= 0 {
+		return
+	}
+
+	// Processes that exited since the last sample can no longer be read, so
+	// only the CPU time and disk I/O of processes that are still running is
+	// counted.
+	var cpuSecs float64
+	var diskReadBytes, diskWriteBytes int64
+	for pid, proc := range usage.procs {
+		prev := c.prevProcs[pid]
+		if delta := proc.cpuSecs - prev.cpuSecs; delta > 0 {
+			cpuSecs += delta
+		}
+		diskReadBytes += counterDelta(proc.diskReadBytes, prev.diskReadBytes)
+		diskWriteBytes += counterDelta(proc.diskWriteBytes, prev.diskWriteBytes)
+	}
+
+	sample := apimodels.ResourceUsageSample{
+		Time:                 c.prevAt,
+		CPUPercent:           100 * cpuSecs / elapsedSecs,
+		MemoryBytes:          usage.memoryBytes,
+		DiskReadBytes:        diskReadBytes,
+		DiskWriteBytes:       diskWriteBytes,
+		HostNetworkSentBytes: counterDelta(network.sentBytes, c.prevNetwork.sentBytes),
+		HostNetworkRecvBytes: counterDelta(network.recvBytes, c.prevNetwork.recvBytes),
+	}
+	c.prevAt = at
+	c.prevProcs = usage.procs
+	c.prevNetwork = network
+
+	c.numSamples++
+	c.cpuPercentSum += sample.CPUPercent
+	c.memorySum += float64(sample.MemoryBytes)
+	if sample.CPUPercent > c.info.MaxCPUPercent {
+		c.info.MaxCPUPercent = sample.CPUPercent
+	}
+	if sample.MemoryBytes > c.info.MaxMemoryBytes {
+		c.info.MaxMemoryBytes = sample.MemoryBytes
+	}
+	c.info.DiskReadBytes += sample.DiskReadBytes
+	c.info.DiskWriteBytes += sample.DiskWriteBytes
+	c.info.HostNetworkSentBytes += sample.HostNetworkSentBytes
+	c.info.HostNetworkRecvBytes += sample.HostNetworkRecvBytes
+
+	if c.numPending == 0 {
+		c.pending = sample
+	} else {
+		c.pending = mergeResourceUsageSamples(c.pending, c.numPending, sample, 1)
+	}
+	c.numPending++
+	if c.numPending < c.samplesPerPoint {
+		return
+	}
+
+	c.info.Samples = append(c.info.Samples, c.pending)
+	c.numPending = 0
+	if len(c.info.Samples) < maxResourceUsageSamples {
+		return
+	}
+
+	// Halve the resolution of the time series so that it stays bounded no
+	// matter how long the task runs.
+	merged := make([]apimodels.ResourceUsageSample, 0, (len(c.info.Samples)+1)/2)
+	for i := 0; i < len(c.info.Samples); i += 2 {
+		if i+1 == len(c.info.Samples) {
+			merged = append(merged, c.info.Samples[i])
+			continue
+		}
+		merged = append(merged, mergeResourceUsageSamples(c.info.Samples[i], c.samplesPerPoint, c.info.Samples[i+1], c.samplesPerPoint))
+	}
+	c.info.Samples = merged
+	c.samplesPerPoint *= 2
+	c.info.SampleIntervalSecs = float64(c.samplesPerPoint) * c.interval.Seconds()
+}
+
+// summary returns the summarized resource usage, including any samples that
+// haven't been merged into a full point yet.
+func (c *resourceUsageCollector) summary() *apimodels.ResourceUsageInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.numSamples == 0 {
+		return nil
+	}
+
+	info := c.info
+	info.Samples = append([]apimodels.ResourceUsageSample{}, c.info.Samples...)
+	if c.numPending > 0 {
+		info.Samples = append(info.Samples, c.pending)
+	}
+	info.AvgCPUPercent = c.cpuPercentSum / float64(c.numSamples)
+	info.AvgMemoryBytes = int64(c.memorySum / float64(c.numSamples))
+
+	return &info
+}
+
+// mergeResourceUsageSamples merges two adjacent points in the time series,
+// which represent n1 and n2 samples respectively. CPU and memory are averaged
+// and disk and network I/O are summed.
+func mergeResourceUsageSamples(s1 apimodels.ResourceUsageSample, n1 int, s2 apimodels.ResourceUsageSample, n2 int) apimodels.ResourceUsageSample {
+	total := float64(n1 + n2)
+	return apimodels.ResourceUsageSample{
+		Time:                 s1.Time,
+		CPUPercent:           (s1.CPUPercent*float64(n1) + s2.CPUPercent*float64(n2)) / total,
+		MemoryBytes:          int64((float64(s1.MemoryBytes)*float64(n1) + float64(s2.MemoryBytes)*float64(n2)) / total),
+		DiskReadBytes:        s1.DiskReadBytes + s2.DiskReadBytes,
+		DiskWriteBytes:       s1.DiskWriteBytes + s2.DiskWriteBytes,
+		HostNetworkSentBytes: s1.HostNetworkSentBytes + s2.HostNetworkSentBytes,
+		HostNetworkRecvBytes: s1.HostNetworkRecvBytes + s2.HostNetworkRecvBytes,
+	}
+}
+
+// counterDelta returns the increase in a cumulative counter. Counters can be
+// reset (e.g. if a disk is removed), in which case there's no increase.
+func counterDelta(cur, prev int64) int64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// readTaskProcessUsage returns the cumulative CPU time and disk I/O of each of
+// the task's processes and the total resident memory of those processes. The
+// task's processes are the ones that the agent started with the task's ID in
+// their environment, along with all of their descendants. The agent itself is
+// not included.
+func readTaskProcessUsage(ctx context.Context, taskID string) (processTreeUsage, error) {
+	procs, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return processTreeUsage{}, errors.Wrap(err, "listing processes")
+	}
+
+	agentPID := int32(os.Getpid())
+	parents := make(map[int32]int32, len(procs))
+	procsByPID := make(map[int32]*process.Process, len(procs))
+	var taskPIDs []int32
+	for _, p := range procs {
+		ppid, err := p.PpidWithContext(ctx)
+		if err != nil {
+			// The process may have exited after it was listed.
+			continue
+		}
+		parents[p.Pid] = ppid
+		procsByPID[p.Pid] = p
+
+		if p.Pid == agentPID {
+			continue
+		}
+		if env, err := p.EnvironWithContext(ctx); err == nil && envHasTaskMarker(env, taskID) {
+			taskPIDs = append(taskPIDs, p.Pid)
+		}
+	}
+
+	usage := processTreeUsage{procs: map[int32]processUsage{}}
+	for _, pid := range withDescendantPIDs(taskPIDs, parents) {
+		if pid == agentPID {
+			continue
+		}
+		p := procsByPID[pid]
+
+		var proc processUsage
+		if times, err := p.TimesWithContext(ctx); err == nil {
+			proc.cpuSecs = times.User + times.System
+		}
+		if io, err := p.IOCountersWithContext(ctx); err == nil {
+			proc.diskReadBytes = int64(io.ReadBytes)
+			proc.diskWriteBytes = int64(io.WriteBytes)
+		}
+		usage.procs[pid] = proc
+		if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil {
+			usage.memoryBytes += int64(memInfo.RSS)
+		}
+	}
+
+	return usage, nil
+}
+
+// envHasTaskMarker returns whether the process environment marks the process
+// as started by the agent for the task.
+func envHasTaskMarker(env []string, taskID string) bool {
+	marker := agentutil.MarkerTaskID + "=" + taskID
+	for _, envVar := range env {
+		if strings.TrimSpace(envVar) == marker {
+			return true
+		}
+	}
+	return false
+}
+
+// withDescendantPIDs returns the given PIDs along with the PIDs of all of
+// their descendants, given a mapping from each PID to its parent PID.
+func withDescendantPIDs(pids []int32, parents map[int32]int32) []int32 {
+	seen := map[int32]bool{}
+	var all []int32
+	for _, pid := range pids {
+		for _, p := range append([]int32{pid}, descendantPIDs(pid, parents)...) {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			all = append(all, p)
+		}
+	}
+	return all
+}
+
+// descendantPIDs returns the PIDs of all the processes descended from the
+// root process, given a mapping from each PID to its parent PID.
+func descendantPIDs(rootPID int32, parents map[int32]int32) []int32 {
+	children := map[int32][]int32{}
+	for pid, ppid := range parents {
+		if pid == ppid {
+			continue
+		}
+		children[ppid] = append(children[ppid], pid)
+	}
+
+	var descendants []int32
+	toVisit := children[rootPID]
+	visited := map[int32]bool{rootPID: true}
+	for len(toVisit) > 0 {
+		pid := toVisit[0]
+		toVisit = toVisit[1:]
+		if visited[pid] {
+			continue
+		}
+		visited[pid] = true
+		descendants = append(descendants, pid)
+		toVisit = append(toVisit, children[pid]...)
+	}
+
+	return descendants
+}
+
+// readNetworkCounters returns the host's cumulative network I/O. Network I/O
+// can't be attributed to single processes, so this is measured for the whole
+// host. Counters that can't be read are left as zero.
+func readNetworkCounters(ctx context.Context) networkCounters {
+	var counters networkCounters
+	if netCounters, err := net.IOCountersWithContext(ctx, false); err == nil && len(netCounters) == 1 {
+		counters.sentBytes = int64(netCounters[0].BytesSent)
+		counters.recvBytes = int64(netCounters[0].BytesRecv)
+	}
+	return counters
+}
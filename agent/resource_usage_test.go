@@ -0,0 +1,186 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	agentutil "github.com/evergreen-ci/evergreen/agent/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescendantPIDs(t *testing.T) {
+	parents := map[int32]int32{
+		1:  0,
+		10: 1,
+		11: 10,
+		12: 10,
+		13: 12,
+		20: 1,
+		21: 20,
+	}
+
+	assert.ElementsMatch(t, []int32{11, 12, 13}, descendantPIDs(10, parents))
+	assert.ElementsMatch(t, []int32{21}, descendantPIDs(20, parents))
+	assert.Empty(t, descendantPIDs(13, parents))
+	assert.Empty(t, descendantPIDs(100, parents))
+}
+
+func TestResourceUsageCollector(t *testing.T) {
+	start := time.Now()
+	makeCollector := func() *resourceUsageCollector {
+		c := newResourceUsageCollector("task", 10*time.Second)
+		c.prevAt = start
+		c.info.TotalMemoryBytes = 1000
+		return c
+	}
+
+	t.Run("ReturnsNilSummaryWithoutSamples", func(t *testing.T) {
+		assert.Nil(t, makeCollector().stop())
+	})
+	t.Run("ComputesUsageRelativeToPreviousSample", func(t *testing.T) {
+		c := makeCollector()
+		c.prevProcs = map[int32]processUsage{
+			1: {cpuSecs: 5, diskReadBytes: 100},
+			2: {cpuSecs: 3, diskReadBytes: 1000},
+		}
+		c.prevNetwork = networkCounters{sentBytes: 50}
+
+		// PID 2 exited and PID 3 started since the previous sample.
+		c.addSample(start.Add(10*time.Second), processTreeUsage{
+			procs: map[int32]processUsage{
+				1: {cpuSecs: 10, diskReadBytes: 250},
+				3: {cpuSecs: 5, diskReadBytes: 50, diskWriteBytes: 20},
+			},
+			memoryBytes: 400,
+		}, networkCounters{sentBytes: 150, recvBytes: 10})
+		c.addSample(start.Add(20*time.Second), processTreeUsage{
+			procs: map[int32]processUsage{
+				1: {cpuSecs: 12, diskReadBytes: 350},
+				3: {cpuSecs: 5, diskReadBytes: 50, diskWriteBytes: 20},
+			},
+			memoryBytes: 200,
+		}, networkCounters{sentBytes: 150, recvBytes: 10})
+
+		usage := c.stop()
+		require.NotNil(t, usage)
+		require.Len(t, usage.Samples, 2)
+
+		assert.Equal(t, start, usage.Samples[0].Time)
+		assert.InDelta(t, 100, usage.Samples[0].CPUPercent, 0.001)
+		assert.EqualValues(t, 400, usage.Samples[0].MemoryBytes)
+		assert.EqualValues(t, 200, usage.Samples[0].DiskReadBytes)
+		assert.EqualValues(t, 20, usage.Samples[0].DiskWriteBytes)
+		assert.EqualValues(t, 100, usage.Samples[0].HostNetworkSentBytes)
+		assert.EqualValues(t, 10, usage.Samples[0].HostNetworkRecvBytes)
+
+		assert.Equal(t, start.Add(10*time.Second), usage.Samples[1].Time)
+		assert.InDelta(t, 20, usage.Samples[1].CPUPercent, 0.001)
+		assert.EqualValues(t, 100, usage.Samples[1].DiskReadBytes)
+
+		assert.InDelta(t, 100, usage.MaxCPUPercent, 0.001)
+		assert.InDelta(t, 60, usage.AvgCPUPercent, 0.001)
+		assert.EqualValues(t, 400, usage.MaxMemoryBytes)
+		assert.EqualValues(t, 300, usage.AvgMemoryBytes)
+		assert.EqualValues(t, 300, usage.DiskReadBytes)
+		assert.EqualValues(t, 20, usage.DiskWriteBytes)
+		assert.EqualValues(t, 100, usage.HostNetworkSentBytes)
+		assert.EqualValues(t, 10, usage.HostNetworkRecvBytes)
+		assert.False(t, usage.ExceededMemory())
+	})
+	t.Run("DownsamplesLongTimeSeries", func(t *testing.T) {
+		c := makeCollector()
+		numSamples := 2*maxResourceUsageSamples + 1
+		for i := 1; i <= numSamples; i++ {
+			c.addSample(start.Add(time.Duration(i)*10*time.Second), processTreeUsage{
+				procs:       map[int32]processUsage{1: {cpuSecs: float64(i), diskWriteBytes: int64(i)}},
+				memoryBytes: int64(i),
+			}, networkCounters{})
+		}
+
+		usage := c.stop()
+		require.NotNil(t, usage)
+		assert.LessOrEqual(t, len(usage.Samples), maxResourceUsageSamples)
+		assert.Equal(t, 40.0, usage.SampleIntervalSecs)
+		assert.Equal(t, start, usage.Samples[0].Time)
+		assert.EqualValues(t, 4, usage.Samples[0].DiskWriteBytes)
+		assert.EqualValues(t, numSamples, usage.MaxMemoryBytes)
+		assert.EqualValues(t, numSamples, usage.DiskWriteBytes)
+
+		var totalDiskWrite int64
+		for _, sample := range usage.Samples {
+			totalDiskWrite += sample.DiskWriteBytes
+			assert.InDelta(t, 10, sample.CPUPercent, 0.001)
+		}
+		assert.Equal(t, usage.DiskWriteBytes, totalDiskWrite)
+	})
+	t.Run("DetectsExceededMemory", func(t *testing.T) {
+		c := makeCollector()
+		c.addSample(start.Add(10*time.Second), processTreeUsage{memoryBytes: 950}, networkCounters{})
+
+		usage := c.stop()
+		require.NotNil(t, usage)
+		assert.True(t, usage.ExceededMemory())
+	})
+}
+
+func TestWithDescendantPIDs(t *testing.T) {
+	parents := map[int32]int32{
+		1:  0,
+		10: 1,
+		11: 10,
+		12: 11,
+		20: 1,
+		21: 20,
+		30: 1,
+	}
+
+	assert.ElementsMatch(t, []int32{10, 11, 12, 20, 21}, withDescendantPIDs([]int32{10, 11, 20}, parents))
+	assert.Empty(t, withDescendantPIDs(nil, parents))
+}
+
+func TestEnvHasTaskMarker(t *testing.T) {
+	assert.True(t, envHasTaskMarker([]string{"PATH=/bin", agentutil.MarkerTaskID + "=task"}, "task"))
+	assert.False(t, envHasTaskMarker([]string{"PATH=/bin", agentutil.MarkerTaskID + "=other_task"}, "task"))
+	assert.False(t, envHasTaskMarker([]string{"PATH=/bin"}, "task"))
+}
+
+func TestReadTaskProcessUsage(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("test requires reading process environments")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	startSleep := func(t *testing.T, taskID string) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "sleep", "10")
+		cmd.Env = append(os.Environ(), agentutil.MarkerTaskID+"="+taskID)
+		require.NoError(t, cmd.Start())
+		t.Cleanup(func() {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		})
+		return cmd
+	}
+	taskCmd := startSleep(t, "task")
+	otherTaskCmd := startSleep(t, "other_task")
+	unmarkedCmd := exec.CommandContext(ctx, "sleep", "10")
+	require.NoError(t, unmarkedCmd.Start())
+	defer func() {
+		_ = unmarkedCmd.Process.Kill()
+		_ = unmarkedCmd.Wait()
+	}()
+
+	usage, err := readTaskProcessUsage(ctx, "task")
+	require.NoError(t, err)
+	assert.Contains(t, usage.procs, int32(taskCmd.Process.Pid))
+	assert.NotContains(t, usage.procs, int32(otherTaskCmd.Process.Pid))
+	assert.NotContains(t, usage.procs, int32(unmarkedCmd.Process.Pid))
+	assert.NotContains(t, usage.procs, int32(os.Getpid()))
+	assert.Positive(t, usage.memoryBytes)
+}
@@ -26,6 +26,7 @@ type taskContext struct {
 	taskConfig                *internal.TaskConfig
 	timeout                   timeoutInfo
 	oomTracker                jasper.OOMTracker
+	resourceUsage             *resourceUsageCollector
 	traceID                   string
 	unsetFunctionVarsDisabled bool
 	// userEndTaskResp is the end task response that the user can define, which
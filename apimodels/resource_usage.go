@@ -0,0 +1,67 @@
+package apimodels
+
+import "time"
+
+// MemoryWarningThreshold is the fraction of a host's total memory that a
+// task's processes can use at peak before the task is considered to have
+// exceeded the memory available on its distro.
+const MemoryWarningThreshold = 0.9
+
+// ResourceUsageInfo summarizes the resources used by a task's processes while
+// it ran, as sampled by the agent. It is sent by the agent when the task ends
+// and stored with the task.
+type ResourceUsageInfo struct {
+	// SampleIntervalSecs is the number of seconds between samples in the time
+	// series. The agent merges adjacent samples to keep the time series
+	// bounded, so this may be larger than the agent's sampling interval.
+	SampleIntervalSecs float64 `bson:"sample_interval_secs" json:"sample_interval_secs"`
+	// NumCPUs is the number of logical CPUs on the host.
+	NumCPUs int `bson:"num_cpus" json:"num_cpus"`
+	// TotalMemoryBytes is the total physical memory on the host.
+	TotalMemoryBytes int64 `bson:"total_memory_bytes" json:"total_memory_bytes"`
+
+	// MaxCPUPercent and AvgCPUPercent are the peak and average CPU usage of
+	// the task's processes. 100 percent is equivalent to one fully-used CPU.
+	MaxCPUPercent float64 `bson:"max_cpu_percent" json:"max_cpu_percent"`
+	AvgCPUPercent float64 `bson:"avg_cpu_percent" json:"avg_cpu_percent"`
+	// MaxMemoryBytes and AvgMemoryBytes are the peak and average resident
+	// memory of the task's processes.
+	MaxMemoryBytes int64 `bson:"max_memory_bytes" json:"max_memory_bytes"`
+	AvgMemoryBytes int64 `bson:"avg_memory_bytes" json:"avg_memory_bytes"`
+	// DiskReadBytes and DiskWriteBytes are the total disk I/O of the task's
+	// processes. They're only measured on platforms that report per-process
+	// I/O (e.g. Linux).
+	DiskReadBytes  int64 `bson:"disk_read_bytes" json:"disk_read_bytes"`
+	DiskWriteBytes int64 `bson:"disk_write_bytes" json:"disk_write_bytes"`
+	// HostNetworkSentBytes and HostNetworkRecvBytes are the total network I/O
+	// of the whole host while the task ran, since network I/O can't be
+	// attributed to single processes.
+	HostNetworkSentBytes int64 `bson:"host_network_sent_bytes" json:"host_network_sent_bytes"`
+	HostNetworkRecvBytes int64 `bson:"host_network_recv_bytes" json:"host_network_recv_bytes"`
+
+	Samples []ResourceUsageSample `bson:"samples,omitempty" json:"samples,omitempty"`
+}
+
+// ResourceUsageSample is a single point in a task's resource usage time
+// series. CPU and memory are the averages over the sample's interval and
+// disk and network are the totals over it. Like the summary, disk I/O is
+// measured for the task's processes and network I/O for the whole host.
+type ResourceUsageSample struct {
+	Time                 time.Time `bson:"time" json:"time"`
+	CPUPercent           float64   `bson:"cpu_percent" json:"cpu_percent"`
+	MemoryBytes          int64     `bson:"memory_bytes" json:"memory_bytes"`
+	DiskReadBytes        int64     `bson:"disk_read_bytes" json:"disk_read_bytes"`
+	DiskWriteBytes       int64     `bson:"disk_write_bytes" json:"disk_write_bytes"`
+	HostNetworkSentBytes int64     `bson:"host_network_sent_bytes" json:"host_network_sent_bytes"`
+	HostNetworkRecvBytes int64     `bson:"host_network_recv_bytes" json:"host_network_recv_bytes"`
+}
+
+// ExceededMemory returns whether the task's peak memory usage came close
+// enough to the host's total memory that the task likely needs a distro with
+// more memory.
+func (i *ResourceUsageInfo) ExceededMemory() bool {
+	if i == nil || i.TotalMemoryBytes <= 0 {
+		return false
+	}
+	return float64(i.MaxMemoryBytes) >= MemoryWarningThreshold*float64(i.TotalMemoryBytes)
+}
@@ -42,6 +42,8 @@ type LogMessage struct {
 	Message   string    `bson:"m" json:"m"`
 	Timestamp time.Time `bson:"ts" json:"ts"`
 	Version   int       `bson:"v" json:"v"`
+	// Attributes are the key-value pairs of a structured log message.
+	Attributes map[string]string `bson:"a,omitempty" json:"a,omitempty"`
 }
 
 // TaskLog is a group of LogMessages, and mirrors the model.TaskLog
@@ -69,6 +71,20 @@ func GetSeverityMapping(s int) string {
 	}
 }
 
+// GetPriorityMapping maps a log message severity back to a log priority.
+func GetPriorityMapping(severity string) level.Priority {
+	switch severity {
+	case LogErrorPrefix:
+		return level.Error
+	case LogWarnPrefix:
+		return level.Warning
+	case LogDebugPrefix:
+		return level.Debug
+	default:
+		return level.Info
+	}
+}
+
 // GetBuildloggerLogsOptions represents the arguments passed into
 // GetBuildloggerLogs function.
 type GetBuildloggerLogsOptions struct {
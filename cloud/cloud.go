@@ -137,6 +137,8 @@ func GetSettings(provider string) (ProviderSettings, error) {
 		return &GCESettings{}, nil
 	case evergreen.ProviderNameVsphere:
 		return &vsphereSettings{}, nil
+	case evergreen.ProviderNameLibvirt:
+		return &libvirtSettings{}, nil
 	}
 	return nil, errors.Errorf("invalid provider name '%s'", provider)
 }
@@ -181,6 +183,8 @@ func GetManager(ctx context.Context, env evergreen.Environment, mgrOpts ManagerO
 		provider = &gceManager{}
 	case evergreen.ProviderNameVsphere:
 		provider = &vsphereManager{}
+	case evergreen.ProviderNameLibvirt:
+		provider = &libvirtManager{env: env}
 	default:
 		return nil, errors.Errorf("no known provider '%s'", mgrOpts.Provider)
 	}
@@ -163,6 +163,9 @@ type FleetConfig struct {
 	// UseCapacityOptimized will cause Fleet to use the capacity-optimized allocation strategy for spawning hosts. Defaults to the AWS default (lowest-cost).
 	// See https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-fleet-allocation-strategy.html for more information about Fleet allocation strategies.
 	UseCapacityOptimized bool `mapstructure:"use_capacity_optimized" json:"use_capacity_optimized,omitempty" bson:"use_capacity_optimized,omitempty"`
+
+	// FallbackToOnDemand will cause Fleet to request an on-demand instance if there is not enough spot capacity to spawn a host.
+	FallbackToOnDemand bool `mapstructure:"fallback_to_on_demand" json:"fallback_to_on_demand,omitempty" bson:"fallback_to_on_demand,omitempty"`
 }
 
 func (f *FleetConfig) awsTargetCapacityType() types.DefaultTargetCapacityType {
@@ -181,10 +184,19 @@ func (f *FleetConfig) awsAllocationStrategy() types.SpotAllocationStrategy {
 	return ""
 }
 
+// shouldFallBackToOnDemand returns whether Fleet should request an on-demand
+// instance when there's not enough spot capacity.
+func (f *FleetConfig) shouldFallBackToOnDemand() bool {
+	return !f.UseOnDemand && f.FallbackToOnDemand
+}
+
 func (f *FleetConfig) validate() error {
 	if f.UseOnDemand && f.UseCapacityOptimized {
 		return errors.New("on-demand instances can't use the capacity-optimized allocation strategy")
 	}
+	if f.UseOnDemand && f.FallbackToOnDemand {
+		return errors.New("on-demand instances can't fall back to on-demand instances")
+	}
 
 	return nil
 }
@@ -836,8 +836,8 @@ func (c *awsClientImpl) CreateFleet(ctx context.Context, input *ec2.CreateFleetI
 				var apiErr smithy.APIError
 				if errors.As(err, &apiErr) {
 					grip.Debug(message.WrapError(apiErr, msg))
-					if strings.Contains(apiErr.Error(), EC2InsufficientCapacity) {
-						return false, err
+					if isEC2InsufficientCapacityError(apiErr) {
+						return false, EC2InsufficientCapacityError
 					}
 				}
 				return true, err
@@ -856,6 +856,11 @@ func (c *awsClientImpl) CreateFleet(ctx context.Context, input *ec2.CreateFleetI
 						Message: utility.FromStringPtr(output.Errors[0].ErrorMessage),
 					}
 					grip.Debug(message.WrapError(err, msg))
+					if isEC2InsufficientCapacityError(err) {
+						// Retrying won't help if there isn't enough capacity
+						// for the request.
+						return false, EC2InsufficientCapacityError
+					}
 					return true, err
 				}
 				err := errors.New("CreateFleet response contained neither an instance ID nor error")
@@ -1022,6 +1027,14 @@ type awsClientMock struct { //nolint
 	RequestGetInstanceInfoError error
 	*ec2.DescribeInstanceTypeOfferingsOutput
 
+	// SpotCapacityUnavailable causes CreateFleet to fail with insufficient
+	// capacity when it's asked for spot instances.
+	SpotCapacityUnavailable bool
+	// InterruptedInstanceIDs are the IDs of spot instances that
+	// DescribeInstances reports as terminated because their capacity was
+	// reclaimed.
+	InterruptedInstanceIDs []string
+
 	launchTemplates []types.LaunchTemplate
 }
 
@@ -1051,6 +1064,15 @@ func (c *awsClientMock) DescribeInstances(ctx context.Context, input *ec2.Descri
 	if c.DescribeInstancesOutput != nil {
 		return c.DescribeInstancesOutput, nil
 	}
+	if len(input.InstanceIds) > 0 && utility.StringSliceContains(c.InterruptedInstanceIDs, input.InstanceIds[0]) {
+		return &ec2.DescribeInstancesOutput{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{mockInterruptedSpotInstance(input.InstanceIds[0])},
+				},
+			},
+		}, nil
+	}
 	ipv6 := types.InstanceIpv6Address{}
 	ipv6.Ipv6Address = aws.String(MockIPV6)
 	return &ec2.DescribeInstancesOutput{
@@ -1090,6 +1112,26 @@ func (c *awsClientMock) DescribeInstances(ctx context.Context, input *ec2.Descri
 	}, nil
 }
 
+// mockInterruptedSpotInstance returns a spot instance that was terminated
+// because its capacity was reclaimed.
+func mockInterruptedSpotInstance(id string) types.Instance {
+	return types.Instance{
+		InstanceId:        aws.String(id),
+		InstanceType:      "instance_type",
+		InstanceLifecycle: types.InstanceLifecycleTypeSpot,
+		State: &types.InstanceState{
+			Name: types.InstanceStateNameTerminated,
+		},
+		StateReason: &types.StateReason{
+			Code:    aws.String(EC2SpotInstanceTermination),
+			Message: aws.String("Server.SpotInstanceTermination: Spot instance termination"),
+		},
+		Placement: &types.Placement{
+			AvailabilityZone: aws.String("us-east-1a"),
+		},
+	}
+}
+
 // CreateTags is a mock for ec2.CreateTags.
 func (c *awsClientMock) CreateTags(ctx context.Context, input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	c.CreateTagsInput = input
@@ -1230,6 +1272,11 @@ func (c *awsClientMock) GetInstanceInfo(ctx context.Context, id string) (*types.
 		return c.Instance, nil
 	}
 
+	if utility.StringSliceContains(c.InterruptedInstanceIDs, id) {
+		instance := mockInterruptedSpotInstance(id)
+		return &instance, nil
+	}
+
 	instance := &types.Instance{}
 	instance.Placement = &types.Placement{}
 	instance.Placement.AvailabilityZone = aws.String("us-east-1a")
@@ -1319,6 +1366,9 @@ func (c *awsClientMock) GetLaunchTemplates(ctx context.Context, input *ec2.Descr
 // CreateFleet is a mock for ec2.CreateFleet
 func (c *awsClientMock) CreateFleet(ctx context.Context, input *ec2.CreateFleetInput) (*ec2.CreateFleetOutput, error) {
 	c.CreateFleetInput = input
+	if c.SpotCapacityUnavailable && input.TargetCapacitySpecification != nil && input.TargetCapacitySpecification.DefaultTargetCapacityType == types.DefaultTargetCapacityTypeSpot {
+		return nil, EC2InsufficientCapacityError
+	}
 	return &ec2.CreateFleetOutput{
 		Instances: []types.CreateFleetInstance{
 			{
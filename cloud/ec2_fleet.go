@@ -553,6 +553,18 @@ func (m *ec2FleetManager) requestFleet(ctx context.Context, h *host.Host, ec2Set
 	}
 
 	createFleetResponse, err := m.client.CreateFleet(ctx, createFleetInput)
+	if err != nil && errors.Cause(err) == EC2InsufficientCapacityError && ec2Settings.FleetOptions.shouldFallBackToOnDemand() {
+		grip.Info(message.Fields{
+			"message":       "not enough spot capacity to spawn host, falling back to on-demand instance",
+			"host_id":       h.Id,
+			"host_tag":      h.Tag,
+			"distro":        h.Distro.Id,
+			"instance_type": ec2Settings.InstanceType,
+		})
+		createFleetInput.TargetCapacitySpecification.DefaultTargetCapacityType = types.DefaultTargetCapacityTypeOnDemand
+		createFleetInput.SpotOptions = nil
+		createFleetResponse, err = m.client.CreateFleet(ctx, createFleetInput)
+	}
 	if err != nil {
 		return "", errors.Wrap(err, "creating fleet")
 	}
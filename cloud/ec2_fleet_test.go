@@ -113,6 +113,39 @@ func TestFleet(t *testing.T) {
 			assert.Len(t, mockClient.CreateFleetInput.LaunchTemplateConfigs, 1)
 			assert.Equal(t, "ht_1", *mockClient.CreateFleetInput.LaunchTemplateConfigs[0].LaunchTemplateSpecification.LaunchTemplateName)
 		},
+		"RequestFleetFallsBackToOnDemandWithoutSpotCapacity": func(*testing.T) {
+			mockClient := m.client.(*awsClientMock)
+			mockClient.SpotCapacityUnavailable = true
+			ec2Settings := &EC2ProviderSettings{
+				InstanceType: "instanceType0",
+				FleetOptions: FleetConfig{UseCapacityOptimized: true, FallbackToOnDemand: true},
+			}
+
+			instanceID, err := m.requestFleet(context.Background(), h, ec2Settings)
+			assert.NoError(t, err)
+			assert.Equal(t, "i-12345", instanceID)
+
+			assert.Equal(t, types.DefaultTargetCapacityTypeOnDemand, mockClient.CreateFleetInput.TargetCapacitySpecification.DefaultTargetCapacityType)
+			assert.Nil(t, mockClient.CreateFleetInput.SpotOptions)
+		},
+		"RequestFleetFailsWithoutSpotCapacityOrFallback": func(*testing.T) {
+			mockClient := m.client.(*awsClientMock)
+			mockClient.SpotCapacityUnavailable = true
+			ec2Settings := &EC2ProviderSettings{InstanceType: "instanceType0"}
+
+			_, err := m.requestFleet(context.Background(), h, ec2Settings)
+			assert.Error(t, err)
+			assert.Equal(t, EC2InsufficientCapacityError, errors.Cause(err))
+			assert.Equal(t, types.DefaultTargetCapacityTypeSpot, mockClient.CreateFleetInput.TargetCapacitySpecification.DefaultTargetCapacityType)
+		},
+		"GetInstanceStatusInterruptedSpotInstance": func(*testing.T) {
+			mockClient := m.client.(*awsClientMock)
+			mockClient.InterruptedInstanceIDs = []string{h.Id}
+
+			status, err := m.GetInstanceStatus(context.Background(), h)
+			assert.NoError(t, err)
+			assert.Equal(t, StatusTerminated, status)
+		},
 		"MakeOverrides": func(*testing.T) {
 			ec2Settings := &EC2ProviderSettings{
 				InstanceType:          "instanceType0",
@@ -29,10 +29,16 @@ const (
 	EC2ErrorNotFound        = "InvalidInstanceID.NotFound"
 	EC2DuplicateKeyPair     = "InvalidKeyPair.Duplicate"
 	EC2InsufficientCapacity = "InsufficientInstanceCapacity"
-	EC2InvalidParam         = "InvalidParameterValue"
-	EC2VolumeNotFound       = "InvalidVolume.NotFound"
-	EC2VolumeResizeRate     = "VolumeModificationRateExceeded"
-	ec2TemplateNameExists   = "InvalidLaunchTemplateName.AlreadyExistsException"
+	// EC2UnfulfillableCapacity is returned when there is not enough spot
+	// capacity to fulfill a request.
+	EC2UnfulfillableCapacity = "UnfulfillableCapacity"
+	// EC2SpotInstanceTermination is the state reason for a spot instance that
+	// was terminated because its capacity was reclaimed.
+	EC2SpotInstanceTermination = "Server.SpotInstanceTermination"
+	EC2InvalidParam            = "InvalidParameterValue"
+	EC2VolumeNotFound          = "InvalidVolume.NotFound"
+	EC2VolumeResizeRate        = "VolumeModificationRateExceeded"
+	ec2TemplateNameExists      = "InvalidLaunchTemplateName.AlreadyExistsException"
 )
 
 var (
@@ -621,3 +627,17 @@ func isEC2InstanceNotFound(err error) bool {
 	}
 	return false
 }
+
+// isEC2InsufficientCapacityError returns whether the error indicates that EC2
+// did not have enough capacity to fulfill the request.
+func isEC2InsufficientCapacityError(err error) bool {
+	if err == EC2InsufficientCapacityError {
+		return true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return utility.StringSliceContains([]string{EC2InsufficientCapacity, EC2UnfulfillableCapacity}, apiErr.ErrorCode())
+	}
+	return false
+}
@@ -0,0 +1,343 @@
+package cloud
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/pod"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// kubernetesPodIDLabel is the label on Kubernetes resources that
+	// identifies the Evergreen pod that owns them.
+	kubernetesPodIDLabel = "evergreen.mongodb.com/pod-id"
+	// kubernetesDefaultRegistry is the registry that images without an
+	// explicit registry host are pulled from.
+	kubernetesDefaultRegistry = "https://index.docker.io/v1/"
+)
+
+// kubernetesWindowsBuilds maps the Windows versions to the OS build that
+// Kubernetes nodes are labeled with.
+var kubernetesWindowsBuilds = map[pod.WindowsVersion]string{
+	pod.WindowsVersionServer2016: "10.0.14393",
+	pod.WindowsVersionServer2019: "10.0.17763",
+	pod.WindowsVersionServer2022: "10.0.20348",
+}
+
+// KubernetesPodManager manages the lifecycle of pods that run in a Kubernetes
+// cluster.
+type KubernetesPodManager struct {
+	client    kubernetes.Interface
+	settings  *evergreen.Settings
+	namespace string
+}
+
+// NewKubernetesPodManager returns a manager for pods in the Kubernetes cluster
+// configured in the settings.
+func NewKubernetesPodManager(c kubernetes.Interface, settings *evergreen.Settings) *KubernetesPodManager {
+	return &KubernetesPodManager{
+		client:    c,
+		settings:  settings,
+		namespace: settings.Providers.Kubernetes.Namespace,
+	}
+}
+
+// kubernetesRepoCreds are the credentials for a private image registry. This
+// is the same format that repository credentials are stored in the secrets
+// storage service.
+type kubernetesRepoCreds struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// CreatePod creates the Kubernetes resources to run the pod and returns
+// information about the created resources. The pod's secret environment
+// variables are stored in a Kubernetes secret. If repoCreds is non-empty, it
+// must contain the JSON-encoded repository credentials used to pull the
+// container image. If the pod has a container pool, it's created in that
+// pool's namespace and scheduled on that pool's nodes.
+func (m *KubernetesPodManager) CreatePod(ctx context.Context, p *pod.Pod, repoCreds string) (*pod.ResourceInfo, error) {
+	var pool *evergreen.KubernetesContainerPool
+	if p.ContainerPool != "" {
+		pool = m.settings.Providers.Kubernetes.GetContainerPool(p.ContainerPool)
+		if pool == nil {
+			return nil, errors.Errorf("container pool '%s' is not configured", p.ContainerPool)
+		}
+	}
+	namespace := m.namespace
+	if pool != nil && pool.Namespace != "" {
+		namespace = pool.Namespace
+	}
+	secrets := m.client.CoreV1().Secrets(namespace)
+
+	name := kubernetesPodName(p.ID)
+	var secretIDs []string
+	var envSecretName string
+	cleanup := func() {
+		for _, secretID := range secretIDs {
+			grip.Warning(errors.Wrapf(ignoreKubernetesNotFound(secrets.Delete(ctx, secretID, metav1.DeleteOptions{})), "cleaning up secret '%s' for pod '%s'", secretID, p.ID))
+		}
+	}
+
+	if len(p.TaskContainerCreationOpts.EnvSecrets) > 0 {
+		data := map[string]string{}
+		for envVarName, s := range p.TaskContainerCreationOpts.EnvSecrets {
+			data[envVarName] = s.Value
+		}
+		envSecret, err := secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: m.objectMeta(p, name+"-env"),
+			Type:       corev1.SecretTypeOpaque,
+			StringData: data,
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return nil, errors.Wrap(err, "creating secret for environment variables")
+		}
+		envSecretName = envSecret.Name
+		secretIDs = append(secretIDs, envSecretName)
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	if repoCreds != "" {
+		dockerConfig, err := exportKubernetesDockerConfig(p.TaskContainerCreationOpts.Image, repoCreds)
+		if err != nil {
+			cleanup()
+			return nil, errors.Wrap(err, "exporting repository credentials")
+		}
+		pullSecret, err := secrets.Create(ctx, &corev1.Secret{
+			ObjectMeta: m.objectMeta(p, name+"-repo-creds"),
+			Type:       corev1.SecretTypeDockerConfigJson,
+			StringData: map[string]string{corev1.DockerConfigJsonKey: dockerConfig},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			cleanup()
+			return nil, errors.Wrap(err, "creating secret for repository credentials")
+		}
+		secretIDs = append(secretIDs, pullSecret.Name)
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: pullSecret.Name})
+	}
+
+	spec := ExportKubernetesPod(m.settings, p.TaskContainerCreationOpts, envSecretName, pool)
+	spec.ObjectMeta = m.objectMeta(p, name)
+	spec.Spec.ImagePullSecrets = pullSecrets
+
+	created, err := m.client.CoreV1().Pods(namespace).Create(ctx, spec, metav1.CreateOptions{})
+	if err != nil {
+		cleanup()
+		return nil, errors.Wrap(err, "creating pod")
+	}
+
+	return &pod.ResourceInfo{
+		ExternalID: created.Name,
+		Cluster:    namespace,
+		Containers: []pod.ContainerResourceInfo{{
+			Name:      agentContainerName,
+			SecretIDs: secretIDs,
+		}},
+	}, nil
+}
+
+// LatestPhase returns the current phase of the pod in the Kubernetes cluster.
+// If the pod no longer exists in the cluster, this returns an empty phase.
+func (m *KubernetesPodManager) LatestPhase(ctx context.Context, p *pod.Pod) (corev1.PodPhase, error) {
+	if p.Resources.ExternalID == "" {
+		return "", errors.New("pod does not have a Kubernetes pod name")
+	}
+	kp, err := m.client.CoreV1().Pods(m.podNamespace(p)).Get(ctx, p.Resources.ExternalID, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "getting pod '%s'", p.Resources.ExternalID)
+	}
+	return kp.Status.Phase, nil
+}
+
+// DeletePod deletes the pod and all of its secrets from the Kubernetes
+// cluster. Resources that have already been deleted are ignored.
+func (m *KubernetesPodManager) DeletePod(ctx context.Context, p *pod.Pod) error {
+	namespace := m.podNamespace(p)
+	if p.Resources.ExternalID != "" {
+		if err := ignoreKubernetesNotFound(m.client.CoreV1().Pods(namespace).Delete(ctx, p.Resources.ExternalID, metav1.DeleteOptions{})); err != nil {
+			return errors.Wrapf(err, "deleting pod '%s'", p.Resources.ExternalID)
+		}
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, c := range p.Resources.Containers {
+		for _, secretID := range c.SecretIDs {
+			catcher.Wrapf(ignoreKubernetesNotFound(m.client.CoreV1().Secrets(namespace).Delete(ctx, secretID, metav1.DeleteOptions{})), "deleting secret '%s'", secretID)
+		}
+	}
+
+	return catcher.Resolve()
+}
+
+// ignoreKubernetesNotFound returns nil if the error is because the resource
+// does not exist.
+func ignoreKubernetesNotFound(err error) error {
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// podNamespace returns the namespace that the pod was created in. This
+// falls back to the configured namespace in case the pod was never created.
+func (m *KubernetesPodManager) podNamespace(p *pod.Pod) string {
+	if p.Resources.Cluster != "" {
+		return p.Resources.Cluster
+	}
+	return m.namespace
+}
+
+func (m *KubernetesPodManager) objectMeta(p *pod.Pod, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{kubernetesPodIDLabel: p.ID},
+	}
+}
+
+// kubernetesPodName returns the name of the Kubernetes pod for the Evergreen
+// pod ID. Kubernetes resource names must be lowercase DNS subdomains, so any
+// other characters are replaced.
+func kubernetesPodName(podID string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '-'
+		}
+	}, podID)
+	return "evg-pod-" + name
+}
+
+// ExportKubernetesPod converts the container creation options into a
+// Kubernetes pod that runs the agent. The CPU and memory from the container
+// size are used as both the resource requests and limits. If envSecretName is
+// given, the secret environment variables are read from that Kubernetes
+// secret; the secret must have a key for each secret environment variable. If
+// pool is given, the pod is only scheduled on nodes in that container pool.
+func ExportKubernetesPod(settings *evergreen.Settings, opts pod.TaskContainerCreationOptions, envSecretName string, pool *evergreen.KubernetesContainerPool) *corev1.Pod {
+	var env []corev1.EnvVar
+	for name, val := range opts.EnvVars {
+		env = append(env, corev1.EnvVar{Name: name, Value: val})
+	}
+	if envSecretName != "" {
+		for name := range opts.EnvSecrets {
+			env = append(env, corev1.EnvVar{
+				Name: name,
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: envSecretName},
+						Key:                  name,
+					},
+				},
+			})
+		}
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+
+	resources := corev1.ResourceList{}
+	if opts.CPU > 0 {
+		// ECS CPU units are 1/1024 of a vCPU, whereas Kubernetes CPU is
+		// measured in 1/1000 of a vCPU.
+		resources[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(opts.CPU*1000/1024), resource.DecimalSI)
+	}
+	if opts.MemoryMB > 0 {
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(opts.MemoryMB)*1024*1024, resource.BinarySI)
+	}
+
+	nodeSelector := map[string]string{}
+	if pool != nil {
+		for label, val := range pool.NodeSelector {
+			nodeSelector[label] = val
+		}
+	}
+	if opts.OS != "" {
+		nodeSelector[corev1.LabelOSStable] = string(opts.OS)
+	}
+	if opts.Arch != "" {
+		nodeSelector[corev1.LabelArchStable] = string(opts.Arch)
+	}
+	if build, ok := kubernetesWindowsBuilds[opts.WindowsVersion]; ok && opts.OS == pod.OSWindows {
+		nodeSelector[corev1.LabelWindowsBuild] = build
+	}
+
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:       agentContainerName,
+				Image:      opts.Image,
+				Command:    bootstrapContainerCommand(settings, opts),
+				WorkingDir: opts.WorkingDir,
+				Env:        env,
+				Ports:      []corev1.ContainerPort{{ContainerPort: int32(agentPort)}},
+				Resources: corev1.ResourceRequirements{
+					Requests: resources,
+					Limits:   resources.DeepCopy(),
+				},
+			}},
+			NodeSelector: nodeSelector,
+			// The agent exits once it's done running tasks, so the pod
+			// should not be restarted.
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+// exportKubernetesDockerConfig converts the JSON-encoded repository
+// credentials into a Docker config that authenticates with the image's
+// registry.
+func exportKubernetesDockerConfig(image, repoCreds string) (string, error) {
+	var creds kubernetesRepoCreds
+	if err := json.Unmarshal([]byte(repoCreds), &creds); err != nil {
+		return "", errors.Wrap(err, "unmarshalling repository credentials")
+	}
+	if creds.Username == "" || creds.Password == "" {
+		return "", errors.New("repository credentials must have a username and password")
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(creds.Username + ":" + creds.Password))
+	dockerConfig := map[string]interface{}{
+		"auths": map[string]interface{}{
+			imageRegistry(image): map[string]string{
+				"username": creds.Username,
+				"password": creds.Password,
+				"auth":     auth,
+			},
+		},
+	}
+	b, err := json.Marshal(dockerConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling Docker config")
+	}
+	return string(b), nil
+}
+
+// imageRegistry returns the registry host for the image. Images that don't
+// explicitly name a registry are pulled from Docker Hub.
+func imageRegistry(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) < 2 {
+		return kubernetesDefaultRegistry
+	}
+	host := parts[0]
+	if strings.ContainsAny(host, ".:") || host == "localhost" {
+		return host
+	}
+	return kubernetesDefaultRegistry
+}
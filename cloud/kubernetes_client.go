@@ -0,0 +1,71 @@
+package cloud
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// kubernetesClientTimeout is the timeout for each request to the Kubernetes
+// API server.
+const kubernetesClientTimeout = time.Minute
+
+// MakeKubernetesClient creates a Kubernetes clientset to interact with the
+// Kubernetes cluster configured in the settings.
+func MakeKubernetesClient(settings *evergreen.Settings) (kubernetes.Interface, error) {
+	switch settings.Providers.Kubernetes.ClientType {
+	case evergreen.KubernetesClientTypeMock:
+		// This should only ever be used for testing purposes.
+		return fake.NewSimpleClientset(), nil
+	default:
+		conf, err := kubernetesRESTConfig(settings.Providers.Kubernetes)
+		if err != nil {
+			return nil, errors.Wrap(err, "getting Kubernetes client config")
+		}
+		c, err := kubernetes.NewForConfig(conf)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating Kubernetes client")
+		}
+		return c, nil
+	}
+}
+
+// kubernetesRESTConfig returns the config to connect to the Kubernetes API
+// server. The kubeconfig file takes precedence, followed by the explicitly
+// configured API server. If neither is configured, this assumes that
+// Evergreen is running inside the cluster and uses its service account.
+func kubernetesRESTConfig(kubeConf evergreen.KubernetesConfig) (*rest.Config, error) {
+	var conf *rest.Config
+	switch {
+	case kubeConf.Kubeconfig != "":
+		rules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConf.Kubeconfig}
+		overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeConf.Context}
+		var err error
+		conf, err = clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading kubeconfig '%s'", kubeConf.Kubeconfig)
+		}
+	case kubeConf.APIServerURL != "":
+		conf = &rest.Config{
+			Host:        kubeConf.APIServerURL,
+			BearerToken: kubeConf.Token,
+		}
+		if kubeConf.CACert != "" {
+			conf.TLSClientConfig.CAData = []byte(kubeConf.CACert)
+		}
+	default:
+		var err error
+		conf, err = rest.InClusterConfig()
+		if err != nil {
+			return nil, errors.Wrap(err, "getting in-cluster config")
+		}
+	}
+	conf.Timeout = kubernetesClientTimeout
+
+	return conf, nil
+}
@@ -0,0 +1,340 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/pod"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestExportKubernetesPod(t *testing.T) {
+	settings := &evergreen.Settings{ApiUrl: "https://example.com"}
+	opts := pod.TaskContainerCreationOptions{
+		Image:          "image",
+		CPU:            2048,
+		MemoryMB:       512,
+		OS:             pod.OSWindows,
+		Arch:           pod.ArchAMD64,
+		WindowsVersion: pod.WindowsVersionServer2019,
+		WorkingDir:     "/data",
+		EnvVars:        map[string]string{pod.PodIDEnvVar: "pod_id"},
+		EnvSecrets:     map[string]pod.Secret{pod.PodSecretEnvVar: {ExternalID: "secret_id", Value: "secret_value"}},
+	}
+
+	t.Run("MapsContainerOptions", func(t *testing.T) {
+		kp := ExportKubernetesPod(settings, opts, "env_secret", nil)
+		require.Len(t, kp.Spec.Containers, 1)
+		c := kp.Spec.Containers[0]
+		assert.Equal(t, agentContainerName, c.Name)
+		assert.Equal(t, opts.Image, c.Image)
+		assert.Equal(t, opts.WorkingDir, c.WorkingDir)
+		assert.Equal(t, bootstrapContainerCommand(settings, opts), c.Command)
+		assert.Equal(t, "2", c.Resources.Requests.Cpu().String())
+		assert.Equal(t, "512Mi", c.Resources.Requests.Memory().String())
+		assert.Equal(t, c.Resources.Requests, c.Resources.Limits)
+		assert.Equal(t, corev1.RestartPolicyNever, kp.Spec.RestartPolicy)
+
+		assert.Equal(t, "windows", kp.Spec.NodeSelector["kubernetes.io/os"])
+		assert.Equal(t, "amd64", kp.Spec.NodeSelector["kubernetes.io/arch"])
+		assert.Equal(t, "10.0.17763", kp.Spec.NodeSelector["node.kubernetes.io/windows-build"])
+
+		require.Len(t, c.Env, 2)
+		assert.Equal(t, pod.PodIDEnvVar, c.Env[0].Name)
+		assert.Equal(t, "pod_id", c.Env[0].Value)
+		assert.Equal(t, pod.PodSecretEnvVar, c.Env[1].Name)
+		assert.Zero(t, c.Env[1].Value, "secret value should not be in plaintext")
+		require.NotZero(t, c.Env[1].ValueFrom)
+		require.NotZero(t, c.Env[1].ValueFrom.SecretKeyRef)
+		assert.Equal(t, "env_secret", c.Env[1].ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, pod.PodSecretEnvVar, c.Env[1].ValueFrom.SecretKeyRef.Key)
+	})
+	t.Run("SelectsContainerPoolNodes", func(t *testing.T) {
+		kp := ExportKubernetesPod(settings, opts, "", &evergreen.KubernetesContainerPool{
+			Name:         "pool",
+			NodeSelector: map[string]string{"evergreen.mongodb.com/pool": "pool"},
+		})
+		assert.Equal(t, "pool", kp.Spec.NodeSelector["evergreen.mongodb.com/pool"])
+		assert.Equal(t, "windows", kp.Spec.NodeSelector["kubernetes.io/os"], "platform node selectors should still apply")
+	})
+	t.Run("OmitsSecretsWithoutSecretName", func(t *testing.T) {
+		kp := ExportKubernetesPod(settings, opts, "", nil)
+		require.Len(t, kp.Spec.Containers, 1)
+		require.Len(t, kp.Spec.Containers[0].Env, 1)
+		assert.Equal(t, pod.PodIDEnvVar, kp.Spec.Containers[0].Env[0].Name)
+	})
+}
+
+func TestImageRegistry(t *testing.T) {
+	assert.Equal(t, kubernetesDefaultRegistry, imageRegistry("ubuntu"))
+	assert.Equal(t, kubernetesDefaultRegistry, imageRegistry("library/ubuntu:22.04"))
+	assert.Equal(t, "ghcr.io", imageRegistry("ghcr.io/evergreen-ci/image"))
+	assert.Equal(t, "localhost:5000", imageRegistry("localhost:5000/image"))
+}
+
+func TestKubernetesPodManager(t *testing.T) {
+	const (
+		namespace     = "evergreen"
+		poolNamespace = "evergreen-pool"
+	)
+	settings := &evergreen.Settings{
+		ApiUrl: "https://example.com",
+		Providers: evergreen.CloudProviders{
+			Kubernetes: evergreen.KubernetesConfig{
+				Namespace: namespace,
+				ContainerPools: []evergreen.KubernetesContainerPool{{
+					Name:         "pool",
+					Namespace:    poolNamespace,
+					NodeSelector: map[string]string{"evergreen.mongodb.com/pool": "pool"},
+				}},
+			},
+		},
+	}
+	makePod := func() *pod.Pod {
+		return &pod.Pod{
+			ID:       "6463d3b5e2f0c4b4a8d6b5e1",
+			Status:   pod.StatusInitializing,
+			Platform: pod.PlatformKubernetes,
+			TaskContainerCreationOpts: pod.TaskContainerCreationOptions{
+				Image:      "ghcr.io/evergreen-ci/image",
+				CPU:        1024,
+				MemoryMB:   256,
+				OS:         pod.OSLinux,
+				Arch:       pod.ArchARM64,
+				EnvVars:    map[string]string{pod.PodIDEnvVar: "6463d3b5e2f0c4b4a8d6b5e1"},
+				EnvSecrets: map[string]pod.Secret{pod.PodSecretEnvVar: {ExternalID: "secret_id", Value: "secret_value"}},
+			},
+		}
+	}
+	listSecrets := func(ctx context.Context, t *testing.T, c *fake.Clientset, namespace string) []corev1.Secret {
+		secrets, err := c.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return secrets.Items
+	}
+	listPods := func(ctx context.Context, t *testing.T, c *fake.Clientset, namespace string) []corev1.Pod {
+		pods, err := c.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return pods.Items
+	}
+	setPodPhase := func(ctx context.Context, t *testing.T, c *fake.Clientset, namespace, name string, phase corev1.PodPhase) {
+		kp, err := c.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		kp.Status.Phase = phase
+		_, err = c.CoreV1().Pods(namespace).UpdateStatus(ctx, kp, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+	failOn := func(c *fake.Clientset, verb, resource string) {
+		c.PrependReactor(verb, resource, func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.Errorf("fake error for %s %s", verb, resource)
+		})
+	}
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager){
+		"CreatesPodAndSecrets": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			res, err := m.CreatePod(ctx, p, "")
+			require.NoError(t, err)
+			require.NotZero(t, res)
+
+			assert.Equal(t, "evg-pod-6463d3b5e2f0c4b4a8d6b5e1", res.ExternalID)
+			assert.Equal(t, namespace, res.Cluster)
+			require.Len(t, res.Containers, 1)
+			assert.Equal(t, agentContainerName, res.Containers[0].Name)
+			require.Len(t, res.Containers[0].SecretIDs, 1)
+
+			kp, err := c.CoreV1().Pods(namespace).Get(ctx, res.ExternalID, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, p.ID, kp.Labels[kubernetesPodIDLabel])
+			assert.Equal(t, "1", kp.Spec.Containers[0].Resources.Requests.Cpu().String())
+			assert.Equal(t, "256Mi", kp.Spec.Containers[0].Resources.Requests.Memory().String())
+			assert.Empty(t, kp.Spec.ImagePullSecrets)
+			assert.NotContains(t, kp.Spec.NodeSelector, "evergreen.mongodb.com/pool")
+
+			secret, err := c.CoreV1().Secrets(namespace).Get(ctx, res.Containers[0].SecretIDs[0], metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+			assert.Equal(t, "secret_value", secret.StringData[pod.PodSecretEnvVar])
+		},
+		"CreatesPodInContainerPool": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			p.ContainerPool = "pool"
+			res, err := m.CreatePod(ctx, p, "")
+			require.NoError(t, err)
+			assert.Equal(t, poolNamespace, res.Cluster)
+
+			kp, err := c.CoreV1().Pods(poolNamespace).Get(ctx, res.ExternalID, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, "pool", kp.Spec.NodeSelector["evergreen.mongodb.com/pool"])
+			assert.Len(t, listSecrets(ctx, t, c, poolNamespace), 1)
+			assert.Empty(t, listPods(ctx, t, c, namespace))
+		},
+		"FailsWithUnconfiguredContainerPool": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			p.ContainerPool = "nonexistent"
+			_, err := m.CreatePod(ctx, p, "")
+			assert.Error(t, err)
+			assert.Empty(t, listSecrets(ctx, t, c, namespace))
+		},
+		"CreatesImagePullSecretForRepoCreds": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			res, err := m.CreatePod(ctx, makePod(), `{"username":"user","password":"pass"}`)
+			require.NoError(t, err)
+			require.Len(t, res.Containers, 1)
+			require.Len(t, res.Containers[0].SecretIDs, 2)
+
+			kp, err := c.CoreV1().Pods(namespace).Get(ctx, res.ExternalID, metav1.GetOptions{})
+			require.NoError(t, err)
+			require.Len(t, kp.Spec.ImagePullSecrets, 1)
+			pullSecret, err := c.CoreV1().Secrets(namespace).Get(ctx, kp.Spec.ImagePullSecrets[0].Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, corev1.SecretTypeDockerConfigJson, pullSecret.Type)
+
+			var dockerConfig struct {
+				Auths map[string]struct {
+					Username string `json:"username"`
+					Password string `json:"password"`
+				} `json:"auths"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(pullSecret.StringData[corev1.DockerConfigJsonKey]), &dockerConfig))
+			require.Contains(t, dockerConfig.Auths, "ghcr.io")
+			assert.Equal(t, "user", dockerConfig.Auths["ghcr.io"].Username)
+			assert.Equal(t, "pass", dockerConfig.Auths["ghcr.io"].Password)
+		},
+		"FailsWithInvalidRepoCreds": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			_, err := m.CreatePod(ctx, makePod(), `{"username":"user"}`)
+			assert.Error(t, err)
+			assert.Empty(t, listPods(ctx, t, c, namespace))
+			assert.Empty(t, listSecrets(ctx, t, c, namespace), "secrets should be cleaned up after failure")
+		},
+		"CleansUpSecretsWhenPodCreationFails": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			failOn(c, "create", "pods")
+			_, err := m.CreatePod(ctx, makePod(), "")
+			assert.Error(t, err)
+			assert.Empty(t, listSecrets(ctx, t, c, namespace))
+		},
+		"ReturnsLatestPhase": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			res, err := m.CreatePod(ctx, p, "")
+			require.NoError(t, err)
+			p.Resources = *res
+
+			setPodPhase(ctx, t, c, namespace, res.ExternalID, corev1.PodRunning)
+			phase, err := m.LatestPhase(ctx, p)
+			require.NoError(t, err)
+			assert.Equal(t, corev1.PodRunning, phase)
+
+			setPodPhase(ctx, t, c, namespace, res.ExternalID, corev1.PodFailed)
+			phase, err = m.LatestPhase(ctx, p)
+			require.NoError(t, err)
+			assert.Equal(t, corev1.PodFailed, phase)
+		},
+		"ReturnsEmptyPhaseForNonexistentPod": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			p.Resources = pod.ResourceInfo{ExternalID: "nonexistent", Cluster: namespace}
+
+			phase, err := m.LatestPhase(ctx, p)
+			require.NoError(t, err)
+			assert.Zero(t, phase)
+		},
+		"FailsToGetPhaseWhenClusterErrors": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			p.Resources = pod.ResourceInfo{ExternalID: "name", Cluster: namespace}
+			failOn(c, "get", "pods")
+
+			_, err := m.LatestPhase(ctx, p)
+			assert.Error(t, err)
+		},
+		"DeletesPodAndSecrets": func(ctx context.Context, t *testing.T, c *fake.Clientset, m *KubernetesPodManager) {
+			p := makePod()
+			res, err := m.CreatePod(ctx, p, `{"username":"user","password":"pass"}`)
+			require.NoError(t, err)
+			p.Resources = *res
+
+			require.NoError(t, m.DeletePod(ctx, p))
+			assert.Empty(t, listPods(ctx, t, c, namespace))
+			assert.Empty(t, listSecrets(ctx, t, c, namespace))
+
+			assert.NoError(t, m.DeletePod(ctx, p), "deleting already-deleted pod should no-op")
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			c := fake.NewSimpleClientset()
+			tCase(ctx, t, c, NewKubernetesPodManager(c, settings))
+		})
+	}
+}
+
+func TestKubernetesRESTConfig(t *testing.T) {
+	t.Run("UsesKubeconfigContext", func(t *testing.T) {
+		kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+		require.NoError(t, os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: default
+clusters:
+- name: default
+  cluster:
+    server: https://default.example.com
+- name: other
+  cluster:
+    server: https://other.example.com
+contexts:
+- name: default
+  context:
+    cluster: default
+    user: user
+- name: other
+  context:
+    cluster: other
+    user: user
+users:
+- name: user
+  user:
+    token: kubeconfig_token
+`), 0600))
+
+		conf, err := kubernetesRESTConfig(evergreen.KubernetesConfig{Kubeconfig: kubeconfig})
+		require.NoError(t, err)
+		assert.Equal(t, "https://default.example.com", conf.Host)
+		assert.Equal(t, "kubeconfig_token", conf.BearerToken)
+		assert.Equal(t, kubernetesClientTimeout, conf.Timeout)
+
+		conf, err = kubernetesRESTConfig(evergreen.KubernetesConfig{Kubeconfig: kubeconfig, Context: "other"})
+		require.NoError(t, err)
+		assert.Equal(t, "https://other.example.com", conf.Host)
+	})
+	t.Run("FailsWithNonexistentKubeconfig", func(t *testing.T) {
+		_, err := kubernetesRESTConfig(evergreen.KubernetesConfig{Kubeconfig: filepath.Join(t.TempDir(), "nonexistent")})
+		assert.Error(t, err)
+	})
+	t.Run("UsesAPIServerURL", func(t *testing.T) {
+		conf, err := kubernetesRESTConfig(evergreen.KubernetesConfig{
+			APIServerURL: "https://kubernetes.example.com",
+			Token:        "token",
+			CACert:       "ca_cert",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://kubernetes.example.com", conf.Host)
+		assert.Equal(t, "token", conf.BearerToken)
+		assert.Equal(t, []byte("ca_cert"), conf.TLSClientConfig.CAData)
+	})
+	t.Run("FallsBackToInClusterConfig", func(t *testing.T) {
+		t.Setenv("KUBERNETES_SERVICE_HOST", "")
+		t.Setenv("KUBERNETES_SERVICE_PORT", "")
+
+		_, err := kubernetesRESTConfig(evergreen.KubernetesConfig{})
+		assert.Error(t, err, "should fail to get in-cluster config outside of a cluster")
+	})
+}
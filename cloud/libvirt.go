@@ -0,0 +1,569 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// defaultLibvirtStoragePool is the storage pool that libvirt creates by
+	// default.
+	defaultLibvirtStoragePool = "default"
+	// defaultLibvirtNetwork is the virtual network that libvirt creates by
+	// default.
+	defaultLibvirtNetwork  = "default"
+	defaultLibvirtNumCPUs  = 2
+	defaultLibvirtMemoryMB = 4096
+)
+
+// libvirtManager implements the Manager interface for libvirt. Each host is a
+// KVM/QEMU virtual machine (a libvirt domain) whose name is the host ID.
+type libvirtManager struct {
+	client   libvirtClient
+	env      evergreen.Environment
+	settings *evergreen.Settings
+}
+
+// libvirtSettings specifies the settings used to configure a host instance.
+type libvirtSettings struct {
+	// URI is the libvirt connection URI for the hypervisor that runs the
+	// VMs (e.g. qemu+ssh://user@kvm-host/system). If it's not set, the URI
+	// in the admin settings is used.
+	URI string `mapstructure:"uri" json:"uri,omitempty" bson:"uri,omitempty"`
+	// BaseImage is the name of the volume in the storage pool that new VMs'
+	// disks are cloned from.
+	BaseImage string `mapstructure:"base_image" json:"base_image" bson:"base_image"`
+	// StoragePool is the storage pool containing the base image, in which VM
+	// disks are created. If it's not set, the storage pool in the admin
+	// settings is used, or libvirt's default pool if neither is set.
+	StoragePool string `mapstructure:"storage_pool" json:"storage_pool,omitempty" bson:"storage_pool,omitempty"`
+	// Network is the libvirt virtual network the VMs are connected to.
+	Network string `mapstructure:"network" json:"network,omitempty" bson:"network,omitempty"`
+
+	NumCPUs  int `mapstructure:"num_cpus" json:"num_cpus,omitempty" bson:"num_cpus,omitempty"`
+	MemoryMB int `mapstructure:"memory_mb" json:"memory_mb,omitempty" bson:"memory_mb,omitempty"`
+	// DiskSizeGB is the size of the VM's disk. If it's not set, the disk is
+	// the same size as the base image.
+	DiskSizeGB int `mapstructure:"disk_size_gb" json:"disk_size_gb,omitempty" bson:"disk_size_gb,omitempty"`
+}
+
+// Validate verifies a set of ProviderSettings and sets defaults for the
+// optional settings.
+func (opts *libvirtSettings) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(opts.BaseImage == "", "base image must not be blank")
+	if opts.URI != "" {
+		catcher.Wrap(validateLibvirtURI(opts.URI), "invalid connection URI")
+	}
+	catcher.NewWhen(opts.NumCPUs < 0, "number of CPUs must be non-negative")
+	catcher.NewWhen(opts.MemoryMB < 0, "memory in MB must be non-negative")
+	catcher.NewWhen(opts.DiskSizeGB < 0, "disk size in GB must be non-negative")
+	if catcher.HasErrors() {
+		return catcher.Resolve()
+	}
+
+	if opts.Network == "" {
+		opts.Network = defaultLibvirtNetwork
+	}
+	if opts.NumCPUs == 0 {
+		opts.NumCPUs = defaultLibvirtNumCPUs
+	}
+	if opts.MemoryMB == 0 {
+		opts.MemoryMB = defaultLibvirtMemoryMB
+	}
+
+	return nil
+}
+
+func (opts *libvirtSettings) FromDistroSettings(d distro.Distro, _ string) error {
+	if len(d.ProviderSettingsList) != 0 {
+		bytes, err := d.ProviderSettingsList[0].MarshalBSON()
+		if err != nil {
+			return errors.Wrap(err, "marshalling provider setting into BSON")
+		}
+		if err := bson.Unmarshal(bytes, opts); err != nil {
+			return errors.Wrap(err, "unmarshalling BSON into provider settings")
+		}
+	}
+	return nil
+}
+
+// validateLibvirtURI checks that the URI is a connection URI for the QEMU
+// driver, which manages KVM/QEMU VMs.
+func validateLibvirtURI(uri string) error {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return errors.Wrapf(err, "parsing URI '%s'", uri)
+	}
+	if u.Scheme != "qemu" && !strings.HasPrefix(u.Scheme, "qemu+") {
+		return errors.Errorf("URI '%s' must use the QEMU driver (e.g. 'qemu+ssh://user@host/system')", uri)
+	}
+	return nil
+}
+
+// Configure loads the default libvirt settings from the global config object.
+func (m *libvirtManager) Configure(ctx context.Context, s *evergreen.Settings) error {
+	m.settings = s
+
+	if m.client == nil {
+		if m.env == nil {
+			return errors.New("libvirt manager requires a non-nil Evergreen environment")
+		}
+		m.client = &libvirtClientImpl{jasper: m.env.JasperManager()}
+	}
+
+	return nil
+}
+
+// getDistroSettings returns the distro's libvirt settings, using the admin
+// settings for any connection settings the distro doesn't set.
+func (m *libvirtManager) getDistroSettings(d distro.Distro) (*libvirtSettings, error) {
+	s := &libvirtSettings{}
+	if err := s.FromDistroSettings(d, ""); err != nil {
+		return nil, errors.Wrapf(err, "decoding provider settings for distro '%s'", d.Id)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid provider settings in distro '%s'", d.Id)
+	}
+
+	if s.URI == "" {
+		s.URI = m.settings.Providers.Libvirt.URI
+	}
+	if s.URI == "" {
+		return nil, errors.Errorf("distro '%s' has no libvirt connection URI and there is no default URI", d.Id)
+	}
+	if s.StoragePool == "" {
+		s.StoragePool = m.volumeStoragePool()
+	}
+
+	return s, nil
+}
+
+// volumeStoragePool returns the storage pool that volumes are created in.
+func (m *libvirtManager) volumeStoragePool() string {
+	if pool := m.settings.Providers.Libvirt.StoragePool; pool != "" {
+		return pool
+	}
+	return defaultLibvirtStoragePool
+}
+
+// volumeURI returns the connection URI for the hypervisor that has the volume.
+// Volumes are created on the same hypervisor as the hosts they're for, which
+// is recorded as the volume's availability zone.
+func (m *libvirtManager) volumeURI(volume *host.Volume) (string, error) {
+	uri := volume.AvailabilityZone
+	if uri == "" {
+		uri = m.settings.Providers.Libvirt.URI
+	}
+	if uri == "" {
+		return "", errors.Errorf("volume '%s' has no libvirt connection URI and there is no default URI", volume.ID)
+	}
+	return uri, nil
+}
+
+// SpawnHost clones a new VM from the distro's base image and starts it.
+//
+// libvirtSettings in the distro should have the following settings:
+//   - BaseImage    (string): name of the base image volume in the storage pool
+//   - URI          (string): (optional) libvirt connection URI e.g. qemu+ssh://evergreen@kvm01/system
+//   - StoragePool  (string): (optional) name of the storage pool e.g. default
+//   - Network      (string): (optional) name of the virtual network e.g. default
+//   - NumCPUs      (int):    (optional) number of CPUs e.g. 2
+//   - MemoryMB     (int):    (optional) memory in MB e.g. 4096
+//   - DiskSizeGB   (int):    (optional) disk size in GB e.g. 100
+//
+// The hypervisor's connection URI is recorded as the host's zone.
+func (m *libvirtManager) SpawnHost(ctx context.Context, h *host.Host) (*host.Host, error) {
+	if h.Distro.Provider != evergreen.ProviderNameLibvirt {
+		return nil, errors.Errorf("can't spawn instance for distro '%s': distro provider is '%s'", h.Distro.Id, h.Distro.Provider)
+	}
+
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err = m.client.CreateDomain(ctx, s.URI, h.Id, s); err != nil {
+		return nil, errors.Wrapf(err, "creating VM for host '%s'", h.Id)
+	}
+	h.Zone = s.URI
+
+	grip.Debug(message.Fields{
+		"message":    "spawned new instance",
+		"instance":   h.Id,
+		"distro":     h.Distro.Id,
+		"provider":   h.Provider,
+		"base_image": s.BaseImage,
+		"uri":        s.URI,
+	})
+
+	return h, nil
+}
+
+func (m *libvirtManager) ModifyHost(context.Context, *host.Host, host.HostModifyOptions) error {
+	return errors.New("can't modify instances with libvirt provider")
+}
+
+// GetInstanceStatus returns the status of the host's VM.
+func (m *libvirtManager) GetInstanceStatus(ctx context.Context, h *host.Host) (CloudStatus, error) {
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return StatusUnknown, errors.WithStack(err)
+	}
+
+	state, err := m.client.GetDomainState(ctx, s.URI, h.Id)
+	if err != nil {
+		return StatusUnknown, errors.Wrapf(err, "getting state of VM for host '%s'", h.Id)
+	}
+
+	return libvirtToEvgStatus(state), nil
+}
+
+func (m *libvirtManager) SetPortMappings(context.Context, *host.Host, *host.Host) error {
+	return errors.New("can't set port mappings with libvirt provider")
+}
+
+// TerminateInstance destroys the host's VM and deletes its disk. Volumes
+// attached to the host are detached but not deleted.
+func (m *libvirtManager) TerminateInstance(ctx context.Context, h *host.Host, user, reason string) error {
+	if h.Status == evergreen.HostTerminated {
+		return errors.Errorf("cannot terminate host '%s' because it's already marked as terminated", h.Id)
+	}
+
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, attachment := range h.Volumes {
+		volume, err := host.FindVolumeByID(attachment.VolumeID)
+		if err != nil {
+			return errors.Wrapf(err, "finding volume '%s'", attachment.VolumeID)
+		}
+		if volume == nil {
+			continue
+		}
+		if err = m.client.DetachVolume(ctx, s.URI, h.Id, m.volumeStoragePool(), volume.ID); err != nil {
+			return errors.Wrapf(err, "detaching volume '%s' from host '%s'", volume.ID, h.Id)
+		}
+		if err = h.RemoveVolumeFromHost(ctx, volume.ID); err != nil {
+			return errors.Wrapf(err, "detaching volume '%s' from host '%s' in DB", volume.ID, h.Id)
+		}
+	}
+
+	if err = m.client.DeleteDomain(ctx, s.URI, h.Id, s.StoragePool); err != nil {
+		return errors.Wrapf(err, "deleting VM for host '%s'", h.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":       "terminated instance",
+		"user":          user,
+		"host_provider": h.Distro.Provider,
+		"host_id":       h.Id,
+		"distro":        h.Distro.Id,
+	})
+
+	return errors.Wrapf(h.Terminate(ctx, user, reason), "terminating host '%s' in DB", h.Id)
+}
+
+// StopInstance shuts down the host's VM and waits for it to stop.
+func (m *libvirtManager) StopInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status == evergreen.HostStopped {
+		return errors.Errorf("cannot stop host '%s' because it is already marked as stopped", h.Id)
+	} else if h.Status != evergreen.HostRunning && h.Status != evergreen.HostStopping {
+		return errors.Errorf("cannot stop host '%s' because its status ('%s') is not a stoppable state", h.Id, h.Status)
+	}
+
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.client.ShutdownDomain(ctx, s.URI, h.Id); err != nil {
+		return errors.Wrapf(err, "shutting down VM for host '%s'", h.Id)
+	}
+	grip.Error(message.WrapError(h.SetStopping(ctx, user), message.Fields{
+		"message": "could not mark host as stopping, continuing to poll instance status anyways",
+		"host_id": h.Id,
+		"user":    user,
+	}))
+
+	// VMs shut down asynchronously, so before we can say the host is stopped,
+	// we have to poll the status until it's actually stopped.
+	if err = m.waitForStatus(ctx, s.URI, h, StatusStopped); err != nil {
+		return errors.Wrap(err, "checking if host stopped")
+	}
+
+	grip.Info(message.Fields{
+		"message":       "stopped instance",
+		"user":          user,
+		"host_provider": h.Distro.Provider,
+		"host_id":       h.Id,
+		"distro":        h.Distro.Id,
+	})
+
+	return errors.Wrap(h.SetStopped(ctx, user), "marking DB host as stopped")
+}
+
+// StartInstance starts the host's stopped VM and waits for it to run.
+func (m *libvirtManager) StartInstance(ctx context.Context, h *host.Host, user string) error {
+	if h.Status != evergreen.HostStopped {
+		return errors.Errorf("cannot start host '%s' because its status is '%s'", h.Id, h.Status)
+	}
+
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.client.StartDomain(ctx, s.URI, h.Id); err != nil {
+		return errors.Wrapf(err, "starting VM for host '%s'", h.Id)
+	}
+
+	if err = m.waitForStatus(ctx, s.URI, h, StatusRunning); err != nil {
+		return errors.Wrap(err, "checking if host started")
+	}
+
+	grip.Info(message.Fields{
+		"message":       "started instance",
+		"user":          user,
+		"host_provider": h.Distro.Provider,
+		"host_id":       h.Id,
+		"distro":        h.Distro.Id,
+	})
+
+	return errors.Wrap(h.SetRunning(ctx, user), "marking DB host as running")
+}
+
+func (m *libvirtManager) waitForStatus(ctx context.Context, uri string, h *host.Host, status CloudStatus) error {
+	return utility.Retry(
+		ctx,
+		func() (bool, error) {
+			state, err := m.client.GetDomainState(ctx, uri, h.Id)
+			if err != nil {
+				return false, errors.Wrap(err, "getting VM state")
+			}
+			if current := libvirtToEvgStatus(state); current != status {
+				return true, errors.Errorf("host status is '%s', not '%s'", current, status)
+			}
+			return false, nil
+		}, utility.RetryOptions{
+			MaxAttempts: checkSuccessAttempts,
+			MinDelay:    checkSuccessInitPeriod,
+			MaxDelay:    checkSuccessMaxDelay,
+		})
+}
+
+// GetDNSName returns the IPv4 address of the host's VM.
+func (m *libvirtManager) GetDNSName(ctx context.Context, h *host.Host) (string, error) {
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	ip, err := m.client.GetDomainIP(ctx, s.URI, h.Id)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting IP for host '%s'", h.Id)
+	}
+
+	return ip, nil
+}
+
+// AttachVolume attaches the volume to the host's VM as the next free virtio
+// disk.
+func (m *libvirtManager) AttachVolume(ctx context.Context, h *host.Host, attachment *host.VolumeAttachment) error {
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if attachment.DeviceName == "" {
+		deviceName, err := nextLibvirtDeviceName(h.HostVolumeDeviceNames())
+		if err != nil {
+			return errors.Wrapf(err, "choosing device name for volume '%s'", attachment.VolumeID)
+		}
+		attachment.DeviceName = deviceName
+	}
+
+	if err = m.client.AttachVolume(ctx, s.URI, h.Id, m.volumeStoragePool(), attachment.VolumeID, attachment.DeviceName); err != nil {
+		return errors.Wrapf(err, "attaching volume '%s' to host '%s'", attachment.VolumeID, h.Id)
+	}
+
+	return errors.Wrapf(h.AddVolumeToHost(ctx, attachment), "attaching volume '%s' to host '%s' in DB", attachment.VolumeID, h.Id)
+}
+
+// DetachVolume detaches the volume from the host's VM.
+func (m *libvirtManager) DetachVolume(ctx context.Context, h *host.Host, volumeID string) error {
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.client.DetachVolume(ctx, s.URI, h.Id, m.volumeStoragePool(), volumeID); err != nil {
+		return errors.Wrapf(err, "detaching volume '%s' from host '%s'", volumeID, h.Id)
+	}
+
+	return errors.Wrapf(h.RemoveVolumeFromHost(ctx, volumeID), "detaching volume '%s' from host '%s' in DB", volumeID, h.Id)
+}
+
+// CreateVolume creates a new volume in the storage pool of the hypervisor
+// given by the volume's availability zone.
+func (m *libvirtManager) CreateVolume(ctx context.Context, volume *host.Volume) (*host.Volume, error) {
+	uri, err := m.volumeURI(volume)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	volume.ID = fmt.Sprintf("evg-vol-%s", utility.RandomString())
+	volume.AvailabilityZone = uri
+	volume.Expiration = time.Now().Add(evergreen.DefaultSpawnHostExpiration)
+
+	if err = m.client.CreateVolume(ctx, uri, m.volumeStoragePool(), volume.ID, int(volume.Size)); err != nil {
+		return nil, errors.Wrapf(err, "creating volume '%s'", volume.ID)
+	}
+	if err = volume.Insert(); err != nil {
+		return nil, errors.Wrapf(err, "creating volume '%s' in DB", volume.ID)
+	}
+
+	return volume, nil
+}
+
+// DeleteVolume deletes the volume from its storage pool.
+func (m *libvirtManager) DeleteVolume(ctx context.Context, volume *host.Volume) error {
+	uri, err := m.volumeURI(volume)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err = m.client.DeleteVolume(ctx, uri, m.volumeStoragePool(), volume.ID); err != nil {
+		return errors.Wrapf(err, "deleting volume '%s'", volume.ID)
+	}
+
+	return errors.Wrapf(volume.Remove(), "deleting volume '%s' in DB", volume.ID)
+}
+
+// ModifyVolume resizes the volume and updates its expiration and name.
+func (m *libvirtManager) ModifyVolume(ctx context.Context, volume *host.Volume, opts *model.VolumeModifyOptions) error {
+	if opts.NoExpiration && opts.HasExpiration {
+		return errors.New("can't set both no expiration and has expiration")
+	}
+
+	if !utility.IsZeroTime(opts.Expiration) {
+		if err := volume.SetExpiration(opts.Expiration); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' expiration in DB", volume.ID)
+		}
+		if err := volume.SetNoExpiration(false); err != nil {
+			return errors.Wrapf(err, "clearing volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+	if opts.NoExpiration {
+		if err := volume.SetExpiration(time.Now().Add(evergreen.SpawnHostNoExpirationDuration)); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' background expiration in DB", volume.ID)
+		}
+		if err := volume.SetNoExpiration(true); err != nil {
+			return errors.Wrapf(err, "setting volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+	if opts.HasExpiration {
+		if err := volume.SetNoExpiration(false); err != nil {
+			return errors.Wrapf(err, "clearing volume '%s' no-expiration in DB", volume.ID)
+		}
+	}
+
+	if opts.Size > 0 {
+		uri, err := m.volumeURI(volume)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err = m.client.ResizeVolume(ctx, uri, m.volumeStoragePool(), volume.ID, int(opts.Size)); err != nil {
+			return errors.Wrapf(err, "resizing volume '%s'", volume.ID)
+		}
+		if err = volume.SetSize(opts.Size); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' size in DB", volume.ID)
+		}
+	}
+
+	if opts.NewName != "" {
+		if err := volume.SetDisplayName(opts.NewName); err != nil {
+			return errors.Wrapf(err, "updating volume '%s' name in DB", volume.ID)
+		}
+	}
+
+	return nil
+}
+
+// GetVolumeAttachment returns the volume's attachment if the VM of the host
+// it's recorded as being attached to actually has it attached.
+func (m *libvirtManager) GetVolumeAttachment(ctx context.Context, volumeID string) (*VolumeAttachment, error) {
+	volume, err := host.FindVolumeByID(volumeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding volume '%s'", volumeID)
+	}
+	if volume == nil {
+		return nil, errors.Errorf("volume '%s' not found", volumeID)
+	}
+	if volume.Host == "" {
+		return nil, nil
+	}
+	h, err := host.FindOneId(ctx, volume.Host)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding host '%s'", volume.Host)
+	}
+	if h == nil {
+		return nil, nil
+	}
+	s, err := m.getDistroSettings(h.Distro)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	disks, err := m.client.GetDomainDisks(ctx, s.URI, h.Id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting disks for host '%s'", h.Id)
+	}
+	for _, disk := range disks {
+		if disk.Volume == volumeID {
+			return &VolumeAttachment{
+				VolumeID:   volumeID,
+				HostID:     h.Id,
+				DeviceName: disk.Target,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (m *libvirtManager) CheckInstanceType(context.Context, string) error {
+	return errors.New("can't specify instance type with libvirt provider")
+}
+
+// Cleanup is a noop for the libvirt provider.
+func (m *libvirtManager) Cleanup(context.Context) error {
+	return nil
+}
+
+// TimeTilNextPayment returns zero because libvirt VMs run on hardware that
+// isn't billed by usage.
+func (m *libvirtManager) TimeTilNextPayment(*host.Host) time.Duration {
+	return time.Duration(0)
+}
+
+// AddSSHKey is a noop for the libvirt provider. The base images must already
+// allow the distro's SSH key.
+func (m *libvirtManager) AddSSHKey(context.Context, evergreen.SSHKeyPair) error {
+	return nil
+}
@@ -0,0 +1,243 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/jasper"
+	"github.com/pkg/errors"
+)
+
+// libvirtDisk is a disk attached to a libvirt domain.
+type libvirtDisk struct {
+	// Target is the device name in the guest (e.g. vdb).
+	Target string
+	// Source is the path to the disk's file on the hypervisor.
+	Source string
+	// Volume is the name of the storage pool volume backing the disk.
+	Volume string
+}
+
+// The libvirtClient interface wraps interaction with libvirt hypervisors.
+// Every method takes the connection URI for the hypervisor to act on, since
+// distros can run VMs on different hypervisors.
+type libvirtClient interface {
+	// CreateDomain clones a disk from the base image and starts a new domain
+	// with the given name that boots from it.
+	CreateDomain(ctx context.Context, uri, name string, s *libvirtSettings) error
+	// GetDomainState returns the domain's state as reported by libvirt, or
+	// libvirtStateNotFound if the domain doesn't exist.
+	GetDomainState(ctx context.Context, uri, name string) (string, error)
+	GetDomainIP(ctx context.Context, uri, name string) (string, error)
+	GetDomainDisks(ctx context.Context, uri, name string) ([]libvirtDisk, error)
+	StartDomain(ctx context.Context, uri, name string) error
+	ShutdownDomain(ctx context.Context, uri, name string) error
+	// DeleteDomain destroys the domain and deletes its disk. It's not an
+	// error if either of them are already gone.
+	DeleteDomain(ctx context.Context, uri, name, pool string) error
+
+	CreateVolume(ctx context.Context, uri, pool, name string, sizeGB int) error
+	DeleteVolume(ctx context.Context, uri, pool, name string) error
+	ResizeVolume(ctx context.Context, uri, pool, name string, sizeGB int) error
+	AttachVolume(ctx context.Context, uri, domain, pool, volume, target string) error
+	DetachVolume(ctx context.Context, uri, domain, pool, volume string) error
+}
+
+// libvirtClientImpl implements libvirtClient using virsh, which connects to
+// the hypervisor itself (e.g. over SSH) so that the app servers don't need the
+// libvirt client libraries.
+type libvirtClientImpl struct {
+	jasper jasper.Manager
+}
+
+// virsh runs a virsh command against the hypervisor and returns its trimmed
+// output. If the command fails, the output is also returned so that callers
+// can check why.
+func (c *libvirtClientImpl) virsh(ctx context.Context, uri string, args ...string) (string, error) {
+	output := util.NewMBCappedWriter()
+	cmdArgs := append([]string{"virsh", "--connect", uri, "--quiet"}, args...)
+	err := c.jasper.CreateCommand(ctx).
+		Add(cmdArgs).
+		SetCombinedWriter(output).
+		Run(ctx)
+	out := strings.TrimSpace(output.String())
+	if err != nil {
+		return out, errors.Wrapf(err, "running virsh command '%s': %s", args[0], out)
+	}
+	return out, nil
+}
+
+func (c *libvirtClientImpl) CreateDomain(ctx context.Context, uri, name string, s *libvirtSettings) error {
+	capacity := fmt.Sprintf("%dG", s.DiskSizeGB)
+	if s.DiskSizeGB == 0 {
+		out, err := c.virsh(ctx, uri, "vol-info", s.BaseImage, "--pool", s.StoragePool, "--bytes")
+		if err != nil {
+			return errors.Wrapf(err, "getting info for base image '%s'", s.BaseImage)
+		}
+		bytes, err := parseLibvirtVolumeCapacity(out)
+		if err != nil {
+			return errors.Wrapf(err, "getting capacity of base image '%s'", s.BaseImage)
+		}
+		capacity = fmt.Sprint(bytes)
+	}
+
+	if _, err := c.virsh(ctx, uri, "vol-create-as", s.StoragePool, name, capacity,
+		"--format", "qcow2",
+		"--backing-vol", s.BaseImage,
+		"--backing-vol-format", "qcow2",
+	); err != nil {
+		return errors.Wrapf(err, "cloning base image '%s'", s.BaseImage)
+	}
+
+	if err := c.defineDomain(ctx, uri, name, s); err != nil {
+		c.cleanupDomain(ctx, uri, name, s.StoragePool)
+		return errors.Wrap(err, "defining domain")
+	}
+
+	if _, err := c.virsh(ctx, uri, "start", name); err != nil {
+		c.cleanupDomain(ctx, uri, name, s.StoragePool)
+		return errors.Wrap(err, "starting domain")
+	}
+
+	return nil
+}
+
+func (c *libvirtClientImpl) defineDomain(ctx context.Context, uri, name string, s *libvirtSettings) error {
+	domainXML, err := makeLibvirtDomainXML(name, s)
+	if err != nil {
+		return errors.Wrap(err, "creating domain XML")
+	}
+
+	f, err := os.CreateTemp("", fmt.Sprintf("%s-*.xml", name))
+	if err != nil {
+		return errors.Wrap(err, "creating domain XML file")
+	}
+	defer func() {
+		grip.Warning(message.WrapError(os.Remove(f.Name()), message.Fields{
+			"message": "could not clean up domain XML file",
+			"file":    f.Name(),
+		}))
+	}()
+	if _, err = f.Write(domainXML); err != nil {
+		grip.Warning(errors.Wrap(f.Close(), "closing domain XML file"))
+		return errors.Wrap(err, "writing domain XML file")
+	}
+	if err = f.Close(); err != nil {
+		return errors.Wrap(err, "closing domain XML file")
+	}
+
+	_, err = c.virsh(ctx, uri, "define", f.Name())
+	return err
+}
+
+// cleanupDomain removes whatever was created for a domain that failed to
+// start.
+func (c *libvirtClientImpl) cleanupDomain(ctx context.Context, uri, name, pool string) {
+	grip.Warning(message.WrapError(c.DeleteDomain(ctx, uri, name, pool), message.Fields{
+		"message": "could not clean up domain that failed to start",
+		"domain":  name,
+		"uri":     uri,
+	}))
+}
+
+func (c *libvirtClientImpl) GetDomainState(ctx context.Context, uri, name string) (string, error) {
+	out, err := c.virsh(ctx, uri, "domstate", name)
+	if err != nil {
+		if isLibvirtNotFoundError(out) {
+			return libvirtStateNotFound, nil
+		}
+		return "", err
+	}
+	return out, nil
+}
+
+func (c *libvirtClientImpl) GetDomainIP(ctx context.Context, uri, name string) (string, error) {
+	out, err := c.virsh(ctx, uri, "domifaddr", name, "--source", "lease")
+	if err != nil {
+		return "", err
+	}
+	return parseLibvirtDomainIP(out)
+}
+
+func (c *libvirtClientImpl) GetDomainDisks(ctx context.Context, uri, name string) ([]libvirtDisk, error) {
+	out, err := c.virsh(ctx, uri, "domblklist", name, "--details")
+	if err != nil {
+		return nil, err
+	}
+	return parseLibvirtDomainDisks(out), nil
+}
+
+func (c *libvirtClientImpl) StartDomain(ctx context.Context, uri, name string) error {
+	_, err := c.virsh(ctx, uri, "start", name)
+	return err
+}
+
+func (c *libvirtClientImpl) ShutdownDomain(ctx context.Context, uri, name string) error {
+	_, err := c.virsh(ctx, uri, "shutdown", name)
+	return err
+}
+
+func (c *libvirtClientImpl) DeleteDomain(ctx context.Context, uri, name, pool string) error {
+	state, err := c.GetDomainState(ctx, uri, name)
+	if err != nil {
+		return errors.Wrap(err, "getting domain state")
+	}
+	if state != libvirtStateNotFound {
+		if libvirtToEvgStatus(state) != StatusStopped {
+			if out, err := c.virsh(ctx, uri, "destroy", name); err != nil && !isLibvirtNotFoundError(out) {
+				return errors.Wrap(err, "destroying domain")
+			}
+		}
+		if out, err := c.virsh(ctx, uri, "undefine", name); err != nil && !isLibvirtNotFoundError(out) {
+			return errors.Wrap(err, "undefining domain")
+		}
+	}
+
+	return errors.Wrap(c.DeleteVolume(ctx, uri, pool, name), "deleting domain disk")
+}
+
+func (c *libvirtClientImpl) CreateVolume(ctx context.Context, uri, pool, name string, sizeGB int) error {
+	_, err := c.virsh(ctx, uri, "vol-create-as", pool, name, fmt.Sprintf("%dG", sizeGB), "--format", "qcow2")
+	return err
+}
+
+func (c *libvirtClientImpl) DeleteVolume(ctx context.Context, uri, pool, name string) error {
+	out, err := c.virsh(ctx, uri, "vol-delete", name, "--pool", pool)
+	if err != nil && !isLibvirtNotFoundError(out) {
+		return err
+	}
+	return nil
+}
+
+func (c *libvirtClientImpl) ResizeVolume(ctx context.Context, uri, pool, name string, sizeGB int) error {
+	_, err := c.virsh(ctx, uri, "vol-resize", name, fmt.Sprintf("%dG", sizeGB), "--pool", pool)
+	return err
+}
+
+func (c *libvirtClientImpl) AttachVolume(ctx context.Context, uri, domain, pool, volume, target string) error {
+	volumePath, err := c.virsh(ctx, uri, "vol-path", volume, "--pool", pool)
+	if err != nil {
+		return errors.Wrap(err, "getting volume path")
+	}
+	_, err = c.virsh(ctx, uri, "attach-disk", domain, volumePath, target,
+		"--driver", "qemu",
+		"--subdriver", "qcow2",
+		"--targetbus", "virtio",
+		"--persistent",
+	)
+	return err
+}
+
+func (c *libvirtClientImpl) DetachVolume(ctx context.Context, uri, domain, pool, volume string) error {
+	volumePath, err := c.virsh(ctx, uri, "vol-path", volume, "--pool", pool)
+	if err != nil {
+		return errors.Wrap(err, "getting volume path")
+	}
+	_, err = c.virsh(ctx, uri, "detach-disk", domain, volumePath, "--persistent")
+	return err
+}
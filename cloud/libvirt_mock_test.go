@@ -0,0 +1,199 @@
+package cloud
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+type libvirtDomainMock struct {
+	uri   string
+	state string
+	disks []libvirtDisk
+}
+
+type libvirtClientMock struct {
+	// API call options
+	failCreate       bool
+	failState        bool
+	failIP           bool
+	failStart        bool
+	failShutdown     bool
+	failDelete       bool
+	failCreateVolume bool
+	failAttach       bool
+	failDetach       bool
+
+	// stopsInstantly determines whether shut down domains stop immediately
+	// or stay in shutdown.
+	stopsInstantly bool
+
+	domains map[string]*libvirtDomainMock
+	volumes map[string]int
+}
+
+func newLibvirtClientMock() *libvirtClientMock {
+	return &libvirtClientMock{
+		stopsInstantly: true,
+		domains:        map[string]*libvirtDomainMock{},
+		volumes:        map[string]int{},
+	}
+}
+
+func (c *libvirtClientMock) CreateDomain(_ context.Context, uri, name string, s *libvirtSettings) error {
+	if c.failCreate {
+		return errors.New("failed to create domain")
+	}
+
+	c.volumes[name] = s.DiskSizeGB
+	c.domains[name] = &libvirtDomainMock{
+		uri:   uri,
+		state: "running",
+		disks: []libvirtDisk{{Target: "vda", Source: path.Join("/var/lib/libvirt/images", name), Volume: name}},
+	}
+
+	return nil
+}
+
+func (c *libvirtClientMock) getDomain(name string) (*libvirtDomainMock, error) {
+	domain, ok := c.domains[name]
+	if !ok {
+		return nil, errors.Errorf("failed to get domain '%s'", name)
+	}
+	return domain, nil
+}
+
+func (c *libvirtClientMock) GetDomainState(_ context.Context, _, name string) (string, error) {
+	if c.failState {
+		return "", errors.New("failed to get domain state")
+	}
+
+	domain, ok := c.domains[name]
+	if !ok {
+		return libvirtStateNotFound, nil
+	}
+
+	return domain.state, nil
+}
+
+func (c *libvirtClientMock) GetDomainIP(_ context.Context, _, name string) (string, error) {
+	if c.failIP {
+		return "", errors.New("failed to get IP")
+	}
+	if _, err := c.getDomain(name); err != nil {
+		return "", err
+	}
+
+	return "192.168.122.2", nil
+}
+
+func (c *libvirtClientMock) GetDomainDisks(_ context.Context, _, name string) ([]libvirtDisk, error) {
+	domain, err := c.getDomain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.disks, nil
+}
+
+func (c *libvirtClientMock) StartDomain(_ context.Context, _, name string) error {
+	if c.failStart {
+		return errors.New("failed to start domain")
+	}
+	domain, err := c.getDomain(name)
+	if err != nil {
+		return err
+	}
+
+	domain.state = "running"
+	return nil
+}
+
+func (c *libvirtClientMock) ShutdownDomain(_ context.Context, _, name string) error {
+	if c.failShutdown {
+		return errors.New("failed to shut down domain")
+	}
+	domain, err := c.getDomain(name)
+	if err != nil {
+		return err
+	}
+
+	if c.stopsInstantly {
+		domain.state = "shut off"
+	} else {
+		domain.state = "in shutdown"
+	}
+	return nil
+}
+
+func (c *libvirtClientMock) DeleteDomain(_ context.Context, _, name, _ string) error {
+	if c.failDelete {
+		return errors.New("failed to delete domain")
+	}
+
+	delete(c.domains, name)
+	delete(c.volumes, name)
+	return nil
+}
+
+func (c *libvirtClientMock) CreateVolume(_ context.Context, _, _, name string, sizeGB int) error {
+	if c.failCreateVolume {
+		return errors.New("failed to create volume")
+	}
+
+	c.volumes[name] = sizeGB
+	return nil
+}
+
+func (c *libvirtClientMock) DeleteVolume(_ context.Context, _, _, name string) error {
+	delete(c.volumes, name)
+	return nil
+}
+
+func (c *libvirtClientMock) ResizeVolume(_ context.Context, _, _, name string, sizeGB int) error {
+	if _, ok := c.volumes[name]; !ok {
+		return errors.Errorf("failed to get vol '%s'", name)
+	}
+
+	c.volumes[name] = sizeGB
+	return nil
+}
+
+func (c *libvirtClientMock) AttachVolume(_ context.Context, _, domainName, _, volume, target string) error {
+	if c.failAttach {
+		return errors.New("failed to attach volume")
+	}
+	domain, err := c.getDomain(domainName)
+	if err != nil {
+		return err
+	}
+	if _, ok := c.volumes[volume]; !ok {
+		return errors.Errorf("failed to get vol '%s'", volume)
+	}
+
+	domain.disks = append(domain.disks, libvirtDisk{
+		Target: target,
+		Source: path.Join("/var/lib/libvirt/images", volume),
+		Volume: volume,
+	})
+	return nil
+}
+
+func (c *libvirtClientMock) DetachVolume(_ context.Context, _, domainName, _, volume string) error {
+	if c.failDetach {
+		return errors.New("failed to detach volume")
+	}
+	domain, err := c.getDomain(domainName)
+	if err != nil {
+		return err
+	}
+
+	for i, disk := range domain.disks {
+		if disk.Volume == volume {
+			domain.disks = append(domain.disks[:i], domain.disks[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("volume '%s' is not attached", volume)
+}
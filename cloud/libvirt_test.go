@@ -0,0 +1,360 @@
+package cloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/birch"
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/stretchr/testify/suite"
+)
+
+type LibvirtSuite struct {
+	client   *libvirtClientMock
+	manager  *libvirtManager
+	hostOpts host.CreateOptions
+	suite.Suite
+}
+
+func TestLibvirtSuite(t *testing.T) {
+	suite.Run(t, new(LibvirtSuite))
+}
+
+func (s *LibvirtSuite) SetupTest() {
+	s.NoError(db.ClearCollections(host.Collection, host.VolumesCollection))
+
+	s.client = newLibvirtClientMock()
+	s.manager = &libvirtManager{
+		client: s.client,
+	}
+	settings := &evergreen.Settings{}
+	settings.Providers.Libvirt.URI = "qemu+ssh://evergreen@kvm.example.com/system"
+	s.NoError(s.manager.Configure(context.Background(), settings))
+
+	s.hostOpts = host.CreateOptions{
+		Distro: distro.Distro{
+			Id:                   "distro",
+			Provider:             evergreen.ProviderNameLibvirt,
+			ProviderSettingsList: []*birch.Document{birch.NewDocument(birch.EC.String("base_image", "ubuntu2204.qcow2"))},
+		},
+	}
+}
+
+func (s *LibvirtSuite) TearDownTest() {
+	s.NoError(db.ClearCollections(host.Collection, host.VolumesCollection))
+}
+
+func (s *LibvirtSuite) TestValidateSettings() {
+	settingsOk := &libvirtSettings{
+		URI:         "qemu:///system",
+		BaseImage:   "ubuntu2204.qcow2",
+		StoragePool: "evergreen",
+		Network:     "evergreen",
+		NumCPUs:     4,
+		MemoryMB:    8192,
+		DiskSizeGB:  100,
+	}
+	s.NoError(settingsOk.Validate())
+
+	settingsMinimal := &libvirtSettings{
+		BaseImage: "ubuntu2204.qcow2",
+	}
+	s.NoError(settingsMinimal.Validate())
+	s.Equal(defaultLibvirtNetwork, settingsMinimal.Network)
+	s.Equal(defaultLibvirtNumCPUs, settingsMinimal.NumCPUs)
+	s.Equal(defaultLibvirtMemoryMB, settingsMinimal.MemoryMB)
+
+	s.Error((&libvirtSettings{}).Validate())
+	s.Error((&libvirtSettings{BaseImage: "ubuntu2204.qcow2", URI: "xen:///system"}).Validate())
+	s.Error((&libvirtSettings{BaseImage: "ubuntu2204.qcow2", NumCPUs: -1}).Validate())
+	s.Error((&libvirtSettings{BaseImage: "ubuntu2204.qcow2", MemoryMB: -1}).Validate())
+	s.Error((&libvirtSettings{BaseImage: "ubuntu2204.qcow2", DiskSizeGB: -1}).Validate())
+}
+
+func (s *LibvirtSuite) TestSpawnInvalidSettings() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hostOpts.Distro = distro.Distro{Provider: evergreen.ProviderNameEc2Fleet}
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	s.hostOpts.Distro = distro.Distro{Provider: evergreen.ProviderNameLibvirt}
+	h = host.NewIntent(s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+	s.Nil(h)
+
+	s.manager.settings.Providers.Libvirt.URI = ""
+	s.hostOpts.Distro = distro.Distro{
+		Provider:             evergreen.ProviderNameLibvirt,
+		ProviderSettingsList: []*birch.Document{birch.NewDocument(birch.EC.String("base_image", "ubuntu2204.qcow2"))},
+	}
+	h = host.NewIntent(s.hostOpts)
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err, "should error without a connection URI")
+	s.Nil(h)
+}
+
+func (s *LibvirtSuite) TestSpawnAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.Require().NotNil(h)
+	s.Equal("qemu+ssh://evergreen@kvm.example.com/system", h.Zone)
+	s.Contains(s.client.domains, h.Id)
+
+	s.client.failCreate = true
+	h = host.NewIntent(s.hostOpts)
+	_, err = s.manager.SpawnHost(ctx, h)
+	s.Error(err)
+}
+
+func (s *LibvirtSuite) TestSpawnUsesDistroURI() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.hostOpts.Distro.ProviderSettingsList = []*birch.Document{birch.NewDocument(
+		birch.EC.String("base_image", "ubuntu2204.qcow2"),
+		birch.EC.String("uri", "qemu+ssh://evergreen@kvm02.example.com/system"),
+	)}
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.NoError(err)
+	s.Require().NotNil(h)
+	s.Equal("qemu+ssh://evergreen@kvm02.example.com/system", h.Zone)
+	s.Equal("qemu+ssh://evergreen@kvm02.example.com/system", s.client.domains[h.Id].uri)
+}
+
+func (s *LibvirtSuite) TestGetInstanceStatus() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	status, err := s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusNonExistent, status)
+
+	h, err = s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.NoError(err)
+	s.Equal(StatusRunning, status)
+
+	s.client.failState = true
+	status, err = s.manager.GetInstanceStatus(ctx, h)
+	s.Error(err)
+	s.Equal(StatusUnknown, status)
+}
+
+func (s *LibvirtSuite) TestTerminateInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert(ctx))
+
+	s.NoError(s.manager.TerminateInstance(ctx, h, evergreen.User, ""))
+	s.NotContains(s.client.domains, h.Id)
+	s.NotContains(s.client.volumes, h.Id)
+
+	dbHost, err := host.FindOneId(ctx, h.Id)
+	s.NoError(err)
+	s.Require().NotNil(dbHost)
+	s.Equal(evergreen.HostTerminated, dbHost.Status)
+
+	s.Error(s.manager.TerminateInstance(ctx, dbHost, evergreen.User, ""), "should not terminate twice")
+}
+
+func (s *LibvirtSuite) TestTerminateInstanceAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert(ctx))
+
+	s.client.failDelete = true
+	s.Error(s.manager.TerminateInstance(ctx, h, evergreen.User, ""))
+
+	dbHost, err := host.FindOneId(ctx, h.Id)
+	s.NoError(err)
+	s.Require().NotNil(dbHost)
+	s.NotEqual(evergreen.HostTerminated, dbHost.Status)
+}
+
+func (s *LibvirtSuite) TestStopAndStartInstance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	h.Status = evergreen.HostRunning
+	s.Require().NoError(h.Insert(ctx))
+
+	s.NoError(s.manager.StopInstance(ctx, h, evergreen.User))
+	s.Equal("shut off", s.client.domains[h.Id].state)
+	dbHost, err := host.FindOneId(ctx, h.Id)
+	s.NoError(err)
+	s.Require().NotNil(dbHost)
+	s.Equal(evergreen.HostStopped, dbHost.Status)
+
+	s.NoError(s.manager.StartInstance(ctx, dbHost, evergreen.User))
+	s.Equal("running", s.client.domains[h.Id].state)
+	dbHost, err = host.FindOneId(ctx, h.Id)
+	s.NoError(err)
+	s.Require().NotNil(dbHost)
+	s.Equal(evergreen.HostRunning, dbHost.Status)
+}
+
+func (s *LibvirtSuite) TestStartInstanceFailsForRunningHost() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	h.Status = evergreen.HostRunning
+	s.Require().NoError(h.Insert(ctx))
+
+	s.Error(s.manager.StartInstance(ctx, h, evergreen.User))
+}
+
+func (s *LibvirtSuite) TestGetDNSName() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+
+	ip, err := s.manager.GetDNSName(ctx, h)
+	s.NoError(err)
+	s.Equal("192.168.122.2", ip)
+
+	s.client.failIP = true
+	ip, err = s.manager.GetDNSName(ctx, h)
+	s.Error(err)
+	s.Empty(ip)
+}
+
+func (s *LibvirtSuite) TestVolumeLifecycle() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert(ctx))
+
+	volume, err := s.manager.CreateVolume(ctx, &host.Volume{Size: 50, AvailabilityZone: h.Zone})
+	s.Require().NoError(err)
+	s.NotEmpty(volume.ID)
+	s.Equal(h.Zone, volume.AvailabilityZone)
+	s.Equal(50, s.client.volumes[volume.ID])
+
+	s.Require().NoError(s.manager.AttachVolume(ctx, h, &host.VolumeAttachment{VolumeID: volume.ID}))
+	s.Require().Len(h.Volumes, 1)
+	s.Equal("vdb", h.Volumes[0].DeviceName)
+
+	attachment, err := s.manager.GetVolumeAttachment(ctx, volume.ID)
+	s.NoError(err)
+	s.Require().NotNil(attachment)
+	s.Equal(h.Id, attachment.HostID)
+	s.Equal("vdb", attachment.DeviceName)
+
+	s.NoError(s.manager.ModifyVolume(ctx, volume, &model.VolumeModifyOptions{Size: 100, NewName: "data"}))
+	s.Equal(100, s.client.volumes[volume.ID])
+	dbVolume, err := host.FindVolumeByID(volume.ID)
+	s.NoError(err)
+	s.Require().NotNil(dbVolume)
+	s.EqualValues(100, dbVolume.Size)
+	s.Equal("data", dbVolume.DisplayName)
+
+	s.NoError(s.manager.DetachVolume(ctx, h, volume.ID))
+	s.Empty(h.Volumes)
+	attachment, err = s.manager.GetVolumeAttachment(ctx, volume.ID)
+	s.NoError(err)
+	s.Nil(attachment)
+
+	s.NoError(s.manager.DeleteVolume(ctx, volume))
+	s.NotContains(s.client.volumes, volume.ID)
+	dbVolume, err = host.FindVolumeByID(volume.ID)
+	s.NoError(err)
+	s.Nil(dbVolume)
+}
+
+func (s *LibvirtSuite) TestAttachVolumeAPICall() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	h := host.NewIntent(s.hostOpts)
+	h, err := s.manager.SpawnHost(ctx, h)
+	s.Require().NoError(err)
+	s.Require().NoError(h.Insert(ctx))
+	volume, err := s.manager.CreateVolume(ctx, &host.Volume{Size: 50, AvailabilityZone: h.Zone})
+	s.Require().NoError(err)
+
+	s.client.failAttach = true
+	s.Error(s.manager.AttachVolume(ctx, h, &host.VolumeAttachment{VolumeID: volume.ID}))
+	s.Empty(h.Volumes)
+}
+
+func (s *LibvirtSuite) TestUtilToEvgStatus() {
+	s.Equal(StatusRunning, libvirtToEvgStatus("running"))
+	s.Equal(StatusStopping, libvirtToEvgStatus("in shutdown"))
+	s.Equal(StatusStopped, libvirtToEvgStatus("shut off"))
+	s.Equal(StatusStopped, libvirtToEvgStatus("paused"))
+	s.Equal(StatusFailed, libvirtToEvgStatus("crashed"))
+	s.Equal(StatusNonExistent, libvirtToEvgStatus(libvirtStateNotFound))
+	s.Equal(StatusUnknown, libvirtToEvgStatus("???"))
+}
+
+func (s *LibvirtSuite) TestParseVirshOutput() {
+	capacity, err := parseLibvirtVolumeCapacity("Name:           ubuntu2204.qcow2\nType:           file\nCapacity:       10737418240 bytes\nAllocation:     2147483648 bytes")
+	s.NoError(err)
+	s.EqualValues(10737418240, capacity)
+	_, err = parseLibvirtVolumeCapacity("Name:           ubuntu2204.qcow2")
+	s.Error(err)
+
+	ip, err := parseLibvirtDomainIP(` Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------
+ vnet0      52:54:00:6b:29:1c    ipv4         192.168.122.45/24`)
+	s.NoError(err)
+	s.Equal("192.168.122.45", ip)
+	_, err = parseLibvirtDomainIP(` Name       MAC address          Protocol     Address
+-------------------------------------------------------------------------------`)
+	s.Error(err)
+
+	disks := parseLibvirtDomainDisks(` Type   Device   Target   Source
+------------------------------------------------------------------
+ file   disk     vda      /var/lib/libvirt/images/host-1
+ file   cdrom    sda      -
+ file   disk     vdb      /var/lib/libvirt/images/evg-vol-1`)
+	s.Require().Len(disks, 2)
+	s.Equal("vdb", disks[1].Target)
+	s.Equal("evg-vol-1", disks[1].Volume)
+}
+
+func (s *LibvirtSuite) TestNextDeviceName() {
+	name, err := nextLibvirtDeviceName(nil)
+	s.NoError(err)
+	s.Equal("vdb", name)
+
+	name, err = nextLibvirtDeviceName([]string{"vdb", "vdd"})
+	s.NoError(err)
+	s.Equal("vdc", name)
+}
@@ -0,0 +1,224 @@
+package cloud
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// libvirtStateNotFound is the state returned for domains that don't exist.
+const libvirtStateNotFound = "not found"
+
+// libvirtToEvgStatus converts a domain state as reported by virsh domstate to
+// a CloudStatus. Paused and suspended domains aren't running tasks, so they're
+// considered stopped.
+func libvirtToEvgStatus(state string) CloudStatus {
+	switch state {
+	case "running", "idle", "blocked":
+		return StatusRunning
+	case "in shutdown":
+		return StatusStopping
+	case "shut off", "paused", "pmsuspended":
+		return StatusStopped
+	case "crashed":
+		return StatusFailed
+	case libvirtStateNotFound:
+		return StatusNonExistent
+	default:
+		return StatusUnknown
+	}
+}
+
+// isLibvirtNotFoundError returns whether the output of a failed virsh command
+// indicates that the domain or volume doesn't exist.
+func isLibvirtNotFoundError(output string) bool {
+	return strings.Contains(output, "failed to get domain") ||
+		strings.Contains(output, "Domain not found") ||
+		strings.Contains(output, "failed to get vol") ||
+		strings.Contains(output, "Storage volume not found")
+}
+
+// nextLibvirtDeviceName returns the first virtio disk device name that isn't
+// already used. vda is always the root disk.
+func nextLibvirtDeviceName(used []string) (string, error) {
+	usedNames := make(map[string]bool, len(used))
+	for _, name := range used {
+		usedNames[name] = true
+	}
+	for letter := 'b'; letter <= 'z'; letter++ {
+		name := fmt.Sprintf("vd%c", letter)
+		if !usedNames[name] {
+			return name, nil
+		}
+	}
+	return "", errors.New("no device names are available")
+}
+
+// parseLibvirtVolumeCapacity parses the capacity in bytes from the output of
+// virsh vol-info --bytes.
+func parseLibvirtVolumeCapacity(output string) (int64, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "Capacity:" {
+			continue
+		}
+		capacity, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "parsing capacity '%s'", fields[1])
+		}
+		return capacity, nil
+	}
+	return 0, errors.New("volume info is missing capacity")
+}
+
+// parseLibvirtDomainIP parses the domain's IPv4 address from the output of
+// virsh domifaddr, which is a table with the columns name, MAC address,
+// protocol and address (including the prefix length).
+func parseLibvirtDomainIP(output string) (string, error) {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[2] != "ipv4" {
+			continue
+		}
+		ip, _, _ := strings.Cut(fields[3], "/")
+		return ip, nil
+	}
+	return "", errors.New("domain has no IPv4 address")
+}
+
+// parseLibvirtDomainDisks parses the disks from the output of virsh
+// domblklist --details, which is a table with the columns type, device, target
+// and source.
+func parseLibvirtDomainDisks(output string) []libvirtDisk {
+	var disks []libvirtDisk
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[1] != "disk" {
+			continue
+		}
+		disks = append(disks, libvirtDisk{
+			Target: fields[2],
+			Source: fields[3],
+			Volume: path.Base(fields[3]),
+		})
+	}
+	return disks
+}
+
+type libvirtDomainXML struct {
+	XMLName  xml.Name `xml:"domain"`
+	Type     string   `xml:"type,attr"`
+	Name     string   `xml:"name"`
+	Memory   libvirtMemoryXML
+	VCPU     int `xml:"vcpu"`
+	OS       libvirtOSXML
+	Features libvirtFeaturesXML
+	CPU      libvirtCPUXML
+	Devices  libvirtDevicesXML
+}
+
+type libvirtMemoryXML struct {
+	XMLName xml.Name `xml:"memory"`
+	Unit    string   `xml:"unit,attr"`
+	Value   int      `xml:",chardata"`
+}
+
+type libvirtOSXML struct {
+	XMLName xml.Name `xml:"os"`
+	Type    string   `xml:"type"`
+	Boot    struct {
+		Dev string `xml:"dev,attr"`
+	} `xml:"boot"`
+}
+
+type libvirtFeaturesXML struct {
+	XMLName xml.Name  `xml:"features"`
+	ACPI    *struct{} `xml:"acpi"`
+	APIC    *struct{} `xml:"apic"`
+}
+
+type libvirtCPUXML struct {
+	XMLName xml.Name `xml:"cpu"`
+	Mode    string   `xml:"mode,attr"`
+}
+
+type libvirtDevicesXML struct {
+	XMLName   xml.Name `xml:"devices"`
+	Disk      libvirtDiskXML
+	Interface libvirtInterfaceXML
+	Serial    libvirtCharDeviceXML `xml:"serial"`
+	Console   libvirtCharDeviceXML `xml:"console"`
+}
+
+type libvirtDiskXML struct {
+	XMLName xml.Name `xml:"disk"`
+	Type    string   `xml:"type,attr"`
+	Device  string   `xml:"device,attr"`
+	Driver  struct {
+		Name string `xml:"name,attr"`
+		Type string `xml:"type,attr"`
+	} `xml:"driver"`
+	Source struct {
+		Pool   string `xml:"pool,attr"`
+		Volume string `xml:"volume,attr"`
+	} `xml:"source"`
+	Target struct {
+		Dev string `xml:"dev,attr"`
+		Bus string `xml:"bus,attr"`
+	} `xml:"target"`
+}
+
+type libvirtInterfaceXML struct {
+	XMLName xml.Name `xml:"interface"`
+	Type    string   `xml:"type,attr"`
+	Source  struct {
+		Network string `xml:"network,attr"`
+	} `xml:"source"`
+	Model struct {
+		Type string `xml:"type,attr"`
+	} `xml:"model"`
+}
+
+type libvirtCharDeviceXML struct {
+	Type string `xml:"type,attr"`
+}
+
+// makeLibvirtDomainXML creates the definition of a KVM domain that boots from
+// the volume with the same name as the domain.
+func makeLibvirtDomainXML(name string, s *libvirtSettings) ([]byte, error) {
+	domain := libvirtDomainXML{
+		Type:     "kvm",
+		Name:     name,
+		Memory:   libvirtMemoryXML{Unit: "MiB", Value: s.MemoryMB},
+		VCPU:     s.NumCPUs,
+		OS:       libvirtOSXML{Type: "hvm"},
+		Features: libvirtFeaturesXML{ACPI: &struct{}{}, APIC: &struct{}{}},
+		CPU:      libvirtCPUXML{Mode: "host-passthrough"},
+		Devices: libvirtDevicesXML{
+			Interface: libvirtInterfaceXML{Type: "network"},
+			Serial:    libvirtCharDeviceXML{Type: "pty"},
+			Console:   libvirtCharDeviceXML{Type: "pty"},
+		},
+	}
+	domain.OS.Boot.Dev = "hd"
+
+	disk := &domain.Devices.Disk
+	disk.Type = "volume"
+	disk.Device = "disk"
+	disk.Driver.Name = "qemu"
+	disk.Driver.Type = "qcow2"
+	disk.Source.Pool = s.StoragePool
+	disk.Source.Volume = name
+	disk.Target.Dev = "vda"
+	disk.Target.Bus = "virtio"
+
+	domain.Devices.Interface.Source.Network = s.Network
+	domain.Devices.Interface.Model.Type = "virtio"
+
+	out, err := xml.MarshalIndent(domain, "", "  ")
+	return out, errors.Wrap(err, "marshalling domain to XML")
+}
@@ -47,6 +47,8 @@ func buildApp() *cli.App {
 		operations.Admin(),
 		operations.Host(),
 		operations.Volume(),
+		operations.Task(),
+		operations.Generate(),
 		operations.Notification(),
 		operations.Buildlogger(),
 
@@ -71,6 +73,7 @@ func buildApp() *cli.App {
 		operations.PatchRemoveModule(),
 		operations.PatchFinalize(),
 		operations.PatchCancel(),
+		operations.PatchTestDiff(),
 		operations.CreateVersion(),
 	}
 
@@ -68,6 +68,7 @@ type Settings struct {
 	CommitQueue         CommitQueueConfig       `yaml:"commit_queue" bson:"commit_queue" json:"commit_queue" id:"commit_queue"`
 	ConfigDir           string                  `yaml:"configdir" bson:"configdir" json:"configdir"`
 	ContainerPools      ContainerPoolsConfig    `yaml:"container_pools" bson:"container_pools" json:"container_pools" id:"container_pools"`
+	Cost                CostConfig              `yaml:"cost" bson:"cost" json:"cost" id:"cost"`
 	Credentials         map[string]string       `yaml:"credentials" bson:"credentials" json:"credentials"`
 	CredentialsNew      util.KeyValuePairSlice  `yaml:"credentials_new" bson:"credentials_new" json:"credentials_new"`
 	Database            DBSettings              `yaml:"database" json:"database" bson:"database"`
@@ -32,9 +32,21 @@ func (b BucketType) validate() error {
 // Evergreen data bucket storage.
 type BucketsConfig struct {
 	LogBucket BucketConfig `bson:"log_bucket" json:"log_bucket" yaml:"log_bucket"`
+	// TaskOutputVersion is the version of task output storage that new
+	// task runs use for their task logs. Version 0 stores logs with Cedar
+	// Buildlogger, version 1 stores plain text log lines in the log
+	// bucket, and version 2 stores log lines along with their attributes
+	// in the log bucket.
+	TaskOutputVersion int `bson:"task_output_version" json:"task_output_version" yaml:"task_output_version"`
 }
 
-var bucketsConfigLogBucketKey = bsonutil.MustHaveTag(BucketsConfig{}, "LogBucket")
+// MaxTaskOutputVersion is the latest task output storage version.
+const MaxTaskOutputVersion = 2
+
+var (
+	bucketsConfigLogBucketKey         = bsonutil.MustHaveTag(BucketsConfig{}, "LogBucket")
+	bucketsConfigTaskOutputVersionKey = bsonutil.MustHaveTag(BucketsConfig{}, "TaskOutputVersion")
+)
 
 // BucketConfig represents the admin config for an individual bucket.
 type BucketConfig struct {
@@ -51,6 +63,7 @@ func (c *BucketConfig) validate() error {
 	catcher := grip.NewBasicCatcher()
 	catcher.Add(c.Type.validate())
 	catcher.NewWhen(c.Type == BucketTypeGridFS && c.DBName == "", "must specify DB name for GridFS bucket")
+	catcher.NewWhen(c.Type == BucketTypeLocal && c.Name == "", "must specify a directory path as the name for local bucket")
 
 	return catcher.Resolve()
 }
@@ -81,7 +94,8 @@ func (c *BucketsConfig) Set(ctx context.Context) error {
 
 	_, err := coll.UpdateOne(ctx, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			bucketsConfigLogBucketKey: c.LogBucket,
+			bucketsConfigLogBucketKey:         c.LogBucket,
+			bucketsConfigTaskOutputVersionKey: c.TaskOutputVersion,
 		},
 	}, options.Update().SetUpsert(true))
 
@@ -89,5 +103,10 @@ func (c *BucketsConfig) Set(ctx context.Context) error {
 }
 
 func (c *BucketsConfig) ValidateAndDefault() error {
-	return c.LogBucket.validate()
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(c.LogBucket.validate())
+	catcher.ErrorfWhen(c.TaskOutputVersion < 0 || c.TaskOutputVersion > MaxTaskOutputVersion, "task output version must be between 0 and %d", MaxTaskOutputVersion)
+	catcher.NewWhen(c.TaskOutputVersion > 0 && c.LogBucket.Name == "", "must specify a log bucket to store task output in")
+
+	return catcher.Resolve()
 }
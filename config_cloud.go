@@ -2,6 +2,7 @@ package evergreen
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/mongodb/anser/bsonutil"
 	"github.com/mongodb/grip"
@@ -12,11 +13,13 @@ import (
 )
 
 var (
-	cloudProvidersAWSKey       = bsonutil.MustHaveTag(CloudProviders{}, "AWS")
-	cloudProvidersDockerKey    = bsonutil.MustHaveTag(CloudProviders{}, "Docker")
-	cloudProvidersGCEKey       = bsonutil.MustHaveTag(CloudProviders{}, "GCE")
-	cloudProvidersOpenStackKey = bsonutil.MustHaveTag(CloudProviders{}, "OpenStack")
-	cloudProvidersVSphereKey   = bsonutil.MustHaveTag(CloudProviders{}, "VSphere")
+	cloudProvidersAWSKey        = bsonutil.MustHaveTag(CloudProviders{}, "AWS")
+	cloudProvidersDockerKey     = bsonutil.MustHaveTag(CloudProviders{}, "Docker")
+	cloudProvidersGCEKey        = bsonutil.MustHaveTag(CloudProviders{}, "GCE")
+	cloudProvidersOpenStackKey  = bsonutil.MustHaveTag(CloudProviders{}, "OpenStack")
+	cloudProvidersVSphereKey    = bsonutil.MustHaveTag(CloudProviders{}, "VSphere")
+	cloudProvidersLibvirtKey    = bsonutil.MustHaveTag(CloudProviders{}, "Libvirt")
+	cloudProvidersKubernetesKey = bsonutil.MustHaveTag(CloudProviders{}, "Kubernetes")
 )
 
 // CloudProviders stores configuration settings for the supported cloud host providers.
@@ -26,6 +29,10 @@ type CloudProviders struct {
 	GCE       GCEConfig       `bson:"gce" json:"gce" yaml:"gce"`
 	OpenStack OpenStackConfig `bson:"openstack" json:"openstack" yaml:"openstack"`
 	VSphere   VSphereConfig   `bson:"vsphere" json:"vsphere" yaml:"vsphere"`
+	Libvirt   LibvirtConfig   `bson:"libvirt" json:"libvirt" yaml:"libvirt"`
+	// Kubernetes represents configuration for using pods in a Kubernetes
+	// cluster.
+	Kubernetes KubernetesConfig `bson:"kubernetes" json:"kubernetes" yaml:"kubernetes"`
 }
 
 func (c *CloudProviders) SectionId() string { return "providers" }
@@ -50,11 +57,13 @@ func (c *CloudProviders) Get(ctx context.Context) error {
 func (c *CloudProviders) Set(ctx context.Context) error {
 	_, err := GetEnvironment().DB().Collection(ConfigCollection).UpdateOne(ctx, byId(c.SectionId()), bson.M{
 		"$set": bson.M{
-			cloudProvidersAWSKey:       c.AWS,
-			cloudProvidersDockerKey:    c.Docker,
-			cloudProvidersGCEKey:       c.GCE,
-			cloudProvidersOpenStackKey: c.OpenStack,
-			cloudProvidersVSphereKey:   c.VSphere,
+			cloudProvidersAWSKey:        c.AWS,
+			cloudProvidersDockerKey:     c.Docker,
+			cloudProvidersGCEKey:        c.GCE,
+			cloudProvidersOpenStackKey:  c.OpenStack,
+			cloudProvidersVSphereKey:    c.VSphere,
+			cloudProvidersLibvirtKey:    c.Libvirt,
+			cloudProvidersKubernetesKey: c.Kubernetes,
 		},
 	}, options.Update().SetUpsert(true))
 
@@ -64,6 +73,7 @@ func (c *CloudProviders) Set(ctx context.Context) error {
 func (c *CloudProviders) ValidateAndDefault() error {
 	catcher := grip.NewBasicCatcher()
 	catcher.Wrap(c.AWS.Pod.Validate(), "invalid ECS config")
+	catcher.Wrap(c.Kubernetes.Validate(), "invalid Kubernetes config")
 	return catcher.Resolve()
 }
 
@@ -334,6 +344,95 @@ const (
 	AWSClientTypeMock AWSClientType = "mock"
 )
 
+// KubernetesConfig represents configuration for running pods in a Kubernetes
+// cluster. The client authenticates with the cluster using the kubeconfig
+// file if it's set, otherwise the API server URL and token if they're set,
+// otherwise the service account of the pod that Evergreen runs in.
+type KubernetesConfig struct {
+	// Kubeconfig is the path to a kubeconfig file used to connect to the
+	// cluster.
+	Kubeconfig string `bson:"kubeconfig" json:"kubeconfig" yaml:"kubeconfig"`
+	// Context is the kubeconfig context to use. If it's not set, the
+	// kubeconfig's current context is used.
+	Context string `bson:"context" json:"context" yaml:"context"`
+	// APIServerURL is the base URL of the cluster's API server.
+	APIServerURL string `bson:"api_server_url" json:"api_server_url" yaml:"api_server_url"`
+	// Namespace is the namespace where pods and their secrets are created.
+	Namespace string `bson:"namespace" json:"namespace" yaml:"namespace"`
+	// Token is the service account bearer token used to authenticate with the
+	// API server.
+	Token string `bson:"token" json:"token" yaml:"token"`
+	// CACert is the PEM-encoded certificate authority used to verify the API
+	// server's certificate. If it's not set, the system's trusted certificates
+	// are used.
+	CACert string `bson:"ca_cert" json:"ca_cert" yaml:"ca_cert"`
+	// ContainerPools are the named groups of nodes in the cluster that
+	// projects can select to run their pods.
+	ContainerPools []KubernetesContainerPool `bson:"container_pools" json:"container_pools" yaml:"container_pools"`
+	// ClientType represents the type of Kubernetes client implementation that
+	// will be used. This is not a value that can or should be configured for
+	// production, but is useful to explicitly set for testing purposes.
+	ClientType KubernetesClientType `bson:"client_type" json:"client_type" yaml:"client_type"`
+}
+
+// KubernetesContainerPool is a named group of nodes in the Kubernetes cluster
+// that pods can be scheduled on.
+type KubernetesContainerPool struct {
+	// Name is the unique name of the container pool.
+	Name string `bson:"name" json:"name" yaml:"name"`
+	// Namespace is the namespace where the pool's pods are created. If it's
+	// not set, the default namespace is used.
+	Namespace string `bson:"namespace" json:"namespace" yaml:"namespace"`
+	// NodeSelector are the node labels that select the pool's nodes.
+	NodeSelector map[string]string `bson:"node_selector" json:"node_selector" yaml:"node_selector"`
+}
+
+// Validate checks that the Kubernetes configuration is valid if it's
+// configured.
+func (c *KubernetesConfig) Validate() error {
+	if c.ClientType == KubernetesClientTypeMock {
+		return nil
+	}
+	catcher := grip.NewBasicCatcher()
+	if c.APIServerURL != "" {
+		_, err := url.ParseRequestURI(c.APIServerURL)
+		catcher.Wrap(err, "invalid API server URL")
+	}
+	catcher.NewWhen((c.APIServerURL != "" || c.Kubeconfig != "" || len(c.ContainerPools) > 0) && c.Namespace == "", "must specify a namespace")
+	poolNames := map[string]bool{}
+	for _, pool := range c.ContainerPools {
+		catcher.NewWhen(pool.Name == "", "container pool must have a name")
+		catcher.ErrorfWhen(poolNames[pool.Name], "duplicate container pool name '%s'", pool.Name)
+		poolNames[pool.Name] = true
+	}
+	return catcher.Resolve()
+}
+
+// GetContainerPool returns the container pool with the given name. If no such
+// container pool is configured, this returns nil.
+func (c *KubernetesConfig) GetContainerPool(name string) *KubernetesContainerPool {
+	for i := range c.ContainerPools {
+		if c.ContainerPools[i].Name == name {
+			return &c.ContainerPools[i]
+		}
+	}
+	return nil
+}
+
+// KubernetesClientType represents the different types of Kubernetes client
+// implementations that can be used.
+type KubernetesClientType string
+
+const (
+	// KubernetesClientTypeBasic is the standard implementation of a
+	// Kubernetes client.
+	KubernetesClientTypeBasic KubernetesClientType = ""
+	// KubernetesClientTypeMock is the fake implementation of a Kubernetes
+	// client for testing purposes only. This should never be used in
+	// production.
+	KubernetesClientTypeMock KubernetesClientType = "mock"
+)
+
 // DockerConfig stores auth info for Docker.
 type DockerConfig struct {
 	APIVersion    string `bson:"api_version" json:"api_version" yaml:"api_version"`
@@ -372,3 +471,13 @@ type VSphereConfig struct {
 	Username string `bson:"username" json:"username" yaml:"username"`
 	Password string `bson:"password" json:"password" yaml:"password"`
 }
+
+// LibvirtConfig stores the default settings for hosts that run in libvirt
+// (KVM/QEMU) virtual machines. Distros can override these settings.
+type LibvirtConfig struct {
+	// URI is the default libvirt connection URI for the hypervisor (e.g.
+	// qemu+ssh://user@kvm-host/system).
+	URI string `bson:"uri" json:"uri" yaml:"uri"`
+	// StoragePool is the default storage pool for VM disks and volumes.
+	StoragePool string `bson:"storage_pool" json:"storage_pool" yaml:"storage_pool"`
+}
@@ -0,0 +1,84 @@
+package evergreen
+
+import (
+	"context"
+
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CostConfig configures how Evergreen estimates what hosts cost to run.
+type CostConfig struct {
+	// DefaultHourlyCost is the hourly cost of a host whose distro and instance
+	// type have no known cost.
+	DefaultHourlyCost float64 `bson:"default_hourly_cost" json:"default_hourly_cost" yaml:"default_hourly_cost"`
+	// InstanceTypeHourlyCosts are the hourly costs of cloud instance types.
+	// These are used for distros that do not set their own hourly cost.
+	InstanceTypeHourlyCosts []InstanceTypeHourlyCost `bson:"instance_type_hourly_costs" json:"instance_type_hourly_costs" yaml:"instance_type_hourly_costs"`
+}
+
+// InstanceTypeHourlyCost is the hourly cost of a cloud instance type.
+type InstanceTypeHourlyCost struct {
+	InstanceType string  `bson:"instance_type" json:"instance_type" yaml:"instance_type"`
+	HourlyCost   float64 `bson:"hourly_cost" json:"hourly_cost" yaml:"hourly_cost"`
+}
+
+// SectionId returns the ID of this config section.
+func (c *CostConfig) SectionId() string { return "cost" }
+
+// Get populates the config from the database.
+func (c *CostConfig) Get(ctx context.Context) error {
+	res := GetEnvironment().DB().Collection(ConfigCollection).FindOne(ctx, byId(c.SectionId()))
+	if err := res.Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			*c = CostConfig{}
+			return nil
+		}
+		return errors.Wrapf(err, "getting config section '%s'", c.SectionId())
+	}
+
+	if err := res.Decode(&c); err != nil {
+		return errors.Wrapf(err, "decoding config section '%s'", c.SectionId())
+	}
+
+	return nil
+}
+
+// Set sets the document in the database to match the in-memory config struct.
+func (c *CostConfig) Set(ctx context.Context) error {
+	_, err := GetEnvironment().DB().Collection(ConfigCollection).UpdateOne(ctx, byId(c.SectionId()), bson.M{
+		"$set": bson.M{
+			costDefaultHourlyCostKey:       c.DefaultHourlyCost,
+			costInstanceTypeHourlyCostsKey: c.InstanceTypeHourlyCosts,
+		},
+	}, options.Update().SetUpsert(true))
+	return errors.Wrapf(err, "updating config section '%s'", c.SectionId())
+}
+
+// ValidateAndDefault validates the cost configuration.
+func (c *CostConfig) ValidateAndDefault() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(c.DefaultHourlyCost < 0, "default hourly cost cannot be negative")
+	instanceTypes := map[string]bool{}
+	for _, cost := range c.InstanceTypeHourlyCosts {
+		catcher.NewWhen(cost.InstanceType == "", "instance type hourly cost must specify an instance type")
+		catcher.ErrorfWhen(cost.HourlyCost < 0, "hourly cost for instance type '%s' cannot be negative", cost.InstanceType)
+		catcher.ErrorfWhen(instanceTypes[cost.InstanceType], "instance type '%s' cannot have more than one hourly cost", cost.InstanceType)
+		instanceTypes[cost.InstanceType] = true
+	}
+	return catcher.Resolve()
+}
+
+// InstanceTypeHourlyCost returns the hourly cost of the given instance type.
+// If the instance type has no known cost, it returns the default hourly cost.
+func (c *CostConfig) InstanceTypeHourlyCost(instanceType string) float64 {
+	for _, cost := range c.InstanceTypeHourlyCosts {
+		if instanceType != "" && cost.InstanceType == instanceType {
+			return cost.HourlyCost
+		}
+	}
+	return c.DefaultHourlyCost
+}
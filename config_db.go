@@ -98,6 +98,7 @@ var (
 	globalGitHubTokenDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "GlobalGitHubTokenDisabled")
 	unrecognizedPodCleanupDisabledKey = bsonutil.MustHaveTag(ServiceFlags{}, "UnrecognizedPodCleanupDisabled")
 	unsetFunctionVarsDisabledKey      = bsonutil.MustHaveTag(ServiceFlags{}, "UnsetFunctionVarsDisabled")
+	taskLogCompactionDryRunKey        = bsonutil.MustHaveTag(ServiceFlags{}, "TaskLogCompactionDryRun")
 
 	// ContainerPoolsConfig keys
 	poolsKey = bsonutil.MustHaveTag(ContainerPoolsConfig{}, "Pools")
@@ -121,6 +122,10 @@ var (
 
 	// GithubCheckRun keys
 	checkRunLimitKey = bsonutil.MustHaveTag(GitHubCheckRunConfig{}, "CheckRunLimit")
+
+	// Cost keys
+	costDefaultHourlyCostKey       = bsonutil.MustHaveTag(CostConfig{}, "DefaultHourlyCost")
+	costInstanceTypeHourlyCostsKey = bsonutil.MustHaveTag(CostConfig{}, "InstanceTypeHourlyCosts")
 )
 
 func byId(id string) bson.M {
@@ -30,6 +30,20 @@ type SchedulerConfig struct {
 	ExpectedRuntimeFactor         int64   `bson:"expected_runtime_factor" json:"expected_runtime_factor" mapstructure:"expected_runtime_factor"`
 	GenerateTaskFactor            int64   `bson:"generate_task_factor" json:"generate_task_factor" mapstructure:"generate_task_factor"`
 	StepbackTaskFactor            int64   `bson:"stepback_task_factor" json:"stepback_task_factor" mapstructure:"stepback_task_factor"`
+	// FairShareProjectWeights are the relative shares of a distro's hosts
+	// that projects get with the fair-share planner. Projects without a
+	// weight have a weight of 1.
+	FairShareProjectWeights []FairShareProjectWeight `bson:"fair_share_project_weights" json:"fair_share_project_weights" mapstructure:"fair_share_project_weights"`
+	// FairShareUsageWindowSeconds is how far back the fair-share planner
+	// looks at how much host time each project has used.
+	FairShareUsageWindowSeconds int `bson:"fair_share_usage_window_seconds" json:"fair_share_usage_window_seconds" mapstructure:"fair_share_usage_window_seconds"`
+}
+
+// FairShareProjectWeight is a project's relative share of a distro's hosts
+// with the fair-share planner.
+type FairShareProjectWeight struct {
+	ProjectID string  `bson:"project_id" json:"project_id" mapstructure:"project_id"`
+	Weight    float64 `bson:"weight" json:"weight" mapstructure:"weight"`
 }
 
 func (c *SchedulerConfig) SectionId() string { return "scheduler" }
@@ -72,6 +86,8 @@ func (c *SchedulerConfig) Set(ctx context.Context) error {
 			"expected_runtime_factor":           c.ExpectedRuntimeFactor,
 			"generate_task_factor":              c.GenerateTaskFactor,
 			"stepback_task_factor":              c.StepbackTaskFactor,
+			"fair_share_project_weights":        c.FairShareProjectWeights,
+			"fair_share_usage_window_seconds":   c.FairShareUsageWindowSeconds,
 		},
 	}, options.Update().SetUpsert(true))
 
@@ -183,5 +199,37 @@ func (c *SchedulerConfig) ValidateAndDefault() error {
 		return errors.New("stepback task factor must be between 0 and 100")
 	}
 
+	projectIDs := map[string]bool{}
+	for _, w := range c.FairShareProjectWeights {
+		if w.ProjectID == "" {
+			return errors.New("fair-share project weight must specify a project")
+		}
+		if w.Weight <= 0 {
+			return errors.Errorf("fair-share weight for project '%s' must be positive", w.ProjectID)
+		}
+		if projectIDs[w.ProjectID] {
+			return errors.Errorf("project '%s' cannot have more than one fair-share weight", w.ProjectID)
+		}
+		projectIDs[w.ProjectID] = true
+	}
+
+	if c.FairShareUsageWindowSeconds < 0 {
+		return errors.New("fair-share usage window seconds cannot be a negative value")
+	}
+
+	if c.FairShareUsageWindowSeconds == 0 {
+		c.FairShareUsageWindowSeconds = 3600
+	}
+
 	return nil
 }
+
+// GetFairShareProjectWeights returns the fair-share weight of each project
+// that has one.
+func (c *SchedulerConfig) GetFairShareProjectWeights() map[string]float64 {
+	weights := make(map[string]float64, len(c.FairShareProjectWeights))
+	for _, w := range c.FairShareProjectWeights {
+		weights[w.ProjectID] = w.Weight
+	}
+	return weights
+}
@@ -30,6 +30,7 @@ func NewConfigSections() ConfigSections {
 		&CloudProviders{},
 		&CommitQueueConfig{},
 		&ContainerPoolsConfig{},
+		&CostConfig{},
 		&DataPipesConfig{},
 		&HostInitConfig{},
 		&HostJasperConfig{},
@@ -38,6 +38,7 @@ type ServiceFlags struct {
 	LegacyUIPublicAccessDisabled   bool `bson:"legacy_ui_public_access_disabled" json:"legacy_ui_public_access_disabled"`
 	GlobalGitHubTokenDisabled      bool `bson:"global_github_token_disabled" json:"global_github_token_disabled"`
 	UnsetFunctionVarsDisabled      bool `bson:"unset_function_vars_disabled" json:"unset_function_vars_disabled"`
+	TaskLogCompactionDryRun        bool `bson:"task_log_compaction_dry_run" json:"task_log_compaction_dry_run"`
 
 	// Notification Flags
 	EventProcessingDisabled      bool `bson:"event_processing_disabled" json:"event_processing_disabled"`
@@ -102,6 +103,7 @@ func (c *ServiceFlags) Set(ctx context.Context) error {
 			globalGitHubTokenDisabledKey:      c.GlobalGitHubTokenDisabled,
 			unrecognizedPodCleanupDisabledKey: c.UnrecognizedPodCleanupDisabled,
 			unsetFunctionVarsDisabledKey:      c.UnsetFunctionVarsDisabled,
+			taskLogCompactionDryRunKey:        c.TaskLogCompactionDryRun,
 		},
 	}, options.Update().SetUpsert(true))
 
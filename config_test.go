@@ -457,6 +457,20 @@ func (s *AdminSuite) TestProvidersConfig() {
 			Username: "vsphere",
 			Password: "vsphere_pass",
 		},
+		Libvirt: LibvirtConfig{
+			URI:         "qemu+ssh://evergreen@kvm.example.com/system",
+			StoragePool: "evergreen",
+		},
+		Kubernetes: KubernetesConfig{
+			APIServerURL: "https://kubernetes.example.com",
+			Namespace:    "evergreen",
+			Token:        "kubernetes_token",
+			ContainerPools: []KubernetesContainerPool{{
+				Name:         "pool",
+				Namespace:    "evergreen-pool",
+				NodeSelector: map[string]string{"pool": "pool"},
+			}},
+		},
 	}
 
 	err := config.Set(ctx)
@@ -467,6 +481,27 @@ func (s *AdminSuite) TestProvidersConfig() {
 	s.Equal(config, settings.Providers)
 }
 
+func (s *AdminSuite) TestKubernetesConfigValidate() {
+	s.NoError((&KubernetesConfig{}).Validate())
+	s.NoError((&KubernetesConfig{Kubeconfig: "/path/to/kubeconfig", Namespace: "evergreen"}).Validate())
+	s.Error((&KubernetesConfig{Kubeconfig: "/path/to/kubeconfig"}).Validate(), "should require a namespace")
+	s.Error((&KubernetesConfig{APIServerURL: "invalid", Namespace: "evergreen"}).Validate())
+	s.Error((&KubernetesConfig{
+		Namespace:      "evergreen",
+		ContainerPools: []KubernetesContainerPool{{Name: "pool"}, {Name: "pool"}},
+	}).Validate(), "should not allow duplicate container pool names")
+	s.Error((&KubernetesConfig{
+		Namespace:      "evergreen",
+		ContainerPools: []KubernetesContainerPool{{NodeSelector: map[string]string{"key": "val"}}},
+	}).Validate(), "should require container pool name")
+
+	conf := KubernetesConfig{ContainerPools: []KubernetesContainerPool{{Name: "pool", Namespace: "pool_namespace"}}}
+	pool := conf.GetContainerPool("pool")
+	s.Require().NotNil(pool)
+	s.Equal("pool_namespace", pool.Namespace)
+	s.Nil(conf.GetContainerPool("nonexistent"))
+}
+
 func (s *AdminSuite) TestRepotrackerConfig() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -491,6 +526,10 @@ func (s *AdminSuite) TestSchedulerConfig() {
 
 	config := SchedulerConfig{
 		TaskFinder: "task_finder",
+		FairShareProjectWeights: []FairShareProjectWeight{
+			{ProjectID: "project", Weight: 2},
+		},
+		FairShareUsageWindowSeconds: 1800,
 	}
 
 	err := config.Set(ctx)
@@ -499,6 +538,19 @@ func (s *AdminSuite) TestSchedulerConfig() {
 	s.NoError(err)
 	s.NotNil(settings)
 	s.Equal(config, settings.Scheduler)
+	s.Equal(map[string]float64{"project": 2}, settings.Scheduler.GetFairShareProjectWeights())
+
+	invalidConfig := SchedulerConfig{
+		FairShareProjectWeights: []FairShareProjectWeight{
+			{ProjectID: "project", Weight: 0},
+		},
+	}
+	s.Error(invalidConfig.ValidateAndDefault())
+	invalidConfig.FairShareProjectWeights = []FairShareProjectWeight{
+		{ProjectID: "project", Weight: 1},
+		{ProjectID: "project", Weight: 2},
+	}
+	s.Error(invalidConfig.ValidateAndDefault())
 }
 
 func (s *AdminSuite) TestSlackConfig() {
@@ -727,6 +779,39 @@ func (s *AdminSuite) TestContainerPoolsConfig() {
 	s.Nil(lookup)
 }
 
+func (s *AdminSuite) TestCostConfig() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	invalidConfig := CostConfig{
+		DefaultHourlyCost: -1,
+		InstanceTypeHourlyCosts: []InstanceTypeHourlyCost{
+			{InstanceType: "m5.xlarge", HourlyCost: 0.192},
+			{InstanceType: "m5.xlarge", HourlyCost: 0.2},
+		},
+	}
+	s.Error(invalidConfig.ValidateAndDefault())
+
+	config := CostConfig{
+		DefaultHourlyCost: 0.1,
+		InstanceTypeHourlyCosts: []InstanceTypeHourlyCost{
+			{InstanceType: "m5.xlarge", HourlyCost: 0.192},
+			{InstanceType: "c5.2xlarge", HourlyCost: 0.34},
+		},
+	}
+	s.NoError(config.ValidateAndDefault())
+	s.NoError(config.Set(ctx))
+
+	settings, err := GetConfig(ctx)
+	s.NoError(err)
+	s.NotNil(settings)
+	s.Equal(config, settings.Cost)
+
+	s.Equal(0.34, settings.Cost.InstanceTypeHourlyCost("c5.2xlarge"))
+	s.Equal(0.1, settings.Cost.InstanceTypeHourlyCost("t2.micro"))
+	s.Equal(0.1, settings.Cost.InstanceTypeHourlyCost(""))
+}
+
 func (s *AdminSuite) TestJIRANotificationsConfig() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1052,6 +1137,7 @@ func (s *AdminSuite) TestBucketsConfig() {
 	s.Equal(config, settings.Buckets)
 
 	config.LogBucket.Name = "logs-2"
+	config.TaskOutputVersion = MaxTaskOutputVersion
 	s.NoError(config.Set(ctx))
 
 	settings, err = GetConfig(ctx)
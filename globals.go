@@ -135,6 +135,10 @@ const (
 	// TaskDescriptionResultsFailed indicates that a task failed because the
 	// test results contained a failure.
 	TaskDescriptionResultsFailed = "test results contained failing test"
+	// TaskDescriptionQuarantinedTestsFailed indicates that a task succeeded
+	// even though it had failing tests, because all of the failing tests are
+	// quarantined in the task's project.
+	TaskDescriptionQuarantinedTestsFailed = "only quarantined tests failed"
 	// TaskDescriptionContainerUnallocatable indicates that the reason a
 	// container task failed is because it cannot be allocated a container.
 	TaskDescriptionContainerUnallocatable = "container task cannot be allocated"
@@ -232,6 +236,9 @@ const (
 	// GenerateTasksActivator represents the activator for tasks that have been
 	// generated by a task generator.
 	GenerateTasksActivator = "generate-tasks-activator"
+	// AutomaticRetryActivator represents the activator for tasks that have
+	// been restarted by their project's retry policy.
+	AutomaticRetryActivator = "automatic-retry-activator"
 
 	// StaleContainerTaskMonitor is the special name representing the unit
 	// responsible for monitoring container tasks that have not dispatched but
@@ -259,8 +266,9 @@ const (
 
 	RoutePaginatorNextPageHeaderKey = "Link"
 
-	PlannerVersionLegacy  = "legacy"
-	PlannerVersionTunable = "tunable"
+	PlannerVersionLegacy    = "legacy"
+	PlannerVersionTunable   = "tunable"
+	PlannerVersionFairShare = "fair-share"
 
 	// TODO: EVG-18706 all distros use DispatcherVersionRevisedWithDependencies, we may be able to remove these and their custom logic
 	DispatcherVersionLegacy                  = "legacy"
@@ -302,6 +310,7 @@ const (
 
 	HostAllocatorDeficit     = "deficit"
 	HostAllocatorUtilization = "utilization"
+	HostAllocatorPredictive  = "predictive"
 
 	HostAllocatorRoundDown    = "round-down"
 	HostAllocatorRoundUp      = "round-up"
@@ -538,6 +547,7 @@ const (
 	ProviderNameStatic      = "static"
 	ProviderNameOpenstack   = "openstack"
 	ProviderNameVsphere     = "vsphere"
+	ProviderNameLibvirt     = "libvirt"
 	ProviderNameMock        = "mock"
 
 	// DefaultEC2Region is the default region where hosts should be spawned.
@@ -571,6 +581,7 @@ var (
 		ProviderNameGce,
 		ProviderNameOpenstack,
 		ProviderNameVsphere,
+		ProviderNameLibvirt,
 		ProviderNameMock,
 		ProviderNameDocker,
 	}
@@ -840,6 +851,7 @@ var (
 		ElapsedBuildActivator,
 		ElapsedTaskActivator,
 		GenerateTasksActivator,
+		AutomaticRetryActivator,
 	}
 
 	// UpHostStatus is a list of all host statuses that are considered up.
@@ -907,6 +919,7 @@ var (
 	ValidTaskPlannerVersions = []string{
 		PlannerVersionLegacy,
 		PlannerVersionTunable,
+		PlannerVersionFairShare,
 	}
 
 	// Set of valid DispatchSettings.Version strings that can be user set via the API
@@ -927,6 +940,7 @@ var (
 	// Set of valid Host Allocators types
 	ValidHostAllocators = []string{
 		HostAllocatorUtilization,
+		HostAllocatorPredictive,
 	}
 
 	ValidHostAllocatorRoundingRules = []string{
@@ -224,6 +224,8 @@ func (r *hostAllocatorSettingsResolver) Version(ctx context.Context, obj *model.
 	switch utility.FromStringPtr(obj.Version) {
 	case evergreen.HostAllocatorUtilization:
 		return HostAllocatorVersionUtilization, nil
+	case evergreen.HostAllocatorPredictive:
+		return HostAllocatorVersionPredictive, nil
 	default:
 		return "", InternalServerError.Send(ctx, fmt.Sprintf("host allocator version '%s' is invalid", utility.FromStringPtr(obj.Version)))
 	}
@@ -240,6 +242,8 @@ func (r *plannerSettingsResolver) Version(ctx context.Context, obj *model.APIPla
 		return PlannerVersionLegacy, nil
 	case evergreen.PlannerVersionTunable:
 		return PlannerVersionTunable, nil
+	case evergreen.PlannerVersionFairShare:
+		return PlannerVersionFairShare, nil
 	default:
 		return "", InternalServerError.Send(ctx, fmt.Sprintf("planner version '%s' is invalid", utility.FromStringPtr(obj.Version)))
 	}
@@ -432,6 +436,8 @@ func (r *hostAllocatorSettingsInputResolver) Version(ctx context.Context, obj *m
 	switch data {
 	case HostAllocatorVersionUtilization:
 		obj.Version = utility.ToStringPtr(evergreen.HostAllocatorUtilization)
+	case HostAllocatorVersionPredictive:
+		obj.Version = utility.ToStringPtr(evergreen.HostAllocatorPredictive)
 	default:
 		return InputValidationError.Send(ctx, fmt.Sprintf("host allocator version '%s' is invalid", data))
 	}
@@ -451,6 +457,8 @@ func (r *plannerSettingsInputResolver) Version(ctx context.Context, obj *model.A
 		obj.Version = utility.ToStringPtr(evergreen.PlannerVersionLegacy)
 	case PlannerVersionTunable:
 		obj.Version = utility.ToStringPtr(evergreen.PlannerVersionTunable)
+	case PlannerVersionFairShare:
+		obj.Version = utility.ToStringPtr(evergreen.PlannerVersionFairShare)
 	default:
 		return InputValidationError.Send(ctx, fmt.Sprintf("planner version '%s' is invalid", data))
 	}
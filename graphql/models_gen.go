@@ -368,6 +368,17 @@ type TaskFilterOptions struct {
 	Variant                    *string      `json:"variant,omitempty"`
 }
 
+// TaskLogSearchOptions is the input to the taskLogs.searchLogs query. The pattern
+// is matched as a substring unless regex is set to true.
+type TaskLogSearchOptions struct {
+	ContextLines *int    `json:"contextLines,omitempty"`
+	Limit        *int    `json:"limit,omitempty"`
+	LogType      *string `json:"logType,omitempty"`
+	MinPriority  *int    `json:"minPriority,omitempty"`
+	Pattern      string  `json:"pattern"`
+	Regex        *bool   `json:"regex,omitempty"`
+}
+
 // TaskLogs is the return value for the task.taskLogs query.
 // It contains the logs for a given task on a given execution.
 type TaskLogs struct {
@@ -376,9 +387,12 @@ type TaskLogs struct {
 	DefaultLogger string                        `json:"defaultLogger"`
 	EventLogs     []*model.TaskAPIEventLogEntry `json:"eventLogs"`
 	Execution     int                           `json:"execution"`
-	SystemLogs    []*apimodels.LogMessage       `json:"systemLogs"`
-	TaskID        string                        `json:"taskId"`
-	TaskLogs      []*apimodels.LogMessage       `json:"taskLogs"`
+	// searchLogs returns the lines of the task's logs that match the given search,
+	// along with any requested context lines.
+	SearchLogs []*apimodels.LogMessage `json:"searchLogs"`
+	SystemLogs []*apimodels.LogMessage `json:"systemLogs"`
+	TaskID     string                  `json:"taskId"`
+	TaskLogs   []*apimodels.LogMessage `json:"taskLogs"`
 }
 
 // TaskQueueDistro[] is the return value for the taskQueueDistros query.
@@ -888,15 +902,17 @@ type HostAllocatorVersion string
 
 const (
 	HostAllocatorVersionUtilization HostAllocatorVersion = "UTILIZATION"
+	HostAllocatorVersionPredictive  HostAllocatorVersion = "PREDICTIVE"
 )
 
 var AllHostAllocatorVersion = []HostAllocatorVersion{
 	HostAllocatorVersionUtilization,
+	HostAllocatorVersionPredictive,
 }
 
 func (e HostAllocatorVersion) IsValid() bool {
 	switch e {
-	case HostAllocatorVersionUtilization:
+	case HostAllocatorVersionUtilization, HostAllocatorVersionPredictive:
 		return true
 	}
 	return false
@@ -1067,18 +1083,20 @@ func (e OverallocatedRule) MarshalGQL(w io.Writer) {
 type PlannerVersion string
 
 const (
-	PlannerVersionLegacy  PlannerVersion = "LEGACY"
-	PlannerVersionTunable PlannerVersion = "TUNABLE"
+	PlannerVersionFairShare PlannerVersion = "FAIR_SHARE"
+	PlannerVersionLegacy    PlannerVersion = "LEGACY"
+	PlannerVersionTunable   PlannerVersion = "TUNABLE"
 )
 
 var AllPlannerVersion = []PlannerVersion{
+	PlannerVersionFairShare,
 	PlannerVersionLegacy,
 	PlannerVersionTunable,
 }
 
 func (e PlannerVersion) IsValid() bool {
 	switch e {
-	case PlannerVersionLegacy, PlannerVersionTunable:
+	case PlannerVersionFairShare, PlannerVersionLegacy, PlannerVersionTunable:
 		return true
 	}
 	return false
@@ -23,6 +23,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -674,6 +675,28 @@ func (r *mutationResolver) PromoteVarsToRepo(ctx context.Context, projectID stri
 	return true, nil
 }
 
+// QuarantineTest is the resolver for the quarantineTest field.
+func (r *mutationResolver) QuarantineTest(ctx context.Context, projectID string, test restModel.APIQuarantinedTest) (*restModel.APIQuarantinedTest, error) {
+	pid, err := model.GetIdForProject(projectID)
+	if err != nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("project '%s' not found", projectID))
+	}
+	usr := mustHaveUser(ctx)
+
+	quarantined := test.ToService()
+	quarantined.ID = ""
+	quarantined.ProjectID = pid
+	quarantined.QuarantinedBy = usr.Username()
+	quarantined.QuarantinedAt = time.Time{}
+	if err = quarantined.Insert(); err != nil {
+		return nil, InputValidationError.Send(ctx, fmt.Sprintf("quarantining test: %s", err.Error()))
+	}
+
+	res := &restModel.APIQuarantinedTest{}
+	res.BuildFromService(quarantined)
+	return res, nil
+}
+
 // RemoveFavoriteProject is the resolver for the removeFavoriteProject field.
 func (r *mutationResolver) RemoveFavoriteProject(ctx context.Context, identifier string) (*restModel.APIProjectRef, error) {
 	p, err := model.FindBranchProjectRef(identifier)
@@ -716,6 +739,25 @@ func (r *mutationResolver) SaveRepoSettingsForSection(ctx context.Context, repoS
 	return changes, nil
 }
 
+// UnquarantineTest is the resolver for the unquarantineTest field.
+func (r *mutationResolver) UnquarantineTest(ctx context.Context, projectID string, quarantineID string) (bool, error) {
+	pid, err := model.GetIdForProject(projectID)
+	if err != nil {
+		return false, ResourceNotFound.Send(ctx, fmt.Sprintf("project '%s' not found", projectID))
+	}
+	quarantined, err := flakytest.FindQuarantinedTestByID(pid, quarantineID)
+	if err != nil {
+		return false, InternalServerError.Send(ctx, fmt.Sprintf("finding quarantined test '%s': %s", quarantineID, err.Error()))
+	}
+	if quarantined == nil {
+		return false, ResourceNotFound.Send(ctx, fmt.Sprintf("quarantined test '%s' not found in project '%s'", quarantineID, projectID))
+	}
+	if err = flakytest.RemoveQuarantinedTest(pid, quarantineID); err != nil {
+		return false, InternalServerError.Send(ctx, err.Error())
+	}
+	return true, nil
+}
+
 // AttachVolumeToHost is the resolver for the attachVolumeToHost field.
 func (r *mutationResolver) AttachVolumeToHost(ctx context.Context, volumeAndHost VolumeHost) (bool, error) {
 	statusCode, err := cloud.AttachVolume(ctx, volumeAndHost.VolumeID, volumeAndHost.HostID)
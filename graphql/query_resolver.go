@@ -15,8 +15,10 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/cost"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -400,6 +402,26 @@ func (r *queryResolver) Patch(ctx context.Context, id string) (*restModel.APIPat
 	return patch, nil
 }
 
+// FlakyTests is the resolver for the flakyTests field.
+func (r *queryResolver) FlakyTests(ctx context.Context, projectID string, includeAll *bool) ([]*restModel.APITestFlakiness, error) {
+	pid, err := model.GetIdForProject(projectID)
+	if err != nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("project '%s' not found", projectID))
+	}
+	flakiness, err := flakytest.FindTestFlakiness(pid, !utility.FromBoolPtr(includeAll))
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding flaky tests for project '%s': %s", projectID, err.Error()))
+	}
+
+	res := []*restModel.APITestFlakiness{}
+	for _, f := range flakiness {
+		apiFlakiness := &restModel.APITestFlakiness{}
+		apiFlakiness.BuildFromService(f)
+		res = append(res, apiFlakiness)
+	}
+	return res, nil
+}
+
 // GithubProjectConflicts is the resolver for the githubProjectConflicts field.
 func (r *queryResolver) GithubProjectConflicts(ctx context.Context, projectID string) (*model.GithubProjectConflicts, error) {
 	pRef, err := model.FindMergedProjectRef(projectID, "", false)
@@ -451,6 +473,40 @@ func (r *queryResolver) Projects(ctx context.Context) ([]*GroupedProjects, error
 	return groupedProjects, nil
 }
 
+// ProjectCost is the resolver for the projectCost field.
+func (r *queryResolver) ProjectCost(ctx context.Context, projectID string, startTime *time.Time, endTime *time.Time) (*restModel.APIProjectCost, error) {
+	pRef, err := model.FindMergedProjectRef(projectID, "", false)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding project '%s': %s", projectID, err.Error()))
+	}
+	if pRef == nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("project '%s' not found", projectID))
+	}
+
+	end := time.Now()
+	if endTime != nil {
+		end = *endTime
+	}
+	start := cost.MonthStart(end)
+	if startTime != nil {
+		start = *startTime
+	}
+	if !end.After(start) {
+		return nil, InputValidationError.Send(ctx, "end time must be after start time")
+	}
+
+	projectCost, err := cost.GetProjectCost(pRef.Id, start, end)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("getting cost for project '%s': %s", projectID, err.Error()))
+	}
+	apiCost := &restModel.APIProjectCost{}
+	apiCost.BuildFromService(*projectCost)
+	if pRef.MonthlyBudget > 0 {
+		apiCost.MonthlyBudget = utility.ToFloat64Ptr(pRef.MonthlyBudget)
+	}
+	return apiCost, nil
+}
+
 // ProjectEvents is the resolver for the projectEvents field.
 func (r *queryResolver) ProjectEvents(ctx context.Context, identifier string, limit *int, before *time.Time) (*ProjectEvents, error) {
 	timestamp := time.Now()
@@ -488,6 +544,26 @@ func (r *queryResolver) ProjectSettings(ctx context.Context, identifier string)
 	return res, nil
 }
 
+// QuarantinedTests is the resolver for the quarantinedTests field.
+func (r *queryResolver) QuarantinedTests(ctx context.Context, projectID string) ([]*restModel.APIQuarantinedTest, error) {
+	pid, err := model.GetIdForProject(projectID)
+	if err != nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("project '%s' not found", projectID))
+	}
+	quarantined, err := flakytest.FindQuarantinedTests(pid)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding quarantined tests for project '%s': %s", projectID, err.Error()))
+	}
+
+	res := []*restModel.APIQuarantinedTest{}
+	for _, q := range quarantined {
+		apiQuarantined := &restModel.APIQuarantinedTest{}
+		apiQuarantined.BuildFromService(q)
+		res = append(res, apiQuarantined)
+	}
+	return res, nil
+}
+
 // RepoEvents is the resolver for the repoEvents field.
 func (r *queryResolver) RepoEvents(ctx context.Context, id string, limit *int, before *time.Time) (*ProjectEvents, error) {
 	timestamp := time.Now()
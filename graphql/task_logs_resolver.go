@@ -7,14 +7,20 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/task"
 	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/taskoutput"
 	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/message"
 )
 
 // AgentLogs is the resolver for the agentLogs field.
@@ -138,6 +144,64 @@ func (r *taskLogsResolver) EventLogs(ctx context.Context, obj *TaskLogs) ([]*res
 	return apiEventLogPointers, nil
 }
 
+// SearchLogs is the resolver for the searchLogs field.
+func (r *taskLogsResolver) SearchLogs(ctx context.Context, obj *TaskLogs, options TaskLogSearchOptions) ([]*apimodels.LogMessage, error) {
+	t, err := task.FindOneIdAndExecution(obj.TaskID, obj.Execution)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Finding task %s: %s", obj.TaskID, err.Error()))
+	}
+	if t == nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("Task %s with execution %d not found", obj.TaskID, obj.Execution))
+	}
+	if evergreen.IsUnstartedTaskStatus(t.Status) {
+		return []*apimodels.LogMessage{}, nil
+	}
+
+	search := log.SearchOptions{
+		Pattern:      options.Pattern,
+		Regex:        utility.FromBoolPtr(options.Regex),
+		MinPriority:  level.Priority(utility.FromIntPtr(options.MinPriority)),
+		ContextLines: utility.FromIntPtr(options.ContextLines),
+		Limit:        utility.FromIntPtr(options.Limit),
+	}
+	if err = search.Validate(); err != nil {
+		return nil, InputValidationError.Send(ctx, fmt.Sprintf("Invalid search options: %s", err.Error()))
+	}
+	logType := taskoutput.TaskLogTypeAll
+	if options.LogType != nil {
+		logType = taskoutput.TaskLogType(*options.LogType)
+	}
+
+	it, err := t.GetTaskLogs(ctx, evergreen.GetEnvironment(), taskoutput.TaskLogGetOptions{
+		LogType: logType,
+		Search:  &search,
+	})
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Searching task logs for task %s: %s", obj.TaskID, err.Error()))
+	}
+	defer func() {
+		grip.Error(message.WrapError(it.Close(), message.Fields{
+			"message": "closing task log search iterator",
+			"task_id": obj.TaskID,
+		}))
+	}()
+
+	logMessages := []*apimodels.LogMessage{}
+	for it.Next() {
+		line := it.Item()
+		logMessages = append(logMessages, &apimodels.LogMessage{
+			Severity:  apimodels.GetSeverityMapping(int(line.Priority)),
+			Message:   line.Data,
+			Timestamp: time.Unix(0, line.Timestamp).UTC(),
+		})
+	}
+	if err = it.Err(); err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Searching task logs for task %s: %s", obj.TaskID, err.Error()))
+	}
+
+	return logMessages, nil
+}
+
 // SystemLogs is the resolver for the systemLogs field.
 func (r *taskLogsResolver) SystemLogs(ctx context.Context, obj *TaskLogs) ([]*apimodels.LogMessage, error) {
 	const logMessageCount = 100
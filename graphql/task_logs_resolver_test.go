@@ -0,0 +1,105 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/pail"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestSearchLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection))
+	}()
+
+	bucketDir := t.TempDir()
+	tsk := task.Task{
+		Id:        "t1",
+		Project:   "project",
+		Execution: 0,
+		Status:    evergreen.TaskFailed,
+		TaskOutputInfo: &taskoutput.TaskOutput{
+			TaskLogs: taskoutput.TaskLogOutput{
+				Version: 1,
+				BucketConfig: evergreen.BucketConfig{
+					Name: bucketDir,
+					Type: evergreen.BucketTypeLocal,
+				},
+			},
+		},
+	}
+	require.NoError(t, tsk.Insert())
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: bucketDir})
+	require.NoError(t, err)
+	ts := time.Now().UnixNano()
+	require.NoError(t, log.NewLogServiceV0(bucket).Append(ctx, "project/t1/0/task_logs/task", []log.LogLine{
+		{Priority: level.Info, Timestamp: ts, Data: "compiling"},
+		{Priority: level.Error, Timestamp: ts + 1, Data: "error: first"},
+		{Priority: level.Info, Timestamp: ts + 2, Data: "retrying"},
+		{Priority: level.Error, Timestamp: ts + 3, Data: "error: second"},
+	}))
+
+	config := New("/graphql")
+	require.NotNil(t, config)
+	obj := &TaskLogs{TaskID: tsk.Id, Execution: tsk.Execution}
+	search := func(t *testing.T, options TaskLogSearchOptions) []string {
+		logMessages, err := config.Resolvers.TaskLogs().SearchLogs(ctx, obj, options)
+		require.NoError(t, err)
+
+		var out []string
+		for _, logMessage := range logMessages {
+			out = append(out, logMessage.Message)
+		}
+		return out
+	}
+
+	t.Run("Substring", func(t *testing.T) {
+		assert.Equal(t, []string{"error: first", "error: second"}, search(t, TaskLogSearchOptions{
+			Pattern: "error",
+			LogType: utility.ToStringPtr(string(taskoutput.TaskLogTypeTask)),
+		}))
+	})
+	t.Run("RegexWithContextLines", func(t *testing.T) {
+		assert.Equal(t, []string{"retrying", "error: second"}, search(t, TaskLogSearchOptions{
+			Pattern:      "^error.*second$",
+			Regex:        utility.TruePtr(),
+			ContextLines: utility.ToIntPtr(1),
+			LogType:      utility.ToStringPtr(string(taskoutput.TaskLogTypeTask)),
+		}))
+	})
+	t.Run("LimitCapsMatches", func(t *testing.T) {
+		assert.Equal(t, []string{"compiling", "error: first", "retrying"}, search(t, TaskLogSearchOptions{
+			Pattern:      "error",
+			ContextLines: utility.ToIntPtr(1),
+			Limit:        utility.ToIntPtr(1),
+			LogType:      utility.ToStringPtr(string(taskoutput.TaskLogTypeTask)),
+		}))
+	})
+	t.Run("EmptyPattern", func(t *testing.T) {
+		_, err := config.Resolvers.TaskLogs().SearchLogs(ctx, obj, TaskLogSearchOptions{})
+		require.Error(t, err)
+		gqlErr, ok := err.(*gqlerror.Error)
+		require.True(t, ok)
+		assert.Equal(t, InputValidationError, gqlErr.Extensions["code"])
+	})
+	t.Run("NonexistentTask", func(t *testing.T) {
+		_, err := config.Resolvers.TaskLogs().SearchLogs(ctx, &TaskLogs{TaskID: "DNE"}, TaskLogSearchOptions{Pattern: "error"})
+		assert.Error(t, err)
+	})
+}
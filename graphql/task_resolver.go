@@ -530,6 +530,25 @@ func (r *taskResolver) ProjectIdentifier(ctx context.Context, obj *restModel.API
 	return obj.ProjectIdentifier, nil
 }
 
+// QueueExplanation is the resolver for the queueExplanation field.
+func (r *taskResolver) QueueExplanation(ctx context.Context, obj *restModel.APITask) (*restModel.APITaskQueueExplanation, error) {
+	taskID := utility.FromStringPtr(obj.Id)
+	t, err := task.FindOneId(taskID)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("finding task '%s': %s", taskID, err.Error()))
+	}
+	if t == nil {
+		return nil, ResourceNotFound.Send(ctx, fmt.Sprintf("task '%s' not found", taskID))
+	}
+	explanation, err := model.ExplainTaskQueue(ctx, *t)
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("explaining task queue for task '%s': %s", taskID, err.Error()))
+	}
+	apiExplanation := restModel.APITaskQueueExplanation{}
+	apiExplanation.BuildFromService(*explanation)
+	return &apiExplanation, nil
+}
+
 // SpawnHostLink is the resolver for the spawnHostLink field.
 func (r *taskResolver) SpawnHostLink(ctx context.Context, obj *restModel.APITask) (*string, error) {
 	host, err := host.FindOne(ctx, host.ById(*obj.HostId))
@@ -625,6 +644,10 @@ func (r *taskResolver) Tests(ctx context.Context, obj *restModel.APITask, opts *
 	if err != nil {
 		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Error getting test results for APITask %s: %s", dbTask.Id, err.Error()))
 	}
+	quarantined, err := dbTask.FindQuarantinedTests()
+	if err != nil {
+		return nil, InternalServerError.Send(ctx, fmt.Sprintf("Error getting quarantined tests for APITask %s: %s", dbTask.Id, err.Error()))
+	}
 
 	apiResults := make([]*restModel.APITest, len(taskResults.Results))
 	for i, t := range taskResults.Results {
@@ -635,6 +658,7 @@ func (r *taskResolver) Tests(ctx context.Context, obj *restModel.APITask, opts *
 		if err = apiTest.BuildFromService(&t); err != nil {
 			return nil, InternalServerError.Send(ctx, err.Error())
 		}
+		apiTest.Quarantined = quarantined.IsQuarantined(t)
 
 		apiResults[i] = apiTest
 	}
@@ -10,16 +10,17 @@ import (
 
 var (
 	// bson fields for the TaskAnnotation struct
-	IdKey              = bsonutil.MustHaveTag(TaskAnnotation{}, "Id")
-	TaskIdKey          = bsonutil.MustHaveTag(TaskAnnotation{}, "TaskId")
-	TaskExecutionKey   = bsonutil.MustHaveTag(TaskAnnotation{}, "TaskExecution")
-	MetadataKey        = bsonutil.MustHaveTag(TaskAnnotation{}, "Metadata")
-	NoteKey            = bsonutil.MustHaveTag(TaskAnnotation{}, "Note")
-	IssuesKey          = bsonutil.MustHaveTag(TaskAnnotation{}, "Issues")
-	SuspectedIssuesKey = bsonutil.MustHaveTag(TaskAnnotation{}, "SuspectedIssues")
-	CreatedIssuesKey   = bsonutil.MustHaveTag(TaskAnnotation{}, "CreatedIssues")
-	IssueLinkIssueKey  = bsonutil.MustHaveTag(IssueLink{}, "IssueKey")
-	MetadataLinksKey   = bsonutil.MustHaveTag(TaskAnnotation{}, "MetadataLinks")
+	IdKey               = bsonutil.MustHaveTag(TaskAnnotation{}, "Id")
+	TaskIdKey           = bsonutil.MustHaveTag(TaskAnnotation{}, "TaskId")
+	TaskExecutionKey    = bsonutil.MustHaveTag(TaskAnnotation{}, "TaskExecution")
+	MetadataKey         = bsonutil.MustHaveTag(TaskAnnotation{}, "Metadata")
+	NoteKey             = bsonutil.MustHaveTag(TaskAnnotation{}, "Note")
+	IssuesKey           = bsonutil.MustHaveTag(TaskAnnotation{}, "Issues")
+	SuspectedIssuesKey  = bsonutil.MustHaveTag(TaskAnnotation{}, "SuspectedIssues")
+	CreatedIssuesKey    = bsonutil.MustHaveTag(TaskAnnotation{}, "CreatedIssues")
+	IssueLinkIssueKey   = bsonutil.MustHaveTag(IssueLink{}, "IssueKey")
+	MetadataLinksKey    = bsonutil.MustHaveTag(TaskAnnotation{}, "MetadataLinks")
+	StepbackCulpritsKey = bsonutil.MustHaveTag(TaskAnnotation{}, "StepbackCulprits")
 )
 
 const (
@@ -27,6 +28,7 @@ const (
 	UIRequester           = "ui"
 	APIRequester          = "api"
 	WebhookRequester      = "webhook"
+	StepbackRequester     = "stepback"
 	MaxMetadataLinks      = 1
 	MaxMetadataTextLength = 40
 )
@@ -30,6 +30,21 @@ type TaskAnnotation struct {
 	CreatedIssues []IssueLink `bson:"created_issues,omitempty" json:"created_issues,omitempty"`
 	// links to be displayed in the UI metadata sidebar
 	MetadataLinks []MetadataLink `bson:"metadata_links,omitempty" json:"metadata_links,omitempty"`
+	// commits that stepback found to have introduced the task's failures
+	StepbackCulprits []StepbackCulprit `bson:"stepback_culprits,omitempty" json:"stepback_culprits,omitempty"`
+}
+
+// StepbackCulprit is the task, found by bisect stepback, that first failed
+// in place of the annotated task.
+type StepbackCulprit struct {
+	// TestName is the test that the culprit introduced a failure in. If it
+	// is empty, the culprit is for the task as a whole.
+	TestName  string  `bson:"test_name,omitempty" json:"test_name,omitempty"`
+	TaskId    string  `bson:"task_id" json:"task_id"`
+	Execution int     `bson:"execution" json:"execution"`
+	Version   string  `bson:"version" json:"version"`
+	Revision  string  `bson:"revision" json:"revision"`
+	Source    *Source `bson:"source,omitempty" json:"source,omitempty"`
 }
 
 // MetadataLink represents an arbitrary link to be associated with a task.
@@ -361,6 +376,30 @@ func AddCreatedTicket(taskId string, execution int, ticket IssueLink, userDispla
 	return errors.Wrapf(err, "adding ticket to task '%s'", taskId)
 }
 
+// AddStepbackCulprits adds the culprits found by stepback to the annotation
+// for the task that started stepback.
+func AddStepbackCulprits(taskId string, execution int, culprits ...StepbackCulprit) error {
+	if len(culprits) == 0 {
+		return nil
+	}
+	source := &Source{
+		Author:    evergreen.StepbackTaskActivator,
+		Time:      time.Now(),
+		Requester: StepbackRequester,
+	}
+	for i := range culprits {
+		culprits[i].Source = source
+	}
+	_, err := db.Upsert(
+		Collection,
+		ByTaskIdAndExecution(taskId, execution),
+		bson.M{
+			"$push": bson.M{StepbackCulpritsKey: bson.M{"$each": culprits}},
+		},
+	)
+	return errors.Wrapf(err, "adding stepback culprits to task '%s'", taskId)
+}
+
 // ValidateMetadataLinks will validate the given metadata links, ensuring that they are valid URLs,
 // that their text is not too long, and that there are not more than MaxMetadataLinks links provided.
 func ValidateMetadataLinks(links ...MetadataLink) error {
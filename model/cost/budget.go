@@ -0,0 +1,73 @@
+package cost
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// BudgetAlertCollection is the collection of alerts that projects have
+// exceeded their monthly budgets.
+const BudgetAlertCollection = "project_budget_alerts"
+
+var (
+	budgetAlertIDKey        = bsonutil.MustHaveTag(BudgetAlert{}, "ID")
+	budgetAlertIDProjectKey = bsonutil.MustHaveTag(BudgetAlertID{}, "ProjectID")
+	budgetAlertIDMonthKey   = bsonutil.MustHaveTag(BudgetAlertID{}, "Month")
+)
+
+// BudgetAlertID uniquely identifies a budget alert. A project is alerted at
+// most once per month.
+type BudgetAlertID struct {
+	ProjectID string    `bson:"project_id"`
+	Month     time.Time `bson:"month"`
+}
+
+// BudgetAlert records that a project's spend exceeded its monthly budget.
+type BudgetAlert struct {
+	ID        BudgetAlertID `bson:"_id"`
+	Budget    float64       `bson:"budget"`
+	Spend     float64       `bson:"spend"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+// MonthStart returns the start of the month containing the given time in UTC.
+func MonthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// RecordBudgetAlert records that the project exceeded its budget for the
+// month. It returns false if the project has already been alerted for the
+// month.
+func RecordBudgetAlert(alert BudgetAlert) (bool, error) {
+	alert.ID.Month = MonthStart(alert.ID.Month)
+	if err := db.Insert(BudgetAlertCollection, alert); err != nil {
+		if db.IsDuplicateKey(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "recording budget alert for project '%s'", alert.ID.ProjectID)
+	}
+	return true, nil
+}
+
+// FindBudgetAlert returns the project's budget alert for the month containing
+// the given time, if one exists.
+func FindBudgetAlert(projectID string, month time.Time) (*BudgetAlert, error) {
+	alert := &BudgetAlert{}
+	err := db.FindOneQ(BudgetAlertCollection, db.Query(bson.M{
+		bsonutil.GetDottedKeyName(budgetAlertIDKey, budgetAlertIDProjectKey): projectID,
+		bsonutil.GetDottedKeyName(budgetAlertIDKey, budgetAlertIDMonthKey):   MonthStart(month),
+	}), alert)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding budget alert for project '%s'", projectID)
+	}
+	return alert, nil
+}
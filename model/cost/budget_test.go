@@ -0,0 +1,48 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordBudgetAlert(t *testing.T) {
+	require.NoError(t, db.Clear(BudgetAlertCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(BudgetAlertCollection))
+	}()
+
+	now := time.Date(2023, time.May, 15, 12, 0, 0, 0, time.UTC)
+	alert := BudgetAlert{
+		ID:        BudgetAlertID{ProjectID: "p1", Month: now},
+		Budget:    100,
+		Spend:     101,
+		CreatedAt: now,
+	}
+	recorded, err := RecordBudgetAlert(alert)
+	require.NoError(t, err)
+	assert.True(t, recorded)
+
+	alert.ID.Month = now.AddDate(0, 0, 10)
+	recorded, err = RecordBudgetAlert(alert)
+	require.NoError(t, err)
+	assert.False(t, recorded, "project should only be alerted once per month")
+
+	alert.ID.Month = now.AddDate(0, 1, 0)
+	recorded, err = RecordBudgetAlert(alert)
+	require.NoError(t, err)
+	assert.True(t, recorded, "project should be alerted again the next month")
+
+	found, err := FindBudgetAlert("p1", now)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.True(t, found.ID.Month.Equal(time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 101.0, found.Spend)
+
+	found, err = FindBudgetAlert("p2", now)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
@@ -0,0 +1,179 @@
+package cost
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// HostHourlyCost returns the estimated hourly cost of running the host.
+func HostHourlyCost(c evergreen.CostConfig, h *host.Host) float64 {
+	return h.Distro.GetHourlyCost(c, h.InstanceType)
+}
+
+// TaskCost returns the provisional estimated cost of running the task on the
+// host, which only covers the time from when the task was dispatched to the
+// host until it finished. Once the host is terminated, AllocateHostCost
+// replaces it with the task's share of the host's entire uptime.
+func TaskCost(c evergreen.CostConfig, h *host.Host, t *task.Task) float64 {
+	return taskRunTime(t, time.Time{}, t.FinishTime).Hours() * HostHourlyCost(c, h)
+}
+
+// taskRunTime returns how long the task ran between when it was dispatched
+// and when it finished, limited to the time range [start, end). If start is
+// zero, the range has no lower bound.
+func taskRunTime(t *task.Task, start, end time.Time) time.Duration {
+	taskStart := t.DispatchTime
+	if utility.IsZeroTime(taskStart) {
+		taskStart = t.StartTime
+	}
+	if utility.IsZeroTime(taskStart) {
+		return 0
+	}
+	if taskStart.Before(start) {
+		taskStart = start
+	}
+
+	taskEnd := t.FinishTime
+	if utility.IsZeroTime(taskEnd) || taskEnd.After(end) {
+		taskEnd = end
+	}
+	if utility.IsZeroTime(taskEnd) || !taskEnd.After(taskStart) {
+		return 0
+	}
+
+	return taskEnd.Sub(taskStart)
+}
+
+// HostCostAllocation is the estimated cost of a host's entire uptime split
+// between the task executions that ran on it.
+type HostCostAllocation struct {
+	// HostCost is the estimated cost of the host from when it was created
+	// until it was terminated.
+	HostCost float64
+	// TaskCosts is each task execution's share of the host cost, in the same
+	// order as the tasks.
+	TaskCosts []float64
+	// Unattributed is the part of the host cost that could not be attributed
+	// to any task because no task ran on the host.
+	Unattributed float64
+}
+
+// NewHostCostAllocation splits the cost of the host's entire uptime,
+// including the time spent provisioning the host, idling between tasks and
+// tearing it down, between the tasks that ran on it in proportion to how long
+// each task ran.
+func NewHostCostAllocation(c evergreen.CostConfig, h *host.Host, tasks []task.Task) HostCostAllocation {
+	hostStart := h.CreationTime
+	if utility.IsZeroTime(hostStart) {
+		hostStart = h.StartTime
+	}
+	hostEnd := h.TerminationTime
+	if utility.IsZeroTime(hostEnd) {
+		hostEnd = time.Now()
+	}
+
+	var alloc HostCostAllocation
+	if !utility.IsZeroTime(hostStart) && hostEnd.After(hostStart) {
+		alloc.HostCost = hostEnd.Sub(hostStart).Hours() * HostHourlyCost(c, h)
+	}
+
+	runTimes := make([]time.Duration, 0, len(tasks))
+	var totalRunTime time.Duration
+	for i := range tasks {
+		runTime := taskRunTime(&tasks[i], hostStart, hostEnd)
+		runTimes = append(runTimes, runTime)
+		totalRunTime += runTime
+	}
+
+	alloc.TaskCosts = make([]float64, len(tasks))
+	if totalRunTime == 0 {
+		alloc.Unattributed = alloc.HostCost
+		return alloc
+	}
+	for i, runTime := range runTimes {
+		alloc.TaskCosts[i] = alloc.HostCost * float64(runTime) / float64(totalRunTime)
+	}
+
+	return alloc
+}
+
+// AllocateHostCost sets the cost of each task execution that ran on the
+// terminated host to its share of the host's entire uptime. The part of the
+// host cost that can't be attributed to any task is logged so that it is
+// still accounted for.
+func AllocateHostCost(ctx context.Context, c evergreen.CostConfig, h *host.Host) error {
+	tasks, err := findHostTaskExecutions(h.Id)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	alloc := NewHostCostAllocation(c, h, tasks)
+	catcher := grip.NewBasicCatcher()
+	for i := range tasks {
+		catcher.Wrapf(tasks[i].SetCost(alloc.TaskCosts[i]), "setting cost for task '%s' execution %d", tasks[i].Id, tasks[i].Execution)
+	}
+
+	grip.Info(message.Fields{
+		"message":           "allocated host cost to tasks",
+		"host_id":           h.Id,
+		"distro":            h.Distro.Id,
+		"instance_type":     h.InstanceType,
+		"num_tasks":         len(tasks),
+		"host_cost":         alloc.HostCost,
+		"unattributed_cost": alloc.Unattributed,
+	})
+
+	return catcher.Resolve()
+}
+
+// findHostTaskExecutions returns every task execution that was assigned to
+// the host, including ones that were aborted or stranded before finishing.
+func findHostTaskExecutions(hostID string) ([]task.Task, error) {
+	events, err := event.Find(event.HostRunningTaskSetEvents(hostID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding task assignment events for host '%s'", hostID)
+	}
+
+	type taskExecution struct {
+		id        string
+		execution int
+	}
+	seen := map[taskExecution]bool{}
+	var tasks []task.Task
+	for _, e := range events {
+		data, ok := e.Data.(*event.HostEventData)
+		if !ok || data.TaskId == "" {
+			continue
+		}
+		execution, err := strconv.Atoi(data.Execution)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing execution for task '%s'", data.TaskId)
+		}
+		key := taskExecution{id: data.TaskId, execution: execution}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		t, err := task.FindOneIdAndExecution(data.TaskId, execution)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding task '%s' execution %d", data.TaskId, execution)
+		}
+		if t == nil || t.HostId != hostID {
+			continue
+		}
+		tasks = append(tasks, *t)
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,187 @@
+package cost
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCost(t *testing.T) {
+	c := evergreen.CostConfig{
+		DefaultHourlyCost: 0.1,
+		InstanceTypeHourlyCosts: []evergreen.InstanceTypeHourlyCost{
+			{InstanceType: "m5.xlarge", HourlyCost: 0.2},
+		},
+	}
+	start := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	for tName, tCase := range map[string]func(t *testing.T, h *host.Host, tsk *task.Task){
+		"UsesHostInstanceTypeCost": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			assert.InDelta(t, 0.4, TaskCost(c, h, tsk), 0.0001)
+		},
+		"PrefersDistroCost": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			h.Distro.CostData.HourlyCost = 1
+			assert.InDelta(t, 2, TaskCost(c, h, tsk), 0.0001)
+		},
+		"UsesDefaultCostForUnknownInstanceType": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			h.InstanceType = "t2.micro"
+			assert.InDelta(t, 0.2, TaskCost(c, h, tsk), 0.0001)
+		},
+		"IncludesTimeBeforeTaskStarted": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			tsk.StartTime = start.Add(time.Hour)
+			assert.InDelta(t, 0.4, TaskCost(c, h, tsk), 0.0001)
+		},
+		"FallsBackToStartTimeWithoutDispatchTime": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			tsk.DispatchTime = time.Time{}
+			tsk.StartTime = start.Add(time.Hour)
+			assert.InDelta(t, 0.2, TaskCost(c, h, tsk), 0.0001)
+		},
+		"IsZeroForUnfinishedTask": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			tsk.FinishTime = time.Time{}
+			assert.Zero(t, TaskCost(c, h, tsk))
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			h := &host.Host{
+				Id:           "h1",
+				Distro:       distro.Distro{Id: "d1"},
+				InstanceType: "m5.xlarge",
+			}
+			tsk := &task.Task{
+				Id:           "t1",
+				DispatchTime: start,
+				StartTime:    start,
+				FinishTime:   start.Add(2 * time.Hour),
+			}
+			tCase(t, h, tsk)
+		})
+	}
+}
+
+func TestNewHostCostAllocation(t *testing.T) {
+	c := evergreen.CostConfig{DefaultHourlyCost: 1}
+	created := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	h := &host.Host{
+		Id:              "h1",
+		Distro:          distro.Distro{Id: "d1"},
+		CreationTime:    created,
+		TerminationTime: created.Add(4 * time.Hour),
+	}
+
+	t.Run("SplitsEntireUptimeByTaskRunTime", func(t *testing.T) {
+		tasks := []task.Task{
+			{Id: "t1", DispatchTime: created.Add(time.Hour), FinishTime: created.Add(2 * time.Hour)},
+			{Id: "t2", DispatchTime: created.Add(2 * time.Hour), FinishTime: created.Add(3 * time.Hour)},
+		}
+		alloc := NewHostCostAllocation(c, h, tasks)
+		assert.InDelta(t, 4, alloc.HostCost, 0.0001)
+		assert.InDelta(t, 2, alloc.TaskCosts[0], 0.0001)
+		assert.InDelta(t, 2, alloc.TaskCosts[1], 0.0001)
+		assert.Zero(t, alloc.Unattributed)
+	})
+	t.Run("ChargesUnfinishedTaskUntilHostTerminated", func(t *testing.T) {
+		tasks := []task.Task{
+			{Id: "t1", DispatchTime: created, FinishTime: created.Add(time.Hour)},
+			{Id: "t2", DispatchTime: created.Add(time.Hour)},
+		}
+		alloc := NewHostCostAllocation(c, h, tasks)
+		assert.InDelta(t, 1, alloc.TaskCosts[0], 0.0001)
+		assert.InDelta(t, 3, alloc.TaskCosts[1], 0.0001)
+	})
+	t.Run("LimitsTaskRunTimeToHostUptime", func(t *testing.T) {
+		tasks := []task.Task{
+			{Id: "t1", DispatchTime: created.Add(-time.Hour), FinishTime: created.Add(time.Hour)},
+			{Id: "t2", DispatchTime: created.Add(3 * time.Hour), FinishTime: created.Add(6 * time.Hour)},
+		}
+		alloc := NewHostCostAllocation(c, h, tasks)
+		assert.InDelta(t, 2, alloc.TaskCosts[0], 0.0001)
+		assert.InDelta(t, 2, alloc.TaskCosts[1], 0.0001)
+	})
+	t.Run("ReportsCostWithoutTasksAsUnattributed", func(t *testing.T) {
+		alloc := NewHostCostAllocation(c, h, nil)
+		assert.Empty(t, alloc.TaskCosts)
+		assert.InDelta(t, 4, alloc.Unattributed, 0.0001)
+	})
+	t.Run("ReportsCostOfTasksThatNeverStartedAsUnattributed", func(t *testing.T) {
+		alloc := NewHostCostAllocation(c, h, []task.Task{{Id: "t1"}})
+		require.Len(t, alloc.TaskCosts, 1)
+		assert.Zero(t, alloc.TaskCosts[0])
+		assert.InDelta(t, 4, alloc.Unattributed, 0.0001)
+	})
+}
+
+func TestAllocateHostCost(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, event.EventCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, event.EventCollection))
+	}()
+
+	c := evergreen.CostConfig{DefaultHourlyCost: 1}
+	created := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+	h := &host.Host{
+		Id:              "h1",
+		Distro:          distro.Distro{Id: "d1"},
+		CreationTime:    created,
+		TerminationTime: created.Add(4 * time.Hour),
+	}
+
+	finished := task.Task{
+		Id:           "t1",
+		Execution:    1,
+		HostId:       h.Id,
+		DispatchTime: created.Add(time.Hour),
+		FinishTime:   created.Add(2 * time.Hour),
+		Cost:         1,
+	}
+	require.NoError(t, finished.Insert())
+	archived := task.Task{
+		Id:           task.MakeOldID("t2", 0),
+		OldTaskId:    "t2",
+		Execution:    0,
+		Archived:     true,
+		HostId:       h.Id,
+		DispatchTime: created.Add(2 * time.Hour),
+	}
+	require.NoError(t, db.Insert(task.OldCollection, archived))
+	otherHost := task.Task{
+		Id:           "t3",
+		HostId:       "h2",
+		DispatchTime: created,
+		FinishTime:   created.Add(4 * time.Hour),
+	}
+	require.NoError(t, otherHost.Insert())
+
+	event.LogHostRunningTaskSet(h.Id, finished.Id, finished.Execution)
+	event.LogHostRunningTaskSet(h.Id, "t2", 0)
+	event.LogHostRunningTaskSet(h.Id, finished.Id, finished.Execution)
+	event.LogHostRunningTaskSet(h.Id, otherHost.Id, otherHost.Execution)
+
+	require.NoError(t, AllocateHostCost(ctx, c, h))
+
+	dbFinished, err := task.FindOneIdAndExecution(finished.Id, finished.Execution)
+	require.NoError(t, err)
+	require.NotZero(t, dbFinished)
+	assert.InDelta(t, 4.0/3, dbFinished.Cost, 0.0001)
+
+	dbArchived, err := task.FindOneIdAndExecution("t2", 0)
+	require.NoError(t, err)
+	require.NotZero(t, dbArchived)
+	assert.InDelta(t, 8.0/3, dbArchived.Cost, 0.0001)
+
+	dbOtherHost, err := task.FindOneId(otherHost.Id)
+	require.NoError(t, err)
+	require.NotZero(t, dbOtherHost)
+	assert.Zero(t, dbOtherHost.Cost)
+}
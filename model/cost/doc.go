@@ -0,0 +1,4 @@
+// Package cost estimates what it costs to run tasks on hosts, aggregates those
+// costs per project, build variant and requester, and tracks which projects
+// have exceeded their monthly budgets.
+package cost
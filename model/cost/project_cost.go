@@ -0,0 +1,169 @@
+package cost
+
+import (
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// CostBreakdown is the estimated cost of a group of a project's tasks.
+type CostBreakdown struct {
+	// BuildVariant is the build variant of the tasks. It's empty if the tasks
+	// are grouped only by requester.
+	BuildVariant string `bson:"build_variant" json:"build_variant"`
+	// Requester is the requester of the tasks. It's empty if the tasks are
+	// grouped only by build variant.
+	Requester string  `bson:"requester" json:"requester"`
+	Cost      float64 `bson:"cost" json:"cost"`
+	NumTasks  int     `bson:"num_tasks" json:"num_tasks"`
+}
+
+// ProjectCost is the estimated cost of the tasks in a project that finished
+// within a time range.
+type ProjectCost struct {
+	ProjectID string    `json:"project_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	TotalCost float64   `json:"total_cost"`
+	NumTasks  int       `json:"num_tasks"`
+	// ByVariant is the cost of each build variant, sorted by most to least
+	// expensive.
+	ByVariant []CostBreakdown `json:"by_variant"`
+	// ByRequester is the cost of each requester, sorted by most to least
+	// expensive.
+	ByRequester []CostBreakdown `json:"by_requester"`
+	// ByVariantAndRequester is the cost of each combination of build variant
+	// and requester, sorted by most to least expensive.
+	ByVariantAndRequester []CostBreakdown `json:"by_variant_and_requester"`
+}
+
+type costBreakdownResult struct {
+	ID       costBreakdownID `bson:"_id"`
+	Cost     float64         `bson:"cost"`
+	NumTasks int             `bson:"num_tasks"`
+}
+
+type costBreakdownID struct {
+	BuildVariant string `bson:"build_variant"`
+	Requester    string `bson:"requester"`
+}
+
+// GetProjectCost returns the estimated cost of the project's tasks that
+// finished in the time range [start, end). This includes the costs of
+// previous executions of tasks that were restarted.
+func GetProjectCost(projectID string, start, end time.Time) (*ProjectCost, error) {
+	if !end.After(start) {
+		return nil, errors.New("end time must be after start time")
+	}
+
+	pipeline := projectCostPipeline(projectID, start, end)
+	var results []costBreakdownResult
+	for _, coll := range []string{task.Collection, task.OldCollection} {
+		var collResults []costBreakdownResult
+		if err := db.Aggregate(coll, pipeline, &collResults); err != nil {
+			return nil, errors.Wrapf(err, "aggregating task costs in collection '%s'", coll)
+		}
+		results = append(results, collResults...)
+	}
+
+	return newProjectCost(projectID, start, end, results), nil
+}
+
+func projectCostPipeline(projectID string, start, end time.Time) []bson.M {
+	return []bson.M{
+		{"$match": bson.M{
+			task.ProjectKey: projectID,
+			task.FinishTimeKey: bson.M{
+				"$gte": start,
+				"$lt":  end,
+			},
+			task.CostKey: bson.M{"$gt": 0},
+		}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"build_variant": "$" + task.BuildVariantKey,
+				"requester":     "$" + task.RequesterKey,
+			},
+			"cost":      bson.M{"$sum": "$" + task.CostKey},
+			"num_tasks": bson.M{"$sum": 1},
+		}},
+	}
+}
+
+// newProjectCost aggregates the per-variant and per-requester results, which
+// may contain more than one result for the same build variant and requester,
+// into the project's cost.
+func newProjectCost(projectID string, start, end time.Time, results []costBreakdownResult) *ProjectCost {
+	pc := &ProjectCost{
+		ProjectID: projectID,
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	byVariant := map[string]*CostBreakdown{}
+	byRequester := map[string]*CostBreakdown{}
+	byVariantAndRequester := map[costBreakdownID]*CostBreakdown{}
+	add := func(breakdowns map[string]*CostBreakdown, key string, b CostBreakdown) {
+		if existing, ok := breakdowns[key]; ok {
+			existing.Cost += b.Cost
+			existing.NumTasks += b.NumTasks
+			return
+		}
+		breakdowns[key] = &b
+	}
+	for _, res := range results {
+		pc.TotalCost += res.Cost
+		pc.NumTasks += res.NumTasks
+
+		add(byVariant, res.ID.BuildVariant, CostBreakdown{BuildVariant: res.ID.BuildVariant, Cost: res.Cost, NumTasks: res.NumTasks})
+		add(byRequester, res.ID.Requester, CostBreakdown{Requester: res.ID.Requester, Cost: res.Cost, NumTasks: res.NumTasks})
+		if existing, ok := byVariantAndRequester[res.ID]; ok {
+			existing.Cost += res.Cost
+			existing.NumTasks += res.NumTasks
+		} else {
+			byVariantAndRequester[res.ID] = &CostBreakdown{
+				BuildVariant: res.ID.BuildVariant,
+				Requester:    res.ID.Requester,
+				Cost:         res.Cost,
+				NumTasks:     res.NumTasks,
+			}
+		}
+	}
+
+	pc.ByVariant = sortedBreakdowns(byVariant)
+	pc.ByRequester = sortedBreakdowns(byRequester)
+	pc.ByVariantAndRequester = make([]CostBreakdown, 0, len(byVariantAndRequester))
+	for _, b := range byVariantAndRequester {
+		pc.ByVariantAndRequester = append(pc.ByVariantAndRequester, *b)
+	}
+	sortBreakdowns(pc.ByVariantAndRequester)
+
+	return pc
+}
+
+func sortedBreakdowns(breakdowns map[string]*CostBreakdown) []CostBreakdown {
+	sorted := make([]CostBreakdown, 0, len(breakdowns))
+	for _, b := range breakdowns {
+		sorted = append(sorted, *b)
+	}
+	sortBreakdowns(sorted)
+	return sorted
+}
+
+// sortBreakdowns sorts the breakdowns from most to least expensive, breaking
+// ties by build variant and then requester so that the order is stable.
+func sortBreakdowns(breakdowns []CostBreakdown) {
+	sort.Slice(breakdowns, func(i, j int) bool {
+		if breakdowns[i].Cost != breakdowns[j].Cost {
+			return breakdowns[i].Cost > breakdowns[j].Cost
+		}
+		if breakdowns[i].BuildVariant != breakdowns[j].BuildVariant {
+			return breakdowns[i].BuildVariant < breakdowns[j].BuildVariant
+		}
+		return breakdowns[i].Requester < breakdowns[j].Requester
+	})
+}
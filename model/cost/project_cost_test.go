@@ -0,0 +1,65 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	_ "github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProjectCost(t *testing.T) {
+	require.NoError(t, db.ClearCollections(task.Collection, task.OldCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, task.OldCollection))
+	}()
+
+	start := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	tasks := []task.Task{
+		{Id: "t1", Project: "p1", BuildVariant: "bv1", Requester: evergreen.RepotrackerVersionRequester, FinishTime: start.Add(time.Hour), Cost: 1},
+		{Id: "t2", Project: "p1", BuildVariant: "bv1", Requester: evergreen.PatchVersionRequester, FinishTime: start.Add(time.Hour), Cost: 2},
+		{Id: "t3", Project: "p1", BuildVariant: "bv2", Requester: evergreen.PatchVersionRequester, FinishTime: start.Add(time.Hour), Cost: 4},
+		{Id: "t4", Project: "p1", BuildVariant: "bv2", Requester: evergreen.PatchVersionRequester, FinishTime: start.Add(time.Hour)},
+		{Id: "t5", Project: "p1", BuildVariant: "bv1", Requester: evergreen.PatchVersionRequester, FinishTime: end, Cost: 8},
+		{Id: "t6", Project: "p2", BuildVariant: "bv1", Requester: evergreen.PatchVersionRequester, FinishTime: start.Add(time.Hour), Cost: 16},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+	oldTask := task.Task{Id: "t3_0", OldTaskId: "t3", Project: "p1", BuildVariant: "bv2", Requester: evergreen.PatchVersionRequester, FinishTime: start.Add(time.Minute), Cost: 0.5}
+	require.NoError(t, db.Insert(task.OldCollection, oldTask))
+
+	pc, err := GetProjectCost("p1", start, end)
+	require.NoError(t, err)
+	require.NotNil(t, pc)
+
+	assert.Equal(t, "p1", pc.ProjectID)
+	assert.InDelta(t, 7.5, pc.TotalCost, 0.0001)
+	assert.Equal(t, 4, pc.NumTasks)
+
+	require.Len(t, pc.ByVariant, 2)
+	assert.Equal(t, "bv2", pc.ByVariant[0].BuildVariant)
+	assert.InDelta(t, 4.5, pc.ByVariant[0].Cost, 0.0001)
+	assert.Equal(t, 2, pc.ByVariant[0].NumTasks)
+	assert.Equal(t, "bv1", pc.ByVariant[1].BuildVariant)
+	assert.InDelta(t, 3, pc.ByVariant[1].Cost, 0.0001)
+
+	require.Len(t, pc.ByRequester, 2)
+	assert.Equal(t, evergreen.PatchVersionRequester, pc.ByRequester[0].Requester)
+	assert.InDelta(t, 6.5, pc.ByRequester[0].Cost, 0.0001)
+	assert.Equal(t, 3, pc.ByRequester[0].NumTasks)
+	assert.Equal(t, evergreen.RepotrackerVersionRequester, pc.ByRequester[1].Requester)
+
+	require.Len(t, pc.ByVariantAndRequester, 3)
+	assert.Equal(t, "bv2", pc.ByVariantAndRequester[0].BuildVariant)
+	assert.Equal(t, evergreen.PatchVersionRequester, pc.ByVariantAndRequester[0].Requester)
+	assert.InDelta(t, 4.5, pc.ByVariantAndRequester[0].Cost, 0.0001)
+
+	_, err = GetProjectCost("p1", end, start)
+	assert.Error(t, err)
+}
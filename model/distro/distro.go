@@ -54,6 +54,7 @@ type Distro struct {
 	IsCluster             bool                  `bson:"is_cluster" json:"is_cluster" mapstructure:"is_cluster"`
 	HomeVolumeSettings    HomeVolumeSettings    `bson:"home_volume_settings" json:"home_volume_settings" mapstructure:"home_volume_settings"`
 	IceCreamSettings      IceCreamSettings      `bson:"icecream_settings,omitempty" json:"icecream_settings,omitempty" mapstructure:"icecream_settings,omitempty"`
+	CostData              CostData              `bson:"cost_data,omitempty" json:"cost_data,omitempty" mapstructure:"cost_data,omitempty"`
 }
 
 // DistroData is the same as a distro, with the only difference being that all
@@ -88,6 +89,20 @@ func (d *Distro) GetDefaultAMI() string {
 	return ami
 }
 
+// GetHourlyCost returns the hourly cost of a host in the distro with the given
+// instance type. The distro's own hourly cost takes precedence over the admin
+// settings' cost for the instance type. If the instance type is empty, the
+// instance type in the distro's provider settings is used.
+func (d *Distro) GetHourlyCost(c evergreen.CostConfig, instanceType string) float64 {
+	if d.CostData.HourlyCost > 0 {
+		return d.CostData.HourlyCost
+	}
+	if instanceType == "" && len(d.ProviderSettingsList) > 0 {
+		instanceType, _ = d.ProviderSettingsList[0].Lookup("instance_type").StringValueOK()
+	}
+	return c.InstanceTypeHourlyCost(instanceType)
+}
+
 // BootstrapSettings encapsulates all settings related to bootstrapping hosts.
 type BootstrapSettings struct {
 	// Required
@@ -135,6 +150,13 @@ type HomeVolumeSettings struct {
 	FormatCommand string `bson:"format_command" json:"format_command" mapstructure:"format_command"`
 }
 
+// CostData is metadata about how much it costs to run the distro's hosts.
+type CostData struct {
+	// HourlyCost is the hourly cost of a host in the distro. If it is not
+	// set, the cost is based on the host's instance type.
+	HourlyCost float64 `bson:"hourly_cost,omitempty" json:"hourly_cost,omitempty" mapstructure:"hourly_cost,omitempty"`
+}
+
 type IceCreamSettings struct {
 	SchedulerHost string `bson:"scheduler_host,omitempty" json:"scheduler_host,omitempty" mapstructure:"scheduler_host,omitempty"`
 	ConfigPath    string `bson:"config_path,omitempty" json:"config_path,omitempty" mapstructure:"config_path,omitempty"`
@@ -240,6 +262,10 @@ type HostAllocatorSettings struct {
 	// AcceptableHostIdleTime is the amount of time we wait for an idle host to be marked as idle.
 	AcceptableHostIdleTime time.Duration `bson:"acceptable_host_idle_time" json:"acceptable_host_idle_time" mapstructure:"acceptable_host_idle_time"`
 	FutureHostFraction     float64       `bson:"future_host_fraction" json:"future_host_fraction" mapstructure:"future_host_fraction"`
+	// WarmPoolSize is the number of hosts to keep provisioned and ready to
+	// run tasks without counting them as task capacity. The host allocator
+	// takes hosts from the warm pool before spawning new ones.
+	WarmPoolSize int `bson:"warm_pool_size,omitempty" json:"warm_pool_size,omitempty" mapstructure:"warm_pool_size,omitempty"`
 }
 
 type FinderSettings struct {
@@ -504,6 +530,8 @@ func (d *Distro) GetImageID() (string, error) {
 		key = "image_name"
 	case evergreen.ProviderNameVsphere:
 		key = "template"
+	case evergreen.ProviderNameLibvirt:
+		key = "base_image"
 	case evergreen.ProviderNameMock, evergreen.ProviderNameStatic, evergreen.ProviderNameOpenstack:
 		return "", nil
 	default:
@@ -647,6 +675,7 @@ func (d *Distro) GetResolvedHostAllocatorSettings(s *evergreen.Settings) (HostAl
 		FeedbackRule:           has.FeedbackRule,
 		HostsOverallocatedRule: has.HostsOverallocatedRule,
 		FutureHostFraction:     has.FutureHostFraction,
+		WarmPoolSize:           has.WarmPoolSize,
 	}
 
 	catcher := grip.NewBasicCatcher()
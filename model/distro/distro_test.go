@@ -173,6 +173,29 @@ func TestGetDefaultAMI(t *testing.T) {
 	assert.Equal(t, d.GetDefaultAMI(), "ami-5678")
 }
 
+func TestGetHourlyCost(t *testing.T) {
+	c := evergreen.CostConfig{
+		DefaultHourlyCost: 0.1,
+		InstanceTypeHourlyCosts: []evergreen.InstanceTypeHourlyCost{
+			{InstanceType: "m5.xlarge", HourlyCost: 0.192},
+			{InstanceType: "c5.2xlarge", HourlyCost: 0.34},
+		},
+	}
+	d := Distro{
+		Id: "d1",
+		ProviderSettingsList: []*birch.Document{
+			birch.NewDocument(birch.EC.String("instance_type", "m5.xlarge")),
+		},
+	}
+
+	assert.Equal(t, 0.192, d.GetHourlyCost(c, ""), "should use the provider settings' instance type")
+	assert.Equal(t, 0.34, d.GetHourlyCost(c, "c5.2xlarge"), "should use the given instance type")
+	assert.Equal(t, 0.1, d.GetHourlyCost(c, "t2.micro"), "should use the default cost for an unknown instance type")
+
+	d.CostData.HourlyCost = 0.5
+	assert.Equal(t, 0.5, d.GetHourlyCost(c, "c5.2xlarge"), "distro cost should take precedence")
+}
+
 func TestValidateContainerPoolDistros(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -136,6 +136,16 @@ func MostRecentHostEvents(id string, tag string, n int) db.Q {
 	return db.Query(filter).Sort([]string{"-" + TimestampKey}).Limit(n)
 }
 
+// HostRunningTaskSetEvents returns a query for the events logged each time a
+// task was assigned to the host, in the order they were assigned.
+func HostRunningTaskSetEvents(hostID string) db.Q {
+	filter := ResourceTypeKeyIs(ResourceTypeHost)
+	filter[ResourceIdKey] = hostID
+	filter[TypeKey] = EventHostRunningTaskSet
+
+	return db.Query(filter).Sort([]string{TimestampKey})
+}
+
 // MostRecentPaginatedHostEvents returns a limited and paginated list of host events for the given
 // host ID and tag sorted in descending order by timestamp as well as the total number of events.
 func MostRecentPaginatedHostEvents(id string, tag string, limit, page int) ([]EventLogEntry, int, error) {
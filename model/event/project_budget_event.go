@@ -0,0 +1,52 @@
+package event
+
+import (
+	"time"
+
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+func init() {
+	registry.AddType(ResourceTypeProjectBudget, func() interface{} { return &ProjectBudgetEventData{} })
+	registry.AllowSubscription(ResourceTypeProjectBudget, EventProjectBudgetExceeded)
+}
+
+const (
+	// ResourceTypeProjectBudget represents a project's monthly budget as a
+	// resource associated with events.
+	ResourceTypeProjectBudget = "PROJECT_BUDGET"
+
+	// EventProjectBudgetExceeded represents an event where a project's
+	// estimated spend for the month exceeded its monthly budget.
+	EventProjectBudgetExceeded = "BUDGET_EXCEEDED"
+)
+
+// ProjectBudgetEventData contains information relevant to a project budget
+// event.
+type ProjectBudgetEventData struct {
+	Month  time.Time `bson:"month" json:"month"`
+	Budget float64   `bson:"budget" json:"budget"`
+	Spend  float64   `bson:"spend" json:"spend"`
+}
+
+// LogProjectBudgetExceeded logs an event indicating that the project's
+// estimated spend for the month has exceeded its monthly budget.
+func LogProjectBudgetExceeded(projectID string, data ProjectBudgetEventData) {
+	e := EventLogEntry{
+		Timestamp:    time.Now(),
+		ResourceId:   projectID,
+		ResourceType: ResourceTypeProjectBudget,
+		EventType:    EventProjectBudgetExceeded,
+		Data:         data,
+	}
+
+	if err := e.Log(); err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"resource_type": ResourceTypeProjectBudget,
+			"message":       "error logging event",
+			"source":        "event-log-fail",
+			"project_id":    projectID,
+		}))
+	}
+}
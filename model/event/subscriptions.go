@@ -73,6 +73,7 @@ const (
 	ObjectBuild   = "build"
 	ObjectHost    = "host"
 	ObjectPatch   = "patch"
+	ObjectProject = "project"
 
 	TriggerOutcome = "outcome"
 	// TriggerFamilyOutcome indicates that a patch or version completed,
@@ -90,6 +91,7 @@ const (
 	TriggerPatchStarted              = "started"
 	TriggerTaskFirstFailureInVersion = "first-failure-in-version"
 	TriggerTaskStarted               = "task-started"
+	TriggerBudgetExceeded            = "budget-exceeded"
 )
 
 type Subscription struct {
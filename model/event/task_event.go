@@ -14,6 +14,8 @@ func init() {
 	registry.AllowSubscription(ResourceTypeTask, TaskStarted)
 	registry.AllowSubscription(ResourceTypeTask, TaskFinished)
 	registry.AllowSubscription(ResourceTypeTask, TaskBlocked)
+	registry.AllowSubscription(ResourceTypeTask, TaskTestFlaky)
+	registry.AllowSubscription(ResourceTypeTask, TaskStepbackCulprit)
 }
 
 const (
@@ -36,6 +38,8 @@ const (
 	TaskJiraAlertCreated       = "TASK_JIRA_ALERT_CREATED"
 	TaskDependenciesOverridden = "TASK_DEPENDENCIES_OVERRIDDEN"
 	MergeTaskUnscheduled       = "MERGE_TASK_UNSCHEDULED"
+	TaskTestFlaky              = "TASK_TEST_FLAKY"
+	TaskStepbackCulprit        = "TASK_STEPBACK_CULPRIT"
 )
 
 // implements Data
@@ -46,9 +50,11 @@ type TaskEventData struct {
 	UserId    string `bson:"u_id,omitempty" json:"user_id,omitempty"`
 	Status    string `bson:"s,omitempty" json:"status,omitempty"`
 	JiraIssue string `bson:"jira,omitempty" json:"jira,omitempty"`
+	TestName  string `bson:"test,omitempty" json:"test,omitempty"`
 
 	Timestamp time.Time `bson:"ts,omitempty" json:"timestamp,omitempty"`
 	Priority  int64     `bson:"pri,omitempty" json:"priority,omitempty"`
+	FlipRate  float64   `bson:"flip_rate,omitempty" json:"flip_rate,omitempty"`
 }
 
 func logTaskEvent(taskId string, eventType string, eventData TaskEventData) {
@@ -217,3 +223,18 @@ func LogMergeTaskUnscheduled(taskId string, execution int, userID string) {
 	logTaskEvent(taskId, MergeTaskUnscheduled,
 		TaskEventData{Execution: execution, UserId: userID})
 }
+
+// LogTaskTestFlaky logs an event indicating that a test run by the task has
+// become flaky. The task is the most recent mainline task that ran the test.
+func LogTaskTestFlaky(taskId string, execution int, testName string, flipRate float64) {
+	logTaskEvent(taskId, TaskTestFlaky,
+		TaskEventData{Execution: execution, TestName: testName, FlipRate: flipRate})
+}
+
+// LogTaskStepbackCulprit logs an event indicating that stepback found the task
+// to be the first one in which the test failed. If the test name is empty,
+// the task is the culprit for the failure of the task as a whole.
+func LogTaskStepbackCulprit(taskId string, execution int, testName string) {
+	logTaskEvent(taskId, TaskStepbackCulprit,
+		TaskEventData{Execution: execution, TestName: testName})
+}
@@ -0,0 +1,144 @@
+package flakytest
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FlakinessCollection  = "test_flakiness"
+	QuarantineCollection = "quarantined_tests"
+)
+
+var (
+	// bson fields for the TestFlakiness struct
+	FlakinessIDKey                = bsonutil.MustHaveTag(TestFlakiness{}, "ID")
+	FlakinessNumRunsKey           = bsonutil.MustHaveTag(TestFlakiness{}, "NumRuns")
+	FlakinessNumFailedKey         = bsonutil.MustHaveTag(TestFlakiness{}, "NumFailed")
+	FlakinessNumFlipsKey          = bsonutil.MustHaveTag(TestFlakiness{}, "NumFlips")
+	FlakinessFlipRateKey          = bsonutil.MustHaveTag(TestFlakiness{}, "FlipRate")
+	FlakinessFlakyKey             = bsonutil.MustHaveTag(TestFlakiness{}, "Flaky")
+	FlakinessLastTaskIDKey        = bsonutil.MustHaveTag(TestFlakiness{}, "LastTaskID")
+	FlakinessLastTaskExecutionKey = bsonutil.MustHaveTag(TestFlakiness{}, "LastTaskExecution")
+	FlakinessLastUpdatedKey       = bsonutil.MustHaveTag(TestFlakiness{}, "LastUpdated")
+
+	// bson fields for the TestFlakinessID struct
+	FlakinessIDProjectKey      = bsonutil.MustHaveTag(TestFlakinessID{}, "Project")
+	FlakinessIDBuildVariantKey = bsonutil.MustHaveTag(TestFlakinessID{}, "BuildVariant")
+	FlakinessIDTaskNameKey     = bsonutil.MustHaveTag(TestFlakinessID{}, "TaskName")
+	FlakinessIDTestNameKey     = bsonutil.MustHaveTag(TestFlakinessID{}, "TestName")
+
+	// bson fields for the QuarantinedTest struct
+	QuarantineIDKey            = bsonutil.MustHaveTag(QuarantinedTest{}, "ID")
+	QuarantineProjectIDKey     = bsonutil.MustHaveTag(QuarantinedTest{}, "ProjectID")
+	QuarantineTestNameKey      = bsonutil.MustHaveTag(QuarantinedTest{}, "TestName")
+	QuarantineTaskNameKey      = bsonutil.MustHaveTag(QuarantinedTest{}, "TaskName")
+	QuarantineBuildVariantKey  = bsonutil.MustHaveTag(QuarantinedTest{}, "BuildVariant")
+	QuarantineQuarantinedAtKey = bsonutil.MustHaveTag(QuarantinedTest{}, "QuarantinedAt")
+)
+
+// FindTestFlakiness returns the flakiness of the project's tests. If
+// onlyFlaky is true, only the tests currently considered flaky are returned.
+func FindTestFlakiness(projectID string, onlyFlaky bool) ([]TestFlakiness, error) {
+	q := bson.M{
+		bsonutil.GetDottedKeyName(FlakinessIDKey, FlakinessIDProjectKey): projectID,
+	}
+	if onlyFlaky {
+		q[FlakinessFlakyKey] = true
+	}
+
+	flakiness := []TestFlakiness{}
+	err := db.FindAllQ(FlakinessCollection, db.Query(q).Sort([]string{"-" + FlakinessFlipRateKey}), &flakiness)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding test flakiness for project '%s'", projectID)
+	}
+
+	return flakiness, nil
+}
+
+// upsertTestFlakiness writes the flakiness of the test.
+func upsertTestFlakiness(f TestFlakiness) error {
+	_, err := db.Upsert(
+		FlakinessCollection,
+		bson.M{FlakinessIDKey: f.ID},
+		bson.M{
+			"$set": bson.M{
+				FlakinessNumRunsKey:           f.NumRuns,
+				FlakinessNumFailedKey:         f.NumFailed,
+				FlakinessNumFlipsKey:          f.NumFlips,
+				FlakinessFlipRateKey:          f.FlipRate,
+				FlakinessFlakyKey:             f.Flaky,
+				FlakinessLastTaskIDKey:        f.LastTaskID,
+				FlakinessLastTaskExecutionKey: f.LastTaskExecution,
+				FlakinessLastUpdatedKey:       f.LastUpdated,
+			},
+		},
+	)
+	return errors.Wrapf(err, "upserting flakiness of test '%s'", f.ID.TestName)
+}
+
+// removeStaleTestFlakiness removes the flakiness of the project's tests that
+// have not been updated since the given time, such as tests that no longer
+// run.
+func removeStaleTestFlakiness(projectID string, before time.Time) error {
+	return errors.Wrapf(db.RemoveAll(FlakinessCollection, bson.M{
+		bsonutil.GetDottedKeyName(FlakinessIDKey, FlakinessIDProjectKey): projectID,
+		FlakinessLastUpdatedKey: bson.M{"$lt": before},
+	}), "removing stale test flakiness for project '%s'", projectID)
+}
+
+// FindQuarantinedTests returns the project's quarantine list.
+func FindQuarantinedTests(projectID string) (QuarantineList, error) {
+	quarantined := QuarantineList{}
+	q := db.Query(bson.M{QuarantineProjectIDKey: projectID}).Sort([]string{QuarantineQuarantinedAtKey})
+	err := db.FindAllQ(QuarantineCollection, q, &quarantined)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding quarantined tests for project '%s'", projectID)
+	}
+
+	return quarantined, nil
+}
+
+// FindOneQuarantinedTest returns the quarantined test matching the query, if
+// any.
+func FindOneQuarantinedTest(query db.Q) (*QuarantinedTest, error) {
+	quarantined := &QuarantinedTest{}
+	err := db.FindOneQ(QuarantineCollection, query, quarantined)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "finding quarantined test")
+	}
+
+	return quarantined, nil
+}
+
+// FindQuarantinedTestByID returns the project's quarantined test with the
+// given ID, if any.
+func FindQuarantinedTestByID(projectID, id string) (*QuarantinedTest, error) {
+	return FindOneQuarantinedTest(db.Query(bson.M{
+		QuarantineIDKey:        id,
+		QuarantineProjectIDKey: projectID,
+	}))
+}
+
+// RemoveQuarantinedTest removes the quarantined test with the given ID from
+// the project's quarantine list.
+func RemoveQuarantinedTest(projectID, id string) error {
+	return errors.Wrapf(db.Remove(QuarantineCollection, bson.M{
+		QuarantineIDKey:        id,
+		QuarantineProjectIDKey: projectID,
+	}), "removing quarantined test '%s'", id)
+}
@@ -0,0 +1,5 @@
+// Package flakytest tracks how often each test flips between passing and
+// failing across recent mainline task executions and maintains the per-project
+// lists of quarantined tests, whose failures do not fail the tasks that run
+// them.
+package flakytest
@@ -0,0 +1,298 @@
+package flakytest
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	// DefaultWindow is how far back to look for mainline task executions
+	// when calculating test flakiness.
+	DefaultWindow = 14 * 24 * time.Hour
+	// DefaultMinRuns is the minimum number of times a test must have passed
+	// or failed within the window before it can be considered flaky.
+	DefaultMinRuns = 10
+	// DefaultFlipRateThreshold is the flip rate at or above which a test is
+	// considered flaky.
+	DefaultFlipRateThreshold = 0.2
+
+	// resultsBatchSize is the maximum number of task runs whose test
+	// results are fetched at once.
+	resultsBatchSize = 100
+)
+
+// TestFlakiness is how often a test in a task flipped between passing and
+// failing across recent mainline task executions.
+type TestFlakiness struct {
+	ID TestFlakinessID `bson:"_id" json:"id"`
+	// NumRuns is the number of executions in which the test passed or
+	// failed. Skipped runs are not counted.
+	NumRuns   int `bson:"num_runs" json:"num_runs"`
+	NumFailed int `bson:"num_failed" json:"num_failed"`
+	// NumFlips is the number of times the test's status differed from its
+	// status in the previous execution.
+	NumFlips int `bson:"num_flips" json:"num_flips"`
+	// FlipRate is the fraction of consecutive runs in which the test's
+	// status flipped.
+	FlipRate float64 `bson:"flip_rate" json:"flip_rate"`
+	Flaky    bool    `bson:"flaky" json:"flaky"`
+	// LastTaskID and LastTaskExecution identify the most recent task
+	// execution that ran the test.
+	LastTaskID        string    `bson:"last_task_id" json:"last_task_id"`
+	LastTaskExecution int       `bson:"last_task_execution" json:"last_task_execution"`
+	LastUpdated       time.Time `bson:"last_updated" json:"last_updated"`
+}
+
+// TestFlakinessID identifies a test in a task.
+type TestFlakinessID struct {
+	Project      string `bson:"project" json:"project"`
+	BuildVariant string `bson:"variant" json:"variant"`
+	TaskName     string `bson:"task_name" json:"task_name"`
+	TestName     string `bson:"test_name" json:"test_name"`
+}
+
+// CalculateOptions configure how test flakiness is calculated for a project.
+type CalculateOptions struct {
+	ProjectID string
+	// Window is how far back to look for mainline task executions. Defaults
+	// to DefaultWindow.
+	Window time.Duration
+	// MinRuns is the minimum number of runs needed for a test to be
+	// considered flaky. Defaults to DefaultMinRuns.
+	MinRuns int
+	// FlipRateThreshold is the flip rate at or above which a test is
+	// considered flaky. Defaults to DefaultFlipRateThreshold.
+	FlipRateThreshold float64
+}
+
+func (o *CalculateOptions) validate() error {
+	if o.ProjectID == "" {
+		return errors.New("must specify a project ID")
+	}
+	if o.Window < 0 || o.MinRuns < 0 || o.FlipRateThreshold < 0 || o.FlipRateThreshold > 1 {
+		return errors.New("window, minimum runs, and flip rate threshold must be non-negative, and the threshold must be at most 1")
+	}
+	if o.Window == 0 {
+		o.Window = DefaultWindow
+	}
+	if o.MinRuns == 0 {
+		o.MinRuns = DefaultMinRuns
+	}
+	if o.FlipRateThreshold == 0 {
+		o.FlipRateThreshold = DefaultFlipRateThreshold
+	}
+
+	return nil
+}
+
+// CalculateFlipRate returns the number of runs, failures, and flips in the
+// chronologically ordered test statuses, along with the flip rate. A flip is a
+// run whose status differs from the previous run's. Skipped and silently
+// failed runs are ignored.
+func CalculateFlipRate(statuses []string) (numRuns, numFailed, numFlips int, flipRate float64) {
+	var last string
+	for _, status := range statuses {
+		if status != evergreen.TestSucceededStatus && status != evergreen.TestFailedStatus {
+			continue
+		}
+
+		numRuns++
+		if status == evergreen.TestFailedStatus {
+			numFailed++
+		}
+		if last != "" && status != last {
+			numFlips++
+		}
+		last = status
+	}
+
+	if numRuns > 1 {
+		flipRate = float64(numFlips) / float64(numRuns-1)
+	}
+
+	return numRuns, numFailed, numFlips, flipRate
+}
+
+// testRuns is the chronologically ordered statuses of a test.
+type testRuns struct {
+	statuses      []string
+	lastTaskID    string
+	lastExecution int
+}
+
+// CalculateProjectFlakiness calculates the flakiness of every test run by
+// the project's mainline tasks that finished within the window and saves it.
+// It returns the tests that have become flaky since the last time flakiness
+// was calculated. Tasks whose test results cannot be fetched are skipped.
+func CalculateProjectFlakiness(ctx context.Context, env evergreen.Environment, opts CalculateOptions) ([]TestFlakiness, error) {
+	if err := opts.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid options")
+	}
+
+	startAt := time.Now()
+	tasks, err := task.FindWithSort(bson.M{
+		task.ProjectKey:    opts.ProjectID,
+		task.RequesterKey:  evergreen.RepotrackerVersionRequester,
+		task.StatusKey:     bson.M{"$in": evergreen.TaskCompletedStatuses},
+		task.FinishTimeKey: bson.M{"$gte": startAt.Add(-opts.Window)},
+		"$or": []bson.M{
+			{task.ResultsServiceKey: bson.M{"$exists": true}},
+			{task.HasCedarResultsKey: true},
+		},
+	}, []string{task.RevisionOrderNumberKey})
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding mainline tasks for project '%s'", opts.ProjectID)
+	}
+
+	results, err := getTaskTestResults(ctx, env, tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := map[TestFlakinessID]*testRuns{}
+	var order []TestFlakinessID
+	for _, res := range results {
+		t := tasks[res.taskIdx]
+		id := TestFlakinessID{
+			Project:      t.Project,
+			BuildVariant: t.BuildVariant,
+			TaskName:     t.DisplayName,
+			TestName:     res.GetDisplayTestName(),
+		}
+		r, ok := runs[id]
+		if !ok {
+			r = &testRuns{}
+			runs[id] = r
+			order = append(order, id)
+		}
+		r.statuses = append(r.statuses, res.Status)
+		r.lastTaskID = t.Id
+		r.lastExecution = t.Execution
+	}
+
+	existing, err := FindTestFlakiness(opts.ProjectID, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding existing flaky tests")
+	}
+	previouslyFlaky := map[TestFlakinessID]bool{}
+	for _, f := range existing {
+		previouslyFlaky[f.ID] = true
+	}
+
+	var newlyFlaky []TestFlakiness
+	for _, id := range order {
+		r := runs[id]
+		f := TestFlakiness{
+			ID:                id,
+			LastTaskID:        r.lastTaskID,
+			LastTaskExecution: r.lastExecution,
+			LastUpdated:       startAt,
+		}
+		f.NumRuns, f.NumFailed, f.NumFlips, f.FlipRate = CalculateFlipRate(r.statuses)
+		f.Flaky = f.NumRuns >= opts.MinRuns && f.FlipRate >= opts.FlipRateThreshold
+
+		if err := upsertTestFlakiness(f); err != nil {
+			return nil, err
+		}
+		if f.Flaky && !previouslyFlaky[id] {
+			newlyFlaky = append(newlyFlaky, f)
+		}
+	}
+
+	if err := removeStaleTestFlakiness(opts.ProjectID, startAt); err != nil {
+		return nil, err
+	}
+
+	return newlyFlaky, nil
+}
+
+// taskTestResult is a test result of the task at the index taskIdx.
+type taskTestResult struct {
+	testresult.TestResult
+	taskIdx int
+}
+
+// getTaskTestResults returns the test results of the given tasks, ordered by
+// the task that ran them. The results are fetched in batches of task runs
+// that use the same test results service. If fetching a batch fails, the
+// results of each of its tasks are fetched separately, and tasks whose results
+// cannot be fetched are skipped so that they do not block the calculation.
+func getTaskTestResults(ctx context.Context, env evergreen.Environment, tasks []task.Task) ([]taskTestResult, error) {
+	taskIdxs := map[testresult.TaskOptions]int{}
+	taskOptsByService := map[string][]testresult.TaskOptions{}
+	var services []string
+	for i, t := range tasks {
+		taskOpts, err := t.CreateTestResultsTaskOptions()
+		if err != nil {
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message": "skipping task whose test results cannot be fetched",
+				"task_id": t.Id,
+				"project": t.Project,
+			}))
+			continue
+		}
+		for _, opts := range taskOpts {
+			taskIdxs[opts] = i
+			if _, ok := taskOptsByService[opts.ResultsService]; !ok {
+				services = append(services, opts.ResultsService)
+			}
+			taskOptsByService[opts.ResultsService] = append(taskOptsByService[opts.ResultsService], opts)
+		}
+	}
+
+	var results []taskTestResult
+	addResults := func(batch []testresult.TaskOptions) error {
+		taskResults, err := testresult.GetMergedTaskTestResults(ctx, env, batch, nil)
+		if err != nil {
+			return err
+		}
+		for _, res := range taskResults.Results {
+			idx, ok := taskIdxs[testresult.TaskOptions{TaskID: res.TaskID, Execution: res.Execution, ResultsService: batch[0].ResultsService}]
+			if !ok {
+				continue
+			}
+			results = append(results, taskTestResult{TestResult: res, taskIdx: idx})
+		}
+
+		return nil
+	}
+	for _, service := range services {
+		taskOpts := taskOptsByService[service]
+		for start := 0; start < len(taskOpts); start += resultsBatchSize {
+			if err := ctx.Err(); err != nil {
+				return nil, errors.Wrap(err, "getting test results")
+			}
+
+			end := start + resultsBatchSize
+			if end > len(taskOpts) {
+				end = len(taskOpts)
+			}
+			if err := addResults(taskOpts[start:end]); err == nil {
+				continue
+			}
+
+			for _, opts := range taskOpts[start:end] {
+				grip.Warning(message.WrapError(addResults([]testresult.TaskOptions{opts}), message.Fields{
+					"message":   "skipping task whose test results cannot be fetched",
+					"task_id":   opts.TaskID,
+					"execution": opts.Execution,
+				}))
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].taskIdx < results[j].taskIdx
+	})
+
+	return results, nil
+}
@@ -0,0 +1,228 @@
+package flakytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFlipRate(t *testing.T) {
+	pass := evergreen.TestSucceededStatus
+	fail := evergreen.TestFailedStatus
+	skip := evergreen.TestSkippedStatus
+
+	for name, test := range map[string]struct {
+		statuses []string
+		runs     int
+		failed   int
+		flips    int
+		flipRate float64
+	}{
+		"NoRuns": {},
+		"SingleRun": {
+			statuses: []string{fail},
+			runs:     1,
+			failed:   1,
+		},
+		"AlwaysPasses": {
+			statuses: []string{pass, pass, pass, pass},
+			runs:     4,
+		},
+		"AlwaysFails": {
+			statuses: []string{fail, fail, fail},
+			runs:     3,
+			failed:   3,
+		},
+		"BrokeOnce": {
+			statuses: []string{pass, pass, pass, fail, fail},
+			runs:     5,
+			failed:   2,
+			flips:    1,
+			flipRate: 0.25,
+		},
+		"FlipsEveryRun": {
+			statuses: []string{pass, fail, pass, fail, pass},
+			runs:     5,
+			failed:   2,
+			flips:    4,
+			flipRate: 1,
+		},
+		"IgnoresSkippedRuns": {
+			statuses: []string{pass, skip, pass, evergreen.TestSilentlyFailedStatus, fail},
+			runs:     3,
+			failed:   1,
+			flips:    1,
+			flipRate: 0.5,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			runs, failed, flips, flipRate := CalculateFlipRate(test.statuses)
+			assert.Equal(t, test.runs, runs)
+			assert.Equal(t, test.failed, failed)
+			assert.Equal(t, test.flips, flips)
+			assert.Equal(t, test.flipRate, flipRate)
+		})
+	}
+}
+
+func TestCalculateProjectFlakiness(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, FlakinessCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	insertTasks := func(t *testing.T, statusesByTest map[string][]string, numTasks int) {
+		for i := 0; i < numTasks; i++ {
+			tsk := task.Task{
+				Id:                  fmt.Sprintf("t%d", i),
+				Project:             "project",
+				BuildVariant:        "bv",
+				DisplayName:         "task",
+				Requester:           evergreen.RepotrackerVersionRequester,
+				Status:              evergreen.TaskFailed,
+				RevisionOrderNumber: i,
+				FinishTime:          time.Now().Add(-time.Hour),
+				ResultsService:      testresult.TestResultsServiceLocal,
+			}
+			require.NoError(t, tsk.Insert())
+
+			for testName, statuses := range statusesByTest {
+				require.NoError(t, testresult.InsertLocal(ctx, env, testresult.TestResult{
+					TaskID:   tsk.Id,
+					TestName: testName,
+					Status:   statuses[i],
+				}))
+			}
+		}
+	}
+
+	for tName, tCase := range map[string]func(t *testing.T){
+		"ReturnsNewlyFlakyTests": func(t *testing.T) {
+			insertTasks(t, map[string][]string{
+				"flaky":  {"pass", "fail", "pass", "fail", "pass", "fail"},
+				"stable": {"pass", "pass", "pass", "pass", "pass", "pass"},
+				"broken": {"pass", "pass", "pass", "fail", "fail", "fail"},
+			}, 6)
+
+			newlyFlaky, err := CalculateProjectFlakiness(ctx, env, CalculateOptions{
+				ProjectID:         "project",
+				MinRuns:           5,
+				FlipRateThreshold: 0.5,
+			})
+			require.NoError(t, err)
+			require.Len(t, newlyFlaky, 1)
+			assert.Equal(t, "flaky", newlyFlaky[0].ID.TestName)
+			assert.Equal(t, "task", newlyFlaky[0].ID.TaskName)
+			assert.Equal(t, "bv", newlyFlaky[0].ID.BuildVariant)
+			assert.Equal(t, 5, newlyFlaky[0].NumFlips)
+			assert.Equal(t, "t5", newlyFlaky[0].LastTaskID)
+
+			all, err := FindTestFlakiness("project", false)
+			require.NoError(t, err)
+			assert.Len(t, all, 3)
+
+			flaky, err := FindTestFlakiness("project", true)
+			require.NoError(t, err)
+			require.Len(t, flaky, 1)
+			assert.Equal(t, "flaky", flaky[0].ID.TestName)
+
+			newlyFlaky, err = CalculateProjectFlakiness(ctx, env, CalculateOptions{
+				ProjectID:         "project",
+				MinRuns:           5,
+				FlipRateThreshold: 0.5,
+			})
+			require.NoError(t, err)
+			assert.Empty(t, newlyFlaky, "tests that were already flaky should not be returned again")
+		},
+		"RequiresMinimumRuns": func(t *testing.T) {
+			insertTasks(t, map[string][]string{
+				"flaky": {"pass", "fail", "pass"},
+			}, 3)
+
+			newlyFlaky, err := CalculateProjectFlakiness(ctx, env, CalculateOptions{ProjectID: "project"})
+			require.NoError(t, err)
+			assert.Empty(t, newlyFlaky)
+
+			all, err := FindTestFlakiness("project", false)
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+			assert.False(t, all[0].Flaky)
+			assert.Equal(t, 1.0, all[0].FlipRate)
+		},
+		"IgnoresPatchTasks": func(t *testing.T) {
+			tsk := task.Task{
+				Id:             "patch_task",
+				Project:        "project",
+				BuildVariant:   "bv",
+				DisplayName:    "task",
+				Requester:      evergreen.PatchVersionRequester,
+				Status:         evergreen.TaskFailed,
+				FinishTime:     time.Now().Add(-time.Hour),
+				ResultsService: testresult.TestResultsServiceLocal,
+			}
+			require.NoError(t, tsk.Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env, testresult.TestResult{
+				TaskID:   tsk.Id,
+				TestName: "test",
+				Status:   evergreen.TestFailedStatus,
+			}))
+
+			_, err := CalculateProjectFlakiness(ctx, env, CalculateOptions{ProjectID: "project"})
+			require.NoError(t, err)
+
+			all, err := FindTestFlakiness("project", false)
+			require.NoError(t, err)
+			assert.Empty(t, all)
+		},
+		"SkipsTasksWhoseResultsCannotBeFetched": func(t *testing.T) {
+			insertTasks(t, map[string][]string{
+				"flaky": {"pass", "fail", "pass"},
+			}, 3)
+			tsk := task.Task{
+				Id:                  "unknown_service_task",
+				Project:             "project",
+				BuildVariant:        "bv",
+				DisplayName:         "task",
+				Requester:           evergreen.RepotrackerVersionRequester,
+				Status:              evergreen.TaskFailed,
+				RevisionOrderNumber: 3,
+				FinishTime:          time.Now().Add(-time.Hour),
+				ResultsService:      "DNE",
+			}
+			require.NoError(t, tsk.Insert())
+
+			_, err := CalculateProjectFlakiness(ctx, env, CalculateOptions{ProjectID: "project"})
+			require.NoError(t, err)
+
+			all, err := FindTestFlakiness("project", false)
+			require.NoError(t, err)
+			require.Len(t, all, 1)
+			assert.Equal(t, 3, all[0].NumRuns)
+			assert.Equal(t, "t2", all[0].LastTaskID)
+		},
+		"FailsWithoutProject": func(t *testing.T) {
+			_, err := CalculateProjectFlakiness(ctx, env, CalculateOptions{})
+			assert.Error(t, err)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(task.Collection, FlakinessCollection))
+			require.NoError(t, testresult.ClearLocal(ctx, env))
+
+			tCase(t)
+		})
+	}
+}
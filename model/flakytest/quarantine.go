@@ -0,0 +1,129 @@
+package flakytest
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// QuarantinedTest is a test whose failures are ignored when determining the
+// status of the project's tasks that run it.
+type QuarantinedTest struct {
+	ID        string `bson:"_id" json:"id"`
+	ProjectID string `bson:"project_id" json:"project_id"`
+	TestName  string `bson:"test_name" json:"test_name"`
+	// TaskName and BuildVariant optionally limit the quarantine to the test
+	// when it runs in the given task or build variant. If empty, the test is
+	// quarantined in every task or build variant.
+	TaskName      string    `bson:"task_name,omitempty" json:"task_name,omitempty"`
+	BuildVariant  string    `bson:"build_variant,omitempty" json:"build_variant,omitempty"`
+	Reason        string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	QuarantinedBy string    `bson:"quarantined_by" json:"quarantined_by"`
+	QuarantinedAt time.Time `bson:"quarantined_at" json:"quarantined_at"`
+}
+
+// Matches returns whether the quarantine applies to the test result when it
+// runs in the given task and build variant.
+func (q *QuarantinedTest) Matches(taskName, buildVariant string, res testresult.TestResult) bool {
+	if q.TaskName != "" && q.TaskName != taskName {
+		return false
+	}
+	if q.BuildVariant != "" && q.BuildVariant != buildVariant {
+		return false
+	}
+
+	return q.TestName == res.TestName || q.TestName == res.GetDisplayTestName()
+}
+
+// Insert validates the quarantined test and adds it to its project's
+// quarantine list.
+func (q *QuarantinedTest) Insert() error {
+	if q.ProjectID == "" {
+		return errors.New("project ID must be specified")
+	}
+	if q.TestName == "" {
+		return errors.New("test name must be specified")
+	}
+
+	existing, err := FindOneQuarantinedTest(db.Query(bson.M{
+		QuarantineProjectIDKey:    q.ProjectID,
+		QuarantineTestNameKey:     q.TestName,
+		QuarantineTaskNameKey:     optionalFieldValue(q.TaskName),
+		QuarantineBuildVariantKey: optionalFieldValue(q.BuildVariant),
+	}))
+	if err != nil {
+		return errors.Wrap(err, "checking for existing quarantined test")
+	}
+	if existing != nil {
+		return errors.Errorf("test '%s' is already quarantined", q.TestName)
+	}
+
+	if q.ID == "" {
+		q.ID = mgobson.NewObjectId().Hex()
+	}
+	if q.QuarantinedAt.IsZero() {
+		q.QuarantinedAt = time.Now()
+	}
+
+	return errors.Wrapf(db.Insert(QuarantineCollection, q), "inserting quarantined test '%s'", q.TestName)
+}
+
+// optionalFieldValue returns the query value for an optional field, which is
+// omitted from the document when it is empty.
+func optionalFieldValue(value string) interface{} {
+	if value == "" {
+		return bson.M{"$exists": false}
+	}
+	return value
+}
+
+// QuarantineList is a project's quarantined tests.
+type QuarantineList []QuarantinedTest
+
+// IsQuarantined returns whether the test result is quarantined when it runs
+// in the given task and build variant.
+func (l QuarantineList) IsQuarantined(taskName, buildVariant string, res testresult.TestResult) bool {
+	for _, q := range l {
+		if q.Matches(taskName, buildVariant, res) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FindQuarantinedTestFailures returns the names of the task's failed tests
+// that are quarantined in its project, and whether the task has failed tests
+// and all of them are quarantined.
+func FindQuarantinedTestFailures(ctx context.Context, env evergreen.Environment, t *task.Task) (testNames []string, onlyQuarantined bool, err error) {
+	quarantined, err := FindQuarantinedTests(t.Project)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(quarantined) == 0 {
+		return nil, false, nil
+	}
+
+	results, err := t.GetTestResults(ctx, env, &testresult.FilterOptions{Statuses: []string{evergreen.TestFailedStatus}})
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "getting failed test results for task '%s'", t.Id)
+	}
+
+	onlyQuarantined = len(results.Results) > 0
+	for _, res := range results.Results {
+		if !quarantined.IsQuarantined(t.DisplayName, t.BuildVariant, res) {
+			onlyQuarantined = false
+			continue
+		}
+		testNames = append(testNames, res.TestName)
+	}
+
+	return testNames, onlyQuarantined, nil
+}
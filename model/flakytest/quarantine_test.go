@@ -0,0 +1,148 @@
+package flakytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantineListIsQuarantined(t *testing.T) {
+	list := QuarantineList{
+		{TestName: "TestEverywhere"},
+		{TestName: "TestInTask", TaskName: "task"},
+		{TestName: "TestInVariantTask", TaskName: "task", BuildVariant: "bv"},
+	}
+
+	assert.True(t, list.IsQuarantined("other_task", "other_bv", testresult.TestResult{TestName: "TestEverywhere"}))
+	assert.True(t, list.IsQuarantined("task", "other_bv", testresult.TestResult{TestName: "TestInTask"}))
+	assert.False(t, list.IsQuarantined("other_task", "bv", testresult.TestResult{TestName: "TestInTask"}))
+	assert.True(t, list.IsQuarantined("task", "bv", testresult.TestResult{TestName: "TestInVariantTask"}))
+	assert.False(t, list.IsQuarantined("task", "other_bv", testresult.TestResult{TestName: "TestInVariantTask"}))
+	assert.False(t, list.IsQuarantined("task", "bv", testresult.TestResult{TestName: "TestNotQuarantined"}))
+	assert.True(t, list.IsQuarantined("task", "bv", testresult.TestResult{TestName: "abc123", DisplayTestName: "TestEverywhere"}), "display test name should match")
+}
+
+func TestQuarantinedTestInsertAndRemove(t *testing.T) {
+	require.NoError(t, db.Clear(QuarantineCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(QuarantineCollection))
+	}()
+
+	assert.Error(t, (&QuarantinedTest{TestName: "test"}).Insert(), "project should be required")
+	assert.Error(t, (&QuarantinedTest{ProjectID: "project"}).Insert(), "test name should be required")
+
+	q := QuarantinedTest{
+		ProjectID:     "project",
+		TestName:      "test",
+		Reason:        "flaky",
+		QuarantinedBy: "me",
+	}
+	require.NoError(t, q.Insert())
+	assert.NotEmpty(t, q.ID)
+	assert.False(t, q.QuarantinedAt.IsZero())
+
+	duplicate := QuarantinedTest{ProjectID: "project", TestName: "test"}
+	assert.Error(t, duplicate.Insert())
+
+	inTask := QuarantinedTest{ProjectID: "project", TestName: "test", TaskName: "task"}
+	require.NoError(t, inTask.Insert())
+	otherProject := QuarantinedTest{ProjectID: "other_project", TestName: "test"}
+	require.NoError(t, otherProject.Insert())
+
+	list, err := FindQuarantinedTests("project")
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	found, err := FindQuarantinedTestByID("other_project", q.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found, "should not find a quarantined test in a different project")
+
+	require.NoError(t, RemoveQuarantinedTest("project", q.ID))
+	list, err = FindQuarantinedTests("project")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, inTask.ID, list[0].ID)
+}
+
+func TestFindQuarantinedTestFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, QuarantineCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	for tName, tCase := range map[string]func(t *testing.T, tsk *task.Task){
+		"OnlyQuarantinedWhenAllFailuresAreQuarantined": func(t *testing.T, tsk *task.Task) {
+			require.NoError(t, (&QuarantinedTest{ProjectID: "project", TestName: "flaky"}).Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env,
+				testresult.TestResult{TaskID: tsk.Id, TestName: "flaky", Status: evergreen.TestFailedStatus},
+				testresult.TestResult{TaskID: tsk.Id, TestName: "stable", Status: evergreen.TestSucceededStatus},
+			))
+
+			testNames, onlyQuarantined, err := FindQuarantinedTestFailures(ctx, env, tsk)
+			require.NoError(t, err)
+			assert.True(t, onlyQuarantined)
+			assert.Equal(t, []string{"flaky"}, testNames)
+		},
+		"NotOnlyQuarantinedWhenUnquarantinedTestFailed": func(t *testing.T, tsk *task.Task) {
+			require.NoError(t, (&QuarantinedTest{ProjectID: "project", TestName: "flaky"}).Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env,
+				testresult.TestResult{TaskID: tsk.Id, TestName: "flaky", Status: evergreen.TestFailedStatus},
+				testresult.TestResult{TaskID: tsk.Id, TestName: "broken", Status: evergreen.TestFailedStatus},
+			))
+
+			testNames, onlyQuarantined, err := FindQuarantinedTestFailures(ctx, env, tsk)
+			require.NoError(t, err)
+			assert.False(t, onlyQuarantined)
+			assert.Equal(t, []string{"flaky"}, testNames)
+		},
+		"NoneWithoutFailedTests": func(t *testing.T, tsk *task.Task) {
+			require.NoError(t, (&QuarantinedTest{ProjectID: "project", TestName: "flaky"}).Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env,
+				testresult.TestResult{TaskID: tsk.Id, TestName: "flaky", Status: evergreen.TestSucceededStatus},
+			))
+
+			testNames, onlyQuarantined, err := FindQuarantinedTestFailures(ctx, env, tsk)
+			require.NoError(t, err)
+			assert.False(t, onlyQuarantined)
+			assert.Empty(t, testNames)
+		},
+		"NoneWhenQuarantinedInDifferentTask": func(t *testing.T, tsk *task.Task) {
+			require.NoError(t, (&QuarantinedTest{ProjectID: "project", TestName: "flaky", TaskName: "other_task"}).Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env,
+				testresult.TestResult{TaskID: tsk.Id, TestName: "flaky", Status: evergreen.TestFailedStatus},
+			))
+
+			testNames, onlyQuarantined, err := FindQuarantinedTestFailures(ctx, env, tsk)
+			require.NoError(t, err)
+			assert.False(t, onlyQuarantined)
+			assert.Empty(t, testNames)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(task.Collection, QuarantineCollection))
+			require.NoError(t, testresult.ClearLocal(ctx, env))
+
+			tsk := &task.Task{
+				Id:             "task_id",
+				Project:        "project",
+				BuildVariant:   "bv",
+				DisplayName:    "task",
+				ResultsService: testresult.TestResultsServiceLocal,
+			}
+			require.NoError(t, tsk.Insert())
+
+			tCase(t, tsk)
+		})
+	}
+}
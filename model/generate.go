@@ -2,6 +2,7 @@ package model
 
 import (
 	"context"
+	"sort"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model/build"
@@ -348,6 +349,133 @@ func (g *GeneratedProject) CheckForCycles(ctx context.Context, v *Version, p *Pr
 	return nil
 }
 
+// GeneratedProjectPreview describes the builds, tasks, and dependencies that a
+// generated project would add to a version.
+type GeneratedProjectPreview struct {
+	// NewBuildVariants are the variants that don't have a build in the version
+	// yet and would get a new build.
+	NewBuildVariants []string
+	// NewTasks are the execution tasks that would be created.
+	NewTasks []task.TaskNode
+	// NewDisplayTasks are the display tasks that would be created.
+	NewDisplayTasks []task.TaskNode
+	// NewDependencies are the dependencies that would be added, either from
+	// the new tasks or onto the new tasks.
+	NewDependencies []task.DependencyEdge
+}
+
+// Preview returns the builds, tasks, and dependencies that the generated
+// project would add to the version without saving anything. The project must
+// already include the generated project, as returned by NewVersion.
+func (g *GeneratedProject) Preview(ctx context.Context, v *Version, p *Project, projectRef *ProjectRef) (*GeneratedProjectPreview, error) {
+	ctx, span := tracer.Start(ctx, "preview-generated-project")
+	defer span.End()
+
+	existingBuilds, err := build.Find(build.ByVersion(v.Id))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding builds for version")
+	}
+	buildSet := map[string]bool{}
+	for _, b := range existingBuilds {
+		buildSet[b.BuildVariant] = true
+	}
+	existingTasks, err := task.FindWithFields(task.ByVersion(v.Id), task.BuildVariantKey, task.DisplayNameKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks for version")
+	}
+	taskSet := map[TVPair]bool{}
+	for _, t := range existingTasks {
+		taskSet[TVPair{Variant: t.BuildVariant, TaskName: t.DisplayName}] = true
+	}
+
+	newTVPairs, _ := g.GetNewTasksAndActivationInfo(ctx, v, p)
+	taskIDs, err := getTaskIdConfig(TaskCreationInfo{
+		Project:    p,
+		ProjectRef: projectRef,
+		Version:    v,
+		Pairs:      *newTVPairs,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "getting task ids")
+	}
+
+	preview := &GeneratedProjectPreview{}
+	newBuildVariants := map[string]bool{}
+	newTaskIDs := map[string]bool{}
+	addNewTask := func(pair TVPair, ids TaskIdTable) *task.TaskNode {
+		if taskSet[pair] {
+			return nil
+		}
+		taskSet[pair] = true
+		if !buildSet[pair.Variant] && !newBuildVariants[pair.Variant] {
+			newBuildVariants[pair.Variant] = true
+			preview.NewBuildVariants = append(preview.NewBuildVariants, pair.Variant)
+		}
+		return &task.TaskNode{
+			ID:      ids.GetId(pair.Variant, pair.TaskName),
+			Name:    pair.TaskName,
+			Variant: pair.Variant,
+		}
+	}
+	for _, pair := range newTVPairs.ExecTasks {
+		if node := addNewTask(pair, taskIDs.ExecutionTasks); node != nil {
+			newTaskIDs[node.ID] = true
+			preview.NewTasks = append(preview.NewTasks, *node)
+		}
+	}
+	for _, pair := range newTVPairs.DisplayTasks {
+		if node := addNewTask(pair, taskIDs.DisplayTasks); node != nil {
+			preview.NewDisplayTasks = append(preview.NewDisplayTasks, *node)
+		}
+	}
+
+	graph, err := task.VersionDependencyGraph(v.Id, false)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating dependency graph for version '%s'", v.Id)
+	}
+	type edgeKey struct {
+		from string
+		to   string
+	}
+	existingEdges := map[edgeKey]bool{}
+	for _, edge := range graph.Edges() {
+		existingEdges[edgeKey{from: edge.From.ID, to: edge.To.ID}] = true
+	}
+	graph, err = g.simulateNewTasks(ctx, graph, v, p, projectRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "simulating new tasks")
+	}
+	for _, edge := range graph.Edges() {
+		if existingEdges[edgeKey{from: edge.From.ID, to: edge.To.ID}] {
+			continue
+		}
+		if newTaskIDs[edge.From.ID] || newTaskIDs[edge.To.ID] {
+			preview.NewDependencies = append(preview.NewDependencies, edge)
+		}
+	}
+
+	sort.Strings(preview.NewBuildVariants)
+	sortTaskNodes(preview.NewTasks)
+	sortTaskNodes(preview.NewDisplayTasks)
+	sort.Slice(preview.NewDependencies, func(i, j int) bool {
+		if preview.NewDependencies[i].From.ID != preview.NewDependencies[j].From.ID {
+			return preview.NewDependencies[i].From.ID < preview.NewDependencies[j].From.ID
+		}
+		return preview.NewDependencies[i].To.ID < preview.NewDependencies[j].To.ID
+	})
+
+	return preview, nil
+}
+
+func sortTaskNodes(nodes []task.TaskNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Variant != nodes[j].Variant {
+			return nodes[i].Variant < nodes[j].Variant
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+}
+
 // simulateNewTasks adds the tasks we're planning to add to the version to the graph and
 // adds simulated edges from each task that depends on the generator to each of the generated tasks.
 func (g *GeneratedProject) simulateNewTasks(ctx context.Context, graph task.DependencyGraph, v *Version, p *Project, projectRef *ProjectRef) (task.DependencyGraph, error) {
@@ -709,7 +837,7 @@ func isNonZeroBV(bv parserBV) bool {
 		bv.Disable != nil || len(bv.Tags) > 0 ||
 		bv.BatchTime != nil || bv.Patchable != nil || bv.PatchOnly != nil ||
 		bv.AllowForGitTag != nil || bv.GitTagOnly != nil || len(bv.AllowedRequesters) > 0 ||
-		bv.Stepback != nil || len(bv.RunOn) > 0 {
+		bv.Stepback != nil || bv.Retry != nil || len(bv.RunOn) > 0 {
 		return true
 	}
 	return false
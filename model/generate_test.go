@@ -10,6 +10,7 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/mock"
 	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/utility"
@@ -1773,6 +1774,98 @@ func TestSimulateNewDependencyGraph(t *testing.T) {
 	})
 }
 
+func TestGeneratedProjectPreview(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, build.Collection))
+	}()
+	require.NoError(t, db.ClearCollections(task.Collection, build.Collection))
+
+	v := &Version{Id: "v0", Requester: evergreen.RepotrackerVersionRequester, Revision: "abcdef", BuildIds: []string{"b0"}}
+	b0 := build.Build{Id: "b0", Version: v.Id, BuildVariant: "bv0"}
+	require.NoError(t, b0.Insert())
+	generatorTask := task.Task{Id: "generator", Version: v.Id, BuildId: b0.Id, BuildVariant: "bv0", DisplayName: "generator"}
+	require.NoError(t, generatorTask.Insert())
+	dependentTask := task.Task{
+		Id:           "dependent",
+		Version:      v.Id,
+		BuildId:      b0.Id,
+		BuildVariant: "bv0",
+		DisplayName:  "dependent",
+		DependsOn:    []task.Dependency{{TaskId: generatorTask.Id, Status: evergreen.TaskSucceeded}},
+	}
+	require.NoError(t, dependentTask.Insert())
+
+	project := &Project{
+		BuildVariants: []BuildVariant{
+			{
+				Name: "bv0",
+				Tasks: []BuildVariantTaskUnit{
+					{Name: "generator", Variant: "bv0"},
+					{Name: "dependent", Variant: "bv0", DependsOn: []TaskUnitDependency{{Name: "generator", Variant: "bv0"}}},
+					{Name: "generated", Variant: "bv0"},
+				},
+			},
+			{
+				Name: "bv1",
+				Tasks: []BuildVariantTaskUnit{
+					{Name: "generated", Variant: "bv1", DependsOn: []TaskUnitDependency{{Name: "generated", Variant: "bv0"}}},
+				},
+				DisplayTasks: []patch.DisplayTask{{Name: "display", ExecTasks: []string{"generated"}}},
+			},
+		},
+		Tasks: []ProjectTask{
+			{Name: "generator"},
+			{Name: "dependent"},
+			{Name: "generated"},
+		},
+	}
+	g := GeneratedProject{
+		Task: &generatorTask,
+		BuildVariants: []parserBV{
+			{
+				Name:  "bv0",
+				Tasks: []parserBVTaskUnit{{Name: "generated"}},
+			},
+			{
+				Name:         "bv1",
+				Tasks:        []parserBVTaskUnit{{Name: "generated"}},
+				DisplayTasks: []displayTask{{Name: "display", ExecutionTasks: []string{"generated"}}},
+			},
+		},
+	}
+
+	preview, err := g.Preview(ctx, v, project, &ProjectRef{Identifier: "mci"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bv1"}, preview.NewBuildVariants)
+	require.Len(t, preview.NewTasks, 2)
+	assert.Equal(t, "bv0", preview.NewTasks[0].Variant)
+	assert.Equal(t, "generated", preview.NewTasks[0].Name)
+	assert.Equal(t, "bv1", preview.NewTasks[1].Variant)
+	assert.Equal(t, "generated", preview.NewTasks[1].Name)
+	require.Len(t, preview.NewDisplayTasks, 1)
+	assert.Equal(t, "bv1", preview.NewDisplayTasks[0].Variant)
+	assert.Equal(t, "display", preview.NewDisplayTasks[0].Name)
+
+	deps := map[string][]string{}
+	for _, dep := range preview.NewDependencies {
+		deps[dep.From.ID] = append(deps[dep.From.ID], dep.To.ID)
+	}
+	assert.Len(t, preview.NewDependencies, 3)
+	assert.ElementsMatch(t, []string{preview.NewTasks[0].ID, preview.NewTasks[1].ID}, deps[dependentTask.Id], "tasks depending on the generator should depend on the generated tasks")
+	assert.Equal(t, []string{preview.NewTasks[0].ID}, deps[preview.NewTasks[1].ID])
+
+	dbTasks, err := task.Find(task.ByVersion(v.Id))
+	require.NoError(t, err)
+	assert.Len(t, dbTasks, 2, "preview should not create any tasks")
+	dbBuilds, err := build.Find(build.ByVersion(v.Id))
+	require.NoError(t, err)
+	assert.Len(t, dbBuilds, 1, "preview should not create any builds")
+}
+
 func TestFilterInactiveTasks(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
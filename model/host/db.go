@@ -94,6 +94,7 @@ var (
 	HomeVolumeIDKey                    = bsonutil.MustHaveTag(Host{}, "HomeVolumeID")
 	PortBindingsKey                    = bsonutil.MustHaveTag(Host{}, "PortBindings")
 	IsVirtualWorkstationKey            = bsonutil.MustHaveTag(Host{}, "IsVirtualWorkstation")
+	InWarmPoolKey                      = bsonutil.MustHaveTag(Host{}, "InWarmPool")
 	SpawnOptionsTaskIDKey              = bsonutil.MustHaveTag(SpawnOptions{}, "TaskID")
 	SpawnOptionsTaskExecutionNumberKey = bsonutil.MustHaveTag(SpawnOptions{}, "TaskExecutionNumber")
 	SpawnOptionsBuildIDKey             = bsonutil.MustHaveTag(SpawnOptions{}, "BuildID")
@@ -172,6 +173,8 @@ func IdleEphemeralGroupedByDistroID(ctx context.Context, env evergreen.Environme
 				StartedByKey:     evergreen.User,
 				ProviderKey:      bson.M{"$in": evergreen.ProviderSpawnable},
 				HasContainersKey: bson.M{"$ne": true},
+				// Hosts in the warm pool are idle by design.
+				InWarmPoolKey: bson.M{"$ne": true},
 				"$or": []bson.M{
 					{
 						StatusKey: evergreen.HostRunning,
@@ -243,6 +246,7 @@ func idleHostsQuery(distroID string) bson.M {
 		RunningTaskKey:   bson.M{"$exists": false},
 		HasContainersKey: bson.M{"$ne": true},
 		StatusKey:        evergreen.HostRunning,
+		InWarmPoolKey:    bson.M{"$ne": true},
 	}
 	if distroID != "" {
 		query[bsonutil.GetDottedKeyName(DistroKey, distro.IdKey)] = distroID
@@ -299,6 +303,51 @@ func AllActiveHosts(ctx context.Context, distroID string) (HostGroup, error) {
 	return activeHosts, nil
 }
 
+// warmPoolReadyHostsQuery returns a query for the hosts in the distro's warm
+// pool that are ready to run tasks, which are the running hosts whose agent
+// has already contacted the app server.
+func warmPoolReadyHostsQuery(distroID string) bson.M {
+	return bson.M{
+		bsonutil.GetDottedKeyName(DistroKey, distro.IdKey): distroID,
+		StartedByKey:      evergreen.User,
+		InWarmPoolKey:     true,
+		StatusKey:         evergreen.HostRunning,
+		AgentStartTimeKey: bson.M{"$gt": utility.ZeroTime},
+		RunningTaskKey:    bson.M{"$exists": false},
+	}
+}
+
+// ClaimWarmPoolHosts takes up to n ready hosts out of the distro's warm pool,
+// oldest first, so that they can run tasks. It returns the IDs of the hosts
+// that were taken out of the pool.
+func ClaimWarmPoolHosts(ctx context.Context, distroID string, n int) ([]string, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	hosts, err := Find(ctx, warmPoolReadyHostsQuery(distroID), options.Find().SetSort(bson.M{CreateTimeKey: 1}).SetLimit(int64(n)))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding ready warm pool hosts")
+	}
+
+	var claimed []string
+	for _, h := range hosts {
+		q := warmPoolReadyHostsQuery(distroID)
+		q[IdKey] = h.Id
+		err = UpdateOne(ctx, q, bson.M{"$unset": bson.M{InWarmPoolKey: 1}})
+		if adb.ResultsNotFound(err) {
+			// The host is no longer ready, so it can't be claimed.
+			continue
+		}
+		if err != nil {
+			return claimed, errors.Wrapf(err, "taking host '%s' out of the warm pool", h.Id)
+		}
+		claimed = append(claimed, h.Id)
+	}
+
+	return claimed, nil
+}
+
 // AllHostsSpawnedByTasksToTerminate finds all hosts spawned by tasks that should be terminated.
 func AllHostsSpawnedByTasksToTerminate(ctx context.Context) ([]Host, error) {
 	catcher := grip.NewBasicCatcher()
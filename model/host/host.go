@@ -155,6 +155,11 @@ type Host struct {
 	// HomeVolumeSize is the size of the home volume in GB
 	HomeVolumeSize int    `bson:"home_volume_size" json:"home_volume_size"`
 	HomeVolumeID   string `bson:"home_volume_id" json:"home_volume_id"`
+
+	// InWarmPool is set if the host is in its distro's warm pool. Hosts in the
+	// warm pool are provisioned and run the agent but don't run tasks until
+	// the host allocator takes them out of the pool.
+	InWarmPool bool `bson:"in_warm_pool,omitempty" json:"in_warm_pool,omitempty"`
 }
 
 type Tag struct {
@@ -2322,6 +2327,7 @@ type HostGroupStats struct {
 	Idle           int `bson:"idle" json:"idle" yaml:"idle"`
 	Active         int `bson:"active" json:"active" yaml:"active"`
 	Provisioning   int `bson:"provisioning" json:"provisioning" yaml:"provisioning"`
+	WarmPool       int `bson:"warm_pool" json:"warm_pool" yaml:"warm_pool"`
 	Total          int `bson:"total" json:"total" yaml:"total"`
 }
 
@@ -2330,6 +2336,9 @@ func (hosts HostGroup) Stats() HostGroupStats {
 
 	for _, h := range hosts {
 		out.Total++
+		if h.InWarmPool {
+			out.WarmPool++
+		}
 
 		if h.Status == evergreen.HostQuarantined {
 			out.Quarantined++
@@ -2360,6 +2369,22 @@ func (hosts HostGroup) Uphosts() HostGroup {
 	return out
 }
 
+// PartitionWarmPool splits the hosts into the hosts that count as task
+// capacity and the hosts that are in the warm pool.
+func (hosts HostGroup) PartitionWarmPool() (taskHosts HostGroup, warmPoolHosts HostGroup) {
+	taskHosts = HostGroup{}
+	warmPoolHosts = HostGroup{}
+
+	for _, h := range hosts {
+		if h.InWarmPool {
+			warmPoolHosts = append(warmPoolHosts, h)
+		} else {
+			taskHosts = append(taskHosts, h)
+		}
+	}
+	return taskHosts, warmPoolHosts
+}
+
 // getNumContainersOnParents returns a slice of running parents and their respective
 // number of current containers currently running in order of longest expected
 // finish time
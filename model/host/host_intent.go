@@ -34,6 +34,7 @@ type CreateOptions struct {
 	IsCluster             bool
 	HomeVolumeSize        int
 	HomeVolumeID          string
+	InWarmPool            bool
 }
 
 // NewIntent creates an intent host using the given host settings. An intent host is a host that
@@ -73,6 +74,7 @@ func NewIntent(options CreateOptions) *Host {
 		NoExpiration:          options.NoExpiration,
 		ExpirationTime:        options.ExpirationTime,
 		ProvisionOptions:      options.ProvisionOptions,
+		InWarmPool:            options.InWarmPool,
 	}
 
 	return intentHost
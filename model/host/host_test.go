@@ -5961,3 +5961,116 @@ func TestClearDockerStdinData(t *testing.T) {
 	require.NotZero(t, dbHost)
 	assert.Empty(t, dbHost.DockerOptions.StdinData)
 }
+
+func TestClaimWarmPoolHosts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(Collection))
+	}()
+
+	d := distro.Distro{Id: "distro"}
+	now := time.Now()
+	hosts := []Host{
+		{
+			Id:             "oldest_ready",
+			Distro:         d,
+			Status:         evergreen.HostRunning,
+			StartedBy:      evergreen.User,
+			InWarmPool:     true,
+			AgentStartTime: now,
+			CreationTime:   now.Add(-time.Hour),
+		},
+		{
+			Id:             "newest_ready",
+			Distro:         d,
+			Status:         evergreen.HostRunning,
+			StartedBy:      evergreen.User,
+			InWarmPool:     true,
+			AgentStartTime: now,
+			CreationTime:   now.Add(-time.Minute),
+		},
+		{
+			Id:           "provisioning",
+			Distro:       d,
+			Status:       evergreen.HostProvisioning,
+			StartedBy:    evergreen.User,
+			InWarmPool:   true,
+			CreationTime: now.Add(-2 * time.Hour),
+		},
+		{
+			Id:           "no_agent",
+			Distro:       d,
+			Status:       evergreen.HostRunning,
+			StartedBy:    evergreen.User,
+			InWarmPool:   true,
+			CreationTime: now.Add(-2 * time.Hour),
+		},
+		{
+			Id:             "not_in_pool",
+			Distro:         d,
+			Status:         evergreen.HostRunning,
+			StartedBy:      evergreen.User,
+			AgentStartTime: now,
+			CreationTime:   now.Add(-2 * time.Hour),
+		},
+		{
+			Id:             "other_distro",
+			Distro:         distro.Distro{Id: "other_distro"},
+			Status:         evergreen.HostRunning,
+			StartedBy:      evergreen.User,
+			InWarmPool:     true,
+			AgentStartTime: now,
+			CreationTime:   now.Add(-2 * time.Hour),
+		},
+	}
+	for _, h := range hosts {
+		require.NoError(t, h.Insert(ctx))
+	}
+
+	claimed, err := ClaimWarmPoolHosts(ctx, d.Id, 0)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	claimed, err = ClaimWarmPoolHosts(ctx, d.Id, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest_ready"}, claimed)
+
+	claimed, err = ClaimWarmPoolHosts(ctx, d.Id, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"newest_ready"}, claimed, "should only claim hosts that are ready to run tasks")
+
+	for _, id := range []string{"oldest_ready", "newest_ready"} {
+		dbHost, err := FindOneId(ctx, id)
+		require.NoError(t, err)
+		require.NotZero(t, dbHost)
+		assert.False(t, dbHost.InWarmPool)
+	}
+	for _, id := range []string{"provisioning", "no_agent", "other_distro"} {
+		dbHost, err := FindOneId(ctx, id)
+		require.NoError(t, err)
+		require.NotZero(t, dbHost)
+		assert.True(t, dbHost.InWarmPool)
+	}
+}
+
+func TestHostGroupPartitionWarmPool(t *testing.T) {
+	hosts := HostGroup{
+		{Id: "h1", Status: evergreen.HostRunning},
+		{Id: "h2", Status: evergreen.HostRunning, InWarmPool: true},
+		{Id: "h3", Status: evergreen.HostProvisioning, InWarmPool: true},
+	}
+
+	taskHosts, warmPoolHosts := hosts.PartitionWarmPool()
+	require.Len(t, taskHosts, 1)
+	assert.Equal(t, "h1", taskHosts[0].Id)
+	require.Len(t, warmPoolHosts, 2)
+	assert.Equal(t, "h2", warmPoolHosts[0].Id)
+	assert.Equal(t, "h3", warmPoolHosts[1].Id)
+
+	stats := hosts.Stats()
+	assert.Equal(t, 2, stats.WarmPool)
+	assert.Equal(t, 3, stats.Total)
+}
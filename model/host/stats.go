@@ -23,6 +23,11 @@ type StatsByDistro struct {
 	NumTasks int `bson:"num_tasks_running" json:"num_tasks_running"`
 	// MaxHosts reports the pool size of the distro.
 	MaxHosts int `bson:"max_hosts" json:"max_hosts"`
+	// NumWarmPool is the number of hosts in the above group that are in the
+	// distro's warm pool.
+	NumWarmPool int `bson:"num_warm_pool" json:"num_warm_pool"`
+	// WarmPoolSize reports the warm pool size of the distro.
+	WarmPoolSize int `bson:"warm_pool_size" json:"warm_pool_size"`
 }
 
 func (d *StatsByDistro) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(d) }
@@ -66,6 +71,16 @@ func (d DistroStats) TasksMap() map[string]int {
 	return out
 }
 
+func (d DistroStats) WarmPoolMap() map[string]int {
+	out := map[string]int{}
+
+	for _, s := range d {
+		out[s.Distro] += s.NumWarmPool
+	}
+
+	return out
+}
+
 func (d DistroStats) MaxHostsExceeded() map[string]int {
 	out := map[string]int{}
 
@@ -133,6 +148,12 @@ func statsByDistroPipeline() []bson.M {
 				"tasks": bson.M{
 					"$addToSet": "$" + RunningTaskKey,
 				},
+				"num_warm_pool": bson.M{
+					"$sum": bson.M{"$cond": []interface{}{bson.M{"$eq": []interface{}{"$" + InWarmPoolKey, true}}, 1, 0}},
+				},
+				"warm_pool_size": bson.M{
+					"$max": "$" + DistroKey + "." + bsonutil.GetDottedKeyName(distro.HostAllocatorSettingsKey, distro.HostAllocatorSettingsWarmPoolSizeKey),
+				},
 				"provider": bson.M{
 					// Grab any provider, since all hosts in a distro have the same provider
 					"$first": "$" + bsonutil.GetDottedKeyName(DistroKey, distro.ProviderKey),
@@ -145,6 +166,8 @@ func statsByDistroPipeline() []bson.M {
 				"status":            "$_id.status",
 				"max_hosts":         1,
 				"count":             1,
+				"num_warm_pool":     1,
+				"warm_pool_size":    1,
 				"num_tasks_running": bson.M{"$size": "$tasks"},
 				"_id":               0,
 				"provider":          1,
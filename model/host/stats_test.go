@@ -128,3 +128,38 @@ func TestHostStatsByDistro(t *testing.T) {
 	sort.Slice(result, func(i, j int) bool { return result[i].Distro < result[j].Distro })
 	assert.Equal(alt, result)
 }
+
+func TestHostStatsByDistroWarmPool(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(db.ClearCollections(Collection))
+	defer func() {
+		assert.NoError(db.ClearCollections(Collection))
+	}()
+
+	d := distro.Distro{
+		Id:                    "debian",
+		Provider:              evergreen.ProviderNameEc2Fleet,
+		HostAllocatorSettings: distro.HostAllocatorSettings{WarmPoolSize: 2},
+	}
+	assert.NoError(db.InsertMany(Collection,
+		Host{Id: "one", Status: evergreen.HostRunning, Distro: d, StartedBy: evergreen.User, RunningTask: "foo"},
+		Host{Id: "two", Status: evergreen.HostRunning, Distro: d, StartedBy: evergreen.User, InWarmPool: true},
+		Host{Id: "three", Status: evergreen.HostProvisioning, Distro: d, StartedBy: evergreen.User, InWarmPool: true},
+	))
+
+	stats, err := GetStatsByDistro()
+	assert.NoError(err)
+	assert.Len(stats, 2)
+	for _, s := range stats {
+		assert.Equal(2, s.WarmPoolSize)
+		switch s.Status {
+		case evergreen.HostRunning:
+			assert.Equal(2, s.Count)
+			assert.Equal(1, s.NumWarmPool)
+		case evergreen.HostProvisioning:
+			assert.Equal(1, s.Count)
+			assert.Equal(1, s.NumWarmPool)
+		}
+	}
+	assert.Equal(2, stats.WarmPoolMap()["debian"])
+}
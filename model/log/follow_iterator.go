@@ -0,0 +1,194 @@
+package log
+
+import (
+	"context"
+	"time"
+
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+)
+
+const defaultFollowPollInterval = time.Second
+
+// FollowOptions represents the arguments for following a log that is still
+// being written.
+type FollowOptions struct {
+	// Get returns an iterator over the lines of the log. The given start
+	// times, keyed by log name and represented as Unix timestamps in
+	// nanoseconds, are the timestamps of the last lines already returned
+	// from each log; lines of those logs before their start time may be
+	// omitted. Logs not in the map are returned in their entirety.
+	Get func(ctx context.Context, logStarts map[string]int64) (LogIterator, error)
+	// IsComplete returns whether the log is complete, meaning that no new
+	// lines will be appended to it.
+	IsComplete func(ctx context.Context) (bool, error)
+	// PollInterval is the time to wait between fetching new lines once
+	// the current lines are exhausted. Defaults to 1 second.
+	PollInterval time.Duration
+}
+
+func (o *FollowOptions) validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(o.Get == nil, "must specify a function to get the log")
+	catcher.NewWhen(o.IsComplete == nil, "must specify a function to check if the log is complete")
+	catcher.NewWhen(o.PollInterval < 0, "poll interval cannot be negative")
+
+	if o.PollInterval == 0 {
+		o.PollInterval = defaultFollowPollInterval
+	}
+
+	return catcher.Resolve()
+}
+
+type followIterator struct {
+	ctx       context.Context
+	opts      FollowOptions
+	current   LogIterator
+	lastFetch bool
+	positions map[string]*followPosition
+	skip      map[string]int
+	started   bool
+	item      LogLine
+	catcher   grip.Catcher
+	exhausted bool
+	closed    bool
+}
+
+// followPosition is the position of the last line returned from a log.
+type followPosition struct {
+	timestamp int64
+	// count is the number of lines returned with the timestamp.
+	count int
+}
+
+// NewFollowIterator returns a LogIterator that streams the lines of a log as
+// they are appended. The log is fetched again each time the current lines
+// are exhausted, until the log is complete or the context errors. Since the
+// lines of a log are appended in timestamp order, the position of the last
+// line returned is tracked separately for each log, so lines appended to one
+// log with a timestamp earlier than the last line returned from another log
+// are still streamed.
+func NewFollowIterator(ctx context.Context, opts FollowOptions) (LogIterator, error) {
+	if err := opts.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid follow options")
+	}
+
+	return &followIterator{
+		ctx:       ctx,
+		opts:      opts,
+		positions: map[string]*followPosition{},
+		skip:      map[string]int{},
+		catcher:   grip.NewBasicCatcher(),
+	}, nil
+}
+
+func (it *followIterator) Next() bool {
+	if it.closed || it.exhausted || it.catcher.HasErrors() {
+		return false
+	}
+
+	for {
+		if it.current == nil {
+			if err := it.fetch(); err != nil {
+				it.catcher.Add(err)
+				return false
+			}
+		}
+
+		if it.current.Next() {
+			line := it.current.Item()
+			pos, ok := it.positions[line.LogName]
+			if !ok {
+				pos = &followPosition{}
+				it.positions[line.LogName] = pos
+			}
+			if line.Timestamp < pos.timestamp {
+				continue
+			}
+			if line.Timestamp == pos.timestamp && it.skip[line.LogName] > 0 {
+				// Lines of this log with the last timestamp
+				// were already returned by the previous fetch.
+				it.skip[line.LogName]--
+				continue
+			}
+
+			if line.Timestamp == pos.timestamp {
+				pos.count++
+			} else {
+				pos.timestamp = line.Timestamp
+				pos.count = 1
+			}
+			it.item = line
+
+			return true
+		}
+
+		it.catcher.Wrap(it.current.Err(), "iterating log lines")
+		it.catcher.Wrap(it.current.Close(), "closing log iterator")
+		it.current = nil
+		if it.catcher.HasErrors() {
+			return false
+		}
+		if it.lastFetch {
+			it.exhausted = true
+			return false
+		}
+	}
+}
+
+// fetch waits for the poll interval, if this is not the first fetch, and then
+// gets the lines of each log appended since the last line returned from it.
+// Whether the log is complete is checked before fetching so that no lines
+// appended before completion are missed.
+func (it *followIterator) fetch() error {
+	if it.started {
+		timer := time.NewTimer(it.opts.PollInterval)
+		defer timer.Stop()
+
+		select {
+		case <-it.ctx.Done():
+			return it.ctx.Err()
+		case <-timer.C:
+		}
+	}
+	it.started = true
+
+	complete, err := it.opts.IsComplete(it.ctx)
+	if err != nil {
+		return errors.Wrap(err, "checking if log is complete")
+	}
+
+	logStarts := make(map[string]int64, len(it.positions))
+	for logName, pos := range it.positions {
+		logStarts[logName] = pos.timestamp
+		it.skip[logName] = pos.count
+	}
+	current, err := it.opts.Get(it.ctx, logStarts)
+	if err != nil {
+		return errors.Wrap(err, "getting log")
+	}
+
+	it.current = current
+	it.lastFetch = complete
+
+	return nil
+}
+
+func (it *followIterator) Exhausted() bool { return it.exhausted }
+
+func (it *followIterator) Err() error { return it.catcher.Resolve() }
+
+func (it *followIterator) Item() LogLine { return it.item }
+
+func (it *followIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+
+	if it.current == nil {
+		return nil
+	}
+
+	return it.current.Close()
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowIterator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logPrefix := "project/task/0/task_logs"
+	logName := logPrefix + "/task"
+	makeLogLines := func(logName string, ts int64, n int) []LogLine {
+		lines := make([]LogLine, n)
+		for i := range lines {
+			lines[i] = LogLine{
+				LogName:   logName,
+				Priority:  level.Info,
+				Timestamp: ts,
+				Data:      fmt.Sprintf("line %d-%d", ts, i),
+			}
+		}
+		return lines
+	}
+	makeLines := func(ts int64, n int) []LogLine {
+		return makeLogLines(logName, ts, n)
+	}
+
+	type followState struct {
+		mu       sync.Mutex
+		complete bool
+	}
+	newFollowOpts := func(svc LogService, state *followState) FollowOptions {
+		return FollowOptions{
+			Get: func(ctx context.Context, logStarts map[string]int64) (LogIterator, error) {
+				return svc.Get(ctx, GetOptions{LogNames: []string{logPrefix}, LogStarts: logStarts})
+			},
+			IsComplete: func(context.Context) (bool, error) {
+				state.mu.Lock()
+				defer state.mu.Unlock()
+				return state.complete, nil
+			},
+			PollInterval: 10 * time.Millisecond,
+		}
+	}
+
+	t.Run("StreamsAppendedLinesUntilComplete", func(t *testing.T) {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		svc := NewLogServiceV0(bucket)
+		state := &followState{}
+
+		// Lines that share a timestamp across chunks must be returned
+		// exactly once.
+		var expected []LogLine
+		expected = append(expected, makeLines(100, 2)...)
+		expected = append(expected, makeLines(200, 2)...)
+		expected = append(expected, makeLines(200, 1)...)
+		expected[4].Data = "line 200-2"
+		expected = append(expected, makeLines(300, 3)...)
+		require.NoError(t, svc.Append(ctx, logName, expected[:4]))
+
+		it, err := NewFollowIterator(ctx, newFollowOpts(svc, state))
+		require.NoError(t, err)
+
+		var out []LogLine
+		for len(out) < 4 && it.Next() {
+			out = append(out, it.Item())
+		}
+		require.NoError(t, svc.Append(ctx, logName, expected[4:5]))
+		for len(out) < 5 && it.Next() {
+			out = append(out, it.Item())
+		}
+		require.NoError(t, svc.Append(ctx, logName, expected[5:]))
+		state.mu.Lock()
+		state.complete = true
+		state.mu.Unlock()
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+
+		assert.NoError(t, it.Err())
+		assert.True(t, it.Exhausted())
+		assert.NoError(t, it.Close())
+		assert.Equal(t, expected, out)
+	})
+	t.Run("StreamsLateLinesOfOtherLogs", func(t *testing.T) {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		svc := NewLogServiceV0(bucket)
+		state := &followState{}
+		agentLogName := logPrefix + "/agent"
+
+		taskLines := makeLines(300, 2)
+		require.NoError(t, svc.Append(ctx, logName, taskLines))
+
+		it, err := NewFollowIterator(ctx, newFollowOpts(svc, state))
+		require.NoError(t, err)
+
+		var out []LogLine
+		for len(out) < 2 && it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.Equal(t, taskLines, out)
+
+		// Lines appended to another log with timestamps earlier than
+		// the last line returned must still be streamed.
+		agentLines := makeLogLines(agentLogName, 100, 2)
+		require.NoError(t, svc.Append(ctx, agentLogName, agentLines))
+		moreTaskLines := makeLines(400, 1)
+		require.NoError(t, svc.Append(ctx, logName, moreTaskLines))
+		state.mu.Lock()
+		state.complete = true
+		state.mu.Unlock()
+
+		out = nil
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.NoError(t, it.Err())
+		assert.True(t, it.Exhausted())
+		assert.Equal(t, append(agentLines, moreTaskLines...), out)
+	})
+	t.Run("OnlyGetsLinesAfterEachLogPosition", func(t *testing.T) {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		svc := NewLogServiceV0(bucket)
+		state := &followState{}
+		require.NoError(t, svc.Append(ctx, logName, makeLines(100, 2)))
+
+		var calls []map[string]int64
+		opts := newFollowOpts(svc, state)
+		get := opts.Get
+		opts.Get = func(ctx context.Context, logStarts map[string]int64) (LogIterator, error) {
+			calls = append(calls, logStarts)
+			return get(ctx, logStarts)
+		}
+		it, err := NewFollowIterator(ctx, opts)
+		require.NoError(t, err)
+
+		var out []LogLine
+		for len(out) < 2 && it.Next() {
+			out = append(out, it.Item())
+		}
+		state.mu.Lock()
+		state.complete = true
+		state.mu.Unlock()
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.NoError(t, it.Err())
+		assert.Len(t, out, 2)
+		require.Len(t, calls, 2)
+		assert.Empty(t, calls[0])
+		assert.Equal(t, map[string]int64{logName: 100}, calls[1])
+	})
+	t.Run("CompleteLog", func(t *testing.T) {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		svc := NewLogServiceV0(bucket)
+		expected := makeLines(100, 3)
+		require.NoError(t, svc.Append(ctx, logName, expected))
+
+		it, err := NewFollowIterator(ctx, newFollowOpts(svc, &followState{complete: true}))
+		require.NoError(t, err)
+
+		var out []LogLine
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.NoError(t, it.Err())
+		assert.True(t, it.Exhausted())
+		assert.Equal(t, expected, out)
+	})
+	t.Run("ContextCanceled", func(t *testing.T) {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+		require.NoError(t, err)
+		tctx, tcancel := context.WithCancel(ctx)
+
+		it, err := NewFollowIterator(tctx, newFollowOpts(NewLogServiceV0(bucket), &followState{}))
+		require.NoError(t, err)
+		tcancel()
+
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+		assert.False(t, it.Exhausted())
+		assert.NoError(t, it.Close())
+	})
+	t.Run("IsCompleteError", func(t *testing.T) {
+		it, err := NewFollowIterator(ctx, FollowOptions{
+			Get: func(context.Context, map[string]int64) (LogIterator, error) {
+				return nil, errors.New("should not be called")
+			},
+			IsComplete: func(context.Context) (bool, error) {
+				return false, errors.New("is complete error")
+			},
+		})
+		require.NoError(t, err)
+
+		assert.False(t, it.Next())
+		assert.Contains(t, it.Err().Error(), "is complete error")
+	})
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := NewFollowIterator(ctx, FollowOptions{})
+		assert.Error(t, err)
+	})
+}
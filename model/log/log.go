@@ -13,6 +13,10 @@ type LogLine struct {
 	Priority  level.Priority
 	Timestamp int64
 	Data      string
+	// Attributes are optional key-value pairs that describe the line,
+	// such as the fields of a structured log message. Attributes are
+	// only persisted by log service versions that support them.
+	Attributes map[string]string
 }
 
 // StreamFromLogIterator streams log lines from the given iterator to the
@@ -38,6 +38,20 @@ type LogIteratorReaderOptions struct {
 	SoftSizeLimit int
 }
 
+// FormatLine returns the given log line as newline-terminated text, including
+// the timestamp and priority of the line if specified by the options.
+func (opts LogIteratorReaderOptions) FormatLine(line LogLine) string {
+	data := line.Data
+	if opts.PrintTime {
+		data = fmt.Sprintf("[%s] %s", time.Unix(0, line.Timestamp).UTC().Format("2006/01/02 15:04:05.000"), data)
+	}
+	if opts.PrintPriority {
+		data = fmt.Sprintf("[P:%3d] %s", line.Priority, data)
+	}
+
+	return data + "\n"
+}
+
 // NewLogIteratorReader returns a reader that reads the log lines from the
 // iterator with the given options. It is the responsibility of the caller to
 // close the iterator.
@@ -77,14 +91,7 @@ func (r *LogIteratorReader) Read(p []byte) (int, error) {
 		}
 
 		r.lastItem = r.it.Item()
-		data := r.it.Item().Data
-		if r.opts.PrintTime {
-			data = fmt.Sprintf("[%s] %s", time.Unix(0, r.it.Item().Timestamp).UTC().Format("2006/01/02 15:04:05.000"), data)
-		}
-		if r.opts.PrintPriority {
-			data = fmt.Sprintf("[P:%3d] %s", r.it.Item().Priority, data)
-		}
-		n = r.writeToBuffer([]byte(data+"\n"), p, n)
+		n = r.writeToBuffer([]byte(r.opts.FormatLine(r.it.Item())), p, n)
 		if n == len(p) {
 			return n, nil
 		}
@@ -0,0 +1,174 @@
+package log
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/pkg/errors"
+)
+
+// SearchOptions represents the arguments for searching Evergreen logs.
+type SearchOptions struct {
+	// Pattern is the substring, or regular expression if Regex is true,
+	// to match against the data of each log line. Required.
+	Pattern string
+	// Regex, when true, interprets Pattern as a regular expression using
+	// RE2 syntax rather than as a substring.
+	Regex bool
+	// MinPriority filters matching log lines to those with a priority
+	// greater than or equal to the given priority. Optional.
+	MinPriority level.Priority
+	// ContextLines is the number of lines to return before and after each
+	// matching log line. Context lines are not subject to the priority
+	// filter. Optional.
+	ContextLines int
+	// Limit is the maximum number of matching log lines to return. The
+	// context lines of the last match are still returned. Optional.
+	Limit int
+}
+
+// Validate checks that the search options are valid.
+func (o SearchOptions) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(o.Pattern == "", "must specify a search pattern")
+	catcher.NewWhen(o.ContextLines < 0, "context lines cannot be negative")
+	catcher.NewWhen(o.MinPriority < 0, "minimum priority cannot be negative")
+	catcher.NewWhen(o.Limit < 0, "limit cannot be negative")
+	if o.Regex {
+		_, err := regexp.Compile(o.Pattern)
+		catcher.Wrap(err, "compiling search pattern")
+	}
+
+	return catcher.Resolve()
+}
+
+func (o SearchOptions) matcher() (func(LogLine) bool, error) {
+	matchData := func(data string) bool { return strings.Contains(data, o.Pattern) }
+	if o.Regex {
+		re, err := regexp.Compile(o.Pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "compiling search pattern")
+		}
+		matchData = re.MatchString
+	}
+
+	return func(line LogLine) bool {
+		return line.Priority >= o.MinPriority && matchData(line.Data)
+	}, nil
+}
+
+type searchIterator struct {
+	it           LogIterator
+	match        func(LogLine) bool
+	contextLines int
+	limit        int
+	matches      int
+	before       []LogLine
+	pending      []LogLine
+	afterCount   int
+	item         LogLine
+	exhausted    bool
+	closed       bool
+}
+
+// NewSearchIterator returns a LogIterator that streams the lines of the given
+// iterator that match the search options, along with any requested context
+// lines. At most ContextLines lines are buffered at any given time. Closing
+// the returned iterator closes the underlying iterator.
+func NewSearchIterator(it LogIterator, opts SearchOptions) (LogIterator, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid search options")
+	}
+	match, err := opts.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return newMatchIterator(it, match, opts.ContextLines, opts.Limit), nil
+}
+
+// NewAttributeFilterIterator returns a LogIterator that streams the lines of
+// the given iterator that have all of the given attribute key-value pairs.
+// Closing the returned iterator closes the underlying iterator.
+func NewAttributeFilterIterator(it LogIterator, attributes map[string]string) LogIterator {
+	return newMatchIterator(it, func(line LogLine) bool {
+		for key, val := range attributes {
+			if lineVal, ok := line.Attributes[key]; !ok || lineVal != val {
+				return false
+			}
+		}
+
+		return true
+	}, 0, 0)
+}
+
+func newMatchIterator(it LogIterator, match func(LogLine) bool, contextLines, limit int) *searchIterator {
+	return &searchIterator{
+		it:           it,
+		match:        match,
+		contextLines: contextLines,
+		limit:        limit,
+	}
+}
+
+func (it *searchIterator) Next() bool {
+	if it.closed || it.exhausted {
+		return false
+	}
+
+	for len(it.pending) == 0 {
+		if it.limitReached() && it.afterCount == 0 {
+			it.exhausted = true
+			return false
+		}
+		if !it.it.Next() {
+			it.exhausted = it.it.Exhausted()
+			return false
+		}
+
+		line := it.it.Item()
+		switch {
+		case !it.limitReached() && it.match(line):
+			it.matches++
+			it.pending = append(it.pending, it.before...)
+			it.pending = append(it.pending, line)
+			it.before = it.before[:0]
+			it.afterCount = it.contextLines
+		case it.afterCount > 0:
+			it.pending = append(it.pending, line)
+			it.afterCount--
+		case it.contextLines > 0:
+			if len(it.before) == it.contextLines {
+				copy(it.before, it.before[1:])
+				it.before = it.before[:len(it.before)-1]
+			}
+			it.before = append(it.before, line)
+		}
+	}
+
+	it.item = it.pending[0]
+	it.pending = it.pending[1:]
+
+	return true
+}
+
+func (it *searchIterator) limitReached() bool {
+	return it.limit > 0 && it.matches >= it.limit
+}
+
+func (it *searchIterator) Exhausted() bool { return it.exhausted }
+
+func (it *searchIterator) Err() error { return it.it.Err() }
+
+func (it *searchIterator) Item() LogLine { return it.item }
+
+func (it *searchIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+
+	return it.it.Close()
+}
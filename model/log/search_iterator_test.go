@@ -0,0 +1,97 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchIterator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+	require.NoError(t, err)
+	svc := NewLogServiceV0(bucket)
+
+	logName := "project/task/0/task_logs/task"
+	ts := time.Now().UnixNano()
+	lines := make([]LogLine, 20)
+	for i := range lines {
+		priority := level.Info
+		if i%5 == 0 {
+			priority = level.Error
+		}
+		lines[i] = LogLine{
+			LogName:   logName,
+			Priority:  priority,
+			Timestamp: ts + int64(i),
+			Data:      fmt.Sprintf("line %02d", i),
+		}
+	}
+	require.NoError(t, svc.Append(ctx, logName, lines[:10]))
+	require.NoError(t, svc.Append(ctx, logName, lines[10:]))
+
+	searchWithLineLimit := func(t *testing.T, opts SearchOptions, lineLimit int) []LogLine {
+		it, err := svc.Get(ctx, GetOptions{LogNames: []string{logName}, LineLimit: lineLimit, Search: &opts})
+		require.NoError(t, err)
+
+		var out []LogLine
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.True(t, it.Exhausted())
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close())
+
+		return out
+	}
+	search := func(t *testing.T, opts SearchOptions) []LogLine {
+		return searchWithLineLimit(t, opts, 0)
+	}
+
+	t.Run("Substring", func(t *testing.T) {
+		assert.Equal(t, lines[10:20], search(t, SearchOptions{Pattern: "line 1"}))
+	})
+	t.Run("Regex", func(t *testing.T) {
+		assert.Equal(t, []LogLine{lines[3], lines[13]}, search(t, SearchOptions{Pattern: `^line \d3$`, Regex: true}))
+	})
+	t.Run("MinPriority", func(t *testing.T) {
+		assert.Equal(t, []LogLine{lines[0], lines[5], lines[10], lines[15]}, search(t, SearchOptions{Pattern: "line", MinPriority: level.Error}))
+	})
+	t.Run("ContextLines", func(t *testing.T) {
+		out := search(t, SearchOptions{Pattern: "line (08|11|19)", Regex: true, ContextLines: 2})
+		expected := append([]LogLine{}, lines[6:14]...)
+		expected = append(expected, lines[17:20]...)
+		assert.Equal(t, expected, out)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		assert.Equal(t, lines[10:13], search(t, SearchOptions{Pattern: "line 1", Limit: 3}))
+	})
+	t.Run("LimitWithContextLines", func(t *testing.T) {
+		out := search(t, SearchOptions{Pattern: "line (08|11|19)", Regex: true, ContextLines: 1, Limit: 2})
+		assert.Equal(t, lines[7:13], out)
+	})
+	t.Run("LineLimitCapsMatches", func(t *testing.T) {
+		assert.Equal(t, []LogLine{lines[15], lines[16]}, searchWithLineLimit(t, SearchOptions{Pattern: "line 1[5-9]", Regex: true}, 2))
+	})
+	t.Run("NoMatches", func(t *testing.T) {
+		assert.Empty(t, search(t, SearchOptions{Pattern: "DNE", ContextLines: 3}))
+	})
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := svc.Get(ctx, GetOptions{LogNames: []string{logName}, Search: &SearchOptions{Pattern: "(", Regex: true}})
+		assert.Error(t, err)
+		_, err = svc.Get(ctx, GetOptions{LogNames: []string{logName}, Search: &SearchOptions{Pattern: "line", ContextLines: -1}})
+		assert.Error(t, err)
+		_, err = svc.Get(ctx, GetOptions{LogNames: []string{logName}, Search: &SearchOptions{Pattern: "line", Limit: -1}})
+		assert.Error(t, err)
+		_, err = svc.Get(ctx, GetOptions{LogNames: []string{logName}, Search: &SearchOptions{}})
+		assert.Error(t, err)
+	})
+}
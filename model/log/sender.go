@@ -2,6 +2,7 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 	"time"
@@ -127,6 +128,7 @@ func (s *sender) Send(m message.Composer) {
 		return
 	}
 
+	attributes := AttributesFromComposer(m)
 	for _, line := range strings.Split(m.String(), "\n") {
 		if line == "" {
 			continue
@@ -147,6 +149,9 @@ func (s *sender) Send(m message.Composer) {
 		if logLine.Timestamp == 0 {
 			logLine.Timestamp = ts
 		}
+		if logLine.Attributes == nil {
+			logLine.Attributes = attributes
+		}
 		if logLine.Timestamp < 0 {
 			s.opts.Local.Send(message.NewErrorMessage(level.Error, errors.Errorf("invalid log line timestamp %d", logLine.Timestamp)))
 			return
@@ -163,6 +168,30 @@ func (s *sender) Send(m message.Composer) {
 	}
 }
 
+// AttributesFromComposer returns the log line attributes of the given
+// message. Only structured messages, whose raw form is grip fields, have
+// attributes; each field value is converted to its string representation and
+// grip's logging metadata is omitted.
+func AttributesFromComposer(m message.Composer) map[string]string {
+	fields, ok := m.Raw().(message.Fields)
+	if !ok {
+		return nil
+	}
+
+	attributes := map[string]string{}
+	for key, val := range fields {
+		if key == "metadata" {
+			continue
+		}
+		attributes[key] = fmt.Sprint(val)
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	return attributes
+}
+
 // Flush flushes anything messages that may be in the buffer to persistent
 // storage determined by the backing log service.
 func (s *sender) Flush(ctx context.Context) error {
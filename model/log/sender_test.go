@@ -150,6 +150,40 @@ func TestSend(t *testing.T) {
 			require.Equal(t, mock.sender.buffer[0].Timestamp, mock.sender.buffer[i].Timestamp)
 		}
 	})
+	t.Run("SetsAttributesFromFields", func(t *testing.T) {
+		mock := newSenderTestMock(ctx)
+
+		m := message.NewFieldsMessage(level.Info, "structured", message.Fields{
+			"step":     "compile",
+			"attempts": 2,
+		})
+		mock.sender.Send(m)
+		require.Len(t, mock.sender.buffer, 1)
+		assert.Equal(t, map[string]string{
+			"message":  "structured",
+			"step":     "compile",
+			"attempts": "2",
+		}, mock.sender.buffer[0].Attributes)
+
+		m = message.ConvertToComposer(level.Info, "unstructured")
+		mock.sender.Send(m)
+		require.Len(t, mock.sender.buffer, 2)
+		assert.Nil(t, mock.sender.buffer[1].Attributes)
+	})
+	t.Run("ParsedAttributesTakePrecedence", func(t *testing.T) {
+		mock := newSenderTestMock(ctx)
+		mock.sender.opts.Parse = func(rawLine string) (LogLine, error) {
+			return LogLine{
+				Data:       rawLine,
+				Attributes: map[string]string{"parsed": "true"},
+			}, nil
+		}
+
+		m := message.NewFieldsMessage(level.Info, "structured", message.Fields{"step": "compile"})
+		mock.sender.Send(m)
+		require.Len(t, mock.sender.buffer, 1)
+		assert.Equal(t, map[string]string{"parsed": "true"}, mock.sender.buffer[0].Attributes)
+	})
 	t.Run("FlushesAtCapacity", func(t *testing.T) {
 		mock := newSenderTestMock(ctx)
 		mock.sender.opts.MaxBufferSize = 4096
@@ -378,3 +412,11 @@ func (s *mockService) Append(ctx context.Context, logName string, lines []LogLin
 
 	return nil
 }
+
+func (s *mockService) Compact(_ context.Context, _ CompactOptions) (CompactResult, error) {
+	return CompactResult{}, errors.New("not implemented")
+}
+
+func (s *mockService) DeleteBefore(_ context.Context, _ DeleteBeforeOptions) (DeleteBeforeResult, error) {
+	return DeleteBeforeResult{}, errors.New("not implemented")
+}
@@ -8,6 +8,8 @@ import (
 type LogService interface {
 	Get(context.Context, GetOptions) (LogIterator, error)
 	Append(context.Context, string, []LogLine) error
+	Compact(context.Context, CompactOptions) (CompactResult, error)
+	DeleteBefore(context.Context, DeleteBeforeOptions) (DeleteBeforeResult, error)
 }
 
 // GetOptions represents the arguments for fetching Evergreen logs.
@@ -21,12 +23,85 @@ type GetOptions struct {
 	// Start is the start time (inclusive) of the time range filter,
 	// represented as a Unix timestamp in nanoseconds. Optional.
 	Start int64
+	// LogStarts overrides the start time of the time range filter for
+	// individual logs, keyed by log name. Logs not in the map use Start.
+	// Optional.
+	LogStarts map[string]int64
 	// End is the end time (inclusive) of the time range filter,
 	// represented as a Unix timestamp in nanoseconds. Optional.
 	End int64
-	// LineLimit limits the number of lines read from the log. Optional.
+	// LineLimit limits the number of lines read from the log. When
+	// searching, it instead limits the number of matching lines returned,
+	// unless the search specifies its own limit. Optional.
 	LineLimit int
 	// TailN is the number of lines to read from the tail of the log.
 	// Optional.
 	TailN int
+	// Attributes filters the log lines to those with all of the given
+	// attribute key-value pairs. Only logs stored with a log service
+	// version that persists attributes can match. Optional.
+	Attributes map[string]string
+	// Search filters the log lines to those matching the search options.
+	// The time range, tail, and attribute filters are applied to the log
+	// before searching. Optional.
+	Search *SearchOptions
+}
+
+// CompactOptions represents the arguments for compacting Evergreen logs.
+type CompactOptions struct {
+	// LogNames are the names of the logs to compact, prefixes may be
+	// specified. At least one name must be specified.
+	LogNames []string
+	// MaxChunkLines is the maximum number of lines in a compacted chunk.
+	// Chunks that already exceed this size are left as is.
+	MaxChunkLines int
+	// DryRun, when true, computes the result of the compaction without
+	// modifying any stored chunks.
+	DryRun bool
+}
+
+// CompactResult describes the outcome of compacting Evergreen logs.
+type CompactResult struct {
+	// ChunksMerged is the number of chunks merged into larger chunks.
+	ChunksMerged int `bson:"chunks_merged" json:"chunks_merged"`
+	// ChunksCreated is the number of compacted chunks created.
+	ChunksCreated int `bson:"chunks_created" json:"chunks_created"`
+	// LinesMerged is the number of lines in the merged chunks.
+	LinesMerged int `bson:"lines_merged" json:"lines_merged"`
+}
+
+// Add adds the counts of the given result to the result.
+func (r *CompactResult) Add(other CompactResult) {
+	r.ChunksMerged += other.ChunksMerged
+	r.ChunksCreated += other.ChunksCreated
+	r.LinesMerged += other.LinesMerged
+}
+
+// DeleteBeforeOptions represents the arguments for deleting expired Evergreen
+// logs.
+type DeleteBeforeOptions struct {
+	// LogNames are the names of the logs to delete from, prefixes may be
+	// specified. At least one name must be specified.
+	LogNames []string
+	// Before is the cutoff time, represented as a Unix timestamp in
+	// nanoseconds. Chunks whose last line is before the cutoff are
+	// deleted.
+	Before int64
+	// DryRun, when true, computes the result of the deletion without
+	// deleting any stored chunks.
+	DryRun bool
+}
+
+// DeleteBeforeResult describes the outcome of deleting expired Evergreen logs.
+type DeleteBeforeResult struct {
+	// ChunksDeleted is the number of chunks deleted.
+	ChunksDeleted int `bson:"chunks_deleted" json:"chunks_deleted"`
+	// LinesDeleted is the number of lines in the deleted chunks.
+	LinesDeleted int `bson:"lines_deleted" json:"lines_deleted"`
+}
+
+// Add adds the counts of the given result to the result.
+func (r *DeleteBeforeResult) Add(other DeleteBeforeResult) {
+	r.ChunksDeleted += other.ChunksDeleted
+	r.LinesDeleted += other.LinesDeleted
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/evergreen-ci/pail"
 	"github.com/jpillora/longestcommon"
+	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/level"
 	"github.com/pkg/errors"
 )
@@ -24,6 +25,34 @@ func NewLogServiceV0(bucket pail.Bucket) *logServiceV0 {
 }
 
 func (s *logServiceV0) Get(ctx context.Context, getOpts GetOptions) (LogIterator, error) {
+	return s.get(ctx, getOpts, s.getParser)
+}
+
+func (s *logServiceV0) Append(ctx context.Context, logName string, lines []LogLine) error {
+	return s.append(ctx, logName, lines, func(line LogLine) (string, error) {
+		return s.formatRawLine(line), nil
+	})
+}
+
+// get returns an iterator over the given logs, parsing the raw lines of each
+// log with the parser returned by makeParser.
+func (s *logServiceV0) get(ctx context.Context, getOpts GetOptions, makeParser func(string) LineParser) (LogIterator, error) {
+	lineLimit := getOpts.LineLimit
+	if getOpts.Search != nil {
+		if err := getOpts.Search.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid search options")
+		}
+
+		// When searching, the line limit caps the number of matching
+		// lines returned rather than the number of lines read.
+		search := *getOpts.Search
+		if search.Limit == 0 {
+			search.Limit = lineLimit
+		}
+		getOpts.Search = &search
+		lineLimit = 0
+	}
+
 	var its []LogIterator
 	logChunks, err := s.getLogChunks(ctx, getOpts.LogNames)
 	if err != nil {
@@ -31,37 +60,188 @@ func (s *logServiceV0) Get(ctx context.Context, getOpts GetOptions) (LogIterator
 	}
 
 	for name, chunks := range logChunks {
+		start := getOpts.Start
+		if logStart, ok := getOpts.LogStarts[name]; ok {
+			start = logStart
+		}
+
 		its = append(its, newChunkIterator(ctx, chunkIteratorOptions{
 			bucket:    s.bucket,
 			chunks:    chunks,
-			parser:    s.getParser(name),
-			start:     getOpts.Start,
+			parser:    makeParser(name),
+			start:     start,
 			end:       getOpts.End,
-			lineLimit: getOpts.LineLimit,
+			lineLimit: lineLimit,
 			tailN:     getOpts.TailN,
 		}))
 	}
 
+	var it LogIterator
 	if len(its) == 1 {
-		return its[0], nil
+		it = its[0]
+	} else {
+		it = newMergingIterator(its...)
+	}
+	if len(getOpts.Attributes) > 0 {
+		it = NewAttributeFilterIterator(it, getOpts.Attributes)
+	}
+	if getOpts.Search != nil {
+		return NewSearchIterator(it, *getOpts.Search)
 	}
-	return newMergingIterator(its...), nil
+
+	return it, nil
 }
 
-func (s *logServiceV0) Append(ctx context.Context, logName string, lines []LogLine) error {
+// append writes the given lines to the log as a single chunk, formatting each
+// line for storage with the given formatter.
+func (s *logServiceV0) append(ctx context.Context, logName string, lines []LogLine, format func(LogLine) (string, error)) error {
 	if len(lines) == 0 {
 		return nil
 	}
 
 	var rawLines []byte
 	for _, line := range lines {
-		rawLines = append(rawLines, []byte(s.formatRawLine(line))...)
+		rawLine, err := format(line)
+		if err != nil {
+			return errors.Wrap(err, "formatting log line")
+		}
+		rawLines = append(rawLines, []byte(rawLine)...)
 	}
 
 	key := fmt.Sprintf("%s/%s", logName, s.createChunkKey(lines[0].Timestamp, lines[len(lines)-1].Timestamp, len(lines)))
 	return errors.Wrap(s.bucket.Put(ctx, key, bytes.NewReader(rawLines)), "writing log chunk to bucket")
 }
 
+// Compact merges runs of adjacent chunks of each log into chunks of up to
+// the maximum number of lines. Since v0 chunks store raw lines, merging
+// simply concatenates the chunk data. The merged chunk is written before the
+// original chunks are removed so that a failure never loses log lines.
+func (s *logServiceV0) Compact(ctx context.Context, opts CompactOptions) (CompactResult, error) {
+	var result CompactResult
+	if len(opts.LogNames) == 0 {
+		return result, errors.New("must specify at least one log name")
+	}
+	if opts.MaxChunkLines <= 0 {
+		return result, errors.New("max chunk lines must be positive")
+	}
+
+	logChunks, err := s.getLogChunks(ctx, opts.LogNames)
+	if err != nil {
+		return result, errors.Wrap(err, "getting log chunks")
+	}
+
+	for logName, chunks := range logChunks {
+		for _, group := range groupChunks(chunks, opts.MaxChunkLines) {
+			if len(group) < 2 {
+				continue
+			}
+
+			var numLines int
+			for _, chunk := range group {
+				numLines += chunk.numLines
+			}
+			result.ChunksMerged += len(group)
+			result.ChunksCreated++
+			result.LinesMerged += numLines
+			if opts.DryRun {
+				continue
+			}
+
+			if err = s.mergeChunks(ctx, logName, group, numLines); err != nil {
+				return result, errors.Wrapf(err, "merging chunks for log '%s'", logName)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// mergeChunks writes the given chunks as a single chunk and removes the
+// original chunks.
+func (s *logServiceV0) mergeChunks(ctx context.Context, logName string, chunks []chunkInfo, numLines int) error {
+	var (
+		data bytes.Buffer
+		keys []string
+	)
+	for _, chunk := range chunks {
+		r, err := s.bucket.Get(ctx, chunk.key)
+		if err != nil {
+			return errors.Wrapf(err, "getting chunk '%s'", chunk.key)
+		}
+		_, err = data.ReadFrom(r)
+		catcher := grip.NewBasicCatcher()
+		catcher.Wrapf(err, "reading chunk '%s'", chunk.key)
+		catcher.Wrapf(r.Close(), "closing chunk '%s'", chunk.key)
+		if catcher.HasErrors() {
+			return catcher.Resolve()
+		}
+		keys = append(keys, chunk.key)
+	}
+
+	key := fmt.Sprintf("%s/%s", logName, s.createChunkKey(chunks[0].start, chunks[len(chunks)-1].end, numLines))
+	if err := s.bucket.Put(ctx, key, &data); err != nil {
+		return errors.Wrap(err, "writing merged log chunk to bucket")
+	}
+
+	return errors.Wrap(s.bucket.RemoveMany(ctx, keys...), "removing merged log chunks from bucket")
+}
+
+// groupChunks partitions the given chunks, sorted by start time, into runs of
+// adjacent chunks with at most maxLines lines in total.
+func groupChunks(chunks []chunkInfo, maxLines int) [][]chunkInfo {
+	var (
+		groups    [][]chunkInfo
+		group     []chunkInfo
+		lineCount int
+	)
+	for _, chunk := range chunks {
+		if len(group) > 0 && lineCount+chunk.numLines > maxLines {
+			groups = append(groups, group)
+			group = nil
+			lineCount = 0
+		}
+		group = append(group, chunk)
+		lineCount += chunk.numLines
+	}
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// DeleteBefore removes every chunk of the given logs whose last line was
+// written before the cutoff.
+func (s *logServiceV0) DeleteBefore(ctx context.Context, opts DeleteBeforeOptions) (DeleteBeforeResult, error) {
+	var result DeleteBeforeResult
+	if len(opts.LogNames) == 0 {
+		return result, errors.New("must specify at least one log name")
+	}
+
+	logChunks, err := s.getLogChunks(ctx, opts.LogNames)
+	if err != nil {
+		return result, errors.Wrap(err, "getting log chunks")
+	}
+
+	var keys []string
+	for _, chunks := range logChunks {
+		for _, chunk := range chunks {
+			if chunk.end >= opts.Before {
+				continue
+			}
+
+			keys = append(keys, chunk.key)
+			result.ChunksDeleted++
+			result.LinesDeleted += chunk.numLines
+		}
+	}
+	if opts.DryRun || len(keys) == 0 {
+		return result, nil
+	}
+
+	return result, errors.Wrap(s.bucket.RemoveMany(ctx, keys...), "removing expired log chunks from bucket")
+}
+
 // getLogChunks maps each logical log to its chunk files stored in pail-backed
 // bucket storage for the given prefix.
 func (s *logServiceV0) getLogChunks(ctx context.Context, logNames []string) (map[string][]chunkInfo, error) {
@@ -70,7 +250,7 @@ func (s *logServiceV0) getLogChunks(ctx context.Context, logNames []string) (map
 	// To reduce potentially expensive list calls, use the LCP of the
 	// given log names when calling `bucket.List`. Key names that do not
 	// have one of the log names as a prefix will get filtered out.
-	prefix := longestcommon.Prefix(logNames)
+	prefix := s.getListPrefix(logNames)
 	match := func(key string) bool {
 		for _, name := range logNames {
 			if strings.HasPrefix(key, name) {
@@ -113,13 +293,32 @@ func (s *logServiceV0) getLogChunks(ctx context.Context, logNames []string) (map
 
 	for _, chunks := range logChunks {
 		sort.Slice(chunks, func(i, j int) bool {
-			return chunks[i].start > chunks[j].start
+			return chunks[i].start < chunks[j].start
 		})
 	}
 
 	return logChunks, nil
 }
 
+// getListPrefix returns the prefix used to list the chunks of the given log
+// names. This is the LCP of the log names truncated to the last path
+// separator, unless the LCP is itself one of the log names. Truncating keeps
+// the prefix valid for directory-based buckets, such as the local file system,
+// which cannot list keys by a partial path component.
+func (s *logServiceV0) getListPrefix(logNames []string) string {
+	prefix := longestcommon.Prefix(logNames)
+	for _, name := range logNames {
+		if name == prefix {
+			return prefix
+		}
+	}
+
+	if idx := strings.LastIndex(prefix, "/"); idx >= 0 {
+		return prefix[:idx]
+	}
+	return ""
+}
+
 // createChunkKey returns a pail-backed bucket storage key that encodes the
 // given log chunk information. This is used primarily for fetching logs.
 func (s *logServiceV0) createChunkKey(start, end int64, numLines int) string {
@@ -0,0 +1,226 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogServiceV0LocalBucket(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+	require.NoError(t, err)
+	svc := NewLogServiceV0(bucket)
+
+	ts := time.Now().UnixNano()
+	logs := map[string][]LogLine{}
+	for _, logName := range []string{"project/task/0/test_logs/foo.log", "project/task/0/test_logs/fob.log"} {
+		for i := 0; i < 3; i++ {
+			var lines []LogLine
+			for j := 0; j < 5; j++ {
+				lines = append(lines, LogLine{
+					LogName:   logName,
+					Priority:  level.Info,
+					Timestamp: ts,
+					Data:      newRandCharSetString(20),
+				})
+				ts += int64(time.Millisecond)
+			}
+			require.NoError(t, svc.Append(ctx, logName, lines))
+			logs[logName] = append(logs[logName], lines...)
+		}
+	}
+
+	readAll := func(t *testing.T, opts GetOptions) []LogLine {
+		it, err := svc.Get(ctx, opts)
+		require.NoError(t, err)
+
+		var lines []LogLine
+		for it.Next() {
+			lines = append(lines, it.Item())
+		}
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close())
+
+		return lines
+	}
+
+	t.Run("SingleLog", func(t *testing.T) {
+		expected := logs["project/task/0/test_logs/foo.log"]
+		assert.Equal(t, expected, readAll(t, GetOptions{LogNames: []string{"project/task/0/test_logs/foo.log"}}))
+	})
+	t.Run("PartialPathComponentPrefix", func(t *testing.T) {
+		lines := readAll(t, GetOptions{LogNames: []string{"project/task/0/test_logs/foo.log", "project/task/0/test_logs/fob.log"}})
+		assert.Len(t, lines, 30)
+	})
+	t.Run("TimeRange", func(t *testing.T) {
+		expected := logs["project/task/0/test_logs/foo.log"][4:11]
+		assert.Equal(t, expected, readAll(t, GetOptions{
+			LogNames: []string{"project/task/0/test_logs/foo.log"},
+			Start:    expected[0].Timestamp,
+			End:      expected[len(expected)-1].Timestamp,
+		}))
+	})
+	t.Run("LogStarts", func(t *testing.T) {
+		foo := logs["project/task/0/test_logs/foo.log"]
+		expected := append(append([]LogLine{}, foo[12:]...), logs["project/task/0/test_logs/fob.log"]...)
+		assert.Equal(t, expected, readAll(t, GetOptions{
+			LogNames:  []string{"project/task/0/test_logs/foo.log", "project/task/0/test_logs/fob.log"},
+			LogStarts: map[string]int64{"project/task/0/test_logs/foo.log": foo[12].Timestamp},
+		}))
+	})
+	t.Run("TailN", func(t *testing.T) {
+		expected := logs["project/task/0/test_logs/foo.log"][8:]
+		assert.Equal(t, expected, readAll(t, GetOptions{
+			LogNames: []string{"project/task/0/test_logs/foo.log"},
+			TailN:    7,
+		}))
+	})
+	t.Run("NonexistentLog", func(t *testing.T) {
+		assert.Empty(t, readAll(t, GetOptions{LogNames: []string{"project/DNE/0/task_logs"}}))
+	})
+}
+
+func TestLogServiceV0Compact(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+	require.NoError(t, err)
+	svc := NewLogServiceV0(bucket)
+
+	logName := "project/task/0/task_logs/task"
+	ts := time.Now().UnixNano()
+	var lines []LogLine
+	for i := 0; i < 10; i++ {
+		chunk := []LogLine{
+			{LogName: logName, Priority: level.Info, Timestamp: ts, Data: newRandCharSetString(10)},
+			{LogName: logName, Priority: level.Info, Timestamp: ts + 1, Data: newRandCharSetString(10)},
+		}
+		require.NoError(t, svc.Append(ctx, logName, chunk))
+		lines = append(lines, chunk...)
+		ts += int64(time.Second)
+	}
+
+	countChunks := func(t *testing.T) int {
+		chunks, err := svc.getLogChunks(ctx, []string{logName})
+		require.NoError(t, err)
+		return len(chunks[logName])
+	}
+	readAll := func(t *testing.T) []LogLine {
+		it, err := svc.Get(ctx, GetOptions{LogNames: []string{logName}})
+		require.NoError(t, err)
+
+		var out []LogLine
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		require.NoError(t, it.Err())
+		require.NoError(t, it.Close())
+
+		return out
+	}
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := svc.Compact(ctx, CompactOptions{MaxChunkLines: 10})
+		assert.Error(t, err)
+		_, err = svc.Compact(ctx, CompactOptions{LogNames: []string{logName}})
+		assert.Error(t, err)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		result, err := svc.Compact(ctx, CompactOptions{LogNames: []string{logName}, MaxChunkLines: 7, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, CompactResult{ChunksMerged: 9, ChunksCreated: 3, LinesMerged: 18}, result)
+		assert.Equal(t, 10, countChunks(t))
+	})
+	t.Run("MergesAdjacentChunks", func(t *testing.T) {
+		result, err := svc.Compact(ctx, CompactOptions{LogNames: []string{logName}, MaxChunkLines: 7})
+		require.NoError(t, err)
+		assert.Equal(t, CompactResult{ChunksMerged: 9, ChunksCreated: 3, LinesMerged: 18}, result)
+		assert.Equal(t, 4, countChunks(t))
+		assert.Equal(t, lines, readAll(t))
+	})
+	t.Run("NoopWhenAlreadyCompacted", func(t *testing.T) {
+		result, err := svc.Compact(ctx, CompactOptions{LogNames: []string{logName}, MaxChunkLines: 7})
+		require.NoError(t, err)
+		assert.Zero(t, result)
+		assert.Equal(t, 4, countChunks(t))
+	})
+	t.Run("DeleteBefore", func(t *testing.T) {
+		before := lines[8].Timestamp
+
+		result, err := svc.DeleteBefore(ctx, DeleteBeforeOptions{LogNames: []string{"project/"}, Before: before, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, DeleteBeforeResult{ChunksDeleted: 1, LinesDeleted: 6}, result)
+		assert.Equal(t, 4, countChunks(t))
+
+		result, err = svc.DeleteBefore(ctx, DeleteBeforeOptions{LogNames: []string{"project/"}, Before: before})
+		require.NoError(t, err)
+		assert.Equal(t, DeleteBeforeResult{ChunksDeleted: 1, LinesDeleted: 6}, result)
+		assert.Equal(t, 3, countChunks(t))
+		assert.Equal(t, lines[6:], readAll(t))
+	})
+}
+
+func TestLogServiceV1(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: t.TempDir()})
+	require.NoError(t, err)
+	svc := NewLogServiceV1(bucket)
+
+	logName := "project/task/0/task_logs/task"
+	ts := time.Now().UnixNano()
+	lines := []LogLine{
+		{LogName: logName, Priority: level.Info, Timestamp: ts, Data: "compiling", Attributes: map[string]string{"step": "compile", "cmd": "make"}},
+		{LogName: logName, Priority: level.Info, Timestamp: ts + 1, Data: "no attributes"},
+		{LogName: logName, Priority: level.Error, Timestamp: ts + 2, Data: "testing failed", Attributes: map[string]string{"step": "test", "cmd": "make"}},
+		{LogName: logName, Priority: level.Info, Timestamp: ts + 3, Data: "data with a \"quote\" and a space", Attributes: map[string]string{"step": "compile"}},
+	}
+	require.NoError(t, svc.Append(ctx, logName, lines[:2]))
+	require.NoError(t, svc.Append(ctx, logName, lines[2:]))
+
+	readAll := func(t *testing.T, opts GetOptions) []LogLine {
+		opts.LogNames = []string{logName}
+		it, err := svc.Get(ctx, opts)
+		require.NoError(t, err)
+
+		var out []LogLine
+		for it.Next() {
+			out = append(out, it.Item())
+		}
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close())
+
+		return out
+	}
+
+	t.Run("RoundTripsAttributes", func(t *testing.T) {
+		assert.Equal(t, lines, readAll(t, GetOptions{}))
+	})
+	t.Run("FiltersByAttributes", func(t *testing.T) {
+		assert.Equal(t, []LogLine{lines[0], lines[3]}, readAll(t, GetOptions{Attributes: map[string]string{"step": "compile"}}))
+		assert.Equal(t, []LogLine{lines[2]}, readAll(t, GetOptions{Attributes: map[string]string{"step": "test", "cmd": "make"}}))
+		assert.Empty(t, readAll(t, GetOptions{Attributes: map[string]string{"step": "compile", "cmd": "DNE"}}))
+	})
+	t.Run("FiltersByAttributesBeforeSearch", func(t *testing.T) {
+		assert.Equal(t, []LogLine{lines[3]}, readAll(t, GetOptions{
+			Attributes: map[string]string{"step": "compile"},
+			Search:     &SearchOptions{Pattern: "quote"},
+		}))
+	})
+	t.Run("Compact", func(t *testing.T) {
+		result, err := svc.Compact(ctx, CompactOptions{LogNames: []string{logName}, MaxChunkLines: 10})
+		require.NoError(t, err)
+		assert.Equal(t, CompactResult{ChunksMerged: 2, ChunksCreated: 1, LinesMerged: 4}, result)
+		assert.Equal(t, lines, readAll(t, GetOptions{}))
+	})
+}
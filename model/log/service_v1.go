@@ -0,0 +1,73 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/pkg/errors"
+)
+
+// logServiceV1 stores its logs with the same chunk layout as v0, but each raw
+// line is a JSON document so that line attributes are persisted.
+type logServiceV1 struct {
+	*logServiceV0
+}
+
+// NewLogServiceV1 returns a new V1 Evergreen log service.
+func NewLogServiceV1(bucket pail.Bucket) *logServiceV1 {
+	return &logServiceV1{logServiceV0: NewLogServiceV0(bucket)}
+}
+
+func (s *logServiceV1) Get(ctx context.Context, getOpts GetOptions) (LogIterator, error) {
+	return s.get(ctx, getOpts, s.getParser)
+}
+
+func (s *logServiceV1) Append(ctx context.Context, logName string, lines []LogLine) error {
+	return s.append(ctx, logName, lines, s.formatRawLine)
+}
+
+// rawLineV1 is the storage format of a v1 log line.
+type rawLineV1 struct {
+	Priority   level.Priority    `json:"p"`
+	Timestamp  int64             `json:"t"`
+	Data       string            `json:"d"`
+	Attributes map[string]string `json:"a,omitempty"`
+}
+
+// formatRawLine formats a log line for storage as a newline-terminated JSON
+// document.
+func (s *logServiceV1) formatRawLine(line LogLine) (string, error) {
+	data, err := json.Marshal(rawLineV1{
+		Priority:   line.Priority,
+		Timestamp:  line.Timestamp,
+		Data:       strings.TrimSuffix(line.Data, "\n"),
+		Attributes: line.Attributes,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "marshalling log line")
+	}
+
+	return string(data) + "\n", nil
+}
+
+// getParser returns a function that parses a raw v1 log line into a LogLine
+// struct.
+func (s *logServiceV1) getParser(logName string) LineParser {
+	return func(data string) (LogLine, error) {
+		var rawLine rawLineV1
+		if err := json.Unmarshal([]byte(data), &rawLine); err != nil {
+			return LogLine{}, errors.Wrap(err, "unmarshalling log line")
+		}
+
+		return LogLine{
+			LogName:    logName,
+			Priority:   rawLine.Priority,
+			Timestamp:  rawLine.Timestamp,
+			Data:       rawLine.Data,
+			Attributes: rawLine.Attributes,
+		}, nil
+	}
+}
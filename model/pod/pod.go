@@ -28,6 +28,13 @@ type Pod struct {
 	Type Type `bson:"type" json:"type"`
 	// Status is the current state of the pod.
 	Status Status `bson:"status"`
+	// Platform is the container orchestration platform that runs the pod. If
+	// it's empty, the pod runs in ECS.
+	Platform Platform `bson:"platform,omitempty" json:"platform,omitempty"`
+	// ContainerPool is the name of the Kubernetes container pool that the pod
+	// runs in. If it's empty, the pod runs in the default namespace without
+	// any additional node constraints.
+	ContainerPool string `bson:"container_pool,omitempty" json:"container_pool,omitempty"`
 	// TaskCreationOpts are options to configure how a task should be
 	// containerized and run in a pod.
 	TaskContainerCreationOpts TaskContainerCreationOptions `bson:"task_creation_opts,omitempty" json:"task_creation_opts,omitempty"`
@@ -52,6 +59,13 @@ type TaskIntentPodOptions struct {
 	// ID is the pod identifier. If unspecified, it defaults to a new BSON
 	// object ID.
 	ID string
+	// Platform is the container orchestration platform that will run the
+	// pod. If unspecified, it defaults to Kubernetes if a container pool is
+	// set and ECS otherwise.
+	Platform Platform
+	// ContainerPool is the name of the Kubernetes container pool that will
+	// run the pod.
+	ContainerPool string
 
 	// The remaining fields correspond to the ones in
 	// TaskContainerCreationOptions.
@@ -78,6 +92,8 @@ func (o *TaskIntentPodOptions) Validate(ecsConf evergreen.ECSConfig) error {
 	catcher.ErrorfWhen(ecsConf.MaxMemoryMB > 0 && o.MemoryMB > ecsConf.MaxMemoryMB, "memory cannot exceed maximum global memory limit of %d MB", ecsConf.MaxCPU)
 	catcher.Wrap(o.OS.Validate(), "invalid OS")
 	catcher.Wrap(o.Arch.Validate(), "invalid CPU architecture")
+	catcher.Wrap(o.Platform.Validate(), "invalid platform")
+	catcher.ErrorfWhen(o.ContainerPool != "" && o.Platform != "" && o.Platform != PlatformKubernetes, "container pool can only be used with the Kubernetes platform")
 	if o.OS == OSWindows {
 		catcher.Wrap(o.WindowsVersion.Validate(), "must specify a valid Windows version")
 	}
@@ -94,6 +110,12 @@ func (o *TaskIntentPodOptions) Validate(ecsConf evergreen.ECSConfig) error {
 	if o.ID == "" {
 		o.ID = primitive.NewObjectID().Hex()
 	}
+	if o.Platform == "" && o.ContainerPool != "" {
+		o.Platform = PlatformKubernetes
+	}
+	if o.Platform == "" {
+		o.Platform = PlatformECS
+	}
 
 	return nil
 }
@@ -138,11 +160,17 @@ func NewTaskIntentPod(ecsConf evergreen.ECSConfig, opts TaskIntentPodOptions) (*
 		ID:                        opts.ID,
 		Status:                    StatusInitializing,
 		Type:                      TypeAgent,
+		Platform:                  opts.Platform,
+		ContainerPool:             opts.ContainerPool,
 		TaskContainerCreationOpts: containerOpts,
 		TimeInfo: TimeInfo{
 			Initializing: time.Now(),
 		},
-		Family: containerOpts.GetFamily(ecsConf),
+	}
+	// Kubernetes pods are created directly from their container options, so
+	// they don't need a pod definition.
+	if p.Platform != PlatformKubernetes {
+		p.Family = containerOpts.GetFamily(ecsConf)
 	}
 
 	return &p, nil
@@ -157,6 +185,32 @@ const (
 	TypeAgent Type = "agent"
 )
 
+// Platform is the container orchestration platform that runs a pod.
+type Platform string
+
+const (
+	// PlatformECS indicates that the pod runs in AWS ECS.
+	PlatformECS Platform = "ecs"
+	// PlatformKubernetes indicates that the pod runs in a Kubernetes cluster.
+	PlatformKubernetes Platform = "kubernetes"
+)
+
+// Validate checks that the pod platform is recognized. An empty platform is
+// valid and is equivalent to ECS.
+func (p Platform) Validate() error {
+	switch p {
+	case "", PlatformECS, PlatformKubernetes:
+		return nil
+	default:
+		return errors.Errorf("unrecognized pod platform '%s'", p)
+	}
+}
+
+// IsKubernetes returns whether or not the pod runs in Kubernetes.
+func (p *Pod) IsKubernetes() bool {
+	return p.Platform == PlatformKubernetes
+}
+
 // Status represents a possible state for a pod.
 type Status string
 
@@ -270,6 +270,25 @@ func TestNewTaskIntentPod(t *testing.T) {
 		assert.NotZero(t, p.ID)
 		assert.Equal(t, p.ID, p.TaskContainerCreationOpts.EnvVars[PodIDEnvVar])
 	})
+	t.Run("DefaultsToKubernetesWithContainerPool", func(t *testing.T) {
+		opts := makeValidOpts()
+		opts.ContainerPool = "pool"
+
+		p, err := NewTaskIntentPod(evergreen.ECSConfig{}, opts)
+		require.NoError(t, err)
+		assert.Equal(t, PlatformKubernetes, p.Platform)
+		assert.Equal(t, opts.ContainerPool, p.ContainerPool)
+		assert.Zero(t, p.Family, "Kubernetes pod should not have a pod definition family")
+	})
+	t.Run("FailsWithContainerPoolForECS", func(t *testing.T) {
+		opts := makeValidOpts()
+		opts.Platform = PlatformECS
+		opts.ContainerPool = "pool"
+
+		p, err := NewTaskIntentPod(evergreen.ECSConfig{}, opts)
+		assert.Error(t, err)
+		assert.Zero(t, p)
+	})
 	t.Run("FailsWithoutPodSecretExternalID", func(t *testing.T) {
 		opts := makeValidOpts()
 		opts.PodSecretExternalID = ""
@@ -128,8 +128,9 @@ type BuildVariantTaskUnit struct {
 	// the distros that the task can be run on
 	RunOn []string `yaml:"run_on,omitempty" bson:"run_on"`
 	// currently unsupported (TODO EVG-578)
-	ExecTimeoutSecs int   `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
-	Stepback        *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+	ExecTimeoutSecs int          `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs"`
+	Stepback        *bool        `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+	Retry           *RetryPolicy `yaml:"retry,omitempty" bson:"retry,omitempty"`
 
 	CommitQueueMerge bool `yaml:"commit_queue_merge,omitempty" bson:"commit_queue_merge"`
 
@@ -227,6 +228,9 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask, bv BuildVariant) {
 	if bvt.Stepback == nil {
 		bvt.Stepback = pt.Stepback
 	}
+	if bvt.Retry == nil {
+		bvt.Retry = pt.Retry
+	}
 
 	// Build variant level settings are lower priority than project task level
 	// settings.
@@ -248,6 +252,9 @@ func (bvt *BuildVariantTaskUnit) Populate(pt ProjectTask, bv BuildVariant) {
 	if bvt.Disable == nil {
 		bvt.Disable = bv.Disable
 	}
+	if bvt.Retry == nil {
+		bvt.Retry = bv.Retry
+	}
 }
 
 // BuildVariantsByName represents a slice of project config build variants that
@@ -409,6 +416,9 @@ type BuildVariant struct {
 	//   3. false = overriding the project setting with false
 	Stepback *bool `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
 
+	// Retry is the default retry policy for the build variant's tasks.
+	Retry *RetryPolicy `yaml:"retry,omitempty" bson:"retry,omitempty"`
+
 	// the default distros.  will be used to run a task if no distro field is
 	// provided for the task
 	RunOn []string `yaml:"run_on,omitempty" bson:"run_on"`
@@ -464,6 +474,42 @@ type Module struct {
 type Include struct {
 	FileName string `yaml:"filename,omitempty" bson:"filename,omitempty"`
 	Module   string `yaml:"module,omitempty" bson:"module,omitempty"`
+	// Owner, Repo and Ref identify another GitHub repository to include the
+	// file from. Ref must be a tag or full commit SHA so that the included
+	// file doesn't change between versions; branches are not allowed.
+	Owner string `yaml:"owner,omitempty" bson:"owner,omitempty"`
+	Repo  string `yaml:"repo,omitempty" bson:"repo,omitempty"`
+	Ref   string `yaml:"ref,omitempty" bson:"ref,omitempty"`
+}
+
+// IsRemote returns whether the file is included from another repository.
+func (i Include) IsRemote() bool {
+	return i.Owner != "" || i.Repo != "" || i.Ref != ""
+}
+
+// Validate returns an error if the include is invalid.
+func (i Include) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(i.FileName == "", "include must specify a file name")
+	if i.IsRemote() {
+		catcher.NewWhen(i.Owner == "" || i.Repo == "" || i.Ref == "", "remote include must specify an owner, repo and ref")
+		catcher.NewWhen(i.Module != "", "remote include cannot also specify a module")
+		catcher.ErrorfWhen(i.Ref != "" && !isCommitSHA(i.Ref) && strings.HasPrefix(i.Ref, "refs/") && !strings.HasPrefix(i.Ref, tagRefPrefix),
+			"remote include ref '%s' must be a tag or commit SHA, not a branch", i.Ref)
+	}
+	return catcher.Resolve()
+}
+
+// key uniquely identifies the included file within a project.
+func (i Include) key() string {
+	switch {
+	case i.IsRemote():
+		return fmt.Sprintf("%s/%s@%s:%s", i.Owner, i.Repo, i.Ref, i.FileName)
+	case i.Module != "":
+		return fmt.Sprintf("%s:%s", i.Module, i.FileName)
+	default:
+		return i.FileName
+	}
 }
 
 type ModuleList []Module
@@ -618,6 +664,16 @@ type ArtifactInstructions struct {
 type YAMLCommandSet struct {
 	SingleCommand *PluginCommandConf  `yaml:"single_command,omitempty" bson:"single_command,omitempty"`
 	MultiCommand  []PluginCommandConf `yaml:"multi_command,omitempty" bson:"multi_command,omitempty"`
+	// Parameters declares the inputs of a function. It's only set for
+	// functions that are defined with their parameters.
+	Parameters []FunctionParameter `yaml:"parameters,omitempty" bson:"parameters,omitempty"`
+}
+
+// functionDefinition is the YAML form of a function that declares its
+// parameters.
+type functionDefinition struct {
+	Parameters []FunctionParameter `yaml:"parameters,omitempty"`
+	Commands   []PluginCommandConf `yaml:"commands,omitempty"`
 }
 
 func (c *YAMLCommandSet) List() []PluginCommandConf {
@@ -640,18 +696,164 @@ func (c *YAMLCommandSet) MarshalYAML() (interface{}, error) {
 			return nil, errors.Wrap(err, "resolving params for command set")
 		}
 	}
+	if len(c.Parameters) > 0 {
+		return functionDefinition{Parameters: c.Parameters, Commands: res}, nil
+	}
 	return res, nil
 }
 
 func (c *YAMLCommandSet) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	err1 := unmarshal(&(c.MultiCommand))
+	if err1 == nil {
+		return nil
+	}
+	// A function definition can't be told apart from a single command until
+	// it's checked for its commands or parameters.
+	def := functionDefinition{}
+	if err := unmarshal(&def); err == nil && (len(def.Commands) > 0 || len(def.Parameters) > 0) {
+		c.MultiCommand = def.Commands
+		c.Parameters = def.Parameters
+		return nil
+	}
 	err2 := unmarshal(&(c.SingleCommand))
-	if err1 == nil || err2 == nil {
+	if err2 == nil {
 		return nil
 	}
 	return err1
 }
 
+// FindParameter returns the declared function parameter with the given name,
+// or nil if it's not declared.
+func (c *YAMLCommandSet) FindParameter(name string) *FunctionParameter {
+	for i := range c.Parameters {
+		if c.Parameters[i].Name == name {
+			return &c.Parameters[i]
+		}
+	}
+	return nil
+}
+
+// ValidateCall returns an error if the vars passed to a function call don't
+// match the function's declared parameters. Functions that don't declare any
+// parameters accept any vars.
+func (c *YAMLCommandSet) ValidateCall(vars map[string]string) error {
+	if len(c.Parameters) == 0 {
+		return nil
+	}
+	catcher := grip.NewBasicCatcher()
+	for _, param := range c.Parameters {
+		val, ok := vars[param.Name]
+		if !ok {
+			catcher.ErrorfWhen(param.Required, "missing required parameter '%s'", param.Name)
+			continue
+		}
+		catcher.Wrapf(param.ValidateValue(val), "invalid value for parameter '%s'", param.Name)
+	}
+	// Sort the undeclared vars so the errors are deterministic.
+	var undeclared []string
+	for name := range vars {
+		if c.FindParameter(name) == nil {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		catcher.Errorf("'%s' is not a declared parameter", name)
+	}
+	return catcher.Resolve()
+}
+
+// ResolveVars returns the vars that a function call should run with. Every
+// declared parameter is set, either to the value passed by the call, its
+// default or an empty string, so that the function never picks up a value
+// for a parameter from the surrounding expansions. Functions that don't
+// declare any parameters run with exactly the vars passed by the call.
+func (c *YAMLCommandSet) ResolveVars(vars map[string]string) map[string]string {
+	if len(c.Parameters) == 0 {
+		return vars
+	}
+	resolved := make(map[string]string, len(vars)+len(c.Parameters))
+	for _, param := range c.Parameters {
+		resolved[param.Name] = param.Default
+	}
+	for name, val := range vars {
+		resolved[name] = val
+	}
+	return resolved
+}
+
+const (
+	FunctionParameterTypeString = "string"
+	FunctionParameterTypeBool   = "bool"
+	FunctionParameterTypeInt    = "int"
+	// FunctionParameterTypeList is a whitespace-separated list of values.
+	FunctionParameterTypeList = "list"
+)
+
+// ValidFunctionParameterTypes are the types that function parameters can
+// have.
+var ValidFunctionParameterTypes = []string{
+	FunctionParameterTypeString,
+	FunctionParameterTypeBool,
+	FunctionParameterTypeInt,
+	FunctionParameterTypeList,
+}
+
+// FunctionParameter declares an input parameter of a function, which is
+// passed to the function in its vars.
+type FunctionParameter struct {
+	Name string `yaml:"name" bson:"name"`
+	// Type is the type of the parameter's value. If it's not set, the
+	// parameter is a string.
+	Type string `yaml:"type,omitempty" bson:"type,omitempty"`
+	// Required indicates that every call to the function must pass the
+	// parameter.
+	Required bool `yaml:"required,omitempty" bson:"required,omitempty"`
+	// Default is the value of the parameter if the call doesn't pass it.
+	Default     string `yaml:"default,omitempty" bson:"default,omitempty"`
+	Description string `yaml:"description,omitempty" bson:"description,omitempty"`
+}
+
+// GetType returns the type of the parameter.
+func (p FunctionParameter) GetType() string {
+	if p.Type == "" {
+		return FunctionParameterTypeString
+	}
+	return p.Type
+}
+
+// Validate returns an error if the parameter declaration is invalid.
+func (p FunctionParameter) Validate() error {
+	catcher := grip.NewBasicCatcher()
+	catcher.NewWhen(p.Name == "", "parameter name cannot be empty")
+	catcher.ErrorfWhen(!utility.StringSliceContains(ValidFunctionParameterTypes, p.GetType()), "invalid type '%s'", p.Type)
+	catcher.NewWhen(p.Required && p.Default != "", "required parameter cannot have a default")
+	if p.Default != "" {
+		catcher.Wrap(p.ValidateValue(p.Default), "invalid default")
+	}
+	return catcher.Resolve()
+}
+
+// ValidateValue returns an error if the value can't be used for the
+// parameter's type. Values that contain expansions can't be checked until
+// the task runs, so they're always considered valid.
+func (p FunctionParameter) ValidateValue(val string) error {
+	if strings.Contains(val, "${") {
+		return nil
+	}
+	switch p.GetType() {
+	case FunctionParameterTypeBool:
+		if _, err := strconv.ParseBool(val); err != nil {
+			return errors.Errorf("'%s' is not a bool", val)
+		}
+	case FunctionParameterTypeInt:
+		if _, err := strconv.Atoi(val); err != nil {
+			return errors.Errorf("'%s' is not an int", val)
+		}
+	}
+	return nil
+}
+
 // TaskUnitDependency holds configuration information about a task/group that must finish before
 // the task/group that contains the dependency can run.
 type TaskUnitDependency struct {
@@ -729,6 +931,62 @@ type ProjectTask struct {
 	AllowedRequesters []evergreen.UserRequester `yaml:"allowed_requesters,omitempty" bson:"allowed_requesters,omitempty"`
 	Stepback          *bool                     `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
 	MustHaveResults   *bool                     `yaml:"must_have_test_results,omitempty" bson:"must_have_test_results,omitempty"`
+	Retry             *RetryPolicy              `yaml:"retry,omitempty" bson:"retry,omitempty"`
+}
+
+const (
+	// RetryFailureTypeSystem retries tasks that failed due to a system
+	// failure, including system timeouts and unresponsive tasks.
+	RetryFailureTypeSystem = "system"
+	// RetryFailureTypeSetup retries tasks that failed in a setup command.
+	RetryFailureTypeSetup = "setup"
+	// RetryFailureTypeTimeout retries tasks that timed out.
+	RetryFailureTypeTimeout = "timeout"
+	// RetryFailureTypeTest retries tasks that failed in a test command.
+	RetryFailureTypeTest = "test"
+)
+
+// ValidRetryFailureTypes are the failure types that a retry policy can retry.
+var ValidRetryFailureTypes = []string{RetryFailureTypeSystem, RetryFailureTypeSetup, RetryFailureTypeTimeout, RetryFailureTypeTest}
+
+// RetryPolicy configures automatically restarting a task when it fails.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the task can run, including
+	// its first execution.
+	MaxAttempts int `yaml:"max_attempts,omitempty" bson:"max_attempts,omitempty"`
+	// FailureTypes are the types of failures that are retried.
+	FailureTypes []string `yaml:"failure_types,omitempty" bson:"failure_types,omitempty"`
+	// TestPatterns are regular expressions that limit retrying test failures
+	// to tasks whose failed tests all match one of the patterns. If empty,
+	// all test failures are retried.
+	TestPatterns []string `yaml:"test_patterns,omitempty" bson:"test_patterns,omitempty"`
+	// BackoffSecs is the number of seconds after the failure before the
+	// retried task can be scheduled.
+	BackoffSecs int `yaml:"backoff_secs,omitempty" bson:"backoff_secs,omitempty"`
+}
+
+// maxRetryBackoffSecs is the longest backoff that a retry policy can have.
+const maxRetryBackoffSecs = 24 * 60 * 60
+
+// Validate returns an error if the retry policy is invalid.
+func (p *RetryPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	catcher := grip.NewBasicCatcher()
+	catcher.ErrorfWhen(p.MaxAttempts < 2 || p.MaxAttempts > evergreen.MaxTaskExecution+1, "max attempts must be between 2 and %d", evergreen.MaxTaskExecution+1)
+	catcher.NewWhen(len(p.FailureTypes) == 0, "must specify at least one failure type to retry")
+	for _, failureType := range p.FailureTypes {
+		catcher.ErrorfWhen(!utility.StringSliceContains(ValidRetryFailureTypes, failureType), "'%s' is not a valid failure type", failureType)
+	}
+	catcher.NewWhen(len(p.TestPatterns) > 0 && !utility.StringSliceContains(p.FailureTypes, RetryFailureTypeTest), "test patterns can only be specified when retrying test failures")
+	for _, pattern := range p.TestPatterns {
+		_, err := regexp.Compile(pattern)
+		catcher.Wrapf(err, "invalid test pattern '%s'", pattern)
+	}
+	catcher.ErrorfWhen(p.BackoffSecs < 0 || p.BackoffSecs > maxRetryBackoffSecs, "backoff must be between 0 and %d seconds", maxRetryBackoffSecs)
+
+	return catcher.Resolve()
 }
 
 type LoggerConfig struct {
@@ -1649,6 +1907,7 @@ func (p *Project) tasksFromGroup(bvTaskGroup BuildVariantTaskUnit) []BuildVarian
 			RunOn:             bvTaskGroup.RunOn,
 			ExecTimeoutSecs:   bvTaskGroup.ExecTimeoutSecs,
 			Stepback:          bvTaskGroup.Stepback,
+			Retry:             bvTaskGroup.Retry,
 			Activate:          bvTaskGroup.Activate,
 			CommitQueueMerge:  bvTaskGroup.CommitQueueMerge,
 		}
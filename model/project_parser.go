@@ -75,7 +75,10 @@ type ParserProject struct {
 	UpdatedByGenerators []string `yaml:"updated_by_generators,omitempty" bson:"updated_by_generators,omitempty"`
 	// List of yamls to merge
 	Include []Include `yaml:"include,omitempty" bson:"include,omitempty"`
-	Enabled *bool     `yaml:"enabled,omitempty" bson:"enabled,omitempty"`
+	// ResolvedIncludes records the files that were included from other
+	// repositories when the includes were merged.
+	ResolvedIncludes []ResolvedInclude `yaml:"resolved_includes,omitempty" bson:"resolved_includes,omitempty"`
+	Enabled          *bool             `yaml:"enabled,omitempty" bson:"enabled,omitempty"`
 
 	// Beginning of ParserProject mergeable fields (this comment is used by the linter).
 	Stepback           *bool                      `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
@@ -154,6 +157,7 @@ type parserTask struct {
 	AllowedRequesters []evergreen.UserRequester `yaml:"allowed_requesters,omitempty" bson:"allowed_requesters,omitempty"`
 	Stepback          *bool                     `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
 	MustHaveResults   *bool                     `yaml:"must_have_test_results,omitempty" bson:"must_have_test_results,omitempty"`
+	Retry             *RetryPolicy              `yaml:"retry,omitempty" bson:"retry,omitempty"`
 }
 
 func (pp *ParserProject) Insert() error {
@@ -324,6 +328,7 @@ type parserBV struct {
 	BatchTime     *int               `yaml:"batchtime,omitempty" bson:"batchtime,omitempty"`
 	CronBatchTime string             `yaml:"cron,omitempty" bson:"cron,omitempty"`
 	Stepback      *bool              `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+	Retry         *RetryPolicy       `yaml:"retry,omitempty" bson:"retry,omitempty"`
 	RunOn         parserStringSlice  `yaml:"run_on,omitempty" bson:"run_on,omitempty"`
 	Tasks         parserBVTaskUnits  `yaml:"tasks,omitempty" bson:"tasks,omitempty"`
 	DisplayTasks  []displayTask      `yaml:"display_tasks,omitempty" bson:"display_tasks,omitempty"`
@@ -395,6 +400,7 @@ func (pbv *parserBV) canMerge() bool {
 		pbv.BatchTime == nil &&
 		pbv.CronBatchTime == "" &&
 		pbv.Stepback == nil &&
+		pbv.Retry == nil &&
 		pbv.RunOn == nil &&
 		pbv.DependsOn == nil &&
 		pbv.Activate == nil &&
@@ -422,6 +428,7 @@ type parserBVTaskUnit struct {
 	DependsOn         parserDependencies        `yaml:"depends_on,omitempty" bson:"depends_on,omitempty"`
 	ExecTimeoutSecs   int                       `yaml:"exec_timeout_secs,omitempty" bson:"exec_timeout_secs,omitempty"`
 	Stepback          *bool                     `yaml:"stepback,omitempty" bson:"stepback,omitempty"`
+	Retry             *RetryPolicy              `yaml:"retry,omitempty" bson:"retry,omitempty"`
 	Distros           parserStringSlice         `yaml:"distros,omitempty" bson:"distros,omitempty"`
 	RunOn             parserStringSlice         `yaml:"run_on,omitempty" bson:"run_on,omitempty"` // Alias for "Distros" TODO: deprecate Distros
 	CommitQueueMerge  bool                      `yaml:"commit_queue_merge,omitempty" bson:"commit_queue_merge,omitempty"`
@@ -641,7 +648,11 @@ func processIntermediateProjectIncludes(ctx context.Context, identifier string,
 		"read_from":   localOpts.ReadFileFrom,
 		"module":      include.Module,
 	})
-	if include.Module != "" {
+	var resolved *ResolvedInclude
+	if include.IsRemote() {
+		yaml, resolved, err = retrieveRemoteInclude(ctx, *localOpts, include)
+		err = errors.Wrapf(err, "%s: retrieving file for remote include '%s/%s'", LoadProjectError, include.Owner, include.Repo)
+	} else if include.Module != "" {
 		yaml, err = retrieveFileForModule(ctx, *localOpts, intermediateProject.Modules, include.Module)
 		err = errors.Wrapf(err, "%s: retrieving file for module '%s'", LoadProjectError, include.Module)
 	} else {
@@ -649,16 +660,18 @@ func processIntermediateProjectIncludes(ctx context.Context, identifier string,
 		err = errors.Wrapf(err, "%s: retrieving file for include '%s'", LoadProjectError, include.FileName)
 	}
 	outputYAMLs <- yamlTuple{
-		yaml: yaml,
-		name: include.FileName,
-		err:  err,
+		yaml:     yaml,
+		name:     include.key(),
+		resolved: resolved,
+		err:      err,
 	}
 }
 
 type yamlTuple struct {
-	yaml []byte
-	name string
-	err  error
+	yaml     []byte
+	name     string
+	resolved *ResolvedInclude
+	err      error
 }
 
 // LoadProjectInto loads the raw data from the config file into project
@@ -680,6 +693,14 @@ func LoadProjectInto(ctx context.Context, data []byte, opts *GetProjectOpts, ide
 			err = errors.New("trying to open include files with empty options")
 			return nil, errors.Wrapf(err, LoadProjectError)
 		}
+		catcher := grip.NewBasicCatcher()
+		for _, include := range intermediateProject.Include {
+			catcher.Wrapf(include.Validate(), "invalid include '%s'", include.FileName)
+		}
+		if catcher.HasErrors() {
+			return nil, errors.Wrap(catcher.Resolve(), LoadProjectError)
+		}
+
 		wg := sync.WaitGroup{}
 		outputYAMLs := make(chan yamlTuple, len(intermediateProject.Include))
 		includesToProcess := make(chan Include, len(intermediateProject.Include))
@@ -709,12 +730,15 @@ func LoadProjectInto(ctx context.Context, data []byte, opts *GetProjectOpts, ide
 		close(outputYAMLs)
 
 		yamlMap := map[string][]byte{}
-		catcher := grip.NewBasicCatcher()
+		resolvedMap := map[string]*ResolvedInclude{}
 		for elem := range outputYAMLs {
 			catcher.Add(elem.err)
 			if elem.yaml != nil {
 				yamlMap[elem.name] = elem.yaml
 			}
+			if elem.resolved != nil {
+				resolvedMap[elem.name] = elem.resolved
+			}
 		}
 
 		if catcher.HasErrors() {
@@ -723,10 +747,13 @@ func LoadProjectInto(ctx context.Context, data []byte, opts *GetProjectOpts, ide
 
 		// We promise to iterate over includes in the order they are defined.
 		for _, path := range intermediateProject.Include {
-			if _, ok := yamlMap[path.FileName]; !ok {
+			if _, ok := yamlMap[path.key()]; !ok {
 				return intermediateProject, errors.WithStack(errors.Errorf("yaml was nil in map for %s, but it never should be", path.FileName))
 			}
-			add, err := createIntermediateProject(yamlMap[path.FileName], opts.UnmarshalStrict)
+			if resolved, ok := resolvedMap[path.key()]; ok {
+				intermediateProject.ResolvedIncludes = append(intermediateProject.ResolvedIncludes, *resolved)
+			}
+			add, err := createIntermediateProject(yamlMap[path.key()], opts.UnmarshalStrict)
 			if err != nil {
 				// Return intermediateProject even if we run into issues to show merge progress.
 				return intermediateProject, errors.Wrapf(err, "%s: loading file '%s'", LoadProjectError, path.FileName)
@@ -1082,6 +1109,7 @@ func evaluateTaskUnits(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluator, v
 			GitTagOnly:      pt.GitTagOnly,
 			Stepback:        pt.Stepback,
 			MustHaveResults: pt.MustHaveResults,
+			Retry:           pt.Retry,
 		}
 		if strings.Contains(strings.TrimSpace(pt.Name), " ") {
 			evalErrs = append(evalErrs, errors.Errorf("spaces are not allowed in task names ('%s')", pt.Name))
@@ -1151,6 +1179,7 @@ func evaluateBuildVariants(tse *taskSelectorEvaluator, tgse *tagSelectorEvaluato
 			AllowForGitTag: pbv.AllowForGitTag,
 			GitTagOnly:     pbv.GitTagOnly,
 			Stepback:       pbv.Stepback,
+			Retry:          pbv.Retry,
 			RunOn:          pbv.RunOn,
 			Tags:           pbv.Tags,
 		}
@@ -1371,6 +1400,7 @@ func getParserBuildVariantTaskUnit(name string, pt parserTask, bvt parserBVTaskU
 		Priority:         bvt.Priority,
 		ExecTimeoutSecs:  bvt.ExecTimeoutSecs,
 		Stepback:         bvt.Stepback,
+		Retry:            bvt.Retry,
 		RunOn:            bvt.RunOn,
 		CommitQueueMerge: bvt.CommitQueueMerge,
 		CronBatchTime:    bvt.CronBatchTime,
@@ -1432,6 +1462,9 @@ func getParserBuildVariantTaskUnit(name string, pt parserTask, bvt parserBVTaskU
 	if res.Stepback == nil {
 		res.Stepback = pt.Stepback
 	}
+	if res.Retry == nil {
+		res.Retry = pt.Retry
+	}
 	if len(res.RunOn) == 0 {
 		// first consider that we may be using the legacy "distros" field
 		res.RunOn = bvt.Distros
@@ -1461,6 +1494,9 @@ func getParserBuildVariantTaskUnit(name string, pt parserTask, bvt parserBVTaskU
 	if res.Disable == nil {
 		res.Disable = bv.Disable
 	}
+	if res.Retry == nil {
+		res.Retry = bv.Retry
+	}
 
 	return res
 }
@@ -0,0 +1,211 @@
+package model
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/thirdparty"
+	"github.com/evergreen-ci/utility"
+	"github.com/google/go-github/v52/github"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// RemoteIncludeCollection caches the contents of files that projects include
+// from other repositories, keyed by the commit SHA they were read at.
+const RemoteIncludeCollection = "remote_includes"
+
+// ResolvedInclude records the exact file that a remote include resolved to
+// when the project was loaded, so that the version's configuration can be
+// reproduced even if the include's tag is later moved.
+type ResolvedInclude struct {
+	FileName string `yaml:"filename" bson:"filename"`
+	Owner    string `yaml:"owner" bson:"owner"`
+	Repo     string `yaml:"repo" bson:"repo"`
+	Ref      string `yaml:"ref" bson:"ref"`
+	// Revision is the commit SHA that the ref resolved to.
+	Revision string `yaml:"revision" bson:"revision"`
+	// ContentHash is the hex-encoded SHA256 hash of the included file's
+	// contents.
+	ContentHash string `yaml:"content_hash" bson:"content_hash"`
+}
+
+// remoteIncludeFile is a cached remote include file. Since the file is read
+// at a pinned commit SHA, its contents never change, so it can be cached
+// indefinitely.
+type remoteIncludeFile struct {
+	ID          string    `bson:"_id"`
+	Owner       string    `bson:"owner"`
+	Repo        string    `bson:"repo"`
+	FileName    string    `bson:"filename"`
+	Revision    string    `bson:"revision"`
+	ContentHash string    `bson:"content_hash"`
+	Content     []byte    `bson:"content"`
+	CreateTime  time.Time `bson:"create_time"`
+}
+
+var remoteIncludeFileIDKey = bsonutil.MustHaveTag(remoteIncludeFile{}, "ID")
+
+// remoteIncludeFileID returns the cache ID for the file in the repository at
+// the commit SHA.
+func remoteIncludeFileID(owner, repo, revision, fileName string) string {
+	return fmt.Sprintf("%s/%s@%s:%s", owner, repo, revision, fileName)
+}
+
+const tagRefPrefix = "refs/tags/"
+
+var commitSHARegexp = regexp.MustCompile("^[0-9a-f]{40}$")
+
+// isCommitSHA returns whether the ref is a full commit SHA.
+func isCommitSHA(ref string) bool {
+	return commitSHARegexp.MatchString(ref)
+}
+
+// retrieveRemoteInclude returns the contents of a file included from another
+// repository along with what the include resolved to. If the repository is
+// given as a local module (keyed by "owner/repo"), the file is read from the
+// local path instead and nothing is resolved.
+func retrieveRemoteInclude(ctx context.Context, opts GetProjectOpts, include Include) ([]byte, *ResolvedInclude, error) {
+	repoName := fmt.Sprintf("%s/%s", include.Owner, include.Repo)
+	if path, ok := opts.LocalModules[repoName]; ok {
+		yaml, err := retrieveFile(ctx, GetProjectOpts{
+			RemotePath:   fmt.Sprintf("%s/%s", path, include.FileName),
+			ReadFileFrom: ReadFromLocal,
+		})
+		return yaml, nil, err
+	} else if opts.ReadFileFrom == ReadFromLocal {
+		return nil, nil, errors.Errorf("local path for repository '%s' is unspecified", repoName)
+	}
+
+	// Tags are resolved every time since they can be moved, but the file at
+	// the commit SHA never changes, so it's only fetched once.
+	var token string
+	revision := include.Ref
+	if !isCommitSHA(revision) {
+		var err error
+		if token, err = createRemoteIncludeToken(ctx, include.Owner, include.Repo); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		if revision, err = resolveRemoteIncludeTag(ctx, token, include); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	fileID := remoteIncludeFileID(include.Owner, include.Repo, revision, include.FileName)
+	cached, err := findRemoteIncludeFile(fileID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "finding cached remote include file")
+	}
+	if cached != nil {
+		return cached.Content, newResolvedInclude(include, cached), nil
+	}
+
+	if token == "" {
+		if token, err = createRemoteIncludeToken(ctx, include.Owner, include.Repo); err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+	file, err := thirdparty.GetGithubFile(ctx, token, include.Owner, include.Repo, include.FileName, revision)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "fetching file '%s' from repository '%s' at revision '%s'", include.FileName, repoName, revision)
+	}
+	content, err := base64.StdEncoding.DecodeString(*file.Content)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "decoding file '%s' from repository '%s'", include.FileName, repoName)
+	}
+	cached = &remoteIncludeFile{
+		ID:          fileID,
+		Owner:       include.Owner,
+		Repo:        include.Repo,
+		FileName:    include.FileName,
+		Revision:    revision,
+		ContentHash: hashRemoteIncludeContent(content),
+		Content:     content,
+		CreateTime:  time.Now(),
+	}
+	if err = cached.insert(); err != nil {
+		return nil, nil, errors.Wrap(err, "caching remote include file")
+	}
+
+	return content, newResolvedInclude(include, cached), nil
+}
+
+func newResolvedInclude(include Include, file *remoteIncludeFile) *ResolvedInclude {
+	return &ResolvedInclude{
+		FileName:    include.FileName,
+		Owner:       include.Owner,
+		Repo:        include.Repo,
+		Ref:         include.Ref,
+		Revision:    file.Revision,
+		ContentHash: file.ContentHash,
+	}
+}
+
+// createRemoteIncludeToken returns a GitHub app installation token that can
+// only read the contents of the included repository.
+func createRemoteIncludeToken(ctx context.Context, owner, repo string) (string, error) {
+	token, err := evergreen.GetEnvironment().Settings().CreateInstallationToken(ctx, owner, repo, &github.InstallationTokenOptions{
+		Repositories: []string{repo},
+		Permissions: &github.InstallationPermissions{
+			Contents: utility.ToStringPtr("read"),
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "creating GitHub app installation token for repository '%s/%s'", owner, repo)
+	}
+	if token == "" {
+		return "", errors.Errorf("GitHub app installation token for repository '%s/%s' is empty", owner, repo)
+	}
+	return token, nil
+}
+
+// resolveRemoteIncludeTag returns the commit SHA that the include's tag
+// points to. Refs other than commit SHAs are always resolved as tags so that
+// branches can't be included.
+func resolveRemoteIncludeTag(ctx context.Context, token string, include Include) (string, error) {
+	tag := include.Ref
+	if !strings.HasPrefix(tag, tagRefPrefix) {
+		tag = tagRefPrefix + tag
+	}
+	sha, err := thirdparty.GetTaggedCommitFromGithub(ctx, token, include.Owner, include.Repo, tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving tag '%s' for repository '%s/%s'", include.Ref, include.Owner, include.Repo)
+	}
+	return sha, nil
+}
+
+func hashRemoteIncludeContent(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// findRemoteIncludeFile returns the cached remote include file with the given
+// ID, if it's been cached.
+func findRemoteIncludeFile(id string) (*remoteIncludeFile, error) {
+	file := &remoteIncludeFile{}
+	err := db.FindOneQ(RemoteIncludeCollection, db.Query(bson.M{remoteIncludeFileIDKey: id}), file)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return file, err
+}
+
+// insert caches the remote include file. It's a no-op if the file is already
+// cached.
+func (f *remoteIncludeFile) insert() error {
+	err := db.Insert(RemoteIncludeCollection, f)
+	if db.IsDuplicateKey(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIncludeValidate(t *testing.T) {
+	for tName, tCase := range map[string]struct {
+		include Include
+		isValid bool
+	}{
+		"LocalFile": {
+			include: Include{FileName: "other.yml"},
+			isValid: true,
+		},
+		"ModuleFile": {
+			include: Include{FileName: "other.yml", Module: "module"},
+			isValid: true,
+		},
+		"RemoteFile": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo", Ref: "v1.0.0"},
+			isValid: true,
+		},
+		"RemoteFileAtTagRef": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo", Ref: "refs/tags/v1.0.0"},
+			isValid: true,
+		},
+		"RemoteFileAtCommit": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo", Ref: "0123456789abcdef0123456789abcdef01234567"},
+			isValid: true,
+		},
+		"RemoteFileAtBranchRef": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo", Ref: "refs/heads/main"},
+		},
+		"RemoteFileAtOtherRef": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo", Ref: "refs/pull/1/head"},
+		},
+		"MissingFileName": {
+			include: Include{Owner: "owner", Repo: "repo", Ref: "v1.0.0"},
+		},
+		"RemoteFileWithoutRef": {
+			include: Include{FileName: "other.yml", Owner: "owner", Repo: "repo"},
+		},
+		"RemoteFileWithModule": {
+			include: Include{FileName: "other.yml", Module: "module", Owner: "owner", Repo: "repo", Ref: "v1.0.0"},
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			err := tCase.include.Validate()
+			if tCase.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadProjectWithLocalRemoteInclude(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "shared.yml"), []byte(`
+tasks:
+- name: shared_task
+`), 0644))
+	yml := `
+include:
+- filename: shared.yml
+  owner: my-org
+  repo: library
+  ref: v1.0.0
+buildvariants:
+- name: bv
+  tasks:
+  - name: shared_task
+`
+
+	ctx := context.Background()
+	t.Run("ReadsFromLocalPath", func(t *testing.T) {
+		proj := &Project{}
+		opts := &GetProjectOpts{
+			LocalModules: map[string]string{"my-org/library": dir},
+			ReadFileFrom: ReadFromLocal,
+		}
+		pp, err := LoadProjectInto(ctx, []byte(yml), opts, "id", proj)
+		require.NoError(t, err)
+		assert.NotNil(t, proj.FindProjectTask("shared_task"))
+		assert.Empty(t, pp.ResolvedIncludes)
+	})
+	t.Run("FailsWithoutLocalPath", func(t *testing.T) {
+		proj := &Project{}
+		opts := &GetProjectOpts{
+			ReadFileFrom: ReadFromLocal,
+		}
+		_, err := LoadProjectInto(ctx, []byte(yml), opts, "id", proj)
+		assert.Error(t, err)
+	})
+}
+
+func TestRemoteIncludeCache(t *testing.T) {
+	require.NoError(t, db.Clear(RemoteIncludeCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(RemoteIncludeCollection))
+	}()
+
+	content := []byte("tasks:\n- name: shared_task\n")
+	const revision = "0123456789abcdef0123456789abcdef01234567"
+	fileID := remoteIncludeFileID("my-org", "library", revision, "shared.yml")
+	otherFileID := remoteIncludeFileID("my-org", "library", "89abcdef0123456789abcdef0123456789abcdef", "shared.yml")
+
+	cached, err := findRemoteIncludeFile(fileID)
+	require.NoError(t, err)
+	assert.Nil(t, cached)
+
+	file := remoteIncludeFile{
+		ID:          fileID,
+		Owner:       "my-org",
+		Repo:        "library",
+		FileName:    "shared.yml",
+		Revision:    revision,
+		ContentHash: hashRemoteIncludeContent(content),
+		Content:     content,
+		CreateTime:  time.Now(),
+	}
+	require.NoError(t, file.insert())
+	require.NoError(t, file.insert(), "caching the same file again should no-op")
+
+	cached, err = findRemoteIncludeFile(fileID)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, revision, cached.Revision)
+	assert.Equal(t, file.ContentHash, cached.ContentHash)
+	assert.Equal(t, content, cached.Content)
+
+	cached, err = findRemoteIncludeFile(otherFileID)
+	require.NoError(t, err)
+	assert.Nil(t, cached, "file at a different commit should not be cached")
+
+	t.Run("ReadsPinnedCommitFromCache", func(t *testing.T) {
+		// A commit SHA is read from the cache without accessing GitHub.
+		readContent, resolved, err := retrieveRemoteInclude(context.Background(), GetProjectOpts{}, Include{
+			FileName: "shared.yml",
+			Owner:    "my-org",
+			Repo:     "library",
+			Ref:      revision,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, content, readContent)
+		require.NotNil(t, resolved)
+		assert.Equal(t, revision, resolved.Revision)
+		assert.Equal(t, revision, resolved.Ref)
+		assert.Equal(t, file.ContentHash, resolved.ContentHash)
+	})
+
+	count, err := db.Count(RemoteIncludeCollection, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
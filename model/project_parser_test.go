@@ -1856,6 +1856,58 @@ buildvariants:
 	assert.Nil(t, proj.BuildVariants[2].Tasks[0].GitTagOnly)
 }
 
+func TestRetryPolicyParsing(t *testing.T) {
+	yml := `
+tasks:
+- name: task_1
+  retry:
+    max_attempts: 3
+    failure_types: [system, setup]
+- name: task_2
+buildvariants:
+- name: bv_1
+  display_name: "bv_display"
+  retry:
+    max_attempts: 2
+    failure_types: [test]
+    test_patterns: ["^TestFlaky"]
+    backoff_secs: 60
+  tasks:
+  - name: task_1
+  - name: task_2
+- name: bv_2
+  display_name: "bv_display"
+  tasks:
+  - name: task_1
+    retry:
+      max_attempts: 4
+      failure_types: [timeout]
+  - name: task_2
+`
+
+	proj := &Project{}
+	ctx := context.Background()
+	_, err := LoadProjectInto(ctx, []byte(yml), nil, "id", proj)
+	require.NoError(t, err)
+	require.Len(t, proj.BuildVariants, 2)
+
+	taskPolicy := &RetryPolicy{MaxAttempts: 3, FailureTypes: []string{RetryFailureTypeSystem, RetryFailureTypeSetup}}
+	bvPolicy := &RetryPolicy{MaxAttempts: 2, FailureTypes: []string{RetryFailureTypeTest}, TestPatterns: []string{"^TestFlaky"}, BackoffSecs: 60}
+	assert.Equal(t, taskPolicy, proj.FindProjectTask("task_1").Retry)
+	assert.Nil(t, proj.FindProjectTask("task_2").Retry)
+	assert.Equal(t, bvPolicy, proj.BuildVariants[0].Retry)
+
+	// The task's policy takes precedence over the build variant's.
+	require.Len(t, proj.BuildVariants[0].Tasks, 2)
+	assert.Equal(t, taskPolicy, proj.BuildVariants[0].Tasks[0].Retry)
+	assert.Equal(t, bvPolicy, proj.BuildVariants[0].Tasks[1].Retry)
+
+	// The build variant task's policy takes precedence over the task's.
+	require.Len(t, proj.BuildVariants[1].Tasks, 2)
+	assert.Equal(t, &RetryPolicy{MaxAttempts: 4, FailureTypes: []string{RetryFailureTypeTimeout}}, proj.BuildVariants[1].Tasks[0].Retry)
+	assert.Nil(t, proj.BuildVariants[1].Tasks[1].Retry)
+}
+
 func TestLoggerConfig(t *testing.T) {
 	assert := assert.New(t)
 	yml := `
@@ -23,6 +23,7 @@ import (
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/user"
 	"github.com/evergreen-ci/evergreen/thirdparty"
@@ -62,6 +63,7 @@ type ProjectRef struct {
 	DispatchingDisabled    *bool               `bson:"dispatching_disabled,omitempty" json:"dispatching_disabled,omitempty" yaml:"dispatching_disabled"`
 	StepbackDisabled       *bool               `bson:"stepback_disabled,omitempty" json:"stepback_disabled,omitempty" yaml:"stepback_disabled"`
 	StepbackBisect         *bool               `bson:"stepback_bisect,omitempty" json:"stepback_bisect,omitempty" yaml:"stepback_bisect"`
+	StepbackTestBisect     *bool               `bson:"stepback_test_bisect,omitempty" json:"stepback_test_bisect,omitempty" yaml:"stepback_test_bisect"`
 	VersionControlEnabled  *bool               `bson:"version_control_enabled,omitempty" json:"version_control_enabled,omitempty" yaml:"version_control_enabled"`
 	PRTestingEnabled       *bool               `bson:"pr_testing_enabled,omitempty" json:"pr_testing_enabled,omitempty" yaml:"pr_testing_enabled"`
 	ManualPRTestingEnabled *bool               `bson:"manual_pr_testing_enabled,omitempty" json:"manual_pr_testing_enabled,omitempty" yaml:"manual_pr_testing_enabled"`
@@ -102,6 +104,16 @@ type ProjectRef struct {
 	// Disable task stats caching for this project.
 	DisabledStatsCache *bool `bson:"disabled_stats_cache,omitempty" json:"disabled_stats_cache,omitempty"`
 
+	// TaskLogRetentionDays is the number of days to keep the task and test
+	// logs stored by the Evergreen log service. If unset, logs are kept
+	// indefinitely.
+	TaskLogRetentionDays int `bson:"task_log_retention_days,omitempty" json:"task_log_retention_days,omitempty" yaml:"task_log_retention_days,omitempty"`
+
+	// MonthlyBudget is how much the project expects to spend running tasks
+	// each month. If it is set, subscribers are notified when the project's
+	// estimated spend for the month exceeds it.
+	MonthlyBudget float64 `bson:"monthly_budget,omitempty" json:"monthly_budget,omitempty" yaml:"monthly_budget,omitempty"`
+
 	// List of commands
 	// Lacks omitempty so that SetupCommands can be identified as either [] or nil in a ProjectSettingsEvent
 	WorkstationConfig WorkstationConfig `bson:"workstation_config" json:"workstation_config"`
@@ -116,6 +128,13 @@ type ProjectRef struct {
 	// Container settings
 	ContainerSizeDefinitions []ContainerResources `bson:"container_size_definitions,omitempty" json:"container_size_definitions,omitempty" yaml:"container_size_definitions,omitempty"`
 	ContainerSecrets         []ContainerSecret    `bson:"container_secrets,omitempty" json:"container_secrets,omitempty" yaml:"container_secrets,omitempty"`
+	// PodPlatform is the container orchestration platform that runs the
+	// project's container tasks. If it's empty, they run in ECS.
+	PodPlatform pod.Platform `bson:"pod_platform,omitempty" json:"pod_platform,omitempty" yaml:"pod_platform,omitempty"`
+	// PodContainerPool is the name of the Kubernetes container pool that runs
+	// the project's container tasks. Setting a container pool implies that
+	// the tasks run in Kubernetes.
+	PodContainerPool string `bson:"pod_container_pool,omitempty" json:"pod_container_pool,omitempty" yaml:"pod_container_pool,omitempty"`
 
 	RepoRefId string `bson:"repo_ref_id" json:"repo_ref_id" yaml:"repo_ref_id"`
 
@@ -319,6 +338,8 @@ var (
 	ProjectRefHiddenKey                   = bsonutil.MustHaveTag(ProjectRef{}, "Hidden")
 	ProjectRefRepotrackerErrorKey         = bsonutil.MustHaveTag(ProjectRef{}, "RepotrackerError")
 	ProjectRefDisabledStatsCacheKey       = bsonutil.MustHaveTag(ProjectRef{}, "DisabledStatsCache")
+	projectRefTaskLogRetentionDaysKey     = bsonutil.MustHaveTag(ProjectRef{}, "TaskLogRetentionDays")
+	ProjectRefMonthlyBudgetKey            = bsonutil.MustHaveTag(ProjectRef{}, "MonthlyBudget")
 	ProjectRefAdminsKey                   = bsonutil.MustHaveTag(ProjectRef{}, "Admins")
 	ProjectRefGitTagAuthorizedUsersKey    = bsonutil.MustHaveTag(ProjectRef{}, "GitTagAuthorizedUsers")
 	ProjectRefGitTagAuthorizedTeamsKey    = bsonutil.MustHaveTag(ProjectRef{}, "GitTagAuthorizedTeams")
@@ -334,6 +355,7 @@ var (
 	projectRefDispatchingDisabledKey      = bsonutil.MustHaveTag(ProjectRef{}, "DispatchingDisabled")
 	projectRefStepbackDisabledKey         = bsonutil.MustHaveTag(ProjectRef{}, "StepbackDisabled")
 	projectRefStepbackBisectKey           = bsonutil.MustHaveTag(ProjectRef{}, "StepbackBisect")
+	projectRefStepbackTestBisectKey       = bsonutil.MustHaveTag(ProjectRef{}, "StepbackTestBisect")
 	projectRefVersionControlEnabledKey    = bsonutil.MustHaveTag(ProjectRef{}, "VersionControlEnabled")
 	projectRefNotifyOnFailureKey          = bsonutil.MustHaveTag(ProjectRef{}, "NotifyOnBuildFailure")
 	projectRefSpawnHostScriptPathKey      = bsonutil.MustHaveTag(ProjectRef{}, "SpawnHostScriptPath")
@@ -347,6 +369,8 @@ var (
 	projectRefPerfEnabledKey              = bsonutil.MustHaveTag(ProjectRef{}, "PerfEnabled")
 	projectRefContainerSecretsKey         = bsonutil.MustHaveTag(ProjectRef{}, "ContainerSecrets")
 	projectRefContainerSizeDefinitionsKey = bsonutil.MustHaveTag(ProjectRef{}, "ContainerSizeDefinitions")
+	projectRefPodPlatformKey              = bsonutil.MustHaveTag(ProjectRef{}, "PodPlatform")
+	projectRefPodContainerPoolKey         = bsonutil.MustHaveTag(ProjectRef{}, "PodContainerPool")
 	projectRefExternalLinksKey            = bsonutil.MustHaveTag(ProjectRef{}, "ExternalLinks")
 	projectRefBannerKey                   = bsonutil.MustHaveTag(ProjectRef{}, "Banner")
 	projectRefParsleyFiltersKey           = bsonutil.MustHaveTag(ProjectRef{}, "ParsleyFilters")
@@ -391,6 +415,14 @@ func (p *ProjectRef) IsStepbackBisect() bool {
 	return utility.FromBoolPtr(p.StepbackBisect)
 }
 
+// IsStepbackTestBisect returns whether bisect stepback should continue past
+// the culprit task to find the commit that broke each failing test. It only
+// applies to projects that use bisect stepback, since linear stepback does
+// not track the culprit task.
+func (p *ProjectRef) IsStepbackTestBisect() bool {
+	return utility.FromBoolPtr(p.StepbackTestBisect)
+}
+
 func (p *ProjectRef) IsAutoPRTestingEnabled() bool {
 	return utility.FromBoolPtr(p.PRTestingEnabled)
 }
@@ -430,6 +462,17 @@ func (p *ProjectRef) IsStatsCacheDisabled() bool {
 	return utility.FromBoolPtr(p.DisabledStatsCache)
 }
 
+// GetTaskLogRetentionCutoff returns the time before which the project's task
+// and test logs should be deleted. Returns the zero time if the project does
+// not set a log retention period.
+func (p *ProjectRef) GetTaskLogRetentionCutoff(now time.Time) time.Time {
+	if p.TaskLogRetentionDays <= 0 {
+		return time.Time{}
+	}
+
+	return now.AddDate(0, 0, -p.TaskLogRetentionDays)
+}
+
 func (p *ProjectRef) IsHidden() bool {
 	return utility.FromBoolPtr(p.Hidden)
 }
@@ -1985,12 +2028,15 @@ func SaveProjectPageForSection(projectId string, p *ProjectRef, section ProjectP
 			projectRefDispatchingDisabledKey:   p.DispatchingDisabled,
 			projectRefStepbackDisabledKey:      p.StepbackDisabled,
 			projectRefStepbackBisectKey:        p.StepbackBisect,
+			projectRefStepbackTestBisectKey:    p.StepbackTestBisect,
 			projectRefVersionControlEnabledKey: p.VersionControlEnabled,
 			ProjectRefDeactivatePreviousKey:    p.DeactivatePrevious,
 			projectRefRepotrackerDisabledKey:   p.RepotrackerDisabled,
 			projectRefPatchingDisabledKey:      p.PatchingDisabled,
 			projectRefTaskSyncKey:              p.TaskSync,
 			ProjectRefDisabledStatsCacheKey:    p.DisabledStatsCache,
+			projectRefTaskLogRetentionDaysKey:  p.TaskLogRetentionDays,
+			ProjectRefMonthlyBudgetKey:         p.MonthlyBudget,
 		}
 		// Unlike other fields, this will only be set if we're actually modifying it since it's used by the backend.
 		if p.TracksPushEvents != nil {
@@ -2121,13 +2167,19 @@ func SaveProjectPageForSection(projectId string, p *ProjectRef, section ProjectP
 				catcher.Add(errors.Wrapf(err, "validating container size '%s'", size.Name))
 			}
 		}
+		catcher.Wrap(p.PodPlatform.Validate(), "invalid pod platform")
+		catcher.ErrorfWhen(p.PodContainerPool != "" && p.PodPlatform == pod.PlatformECS, "container pool can only be used with the Kubernetes pod platform")
 		if catcher.HasErrors() {
-			return false, errors.Wrapf(catcher.Resolve(), "validating container size definitions")
+			return false, errors.Wrapf(catcher.Resolve(), "validating container settings")
 		}
 		err = db.Update(coll,
 			bson.M{ProjectRefIdKey: projectId},
 			bson.M{
-				"$set": bson.M{projectRefContainerSizeDefinitionsKey: p.ContainerSizeDefinitions},
+				"$set": bson.M{
+					projectRefContainerSizeDefinitionsKey: p.ContainerSizeDefinitions,
+					projectRefPodPlatformKey:              p.PodPlatform,
+					projectRefPodContainerPoolKey:         p.PodContainerPool,
+				},
 			})
 	case ProjectPageViewsAndFiltersSection:
 		err = db.Update(coll,
@@ -1892,6 +1892,32 @@ func TestCommandsRunOnTV(t *testing.T) {
 	}
 }
 
+func TestFunctionParameters(t *testing.T) {
+	fn := &YAMLCommandSet{
+		MultiCommand: []PluginCommandConf{{Command: "shell.exec"}},
+		Parameters: []FunctionParameter{
+			{Name: "suite", Required: true},
+			{Name: "jobs", Type: FunctionParameterTypeInt, Default: "4"},
+			{Name: "verbose", Type: FunctionParameterTypeBool},
+		},
+	}
+
+	t.Run("ValidateCall", func(t *testing.T) {
+		assert.NoError(t, fn.ValidateCall(map[string]string{"suite": "unit"}))
+		assert.NoError(t, fn.ValidateCall(map[string]string{"suite": "unit", "jobs": "${num_jobs}", "verbose": "false"}))
+		assert.Error(t, fn.ValidateCall(nil))
+		assert.Error(t, fn.ValidateCall(map[string]string{"suite": "unit", "jobs": "four"}))
+		assert.Error(t, fn.ValidateCall(map[string]string{"suite": "unit", "other": "value"}))
+		assert.NoError(t, (&YAMLCommandSet{}).ValidateCall(map[string]string{"other": "value"}))
+	})
+	t.Run("ResolveVars", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"suite": "unit", "jobs": "4", "verbose": ""}, fn.ResolveVars(map[string]string{"suite": "unit"}))
+		assert.Equal(t, map[string]string{"suite": "unit", "jobs": "8", "verbose": "true"}, fn.ResolveVars(map[string]string{"suite": "unit", "jobs": "8", "verbose": "true"}))
+		vars := map[string]string{"other": "value"}
+		assert.Equal(t, vars, (&YAMLCommandSet{}).ResolveVars(vars))
+	})
+}
+
 func TestCommandsRunOnBV(t *testing.T) {
 	cmd := evergreen.S3PullCommandName
 	variant := "variant"
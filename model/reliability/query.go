@@ -89,22 +89,8 @@ type TaskReliability struct {
 // https://en.wikipedia.org/wiki/Binomial_proportion_confidence_interval#Wilson_score_interval
 // and return the lower value (for success rates).
 func (s *TaskReliability) calculateSuccessRate() {
-	total := float64(s.NumTotal)
-	success := float64(s.NumSuccess)
-	low := 0.0
-	high := 0.0
-	p := 0.0
-
-	if total != 0 {
-		p = success / total
-
-		dist := s.Z * math.Sqrt((p*(1.-p)+s.Z*s.Z/(4.*total))/total)
-		denominator := 1. + s.Z*s.Z/total
-		c1 := p + s.Z*s.Z/(2.*total)
-		high = math.Min(1, (c1+dist)/denominator)
-		low = math.Max(0, (c1-dist)/denominator)
-	}
-	s.SuccessRate = (math.Ceil(low*100) / 100)
+	low, p, high := wilsonScoreInterval(s.NumSuccess, s.NumTotal, s.Z)
+	s.SuccessRate = roundUpRate(low)
 	grip.Info(message.Fields{
 		"message":      "calculated task success rate",
 		"num_success":  s.NumSuccess,
@@ -118,6 +104,38 @@ func (s *TaskReliability) calculateSuccessRate() {
 	})
 }
 
+// wilsonScoreInterval returns the low and high bounds of the Wilson score
+// interval for the given number of successes out of the total, along with the
+// observed success proportion p.
+func wilsonScoreInterval(numSuccess, numTotal int, z float64) (low, p, high float64) {
+	if numTotal == 0 {
+		return 0, 0, 0
+	}
+
+	total := float64(numTotal)
+	p = float64(numSuccess) / total
+
+	dist := z * math.Sqrt((p*(1.-p)+z*z/(4.*total))/total)
+	denominator := 1. + z*z/total
+	c1 := p + z*z/(2.*total)
+	high = math.Min(1, (c1+dist)/denominator)
+	low = math.Max(0, (c1-dist)/denominator)
+	return low, p, high
+}
+
+// wilsonLowerBound returns the success rate reported for the given number of
+// successes out of the total, which is the lower bound of the Wilson score
+// interval.
+func wilsonLowerBound(numSuccess, numTotal int, z float64) float64 {
+	low, _, _ := wilsonScoreInterval(numSuccess, numTotal, z)
+	return roundUpRate(low)
+}
+
+// roundUpRate rounds the rate up to two decimal places.
+func roundUpRate(rate float64) float64 {
+	return math.Ceil(rate*100) / 100
+}
+
 // Create a TaskReliability struct from the task stats and calculate the success rate
 // using the z score.
 func newTaskReliability(taskStat taskstats.TaskStats, z float64) TaskReliability {
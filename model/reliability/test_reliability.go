@@ -0,0 +1,203 @@
+package reliability
+
+// This file provides the test-level equivalent of the task reliability
+// queries. Test reliability scores are computed from the pre-computed
+// daily_test_stats documents (see teststats.db.go) and always grouped by
+// test, task and build variant for each date period.
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/teststats"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TestReliabilityFilter represents search and aggregation parameters when
+// querying test statistics. Distros and GroupBy are ignored since test
+// statistics are not recorded per distro.
+type TestReliabilityFilter struct {
+	TaskReliabilityFilter
+	Tests []string
+	// StartAtTest is the name of the test at which to resume the query when
+	// paginating. The remainder of the position is given by StartAt.
+	StartAtTest string
+}
+
+// ValidateForTestReliability validates that the filter is valid for use with
+// test stats.
+func (f *TestReliabilityFilter) ValidateForTestReliability() error {
+	catcher := grip.NewBasicCatcher()
+
+	catcher.NewWhen(f.Project == "", "missing project")
+	catcher.NewWhen(f.GroupNumDays <= 0, "invalid group num days")
+	catcher.NewWhen(len(f.Requesters) == 0, "missing requesters")
+	catcher.NewWhen(f.Sort != taskstats.SortEarliestFirst && f.Sort != taskstats.SortLatestFirst, "invalid sort")
+
+	catcher.NewWhen(!f.AfterDate.Equal(utility.GetUTCDay(f.AfterDate)), "invalid 'after' date")
+	catcher.NewWhen(!f.BeforeDate.Equal(utility.GetUTCDay(f.BeforeDate)), "invalid 'before' date")
+	catcher.NewWhen(f.BeforeDate.Before(f.AfterDate), "'after' date restriction must be earlier than 'before' date restriction")
+
+	catcher.NewWhen(f.Limit > MaxQueryLimit || f.Limit <= 0, "invalid limit")
+	catcher.NewWhen(f.Significance > MaxSignificanceLimit || f.Significance < MinSignificanceLimit, "invalid significance")
+	catcher.NewWhen(len(f.Tests) == 0, "missing tests")
+
+	if f.StartAt != nil {
+		catcher.NewWhen(!f.StartAt.Date.Equal(utility.GetUTCDay(f.StartAt.Date)), "invalid 'start at' date")
+		catcher.NewWhen(f.StartAt.BuildVariant == "", "missing 'start at' build variant")
+		catcher.NewWhen(f.StartAt.Task == "", "missing 'start at' task name")
+		catcher.NewWhen(f.StartAtTest == "", "missing 'start at' test name")
+	}
+
+	return catcher.Resolve()
+}
+
+// buildTestPaginationOrBranches builds an expression for the conditions
+// imposed by the filter StartAt and StartAtTest fields.
+func (filter TestReliabilityFilter) buildTestPaginationOrBranches() []bson.M {
+	var nextDate interface{}
+	if filter.GroupNumDays > 1 {
+		nextDate = filter.StartAt.Date
+	}
+
+	return taskstats.BuildPaginationOrBranches([]taskstats.PaginationField{
+		{Field: teststats.DBTestStatsIDDateKeyFull, Descending: filter.Sort == taskstats.SortLatestFirst, Strict: true, Value: filter.StartAt.Date, NextValue: nextDate},
+		{Field: teststats.DBTestStatsIDBuildVariantKeyFull, Strict: true, Value: filter.StartAt.BuildVariant},
+		{Field: teststats.DBTestStatsIDTaskNameKeyFull, Strict: true, Value: filter.StartAt.Task},
+		{Field: teststats.DBTestStatsIDTestNameKeyFull, Strict: true, Value: filter.StartAtTest},
+	})
+}
+
+// buildMatchStageForTest builds the match stage of the test query pipeline
+// based on the filter options.
+func (filter TestReliabilityFilter) buildMatchStageForTest() bson.M {
+	boundaries := filter.dateBoundaries()
+
+	match := bson.M{
+		teststats.DBTestStatsIDDateKeyFull: bson.M{
+			"$lt":  boundaries[0],
+			"$gte": boundaries[len(boundaries)-1],
+		},
+		teststats.DBTestStatsIDProjectKeyFull:   filter.Project,
+		teststats.DBTestStatsIDRequesterKeyFull: bson.M{"$in": filter.Requesters},
+		teststats.DBTestStatsIDTestNameKeyFull:  taskstats.BuildMatchArrayExpression(filter.Tests),
+	}
+	if len(filter.Tasks) > 0 {
+		match[teststats.DBTestStatsIDTaskNameKeyFull] = taskstats.BuildMatchArrayExpression(filter.Tasks)
+	}
+	if len(filter.BuildVariants) > 0 {
+		match[teststats.DBTestStatsIDBuildVariantKeyFull] = taskstats.BuildMatchArrayExpression(filter.BuildVariants)
+	}
+
+	if filter.StartAt != nil {
+		match["$or"] = filter.buildTestPaginationOrBranches()
+	}
+	return bson.M{"$match": match}
+}
+
+// testReliabilityQueryPipeline creates an aggregation pipeline to query test
+// statistics for reliability.
+func (filter TestReliabilityFilter) testReliabilityQueryPipeline() []bson.M {
+	return []bson.M{
+		filter.buildMatchStageForTest(),
+		{"$group": bson.M{
+			"_id": bson.M{
+				"date":      filter.buildDateStageGroupID("date", teststats.DBTestStatsIDDateKeyFull),
+				"variant":   "$" + teststats.DBTestStatsIDBuildVariantKeyFull,
+				"task_name": "$" + teststats.DBTestStatsIDTaskNameKeyFull,
+				"test_name": "$" + teststats.DBTestStatsIDTestNameKeyFull,
+			},
+			teststats.DBTestStatsNumPassKey:    bson.M{"$sum": "$" + teststats.DBTestStatsNumPassKey},
+			teststats.DBTestStatsNumFailKey:    bson.M{"$sum": "$" + teststats.DBTestStatsNumFailKey},
+			teststats.DBTestStatsLastUpdateKey: bson.M{"$max": "$" + teststats.DBTestStatsLastUpdateKey},
+			"total_duration_pass":              bson.M{"$sum": bson.M{"$multiply": taskstats.Array{"$" + teststats.DBTestStatsNumPassKey, "$" + teststats.DBTestStatsAvgDurationPassKey}}},
+		}},
+		{"$project": bson.M{
+			"_id":                              0,
+			"test_name":                        "$_id.test_name",
+			"task_name":                        "$_id.task_name",
+			"variant":                          "$_id.variant",
+			"date":                             "$_id.date",
+			teststats.DBTestStatsNumPassKey:    1,
+			teststats.DBTestStatsNumFailKey:    1,
+			teststats.DBTestStatsLastUpdateKey: 1,
+			"num_total":                        bson.M{"$add": taskstats.Array{"$" + teststats.DBTestStatsNumPassKey, "$" + teststats.DBTestStatsNumFailKey}},
+			teststats.DBTestStatsAvgDurationPassKey: bson.M{"$cond": bson.M{"if": bson.M{"$ne": taskstats.Array{"$" + teststats.DBTestStatsNumPassKey, 0}},
+				"then": bson.M{"$divide": taskstats.Array{"$total_duration_pass", "$" + teststats.DBTestStatsNumPassKey}},
+				"else": nil}},
+		}},
+		{"$sort": bson.D{
+			{Key: "date", Value: taskstats.SortDateOrder(filter.Sort)},
+			{Key: "variant", Value: 1},
+			{Key: "task_name", Value: 1},
+			{Key: "test_name", Value: 1},
+		}},
+		{"$limit": filter.Limit},
+	}
+}
+
+// GetTestStats creates an aggregation to find test stats matching the filter
+// state.
+func (filter TestReliabilityFilter) GetTestStats() ([]teststats.TestStats, error) {
+	var testStats []teststats.TestStats
+	err := db.Aggregate(teststats.DailyTestStatsCollection, filter.testReliabilityQueryPipeline(), &testStats)
+	return testStats, err
+}
+
+// TestReliability represents test execution statistics.
+type TestReliability struct {
+	TestName        string
+	TaskName        string
+	BuildVariant    string
+	Date            time.Time
+	NumTotal        int
+	NumPass         int
+	NumFail         int
+	AvgDurationPass float64
+	SuccessRate     float64
+	Z               float64
+	LastUpdate      time.Time
+}
+
+// Create a TestReliability struct from the test stats and calculate the
+// success rate using the z score.
+func newTestReliability(testStat teststats.TestStats, z float64) TestReliability {
+	return TestReliability{
+		TestName:        testStat.TestName,
+		TaskName:        testStat.TaskName,
+		BuildVariant:    testStat.BuildVariant,
+		Date:            testStat.Date,
+		NumTotal:        testStat.NumTotal,
+		NumPass:         testStat.NumPass,
+		NumFail:         testStat.NumFail,
+		AvgDurationPass: testStat.AvgDurationPass,
+		SuccessRate:     wilsonLowerBound(testStat.NumPass, testStat.NumTotal, z),
+		Z:               z,
+		LastUpdate:      testStat.LastUpdate,
+	}
+}
+
+// GetTestReliabilityScores queries the precomputed test statistics using a
+// filter and then calculates the success reliability score from the lower
+// bound wilson confidence interval, in the same way as
+// GetTaskReliabilityScores.
+func GetTestReliabilityScores(filter TestReliabilityFilter) ([]TestReliability, error) {
+	if err := filter.ValidateForTestReliability(); err != nil {
+		return nil, errors.Wrap(err, "invalid stats filter")
+	}
+	testStats, err := filter.GetTestStats()
+	if err != nil {
+		return nil, errors.Wrap(err, "aggregating test statistics")
+	}
+
+	scores := make([]TestReliability, len(testStats))
+	z := significanceToZ(filter.Significance)
+	for i, testStat := range testStats {
+		scores[i] = newTestReliability(testStat, z)
+	}
+	return scores, nil
+}
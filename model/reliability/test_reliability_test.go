@@ -0,0 +1,188 @@
+package reliability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/teststats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createValidTestFilter() TestReliabilityFilter {
+	return TestReliabilityFilter{
+		TaskReliabilityFilter: TaskReliabilityFilter{
+			StatsFilter: taskstats.StatsFilter{
+				Project:      project,
+				Requesters:   requesters,
+				AfterDate:    day1,
+				BeforeDate:   day2,
+				GroupBy:      taskstats.GroupByVariant,
+				GroupNumDays: 1,
+				Sort:         taskstats.SortLatestFirst,
+				Limit:        MaxQueryLimit,
+			},
+			Significance: DefaultSignificance,
+		},
+		Tests: []string{"test1"},
+	}
+}
+
+func TestValidateForTestReliability(t *testing.T) {
+	for name, test := range map[string]struct {
+		modify func(*TestReliabilityFilter)
+		valid  bool
+	}{
+		"Valid": {
+			modify: func(*TestReliabilityFilter) {},
+			valid:  true,
+		},
+		"ValidWithoutTasks": {
+			modify: func(f *TestReliabilityFilter) { f.Tasks = nil },
+			valid:  true,
+		},
+		"ValidWithStartAt": {
+			modify: func(f *TestReliabilityFilter) {
+				f.StartAt = &taskstats.StartAt{Date: day1, BuildVariant: "v1", Task: "task1"}
+				f.StartAtTest = "test1"
+			},
+			valid: true,
+		},
+		"MissingTests": {
+			modify: func(f *TestReliabilityFilter) { f.Tests = nil },
+		},
+		"MissingRequesters": {
+			modify: func(f *TestReliabilityFilter) { f.Requesters = nil },
+		},
+		"AfterDateAfterBeforeDate": {
+			modify: func(f *TestReliabilityFilter) { f.AfterDate, f.BeforeDate = f.BeforeDate, f.AfterDate },
+		},
+		"AfterDateNotUTCDay": {
+			modify: func(f *TestReliabilityFilter) { f.AfterDate = f.AfterDate.Add(time.Hour) },
+		},
+		"InvalidLimit": {
+			modify: func(f *TestReliabilityFilter) { f.Limit = 0 },
+		},
+		"InvalidSignificance": {
+			modify: func(f *TestReliabilityFilter) { f.Significance = 1.5 },
+		},
+		"InvalidSort": {
+			modify: func(f *TestReliabilityFilter) { f.Sort = "sideways" },
+		},
+		"StartAtMissingTest": {
+			modify: func(f *TestReliabilityFilter) {
+				f.StartAt = &taskstats.StartAt{Date: day1, BuildVariant: "v1", Task: "task1"}
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			filter := createValidTestFilter()
+			test.modify(&filter)
+			if test.valid {
+				assert.NoError(t, filter.ValidateForTestReliability())
+			} else {
+				assert.Error(t, filter.ValidateForTestReliability())
+			}
+		})
+	}
+}
+
+func TestWilsonLowerBound(t *testing.T) {
+	z := significanceToZ(DefaultSignificance)
+
+	assert.Equal(t, 0.0, wilsonLowerBound(0, 0, z))
+	assert.InDelta(t, 0.0, wilsonLowerBound(0, 10, z), 0.01)
+	assert.True(t, wilsonLowerBound(10, 10, z) < 1.0, "small samples should not be perfectly reliable")
+	assert.True(t, wilsonLowerBound(100, 100, z) > wilsonLowerBound(10, 10, z), "larger samples should be more reliable")
+	assert.True(t, wilsonLowerBound(90, 100, z) < wilsonLowerBound(99, 100, z))
+
+	taskReliability := newTaskReliability(taskstats.TaskStats{NumSuccess: 9, NumFailed: 1, NumTotal: 10}, z)
+	testReliability := newTestReliability(teststats.TestStats{NumPass: 9, NumFail: 1, NumTotal: 10}, z)
+	assert.Equal(t, taskReliability.SuccessRate, testReliability.SuccessRate, "test and task success rates should be calculated the same way")
+}
+
+func TestGetTestReliabilityScores(t *testing.T) {
+	require.NoError(t, db.Clear(teststats.DailyTestStatsCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(teststats.DailyTestStatsCollection))
+	}()
+
+	insertTestStats := func(t *testing.T, testName, taskName, variant string, date time.Time, numPass, numFail int, avgDuration float64) {
+		require.NoError(t, db.Insert(teststats.DailyTestStatsCollection, &teststats.DBTestStats{
+			Id: teststats.DBTestStatsID{
+				TestName:     testName,
+				TaskName:     taskName,
+				BuildVariant: variant,
+				Project:      project,
+				Requester:    requesters[0],
+				Date:         date,
+			},
+			NumPass:         numPass,
+			NumFail:         numFail,
+			AvgDurationPass: avgDuration,
+		}))
+	}
+	insertTestStats(t, "test1", "task1", "v1", day1, 8, 2, 10)
+	insertTestStats(t, "test1", "task1", "v1", day1.Add(24*time.Hour), 2, 0, 20)
+	insertTestStats(t, "test1", "task1", "v2", day1, 5, 5, 10)
+	insertTestStats(t, "test2", "task1", "v1", day1, 10, 0, 10)
+
+	t.Run("GroupsByDay", func(t *testing.T) {
+		filter := createValidTestFilter()
+		filter.AfterDate = day1
+		filter.BeforeDate = day1.Add(24 * time.Hour)
+
+		scores, err := GetTestReliabilityScores(filter)
+		require.NoError(t, err)
+		require.Len(t, scores, 3)
+
+		// Latest first, then sorted by variant.
+		assert.Equal(t, day1.Add(24*time.Hour), scores[0].Date)
+		assert.Equal(t, "v1", scores[0].BuildVariant)
+		assert.Equal(t, 2, scores[0].NumTotal)
+		assert.Equal(t, day1, scores[1].Date)
+		assert.Equal(t, "v1", scores[1].BuildVariant)
+		assert.Equal(t, 10, scores[1].NumTotal)
+		assert.Equal(t, day1, scores[2].Date)
+		assert.Equal(t, "v2", scores[2].BuildVariant)
+		assert.True(t, scores[1].SuccessRate > scores[2].SuccessRate)
+	})
+	t.Run("GroupsByMultipleDays", func(t *testing.T) {
+		filter := createValidTestFilter()
+		filter.AfterDate = day1
+		filter.BeforeDate = day1.Add(24 * time.Hour)
+		filter.GroupNumDays = 2
+		filter.BuildVariants = []string{"v1"}
+
+		scores, err := GetTestReliabilityScores(filter)
+		require.NoError(t, err)
+		require.Len(t, scores, 1)
+		assert.Equal(t, "test1", scores[0].TestName)
+		assert.Equal(t, 10, scores[0].NumPass)
+		assert.Equal(t, 2, scores[0].NumFail)
+		assert.Equal(t, 12, scores[0].NumTotal)
+		assert.Equal(t, 12.0, scores[0].AvgDurationPass)
+	})
+	t.Run("Paginates", func(t *testing.T) {
+		filter := createValidTestFilter()
+		filter.AfterDate = day1
+		filter.BeforeDate = day1.Add(24 * time.Hour)
+		filter.StartAt = &taskstats.StartAt{Date: day1, BuildVariant: "v1", Task: "task1"}
+		filter.StartAtTest = "test1"
+
+		scores, err := GetTestReliabilityScores(filter)
+		require.NoError(t, err)
+		require.Len(t, scores, 1)
+		assert.Equal(t, day1, scores[0].Date)
+		assert.Equal(t, "v2", scores[0].BuildVariant)
+	})
+	t.Run("FailsWithInvalidFilter", func(t *testing.T) {
+		filter := createValidTestFilter()
+		filter.Tests = nil
+
+		_, err := GetTestReliabilityScores(filter)
+		assert.Error(t, err)
+	})
+}
@@ -1,10 +1,12 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"strings"
 
+	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/apimodels"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
@@ -200,3 +202,193 @@ func StatusDiffTests(original, patch []testresult.TestResult) []TestStatusDiff {
 
 	return diff
 }
+
+// TestResultDiff describes how a single test's result changed between a base
+// task and a task being compared against it.
+type TestResultDiff struct {
+	TestName     string `json:"test_name"`
+	TaskName     string `json:"task_name"`
+	BuildVariant string `json:"build_variant"`
+	BaseStatus   string `json:"base_status"`
+	Status       string `json:"status"`
+	BaseTaskID   string `json:"base_task_id"`
+	TaskID       string `json:"task_id"`
+}
+
+// TestResultsDiff categorizes the differences between two sets of test
+// results.
+type TestResultsDiff struct {
+	// NewFailures are tests that failed but did not fail in the base.
+	NewFailures []TestResultDiff `json:"new_failures"`
+	// Fixed are tests that passed but failed in the base.
+	Fixed []TestResultDiff `json:"fixed"`
+	// StillFailing are tests that failed in both the base and the
+	// comparison.
+	StillFailing []TestResultDiff `json:"still_failing"`
+	// Added are tests that do not exist in the base.
+	Added []TestResultDiff `json:"added"`
+	// Removed are tests that only exist in the base.
+	Removed []TestResultDiff `json:"removed"`
+}
+
+func (d *TestResultsDiff) merge(other TestResultsDiff) {
+	d.NewFailures = append(d.NewFailures, other.NewFailures...)
+	d.Fixed = append(d.Fixed, other.Fixed...)
+	d.StillFailing = append(d.StillFailing, other.StillFailing...)
+	d.Added = append(d.Added, other.Added...)
+	d.Removed = append(d.Removed, other.Removed...)
+}
+
+func isFailedTestStatus(status string) bool {
+	return status == evergreen.TestFailedStatus || status == evergreen.TestSilentlyFailedStatus
+}
+
+// DiffTestResults compares the patch test results against the base test
+// results, matching tests by their display name. Skipped tests are only
+// reported if they were added or removed.
+func DiffTestResults(base, patch []testresult.TestResult) TestResultsDiff {
+	diff := TestResultsDiff{}
+
+	baseMap := make(map[string]testresult.TestResult, len(base))
+	for _, test := range base {
+		baseMap[test.GetDisplayTestName()] = test
+	}
+
+	seen := make(map[string]bool, len(patch))
+	for _, test := range patch {
+		name := test.GetDisplayTestName()
+		seen[name] = true
+
+		testDiff := TestResultDiff{
+			TestName: name,
+			Status:   test.Status,
+			TaskID:   test.TaskID,
+		}
+		baseTest, ok := baseMap[name]
+		if !ok {
+			diff.Added = append(diff.Added, testDiff)
+			continue
+		}
+		testDiff.BaseStatus = baseTest.Status
+		testDiff.BaseTaskID = baseTest.TaskID
+
+		switch {
+		case isFailedTestStatus(test.Status) && isFailedTestStatus(baseTest.Status):
+			diff.StillFailing = append(diff.StillFailing, testDiff)
+		case isFailedTestStatus(test.Status):
+			diff.NewFailures = append(diff.NewFailures, testDiff)
+		case isFailedTestStatus(baseTest.Status) && test.Status == evergreen.TestSucceededStatus:
+			diff.Fixed = append(diff.Fixed, testDiff)
+		}
+	}
+
+	for _, test := range base {
+		name := test.GetDisplayTestName()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		diff.Removed = append(diff.Removed, TestResultDiff{
+			TestName:   name,
+			BaseStatus: test.Status,
+			BaseTaskID: test.TaskID,
+		})
+	}
+
+	return diff
+}
+
+// DiffTaskTestResults returns the diff between the test results of the given
+// task and those of the base task. If the base task is nil, all of the task's
+// tests are considered added.
+func DiffTaskTestResults(ctx context.Context, env evergreen.Environment, baseTask, t *task.Task) (TestResultsDiff, error) {
+	if t == nil {
+		return TestResultsDiff{}, errors.New("task to compare must not be nil")
+	}
+
+	results, err := t.GetTestResults(ctx, env, nil)
+	if err != nil {
+		return TestResultsDiff{}, errors.Wrapf(err, "getting test results for task '%s'", t.Id)
+	}
+
+	var baseResults testresult.TaskTestResults
+	if baseTask != nil {
+		baseResults, err = baseTask.GetTestResults(ctx, env, nil)
+		if err != nil {
+			return TestResultsDiff{}, errors.Wrapf(err, "getting test results for base task '%s'", baseTask.Id)
+		}
+	}
+
+	diff := DiffTestResults(baseResults.Results, results.Results)
+	diff.setTaskInfo(baseTask, t)
+
+	return diff, nil
+}
+
+// setTaskInfo fills in the task name and build variant of every test diff
+// and, for tests whose results came from a display task's execution tasks,
+// replaces the execution task IDs with the IDs of the compared tasks.
+func (d *TestResultsDiff) setTaskInfo(baseTask, t *task.Task) {
+	var baseTaskID string
+	if baseTask != nil {
+		baseTaskID = baseTask.Id
+	}
+	for _, category := range [][]TestResultDiff{d.NewFailures, d.Fixed, d.StillFailing, d.Added, d.Removed} {
+		for i := range category {
+			category[i].TaskName = t.DisplayName
+			category[i].BuildVariant = t.BuildVariant
+			if category[i].TaskID != "" {
+				category[i].TaskID = t.Id
+			}
+			if category[i].BaseTaskID != "" {
+				category[i].BaseTaskID = baseTaskID
+			}
+		}
+	}
+}
+
+// DiffVersionTestResults returns the diff between the test results of every
+// finished task in the given version and the test results of the matching
+// task in the base version. Tasks are matched by build variant and display
+// name. If the base version ID is empty, the version's base version is used,
+// which for a patch is the mainline version it was created from.
+func DiffVersionTestResults(ctx context.Context, env evergreen.Environment, baseVersionID, versionID string) (TestResultsDiff, error) {
+	if baseVersionID == "" {
+		baseVersion, err := FindBaseVersionForVersion(versionID)
+		if err != nil {
+			return TestResultsDiff{}, errors.Wrapf(err, "finding base version for version '%s'", versionID)
+		}
+		if baseVersion == nil {
+			return TestResultsDiff{}, errors.Errorf("version '%s' has no base version", versionID)
+		}
+		baseVersionID = baseVersion.Id
+	}
+
+	tasks, err := task.FindCompletedTasksByVersion(ctx, versionID, nil)
+	if err != nil {
+		return TestResultsDiff{}, errors.Wrapf(err, "finding completed tasks for version '%s'", versionID)
+	}
+	baseTasks, err := task.FindCompletedTasksByVersion(ctx, baseVersionID, nil)
+	if err != nil {
+		return TestResultsDiff{}, errors.Wrapf(err, "finding completed tasks for base version '%s'", baseVersionID)
+	}
+
+	baseTaskMap := make(map[string]*task.Task, len(baseTasks))
+	for i := range baseTasks {
+		baseTaskMap[baseTasks[i].BuildVariant+"/"+baseTasks[i].DisplayName] = &baseTasks[i]
+	}
+
+	// NOTE: tasks that only ran in the base version are skipped, since a
+	// patch usually only runs a subset of the mainline tasks.
+	diff := TestResultsDiff{}
+	for i := range tasks {
+		t := &tasks[i]
+		taskDiff, err := DiffTaskTestResults(ctx, env, baseTaskMap[t.BuildVariant+"/"+t.DisplayName], t)
+		if err != nil {
+			return TestResultsDiff{}, err
+		}
+		diff.merge(taskDiff)
+	}
+
+	return diff, nil
+}
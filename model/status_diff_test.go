@@ -0,0 +1,156 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTestResults(t *testing.T) {
+	base := []testresult.TestResult{
+		{TaskID: "base", TestName: "still_passing", Status: evergreen.TestSucceededStatus},
+		{TaskID: "base", TestName: "new_failure", Status: evergreen.TestSucceededStatus},
+		{TaskID: "base", TestName: "fixed", Status: evergreen.TestFailedStatus},
+		{TaskID: "base", TestName: "still_failing", Status: evergreen.TestFailedStatus},
+		{TaskID: "base", TestName: "removed", Status: evergreen.TestSucceededStatus},
+		{TaskID: "base", TestName: "now_skipped", Status: evergreen.TestFailedStatus},
+	}
+	patch := []testresult.TestResult{
+		{TaskID: "patch", TestName: "still_passing", Status: evergreen.TestSucceededStatus},
+		{TaskID: "patch", TestName: "new_failure", Status: evergreen.TestSilentlyFailedStatus},
+		{TaskID: "patch", TestName: "fixed", Status: evergreen.TestSucceededStatus},
+		{TaskID: "patch", TestName: "still_failing", Status: evergreen.TestFailedStatus},
+		{TaskID: "patch", TestName: "added", Status: evergreen.TestFailedStatus},
+		{TaskID: "patch", TestName: "now_skipped", Status: evergreen.TestSkippedStatus},
+	}
+
+	diff := DiffTestResults(base, patch)
+	require.Len(t, diff.NewFailures, 1)
+	assert.Equal(t, TestResultDiff{
+		TestName:   "new_failure",
+		BaseStatus: evergreen.TestSucceededStatus,
+		Status:     evergreen.TestSilentlyFailedStatus,
+		BaseTaskID: "base",
+		TaskID:     "patch",
+	}, diff.NewFailures[0])
+	require.Len(t, diff.Fixed, 1)
+	assert.Equal(t, "fixed", diff.Fixed[0].TestName)
+	require.Len(t, diff.StillFailing, 1)
+	assert.Equal(t, "still_failing", diff.StillFailing[0].TestName)
+	require.Len(t, diff.Added, 1)
+	assert.Equal(t, "added", diff.Added[0].TestName)
+	assert.Empty(t, diff.Added[0].BaseStatus)
+	require.Len(t, diff.Removed, 1)
+	assert.Equal(t, "removed", diff.Removed[0].TestName)
+	assert.Empty(t, diff.Removed[0].Status)
+
+	t.Run("UsesDisplayTestName", func(t *testing.T) {
+		diff := DiffTestResults(
+			[]testresult.TestResult{{TestName: "test.js", DisplayTestName: "test", Status: evergreen.TestSucceededStatus}},
+			[]testresult.TestResult{{TestName: "other/test.js", DisplayTestName: "test", Status: evergreen.TestFailedStatus}},
+		)
+		require.Len(t, diff.NewFailures, 1)
+		assert.Equal(t, "test", diff.NewFailures[0].TestName)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Removed)
+	})
+}
+
+func TestDiffVersionTestResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection, VersionCollection))
+	require.NoError(t, testresult.ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, VersionCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	baseVersion := Version{
+		Id:         "base_version",
+		Identifier: "project",
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t, baseVersion.Insert())
+	patchVersion := Version{
+		Id:         "patch_version",
+		Identifier: "project",
+		Revision:   "abc",
+		Requester:  evergreen.PatchVersionRequester,
+	}
+	require.NoError(t, patchVersion.Insert())
+
+	insertTask := func(t *testing.T, id, version, variant string, results ...testresult.TestResult) {
+		tsk := task.Task{
+			Id:             id,
+			Version:        version,
+			BuildVariant:   variant,
+			DisplayName:    "task",
+			Status:         evergreen.TaskFailed,
+			ResultsService: testresult.TestResultsServiceLocal,
+		}
+		require.NoError(t, tsk.Insert())
+		for i := range results {
+			results[i].TaskID = id
+		}
+		require.NoError(t, testresult.InsertLocal(ctx, env, results...))
+	}
+	insertTask(t, "base_v1", baseVersion.Id, "v1",
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TestName: "test2", Status: evergreen.TestFailedStatus},
+	)
+	insertTask(t, "base_v2", baseVersion.Id, "v2",
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestFailedStatus},
+	)
+	insertTask(t, "patch_v1", patchVersion.Id, "v1",
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TestName: "test2", Status: evergreen.TestSucceededStatus},
+	)
+	insertTask(t, "patch_v3", patchVersion.Id, "v3",
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestSucceededStatus},
+	)
+
+	t.Run("DefaultsToBaseVersion", func(t *testing.T) {
+		diff, err := DiffVersionTestResults(ctx, env, "", patchVersion.Id)
+		require.NoError(t, err)
+
+		require.Len(t, diff.NewFailures, 1)
+		assert.Equal(t, "test1", diff.NewFailures[0].TestName)
+		assert.Equal(t, "v1", diff.NewFailures[0].BuildVariant)
+		assert.Equal(t, "task", diff.NewFailures[0].TaskName)
+		assert.Equal(t, "base_v1", diff.NewFailures[0].BaseTaskID)
+		assert.Equal(t, "patch_v1", diff.NewFailures[0].TaskID)
+		require.Len(t, diff.Fixed, 1)
+		assert.Equal(t, "test2", diff.Fixed[0].TestName)
+		require.Len(t, diff.Added, 1)
+		assert.Equal(t, "v3", diff.Added[0].BuildVariant)
+		assert.Empty(t, diff.Added[0].BaseTaskID)
+		assert.Empty(t, diff.StillFailing)
+		assert.Empty(t, diff.Removed, "tasks that only ran in the base should be skipped")
+	})
+	t.Run("ExplicitBaseVersion", func(t *testing.T) {
+		diff, err := DiffVersionTestResults(ctx, env, patchVersion.Id, baseVersion.Id)
+		require.NoError(t, err)
+
+		require.Len(t, diff.NewFailures, 1)
+		assert.Equal(t, "test2", diff.NewFailures[0].TestName)
+		require.Len(t, diff.Fixed, 1)
+		assert.Equal(t, "test1", diff.Fixed[0].TestName)
+		require.Len(t, diff.Added, 1)
+		assert.Equal(t, "v2", diff.Added[0].BuildVariant)
+	})
+	t.Run("FailsWithoutBaseVersion", func(t *testing.T) {
+		_, err := DiffVersionTestResults(ctx, env, "", "nonexistent")
+		assert.Error(t, err)
+	})
+}
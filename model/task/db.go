@@ -55,6 +55,11 @@ var (
 	ActivatedKey                   = bsonutil.MustHaveTag(Task{}, "Activated")
 	ContainerAllocatedKey          = bsonutil.MustHaveTag(Task{}, "ContainerAllocated")
 	ContainerAllocationAttemptsKey = bsonutil.MustHaveTag(Task{}, "ContainerAllocationAttempts")
+	NumInterruptionsKey            = bsonutil.MustHaveTag(Task{}, "NumInterruptions")
+	HostInterruptionsKey           = bsonutil.MustHaveTag(Task{}, "HostInterruptions")
+	NumAutomaticRetriesKey         = bsonutil.MustHaveTag(Task{}, "NumAutomaticRetries")
+	AutomaticallyRetriedKey        = bsonutil.MustHaveTag(Task{}, "AutomaticallyRetried")
+	RetryAtKey                     = bsonutil.MustHaveTag(Task{}, "RetryAt")
 	DeactivatedForDependencyKey    = bsonutil.MustHaveTag(Task{}, "DeactivatedForDependency")
 	BuildIdKey                     = bsonutil.MustHaveTag(Task{}, "BuildId")
 	DistroIdKey                    = bsonutil.MustHaveTag(Task{}, "DistroId")
@@ -81,12 +86,15 @@ var (
 	AbortedKey                     = bsonutil.MustHaveTag(Task{}, "Aborted")
 	AbortInfoKey                   = bsonutil.MustHaveTag(Task{}, "AbortInfo")
 	TimeTakenKey                   = bsonutil.MustHaveTag(Task{}, "TimeTaken")
+	CostKey                        = bsonutil.MustHaveTag(Task{}, "Cost")
+	ResourceUsageKey               = bsonutil.MustHaveTag(Task{}, "ResourceUsage")
 	ExpectedDurationKey            = bsonutil.MustHaveTag(Task{}, "ExpectedDuration")
 	ExpectedDurationStddevKey      = bsonutil.MustHaveTag(Task{}, "ExpectedDurationStdDev")
 	DurationPredictionKey          = bsonutil.MustHaveTag(Task{}, "DurationPrediction")
 	PriorityKey                    = bsonutil.MustHaveTag(Task{}, "Priority")
 	ActivatedByKey                 = bsonutil.MustHaveTag(Task{}, "ActivatedBy")
 	StepbackInfoKey                = bsonutil.MustHaveTag(Task{}, "StepbackInfo")
+	TestStepbackInfoKey            = bsonutil.MustHaveTag(Task{}, "TestStepbackInfo")
 	ExecutionTasksKey              = bsonutil.MustHaveTag(Task{}, "ExecutionTasks")
 	DisplayOnlyKey                 = bsonutil.MustHaveTag(Task{}, "DisplayOnly")
 	DisplayTaskIdKey               = bsonutil.MustHaveTag(Task{}, "DisplayTaskId")
@@ -101,6 +109,7 @@ var (
 	ResultsServiceKey              = bsonutil.MustHaveTag(Task{}, "ResultsService")
 	HasCedarResultsKey             = bsonutil.MustHaveTag(Task{}, "HasCedarResults")
 	ResultsFailedKey               = bsonutil.MustHaveTag(Task{}, "ResultsFailed")
+	QuarantinedTestsKey            = bsonutil.MustHaveTag(Task{}, "QuarantinedTests")
 	IsGithubCheckKey               = bsonutil.MustHaveTag(Task{}, "IsGithubCheck")
 	HostCreateDetailsKey           = bsonutil.MustHaveTag(Task{}, "HostCreateDetails")
 
@@ -762,11 +771,22 @@ func schedulableHostTasksQuery() bson.M {
 			{UnattainableDependencyKey: false},
 			{OverrideDependenciesKey: true},
 		}},
+		// Filter automatic retries that are still backing off
+		retryAtElapsed(),
 	}
 
 	return q
 }
 
+// retryAtElapsed returns the query filter for tasks that are not waiting for
+// an automatic retry backoff to elapse.
+func retryAtElapsed() bson.M {
+	return bson.M{"$or": []bson.M{
+		{RetryAtKey: bson.M{"$exists": false}},
+		{RetryAtKey: bson.M{"$lte": time.Now()}},
+	}}
+}
+
 // FindNeedsContainerAllocation returns all container tasks that are waiting for
 // a container to be allocated to them sorted by activation time.
 func FindNeedsContainerAllocation() ([]Task, error) {
@@ -778,6 +798,7 @@ func FindNeedsContainerAllocation() ([]Task, error) {
 func needsContainerAllocation() bson.M {
 	q := ScheduledContainerTasksQuery()
 	q[ContainerAllocatedKey] = false
+	q["$and"] = []bson.M{retryAtElapsed()}
 	return q
 }
 
@@ -881,6 +902,29 @@ func TasksByBuildIdPipeline(buildId, taskId, taskStatus string,
 	return pipeline
 }
 
+// FindFinishedWithLogServiceOutput returns the completed tasks in the given
+// project that finished after the given time and store task or test logs with
+// the Evergreen log service.
+func FindFinishedWithLogServiceOutput(projectID string, finishedAfter time.Time) ([]Task, error) {
+	query := db.Query(bson.M{
+		ProjectKey:    projectID,
+		StatusKey:     bson.M{"$in": evergreen.TaskCompletedStatuses},
+		FinishTimeKey: bson.M{"$gt": finishedAfter},
+		"$or": []bson.M{
+			{bsonutil.GetDottedKeyName(TaskOutputInfoKey, "task_logs", "version"): bson.M{"$gt": 0}},
+			{bsonutil.GetDottedKeyName(TaskOutputInfoKey, "test_logs", "version"): bson.M{"$gt": 0}},
+		},
+	}).WithFields(IdKey, ProjectKey, ExecutionKey, TaskOutputInfoKey)
+
+	tasks := []Task{}
+	err := db.FindAllQ(Collection, query, &tasks)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+
+	return tasks, errors.Wrap(err, "finding finished tasks with log service output")
+}
+
 // GetRecentTasks returns the task results used by the recent_tasks endpoints.
 func GetRecentTasks(period time.Duration) ([]Task, error) {
 	query := db.Query(
@@ -2922,3 +2966,42 @@ func findCompletedTasks(ctx context.Context, filter bson.M, taskIDs []string) ([
 
 	return out, nil
 }
+
+// GetProjectHostUsage returns how much host time each project's tasks have
+// used on the distro between since and now. Tasks that are still in progress
+// count the time up to now.
+func GetProjectHostUsage(distroID string, since, now time.Time) (map[string]time.Duration, error) {
+	tasks, err := FindWithFields(bson.M{
+		DistroIdKey: distroID,
+		"$or": []bson.M{
+			{StatusKey: bson.M{"$in": evergreen.TaskInProgressStatuses}},
+			{FinishTimeKey: bson.M{"$gte": since}},
+		},
+	}, ProjectKey, StatusKey, DispatchTimeKey, StartTimeKey, FinishTimeKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding recent tasks in distro '%s'", distroID)
+	}
+
+	usage := map[string]time.Duration{}
+	for _, t := range tasks {
+		start := t.StartTime
+		if utility.IsZeroTime(start) {
+			start = t.DispatchTime
+		}
+		if utility.IsZeroTime(start) {
+			continue
+		}
+		if start.Before(since) {
+			start = since
+		}
+		end := now
+		if !utility.StringSliceContains(evergreen.TaskInProgressStatuses, t.Status) && !utility.IsZeroTime(t.FinishTime) && t.FinishTime.Before(now) {
+			end = t.FinishTime
+		}
+		if end.After(start) {
+			usage[t.Project] += end.Sub(start)
+		}
+	}
+
+	return usage, nil
+}
@@ -2057,3 +2057,64 @@ func TestActivateTasksUpdate(t *testing.T) {
 		assert.EqualValues(t, 0, dbTask.Priority)
 	})
 }
+
+func TestGetProjectHostUsage(t *testing.T) {
+	defer func() {
+		require.NoError(t, db.Clear(Collection))
+	}()
+	require.NoError(t, db.Clear(Collection))
+
+	now := time.Now().Round(time.Second)
+	since := now.Add(-time.Hour)
+	tasks := []Task{
+		{
+			Id:         "finished_in_window",
+			Project:    "p1",
+			DistroId:   "d1",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  now.Add(-30 * time.Minute),
+			FinishTime: now.Add(-10 * time.Minute),
+		},
+		{
+			Id:         "started_before_window",
+			Project:    "p1",
+			DistroId:   "d1",
+			Status:     evergreen.TaskFailed,
+			StartTime:  now.Add(-2 * time.Hour),
+			FinishTime: now.Add(-50 * time.Minute),
+		},
+		{
+			Id:        "running",
+			Project:   "p2",
+			DistroId:  "d1",
+			Status:    evergreen.TaskStarted,
+			StartTime: now.Add(-15 * time.Minute),
+		},
+		{
+			Id:         "finished_before_window",
+			Project:    "p2",
+			DistroId:   "d1",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  now.Add(-3 * time.Hour),
+			FinishTime: now.Add(-2 * time.Hour),
+		},
+		{
+			Id:         "other_distro",
+			Project:    "p3",
+			DistroId:   "d2",
+			Status:     evergreen.TaskSucceeded,
+			StartTime:  now.Add(-30 * time.Minute),
+			FinishTime: now.Add(-10 * time.Minute),
+		},
+	}
+	for _, tsk := range tasks {
+		require.NoError(t, tsk.Insert())
+	}
+
+	usage, err := GetProjectHostUsage("d1", since, now)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"p1": 30 * time.Minute,
+		"p2": 15 * time.Minute,
+	}, usage)
+}
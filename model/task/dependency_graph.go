@@ -147,6 +147,16 @@ func (g *DependencyGraph) addEdgeToGraph(edge DependencyEdge) {
 	g.edgesToDependencies[edgeKey{from: edge.From, to: edge.To}] = edge
 }
 
+// Edges returns a slice of all the edges in the graph.
+func (g *DependencyGraph) Edges() []DependencyEdge {
+	edges := make([]DependencyEdge, 0, len(g.edgesToDependencies))
+	for _, edge := range g.edgesToDependencies {
+		edges = append(edges, edge)
+	}
+
+	return edges
+}
+
 // EdgesIntoTask returns all the edges that point to t.
 // For a regular graph these edges are tasks that directly depend on t.
 // If the graph is transposed these edges are tasks t directly depends on.
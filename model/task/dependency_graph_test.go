@@ -236,6 +236,37 @@ func TestGetDependencyEdge(t *testing.T) {
 	})
 }
 
+func TestEdges(t *testing.T) {
+	tasks := []Task{
+		{Id: "t0", DependsOn: []Dependency{{TaskId: "t1"}}},
+		{Id: "t1", DependsOn: []Dependency{{TaskId: "t2", Status: evergreen.TaskSucceeded}}},
+		{Id: "t2"},
+	}
+
+	t.Run("EmptyGraph", func(t *testing.T) {
+		g := NewDependencyGraph(false)
+		assert.Empty(t, g.Edges())
+	})
+
+	t.Run("ForwardEdges", func(t *testing.T) {
+		g := NewDependencyGraph(false)
+		g.buildFromTasks(tasks)
+		assert.ElementsMatch(t, []DependencyEdge{
+			{From: tasks[0].ToTaskNode(), To: tasks[1].ToTaskNode()},
+			{From: tasks[1].ToTaskNode(), To: tasks[2].ToTaskNode(), Status: evergreen.TaskSucceeded},
+		}, g.Edges())
+	})
+
+	t.Run("ReversedEdges", func(t *testing.T) {
+		g := NewDependencyGraph(true)
+		g.buildFromTasks(tasks)
+		assert.ElementsMatch(t, []DependencyEdge{
+			{From: tasks[1].ToTaskNode(), To: tasks[0].ToTaskNode()},
+			{From: tasks[2].ToTaskNode(), To: tasks[1].ToTaskNode(), Status: evergreen.TaskSucceeded},
+		}, g.Edges())
+	})
+}
+
 func TestTasksDependingOnTask(t *testing.T) {
 	tasks := []Task{
 		{Id: "t0", DependsOn: []Dependency{{TaskId: "t1"}}},
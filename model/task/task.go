@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"time"
 
@@ -95,6 +96,10 @@ type Task struct {
 	HasCedarResults   bool   `bson:"has_cedar_results,omitempty" json:"has_cedar_results,omitempty"`
 	ResultsFailed     bool   `bson:"results_failed,omitempty" json:"results_failed,omitempty"`
 	MustHaveResults   bool   `bson:"must_have_results,omitempty" json:"must_have_results,omitempty"`
+	// QuarantinedTests are the names of the failed tests that were
+	// quarantined in the task's project when the task finished, so their
+	// failures were ignored.
+	QuarantinedTests []string `bson:"quarantined_tests,omitempty" json:"quarantined_tests,omitempty"`
 	// only relevant if the task is running.  the time of the last heartbeat
 	// sent back by the agent
 	LastHeartbeat time.Time `bson:"last_heartbeat" json:"last_heartbeat"`
@@ -110,6 +115,25 @@ type Task struct {
 	// ContainerAllocationAttempts is the number of times this task has
 	// been allocated a container to run it (for a single execution).
 	ContainerAllocationAttempts int `bson:"container_allocation_attempts" json:"container_allocation_attempts"`
+	// NumInterruptions is the number of times this task execution was put
+	// back in the queue because its host's capacity was reclaimed (e.g. a spot
+	// instance interruption).
+	NumInterruptions int `bson:"num_interruptions,omitempty" json:"num_interruptions,omitempty"`
+	// HostInterruptions records each attempt at running this task execution
+	// that was interrupted, in the order they were interrupted. The attempt
+	// numbers in the task logs refer to these.
+	HostInterruptions []HostInterruption `bson:"host_interruptions,omitempty" json:"host_interruptions,omitempty"`
+	// NumAutomaticRetries is the number of consecutive times the task was
+	// automatically restarted by its retry policy before this execution. It
+	// is zero if this execution was not started by an automatic retry.
+	NumAutomaticRetries int `bson:"num_automatic_retries,omitempty" json:"num_automatic_retries,omitempty"`
+	// AutomaticallyRetried indicates that this execution failed and was
+	// automatically restarted by its retry policy, so it is not the task's
+	// final outcome.
+	AutomaticallyRetried bool `bson:"automatically_retried,omitempty" json:"automatically_retried,omitempty"`
+	// RetryAt is the earliest time that an automatically retried execution
+	// can be scheduled, if its retry policy has a backoff.
+	RetryAt time.Time `bson:"retry_at,omitempty" json:"retry_at,omitempty"`
 
 	BuildId  string `bson:"build_id" json:"build_id"`
 	DistroId string `bson:"distro" json:"distro"`
@@ -222,8 +246,18 @@ type Task struct {
 
 	// TimeTaken is how long the task took to execute (if it has finished) or how long the task has been running (if it has started)
 	TimeTaken time.Duration `bson:"time_taken" json:"time_taken"`
+	// Cost is the estimated cost of running the task, which is its share of
+	// the uptime of the host that ran it.
+	Cost float64 `bson:"cost,omitempty" json:"cost,omitempty"`
+	// ResourceUsage is the summary of the resources used by the task's
+	// processes, as reported by the agent when the task finishes.
+	ResourceUsage *apimodels.ResourceUsageInfo `bson:"resource_usage,omitempty" json:"resource_usage,omitempty"`
 	// WaitSinceDependenciesMet is populated in GetDistroQueueInfo, used for host allocation
 	WaitSinceDependenciesMet time.Duration `bson:"wait_since_dependencies_met,omitempty" json:"wait_since_dependencies_met,omitempty"`
+	// SortingValueBreakdown is populated by the tunable and fair-share
+	// planners when they order the task queue and is saved with the task's
+	// queue item. It is never populated by the legacy planner.
+	SortingValueBreakdown *SortingValueBreakdown `bson:"-" json:"-"`
 
 	// how long we expect the task to take from start to
 	// finish. expected duration is the legacy value, but the UI
@@ -241,6 +275,9 @@ type Task struct {
 	LatestParentExecution int      `bson:"latest_parent_execution" json:"latest_parent_execution"`
 
 	StepbackInfo *StepbackInfo `bson:"stepback_info,omitempty" json:"stepback_info,omitempty"`
+	// TestStepbackInfo holds the test-level bisect stepbacks that this task
+	// was activated for. It is consumed once the task finishes.
+	TestStepbackInfo []TestStepbackInfo `bson:"test_stepback_info,omitempty" json:"test_stepback_info,omitempty"`
 
 	// ResetWhenFinished indicates that a task should be reset once it is
 	// finished running. This is typically to deal with tasks that should be
@@ -298,6 +335,29 @@ type StepbackInfo struct {
 	// NextStepbackTaskId stores the next task id to stepback to when doing bisect stepback. This
 	// is the middle of LastFailingStepbackTaskId and LastPassingStepbackTaskId.
 	NextStepbackTaskId string `bson:"next_stepback_task_id,omitempty" json:"next_stepback_task_id"`
+	// OriginalStepbackTaskId and OriginalStepbackExecution store the failing
+	// task that started stepback.
+	OriginalStepbackTaskId    string `bson:"original_stepback_task_id,omitempty" json:"original_stepback_task_id"`
+	OriginalStepbackExecution int    `bson:"original_stepback_execution,omitempty" json:"original_stepback_execution"`
+}
+
+// TestStepbackInfo helps determine which task to bisect to when finding the
+// commit that introduced a particular set of test failures. It is only used
+// after bisect stepback has found the culprit task for the task as a whole,
+// for tests that failed in the original task but not in the culprit task.
+type TestStepbackInfo struct {
+	// OriginalTaskId and OriginalExecution store the failing task that
+	// started stepback.
+	OriginalTaskId    string `bson:"original_task_id" json:"original_task_id"`
+	OriginalExecution int    `bson:"original_execution" json:"original_execution"`
+	// LastFailingStepbackTaskId stores the earliest task known to fail the
+	// tests.
+	LastFailingStepbackTaskId string `bson:"last_failing_stepback_task_id" json:"last_failing_stepback_task_id"`
+	// LastPassingStepbackTaskId stores the latest task known to not fail the
+	// tests.
+	LastPassingStepbackTaskId string `bson:"last_passing_stepback_task_id" json:"last_passing_stepback_task_id"`
+	// TestNames are the names of the tests being bisected.
+	TestNames []string `bson:"test_names" json:"test_names"`
 }
 
 // ExecutionPlatform indicates the type of environment that the task runs in.
@@ -348,6 +408,39 @@ type SyncAtEndOptions struct {
 	Timeout  time.Duration `bson:"timeout,omitempty" json:"timeout,omitempty"`
 }
 
+// SortingValueBreakdown is the breakdown of the value that the tunable planner
+// gives a task's planning unit (e.g. its task group or version), by factor.
+// Units with higher values are placed earlier in the queue. The fair-share
+// planner keeps the tunable planner's values, but the legacy planner orders
+// tasks by pairwise comparison, so it does not produce a breakdown.
+type SortingValueBreakdown struct {
+	// TotalValue is the sum of all the values.
+	TotalValue int64 `bson:"total_value" json:"total_value"`
+	// Priority is the unit's priority, which multiplies most of the other
+	// factors.
+	Priority int64 `bson:"priority" json:"priority"`
+	// NumTasks is the number of tasks in the unit.
+	NumTasks int64 `bson:"num_tasks" json:"num_tasks"`
+	// PatchValue is the value from being part of a patch.
+	PatchValue int64 `bson:"patch_value" json:"patch_value"`
+	// PatchTimeInQueueValue is the value from how long the patch has been
+	// waiting.
+	PatchTimeInQueueValue int64 `bson:"patch_time_in_queue_value" json:"patch_time_in_queue_value"`
+	// CommitQueueValue is the value from being part of a commit queue or
+	// merge queue version.
+	CommitQueueValue int64 `bson:"commit_queue_value" json:"commit_queue_value"`
+	// MainlineTimeInQueueValue is the value from how recently the mainline
+	// tasks were activated.
+	MainlineTimeInQueueValue int64 `bson:"mainline_time_in_queue_value" json:"mainline_time_in_queue_value"`
+	// StepbackValue is the value from being activated by stepback.
+	StepbackValue int64 `bson:"stepback_value" json:"stepback_value"`
+	// DependentsValue is the value from how many tasks depend on the unit.
+	DependentsValue int64 `bson:"dependents_value" json:"dependents_value"`
+	// ExpectedRuntimeValue is the value from how long the tasks are expected
+	// to run.
+	ExpectedRuntimeValue int64 `bson:"expected_runtime_value" json:"expected_runtime_value"`
+}
+
 // Dependency represents a task that must be completed before the owning
 // task can be scheduled.
 type Dependency struct {
@@ -1518,6 +1611,34 @@ func (t *Task) SetStepbackInfo(s StepbackInfo) error {
 		})
 }
 
+// AddTestStepbackInfo adds a test-level bisect stepback to the task.
+func (t *Task) AddTestStepbackInfo(s TestStepbackInfo) error {
+	t.TestStepbackInfo = append(t.TestStepbackInfo, s)
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$push": bson.M{
+				TestStepbackInfoKey: s,
+			},
+		})
+}
+
+// ClearTestStepbackInfo removes the test-level bisect stepbacks from the task.
+func (t *Task) ClearTestStepbackInfo() error {
+	t.TestStepbackInfo = nil
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$unset": bson.M{
+				TestStepbackInfoKey: "",
+			},
+		})
+}
+
 // initializeTaskOutputInfo returns the task output information with the most
 // up-to-date configuration for the task run. Returns false if the task will
 // never have output. This function should only be used to set the task output
@@ -1579,6 +1700,46 @@ func (t *Task) GetTaskLogs(ctx context.Context, env evergreen.Environment, getOp
 	return output.TaskLogs.Get(ctx, env, taskOpts, getOpts)
 }
 
+// UsesLogServiceForTaskLogs returns whether the task's task logs are stored
+// with the Evergreen log service.
+func (t *Task) UsesLogServiceForTaskLogs() bool {
+	return t.TaskOutputInfo != nil && t.TaskOutputInfo.TaskLogs.Version != taskoutput.TaskOutputVersionCedar
+}
+
+// TaskLogAttemptAttribute is the task log line attribute that holds the
+// number of the attempt at running the task execution that logged the line.
+// Lines without it were logged by the execution's first attempt.
+const TaskLogAttemptAttribute = "attempt"
+
+// AppendTaskLogs appends the given lines to the task's task logs of the given
+// type. The task's task logs must be stored with the Evergreen log service.
+// If earlier attempts at running the task execution were interrupted, the
+// lines are tagged with the current attempt number.
+func (t *Task) AppendTaskLogs(ctx context.Context, env evergreen.Environment, logType taskoutput.TaskLogType, lines []log.LogLine) error {
+	if !t.UsesLogServiceForTaskLogs() {
+		return errors.Errorf("task '%s' does not store its task logs with the Evergreen log service", t.Id)
+	}
+
+	if t.NumInterruptions > 0 {
+		attempt := strconv.Itoa(t.NumInterruptions)
+		for i := range lines {
+			if _, ok := lines[i].Attributes[TaskLogAttemptAttribute]; ok {
+				continue
+			}
+			if lines[i].Attributes == nil {
+				lines[i].Attributes = map[string]string{}
+			}
+			lines[i].Attributes[TaskLogAttemptAttribute] = attempt
+		}
+	}
+
+	return t.TaskOutputInfo.TaskLogs.Append(ctx, env, taskoutput.TaskOptions{
+		ProjectID: t.Project,
+		TaskID:    t.Id,
+		Execution: t.Execution,
+	}, logType, lines)
+}
+
 // GetTestLogs returns the task's test logs with the specified options.
 func (t *Task) GetTestLogs(ctx context.Context, env evergreen.Environment, getOpts taskoutput.TestLogGetOptions) (log.LogIterator, error) {
 	if t.DisplayOnly {
@@ -1634,6 +1795,29 @@ func (t *Task) SetResultsInfo(service string, failedResults bool) error {
 	return errors.WithStack(UpdateOne(ById(t.Id), bson.M{"$set": set}))
 }
 
+// SetCost sets the estimated cost of running the task execution, which may
+// be archived.
+func (t *Task) SetCost(cost float64) error {
+	coll := Collection
+	if t.Archived {
+		coll = OldCollection
+	}
+	if err := db.Update(coll, ById(t.Id), bson.M{"$set": bson.M{CostKey: cost}}); err != nil {
+		return errors.Wrap(err, "setting task cost")
+	}
+	t.Cost = cost
+	return nil
+}
+
+// SetResourceUsage sets the resource usage reported by the agent for the task.
+func (t *Task) SetResourceUsage(usage *apimodels.ResourceUsageInfo) error {
+	if err := UpdateOne(ById(t.Id), bson.M{"$set": bson.M{ResourceUsageKey: usage}}); err != nil {
+		return errors.Wrap(err, "setting task resource usage")
+	}
+	t.ResourceUsage = usage
+	return nil
+}
+
 // HasResults returns whether the task has test results or not.
 func (t *Task) HasResults() bool {
 	if t.DisplayOnly && len(t.ExecutionTasks) > 0 {
@@ -2052,6 +2236,54 @@ func (t *Task) MarkEnd(finishTime time.Time, detail *apimodels.TaskEndDetail) er
 
 }
 
+// SetQuarantinedTests records the names of the task's failed tests that were
+// quarantined when it finished.
+func (t *Task) SetQuarantinedTests(testNames []string) error {
+	if len(testNames) == 0 {
+		return nil
+	}
+
+	t.QuarantinedTests = testNames
+	return UpdateOne(
+		bson.M{IdKey: t.Id},
+		bson.M{"$set": bson.M{QuarantinedTestsKey: testNames}},
+	)
+}
+
+// QuarantinedTestsByTask maps task IDs to the names of the failed tests that
+// were quarantined when each task finished.
+type QuarantinedTestsByTask map[string][]string
+
+// IsQuarantined returns whether the test result's failure was ignored because
+// the test was quarantined when its task finished.
+func (q QuarantinedTestsByTask) IsQuarantined(res testresult.TestResult) bool {
+	return utility.StringSliceContains(q[res.TaskID], res.TestName)
+}
+
+// FindQuarantinedTests returns the quarantined tests recorded for the task's
+// test results, which belong to its execution tasks if it is a display task.
+func (t *Task) FindQuarantinedTests() (QuarantinedTestsByTask, error) {
+	if !t.DisplayOnly {
+		return QuarantinedTestsByTask{t.Id: t.QuarantinedTests}, nil
+	}
+
+	execTasks, err := FindByExecutionTasksAndMaxExecution(t.ExecutionTasks, t.Execution)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding execution tasks for display task '%s'", t.Id)
+	}
+
+	quarantined := QuarantinedTestsByTask{}
+	for _, et := range execTasks {
+		id := et.Id
+		if et.OldTaskId != "" {
+			id = et.OldTaskId
+		}
+		quarantined[id] = et.QuarantinedTests
+	}
+
+	return quarantined, nil
+}
+
 // GetDisplayStatus finds and sets DisplayStatus to the task. It should reflect
 // the statuses assigned during the addDisplayStatus aggregation step.
 func (t *Task) GetDisplayStatus() string {
@@ -2140,6 +2372,143 @@ func (t *Task) Reset(ctx context.Context) error {
 	)
 }
 
+// ResetForAutomaticRetry performs the same DB updates as (*Task).Reset, but
+// also records that the new execution is an automatic retry of the task that
+// cannot be scheduled until retryAt.
+func (t *Task) ResetForAutomaticRetry(ctx context.Context, retryAt time.Time) error {
+	numRetries := t.NumAutomaticRetries + 1
+	update := resetTaskUpdate(t)
+	retryUpdate := bson.M{NumAutomaticRetriesKey: numRetries}
+	if !utility.IsZeroTime(retryAt) {
+		retryUpdate[RetryAtKey] = retryAt
+	}
+	update = append(update, bson.M{"$set": retryUpdate})
+
+	if err := UpdateOneContext(ctx,
+		bson.M{
+			IdKey:       t.Id,
+			StatusKey:   bson.M{"$in": evergreen.TaskCompletedStatuses},
+			CanResetKey: true,
+		},
+		update,
+	); err != nil {
+		return err
+	}
+
+	t.NumAutomaticRetries = numRetries
+	t.RetryAt = retryAt
+	return nil
+}
+
+// HostInterruption describes an attempt at running a task execution that was
+// interrupted because its host's capacity was reclaimed. The attempt's partial
+// task logs are the lines tagged with its attempt number and its partial test
+// results are the ones that finished between when it started and when it was
+// interrupted.
+type HostInterruption struct {
+	// Attempt is the attempt number of the interrupted attempt, starting at
+	// zero for the execution's first attempt.
+	Attempt       int       `bson:"attempt" json:"attempt"`
+	HostID        string    `bson:"host_id" json:"host_id"`
+	DispatchTime  time.Time `bson:"dispatch_time" json:"dispatch_time"`
+	StartTime     time.Time `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	InterruptTime time.Time `bson:"interrupt_time" json:"interrupt_time"`
+	// ResultsService and ResultsFailed are the state of the execution's
+	// test results when the attempt was interrupted.
+	ResultsService string `bson:"results_service,omitempty" json:"results_service,omitempty"`
+	ResultsFailed  bool   `bson:"results_failed,omitempty" json:"results_failed,omitempty"`
+}
+
+// MarkAsHostInterrupted puts a host task back in the queue because its host's
+// capacity is being reclaimed (e.g. a spot instance interruption). Unlike a
+// reset, the task keeps its current execution and the interrupted attempt is
+// recorded in the task's host interruptions. The task is given a new secret so
+// that the agent on the interrupted host can no longer act on its behalf. This
+// will fail if the task is no longer running on the host.
+func (t *Task) MarkAsHostInterrupted(ctx context.Context) error {
+	interruption := HostInterruption{
+		Attempt:        t.NumInterruptions,
+		HostID:         t.HostId,
+		DispatchTime:   t.DispatchTime,
+		StartTime:      t.StartTime,
+		InterruptTime:  time.Now(),
+		ResultsService: t.ResultsService,
+		ResultsFailed:  t.ResultsFailed,
+	}
+	newSecret := utility.RandomString()
+	query := bson.M{
+		IdKey:        t.Id,
+		ExecutionKey: t.Execution,
+		HostIdKey:    t.HostId,
+		StatusKey:    bson.M{"$in": []string{evergreen.TaskDispatched, evergreen.TaskStarted}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			StatusKey:        evergreen.TaskUndispatched,
+			SecretKey:        newSecret,
+			DispatchTimeKey:  utility.ZeroTime,
+			StartTimeKey:     utility.ZeroTime,
+			LastHeartbeatKey: utility.ZeroTime,
+		},
+		"$unset": bson.M{
+			HostIdKey:         "",
+			AgentVersionKey:   "",
+			TaskOutputInfoKey: "",
+			ResultsServiceKey: "",
+			ResultsFailedKey:  "",
+			AbortedKey:        "",
+			AbortInfoKey:      "",
+			DetailsKey:        "",
+		},
+		"$inc":  bson.M{NumInterruptionsKey: 1},
+		"$push": bson.M{HostInterruptionsKey: interruption},
+	}
+	if err := UpdateOneContext(ctx, query, update); err != nil {
+		return err
+	}
+
+	event.LogHostTaskUndispatched(t.Id, t.Execution, t.HostId)
+
+	t.Status = evergreen.TaskUndispatched
+	t.Secret = newSecret
+	t.DispatchTime = utility.ZeroTime
+	t.StartTime = utility.ZeroTime
+	t.LastHeartbeat = utility.ZeroTime
+	t.HostId = ""
+	t.AgentVersion = ""
+	t.TaskOutputInfo = nil
+	t.ResultsService = ""
+	t.ResultsFailed = false
+	t.Aborted = false
+	t.AbortInfo = AbortInfo{}
+	t.Details = apimodels.TaskEndDetail{}
+	t.NumInterruptions++
+	t.HostInterruptions = append(t.HostInterruptions, interruption)
+
+	return nil
+}
+
+// SetAutomaticallyRetried marks the task execution as having been
+// automatically restarted by its retry policy.
+func (t *Task) SetAutomaticallyRetried() error {
+	t.AutomaticallyRetried = true
+	return UpdateOne(
+		bson.M{
+			IdKey: t.Id,
+		},
+		bson.M{
+			"$set": bson.M{
+				AutomaticallyRetriedKey: true,
+			},
+		})
+}
+
+// IsAutomaticRetry returns whether the task execution was started by its
+// retry policy after the previous execution failed.
+func (t *Task) IsAutomaticRetry() bool {
+	return t.NumAutomaticRetries > 0
+}
+
 // ResetTasks performs the same DB updates as (*Task).Reset, but resets many
 // tasks instead of a single one.
 func ResetTasks(tasks []Task) error {
@@ -2181,11 +2550,14 @@ func resetTaskUpdate(t *Task) []bson.M {
 		t.FinishTime = utility.ZeroTime
 		t.DependenciesMetTime = utility.ZeroTime
 		t.TimeTaken = 0
+		t.Cost = 0
+		t.ResourceUsage = nil
 		t.LastHeartbeat = utility.ZeroTime
 		t.Details = apimodels.TaskEndDetail{}
 		t.TaskOutputInfo = nil
 		t.ResultsService = ""
 		t.ResultsFailed = false
+		t.QuarantinedTests = nil
 		t.HasCedarResults = false
 		t.ResetWhenFinished = false
 		t.ResetFailedWhenFinished = false
@@ -2193,6 +2565,11 @@ func resetTaskUpdate(t *Task) []bson.M {
 		t.HostCreateDetails = []HostCreateDetail{}
 		t.OverrideDependencies = false
 		t.ContainerAllocationAttempts = 0
+		t.NumInterruptions = 0
+		t.HostInterruptions = nil
+		t.NumAutomaticRetries = 0
+		t.AutomaticallyRetried = false
+		t.RetryAt = time.Time{}
 		t.CanReset = false
 	}
 	update := []bson.M{
@@ -2224,15 +2601,23 @@ func resetTaskUpdate(t *Task) []bson.M {
 				TaskOutputInfoKey,
 				ResultsServiceKey,
 				ResultsFailedKey,
+				QuarantinedTestsKey,
 				HasCedarResultsKey,
 				ResetWhenFinishedKey,
 				ResetFailedWhenFinishedKey,
 				AgentVersionKey,
 				HostIdKey,
 				PodIDKey,
+				CostKey,
+				ResourceUsageKey,
 				HostCreateDetailsKey,
 				OverrideDependenciesKey,
 				CanResetKey,
+				NumInterruptionsKey,
+				HostInterruptionsKey,
+				NumAutomaticRetriesKey,
+				AutomaticallyRetriedKey,
+				RetryAtKey,
 			},
 		},
 	}
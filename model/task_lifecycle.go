@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -11,15 +12,20 @@ import (
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
+	"github.com/evergreen-ci/evergreen/model/cost"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/utility"
 	adb "github.com/mongodb/anser/db"
 	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson"
@@ -600,6 +606,8 @@ func doBisectStepback(ctx context.Context, t *task.Task) error {
 		}
 		s = task.StepbackInfo{
 			LastPassingStepbackTaskId: lastPassing.Id,
+			OriginalStepbackTaskId:    t.Id,
+			OriginalStepbackExecution: t.Execution,
 		}
 	}
 
@@ -621,9 +629,11 @@ func doBisectStepback(ctx context.Context, t *task.Task) error {
 	if nextTask == nil {
 		return errors.Errorf("midway task could not be found for tasks '%s' '%s'", s.LastFailingStepbackTaskId, s.LastPassingStepbackTaskId)
 	}
-	// If our next task is last passing Id, we have finished stepback.
-	if nextTask.Id == s.LastPassingStepbackTaskId {
-		return nil
+	// If our next task is the last passing or last failing task, there are no
+	// tasks left between them, so we have finished stepback and the last
+	// failing task is the culprit.
+	if nextTask.Id == s.LastPassingStepbackTaskId || nextTask.Id == s.LastFailingStepbackTaskId {
+		return errors.Wrap(finishBisectStepback(ctx, s), "finishing bisect stepback")
 	}
 	// If the next task has finished, negative priority, or already activated, no-op.
 	if nextTask.IsFinished() || nextTask.Priority < 0 || nextTask.Activated {
@@ -678,6 +688,34 @@ func MarkEnd(ctx context.Context, settings *evergreen.Settings, t *task.Task, ca
 		detailsCopy.Status = evergreen.TaskFailed
 		detailsCopy.Description = evergreen.TaskDescriptionResultsFailed
 	}
+	if t.ResultsFailed {
+		quarantinedTests, onlyQuarantined, err := flakytest.FindQuarantinedTestFailures(ctx, evergreen.GetEnvironment(), t)
+		grip.Error(message.WrapError(err, message.Fields{
+			"message": "could not check task for quarantined test failures",
+			"task_id": t.Id,
+			"project": t.Project,
+		}))
+		grip.Error(message.WrapError(t.SetQuarantinedTests(quarantinedTests), message.Fields{
+			"message": "could not record quarantined test failures",
+			"task_id": t.Id,
+			"project": t.Project,
+		}))
+
+		// Quarantined tests can only override a failure caused solely
+		// by failing test results, not one where something else also
+		// went wrong.
+		resultsFailedOnly := detailsCopy.Status == evergreen.TaskFailed && detailsCopy.Description == evergreen.TaskDescriptionResultsFailed && !detailsCopy.TimedOut && !t.Aborted
+		if onlyQuarantined && resultsFailedOnly {
+			grip.Info(message.Fields{
+				"message":   "ignoring task failure because only quarantined tests failed",
+				"task_id":   t.Id,
+				"execution": t.Execution,
+				"project":   t.Project,
+			})
+			detailsCopy.Status = evergreen.TaskSucceeded
+			detailsCopy.Description = evergreen.TaskDescriptionQuarantinedTestsFailed
+		}
+	}
 
 	if t.Status == detailsCopy.Status {
 		grip.Warning(message.Fields{
@@ -717,12 +755,35 @@ func MarkEnd(ctx context.Context, settings *evergreen.Settings, t *task.Task, ca
 		return errors.Wrap(err, "marking task finished")
 	}
 
-	if err = UpdateBlockedDependencies(t); err != nil {
-		return errors.Wrap(err, "updating blocked dependencies")
+	if t.IsHostTask() && t.HostId != "" && settings != nil {
+		grip.Warning(message.WrapError(setHostTaskCost(ctx, settings.Cost, t), message.Fields{
+			"message": "could not set task cost",
+			"task_id": t.Id,
+			"host_id": t.HostId,
+		}))
 	}
 
-	if err = t.MarkDependenciesFinished(true); err != nil {
-		return errors.Wrap(err, "updating dependency met status")
+	retryPolicy, err := getAutomaticRetryPolicy(ctx, t)
+	grip.Error(message.WrapError(err, message.Fields{
+		"message":   "could not check task for automatic retry",
+		"task_id":   t.Id,
+		"execution": t.Execution,
+		"project":   t.Project,
+	}))
+	// Dependents of a task that will be automatically retried wait for the
+	// retry to finish instead of being blocked by this execution.
+	if retryPolicy != nil {
+		if err = t.SetAutomaticallyRetried(); err != nil {
+			return errors.Wrap(err, "marking task as automatically retried")
+		}
+	} else {
+		if err = UpdateBlockedDependencies(t); err != nil {
+			return errors.Wrap(err, "updating blocked dependencies")
+		}
+
+		if err = t.MarkDependenciesFinished(true); err != nil {
+			return errors.Wrap(err, "updating dependency met status")
+		}
 	}
 
 	status := t.GetDisplayStatus()
@@ -767,6 +828,13 @@ func MarkEnd(ctx context.Context, settings *evergreen.Settings, t *task.Task, ca
 		}
 	}
 
+	if retryPolicy != nil {
+		if err = logTaskEndStats(ctx, t); err != nil {
+			return errors.Wrap(err, "logging task end stats")
+		}
+		return errors.Wrap(retryTaskAutomatically(ctx, t, retryPolicy), "automatically retrying task")
+	}
+
 	// activate/deactivate other task if this is not a patch request's task
 	if !evergreen.IsPatchRequester(t.Requester) {
 		if t.IsPartOfDisplay() {
@@ -781,6 +849,9 @@ func MarkEnd(ctx context.Context, settings *evergreen.Settings, t *task.Task, ca
 		if err != nil {
 			return errors.Wrap(err, "evaluating stepback")
 		}
+		if err = evalTestStepback(ctx, t); err != nil {
+			return errors.Wrap(err, "evaluating test stepback")
+		}
 	}
 
 	if err = UpdateBuildAndVersionStatusForTask(ctx, t); err != nil {
@@ -798,6 +869,25 @@ func MarkEnd(ctx context.Context, settings *evergreen.Settings, t *task.Task, ca
 	return nil
 }
 
+// setHostTaskCost sets the provisional estimated cost of running the finished
+// task on its host. The cost is replaced by the task's share of the host's
+// entire uptime once the host is terminated.
+func setHostTaskCost(ctx context.Context, c evergreen.CostConfig, t *task.Task) error {
+	h, err := host.FindOneId(ctx, t.HostId)
+	if err != nil {
+		return errors.Wrapf(err, "finding host '%s'", t.HostId)
+	}
+	if h == nil {
+		return errors.Errorf("host '%s' not found", t.HostId)
+	}
+
+	taskCost := cost.TaskCost(c, h, t)
+	if taskCost <= 0 {
+		return nil
+	}
+	return errors.Wrap(t.SetCost(taskCost), "setting task cost")
+}
+
 // logTaskEndStats logs information a task after it
 // completes. It also logs information about the total runtime and instance
 // type, which can be used to measure the cost of running a task.
@@ -847,6 +937,7 @@ func logTaskEndStats(ctx context.Context, t *task.Task) error {
 		msg["host_id"] = taskHost.Id
 		msg["distro"] = taskHost.Distro.Id
 		msg["provider"] = taskHost.Distro.Provider
+		msg["cost"] = t.Cost
 		if evergreen.IsEc2Provider(taskHost.Distro.Provider) && len(taskHost.Distro.ProviderSettingsList) > 0 {
 			instanceType, ok := taskHost.Distro.ProviderSettingsList[0].Lookup("instance_type").StringValueOK()
 			if ok {
@@ -1294,7 +1385,8 @@ func evalBisectStepback(ctx context.Context, t *task.Task, caller string, stepba
 
 	// If the stepback info is nil but we reached this point, this must be the first
 	// iteration of stepback.
-	newStepback := t.StepbackInfo == nil && evergreen.IsFailedTaskStatus(t.Status)
+	// Tasks that were activated to bisect tests don't start a new stepback.
+	newStepback := t.StepbackInfo == nil && len(t.TestStepbackInfo) == 0 && evergreen.IsFailedTaskStatus(t.Status)
 	// If the stepback is not nil, this is an ongoing stepback.
 	existingStepback := t.StepbackInfo != nil
 	if newStepback || existingStepback {
@@ -2138,6 +2230,98 @@ func ClearAndResetStrandedHostTask(ctx context.Context, settings *evergreen.Sett
 	return nil
 }
 
+// maxTaskInterruptions is the maximum number of times a single task execution
+// can be put back in the queue because its host was interrupted before it's
+// reset like any other stranded task.
+const maxTaskInterruptions = 3
+
+// ResetInterruptedHostTask puts the task running on a host whose capacity is
+// being reclaimed (e.g. a spot instance interruption) back in the queue. Unlike
+// ClearAndResetStrandedHostTask, the task keeps its current execution so that
+// the interruption does not use up a user-visible execution, and the task is
+// not marked finished, so it does not notify, step back or get retried. The
+// interrupted attempt is recorded in the task's host interruptions and its
+// partial task logs are tagged with its attempt number. Tasks in single-host
+// task groups and tasks that have already been interrupted too many times are
+// reset as stranded tasks instead.
+func ResetInterruptedHostTask(ctx context.Context, settings *evergreen.Settings, h *host.Host) error {
+	if h.RunningTask == "" {
+		return nil
+	}
+
+	t, err := task.FindOneIdAndExecution(h.RunningTask, h.RunningTaskExecution)
+	if err != nil {
+		return errors.Wrapf(err, "finding running task '%s' execution '%d' from host '%s'", h.RunningTask, h.RunningTaskExecution, h.Id)
+	} else if t == nil {
+		return nil
+	}
+	if t.IsFinished() {
+		return nil
+	}
+
+	if t.IsPartOfSingleHostTaskGroup() || t.NumInterruptions >= maxTaskInterruptions {
+		return errors.Wrapf(ClearAndResetStrandedHostTask(ctx, settings, h), "resetting interrupted task '%s' as a stranded task", t.Id)
+	}
+
+	if err = h.ClearRunningTask(ctx); err != nil {
+		return errors.Wrapf(err, "clearing running task from host '%s'", h.Id)
+	}
+
+	interrupted := *t
+	if err = t.MarkAsHostInterrupted(ctx); err != nil {
+		if adb.ResultsNotFound(err) {
+			// The task finished or was reset before it could be requeued.
+			return nil
+		}
+		return errors.Wrapf(err, "requeueing interrupted task '%s'", t.Id)
+	}
+
+	grip.Warning(message.WrapError(logTaskAttemptInterrupted(ctx, &interrupted, h), message.Fields{
+		"message":   "could not tag interrupted task attempt's logs",
+		"task":      t.Id,
+		"execution": t.Execution,
+		"host_id":   h.Id,
+	}))
+
+	grip.Info(message.Fields{
+		"message":           "requeued task from interrupted host",
+		"task":              t.Id,
+		"execution":         t.Execution,
+		"host_id":           h.Id,
+		"distro":            h.Distro.Id,
+		"num_interruptions": t.NumInterruptions,
+	})
+
+	catcher := grip.NewBasicCatcher()
+	if t.IsPartOfDisplay() {
+		catcher.Wrap(UpdateDisplayTaskForTask(t), "updating display task")
+	}
+	catcher.Wrap(UpdateBuildAndVersionStatusForTask(ctx, t), "updating build/version status")
+
+	return catcher.Resolve()
+}
+
+// logTaskAttemptInterrupted appends a line to the interrupted task attempt's
+// task logs, tagged with the attempt number, marking where the attempt's
+// partial output ends.
+func logTaskAttemptInterrupted(ctx context.Context, t *task.Task, h *host.Host) error {
+	if !t.UsesLogServiceForTaskLogs() {
+		return nil
+	}
+
+	line := log.LogLine{
+		Priority:  level.Warning,
+		Timestamp: time.Now().UnixNano(),
+		Data:      fmt.Sprintf("Task attempt %d was interrupted because the capacity of host '%s' is being reclaimed; the task will be rerun in the same execution.", t.NumInterruptions, h.Id),
+		Attributes: map[string]string{
+			task.TaskLogAttemptAttribute: strconv.Itoa(t.NumInterruptions),
+			"host_id":                    h.Id,
+		},
+	}
+
+	return t.AppendTaskLogs(ctx, evergreen.GetEnvironment(), taskoutput.TaskLogTypeTask, []log.LogLine{line})
+}
+
 // FixStaleTask fixes a task that has exceeded the heartbeat timeout.
 // The current task execution is marked as finished and, if the task was not
 // aborted, the task is reset. If the task was aborted, we do not reset the task
@@ -16,16 +16,21 @@ import (
 	"github.com/evergreen-ci/evergreen/model/commitqueue"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/evergreen/model/testresult"
 	"github.com/evergreen-ci/evergreen/model/user"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
 	"github.com/mongodb/grip/message"
 	"github.com/mongodb/grip/send"
 	. "github.com/smartystreets/goconvey/convey"
@@ -4661,6 +4666,240 @@ func TestClearAndResetStaleStrandedHostTask(t *testing.T) {
 	assert.Equal("system", runningTask.Details.Type)
 }
 
+func TestResetInterruptedHostTask(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(host.Collection, task.Collection, task.OldCollection, build.Collection, VersionCollection, event.EventCollection))
+	}()
+
+	getTaskLogs := func(t *testing.T, tsk *task.Task, attributes map[string]string) []string {
+		it, err := tsk.GetTaskLogs(ctx, env, taskoutput.TaskLogGetOptions{
+			LogType:    taskoutput.TaskLogTypeTask,
+			Attributes: attributes,
+		})
+		require.NoError(t, err)
+		var lines []string
+		for it.Next() {
+			lines = append(lines, it.Item().Data)
+		}
+		require.NoError(t, it.Err())
+		require.NoError(t, it.Close())
+		return lines
+	}
+
+	for tName, tCase := range map[string]func(t *testing.T, h *host.Host, tsk *task.Task){
+		"RequeuesTaskInSameExecution": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			oldSecret := tsk.Secret
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskUndispatched, dbTask.Status)
+			assert.True(t, dbTask.Activated)
+			assert.Equal(t, tsk.Execution, dbTask.Execution)
+			assert.Equal(t, 1, dbTask.NumInterruptions)
+			assert.Zero(t, dbTask.HostId)
+			assert.True(t, utility.IsZeroTime(dbTask.DispatchTime))
+			assert.NotEqual(t, oldSecret, dbTask.Secret)
+			assert.Zero(t, dbTask.Details)
+
+			dbHost, err := host.FindOneId(ctx, h.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost)
+			assert.Zero(t, dbHost.RunningTask)
+
+			oldTasks, err := task.FindOld(task.ById(tsk.Id))
+			require.NoError(t, err)
+			assert.Empty(t, oldTasks, "interrupted task should not have been archived")
+		},
+		"DoesNotMarkTaskFinished": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			events, err := event.Find(event.MostRecentTaskEvents(tsk.Id, 10))
+			require.NoError(t, err)
+			for _, e := range events {
+				assert.NotEqual(t, event.TaskFinished, e.EventType, "interrupted task should not log a finished event")
+			}
+
+			dbBuild, err := build.FindOneId(tsk.BuildId)
+			require.NoError(t, err)
+			require.NotZero(t, dbBuild)
+			assert.NotEqual(t, evergreen.BuildFailed, dbBuild.Status)
+		},
+		"RecordsInterruptedAttempt": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			require.Len(t, dbTask.HostInterruptions, 1)
+			interruption := dbTask.HostInterruptions[0]
+			assert.Zero(t, interruption.Attempt)
+			assert.Equal(t, h.Id, interruption.HostID)
+			assert.False(t, utility.IsZeroTime(interruption.DispatchTime))
+			assert.False(t, utility.IsZeroTime(interruption.InterruptTime))
+			assert.Equal(t, testresult.TestResultsServiceLocal, interruption.ResultsService)
+			assert.True(t, interruption.ResultsFailed)
+
+			// The rerun's results should not be considered failed because
+			// of the interrupted attempt's partial results.
+			assert.Zero(t, dbTask.ResultsService)
+			assert.False(t, dbTask.ResultsFailed)
+		},
+		"TagsInterruptedAttemptLogs": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, tsk.AppendTaskLogs(ctx, env, taskoutput.TaskLogTypeTask, []log.LogLine{
+				{Priority: level.Info, Timestamp: time.Now().UnixNano(), Data: "partial output"},
+			}))
+
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+
+			// The rerun logs to the same execution, tagged with its
+			// attempt number.
+			dbTask.Status = evergreen.TaskStarted
+			dbTask.TaskOutputInfo = tsk.TaskOutputInfo
+			require.NoError(t, dbTask.AppendTaskLogs(ctx, env, taskoutput.TaskLogTypeTask, []log.LogLine{
+				{Priority: level.Info, Timestamp: time.Now().UnixNano(), Data: "rerun output"},
+			}))
+
+			lines := getTaskLogs(t, dbTask, nil)
+			require.Len(t, lines, 3)
+			assert.Equal(t, "partial output", lines[0])
+			assert.Contains(t, lines[1], "Task attempt 0 was interrupted")
+			assert.Equal(t, "rerun output", lines[2])
+
+			lines = getTaskLogs(t, dbTask, map[string]string{task.TaskLogAttemptAttribute: "0"})
+			require.Len(t, lines, 1)
+			assert.Contains(t, lines[0], "Task attempt 0 was interrupted")
+
+			lines = getTaskLogs(t, dbTask, map[string]string{task.TaskLogAttemptAttribute: "1"})
+			require.Len(t, lines, 1)
+			assert.Equal(t, "rerun output", lines[0])
+		},
+		"CountsInterruptionsOfSameExecution": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			require.NoError(t, task.UpdateOne(bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{
+				task.StatusKey:       evergreen.TaskStarted,
+				task.HostIdKey:       h.Id,
+				task.DispatchTimeKey: time.Now(),
+			}}))
+			require.NoError(t, db.Update(host.Collection, bson.M{host.IdKey: h.Id}, bson.M{"$set": bson.M{host.RunningTaskKey: tsk.Id}}))
+
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, tsk.Execution, dbTask.Execution)
+			assert.Equal(t, 2, dbTask.NumInterruptions)
+			require.Len(t, dbTask.HostInterruptions, 2)
+			assert.Equal(t, 0, dbTask.HostInterruptions[0].Attempt)
+			assert.Equal(t, 1, dbTask.HostInterruptions[1].Attempt)
+		},
+		"ResetsTaskAsStrandedAfterMaxInterruptions": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, task.UpdateOne(bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{task.NumInterruptionsKey: maxTaskInterruptions}}))
+
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskUndispatched, dbTask.Status)
+			assert.Equal(t, tsk.Execution+1, dbTask.Execution)
+			assert.Zero(t, dbTask.NumInterruptions)
+			assert.Empty(t, dbTask.HostInterruptions)
+
+			archivedTask, err := task.FindOneOldByIdAndExecution(tsk.Id, tsk.Execution)
+			require.NoError(t, err)
+			require.NotZero(t, archivedTask)
+			assert.Equal(t, evergreen.TaskDescriptionStranded, archivedTask.Details.Description)
+		},
+		"NoopsForFinishedTask": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, task.UpdateOne(bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{task.StatusKey: evergreen.TaskSucceeded}}))
+
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskSucceeded, dbTask.Status)
+			assert.Equal(t, tsk.Execution, dbTask.Execution)
+			assert.Zero(t, dbTask.NumInterruptions)
+		},
+		"NoopsIfTaskLeftHostBeforeRequeue": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			require.NoError(t, task.UpdateOne(bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{task.HostIdKey: "other_host"}}))
+
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskStarted, dbTask.Status)
+			assert.Equal(t, "other_host", dbTask.HostId)
+			assert.Zero(t, dbTask.NumInterruptions)
+			assert.Empty(t, dbTask.HostInterruptions)
+		},
+		"NoopsForHostWithoutRunningTask": func(t *testing.T, h *host.Host, tsk *task.Task) {
+			h.RunningTask = ""
+			require.NoError(t, ResetInterruptedHostTask(ctx, &evergreen.Settings{}, h))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskStarted, dbTask.Status)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(host.Collection, task.Collection, task.OldCollection, build.Collection, VersionCollection, event.EventCollection))
+
+			v := Version{Id: "version"}
+			require.NoError(t, v.Insert())
+			b := build.Build{Id: "build", Version: v.Id}
+			require.NoError(t, b.Insert())
+			tsk := &task.Task{
+				Id:             "task",
+				Project:        "project",
+				Status:         evergreen.TaskStarted,
+				Activated:      true,
+				ActivatedTime:  time.Now(),
+				DispatchTime:   time.Now(),
+				BuildId:        b.Id,
+				Version:        v.Id,
+				HostId:         "host",
+				Secret:         "secret",
+				ResultsService: testresult.TestResultsServiceLocal,
+				ResultsFailed:  true,
+				TaskOutputInfo: &taskoutput.TaskOutput{
+					TaskLogs: taskoutput.TaskLogOutput{
+						Version: taskoutput.TaskOutputVersionLogServiceV1,
+						BucketConfig: evergreen.BucketConfig{
+							Name: t.TempDir(),
+							Type: evergreen.BucketTypeLocal,
+						},
+					},
+				},
+			}
+			require.NoError(t, tsk.Insert())
+			h := &host.Host{
+				Id:          "host",
+				Status:      evergreen.HostDecommissioned,
+				RunningTask: tsk.Id,
+			}
+			require.NoError(t, h.Insert(ctx))
+
+			tCase(t, h, tsk)
+		})
+	}
+}
+
 func TestClearAndResetStrandedHostTaskFailedOnly(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -5516,6 +5755,80 @@ func TestMarkEndWithNoResults(t *testing.T) {
 	assert.Equal(t, evergreen.TaskSucceeded, dbTask.Status)
 }
 
+func TestMarkEndWithQuarantinedTests(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, build.Collection, VersionCollection, ParserProjectCollection, flakytest.QuarantineCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	for tName, tCase := range map[string]struct {
+		details             apimodels.TaskEndDetail
+		expectedStatus      string
+		expectedDescription string
+	}{
+		"OverridesFailureCausedOnlyByTestResults": {
+			details:             apimodels.TaskEndDetail{Status: evergreen.TaskSucceeded, Type: evergreen.CommandTypeTest},
+			expectedStatus:      evergreen.TaskSucceeded,
+			expectedDescription: evergreen.TaskDescriptionQuarantinedTestsFailed,
+		},
+		"KeepsFailureWhenCommandAlsoFailed": {
+			details:             apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeTest, Description: "command failed"},
+			expectedStatus:      evergreen.TaskFailed,
+			expectedDescription: "command failed",
+		},
+		"KeepsFailureWhenTimedOut": {
+			details:             apimodels.TaskEndDetail{Status: evergreen.TaskFailed, Type: evergreen.CommandTypeTest, Description: evergreen.TaskDescriptionResultsFailed, TimedOut: true},
+			expectedStatus:      evergreen.TaskFailed,
+			expectedDescription: evergreen.TaskDescriptionResultsFailed,
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(task.Collection, build.Collection, VersionCollection, ParserProjectCollection, flakytest.QuarantineCollection))
+			require.NoError(t, testresult.ClearLocal(ctx, env))
+
+			tsk := task.Task{
+				Id:             "t1",
+				Project:        "project",
+				DisplayName:    "task",
+				BuildVariant:   "bv",
+				Status:         evergreen.TaskStarted,
+				Activated:      true,
+				BuildId:        "b",
+				Version:        "v",
+				ResultsService: testresult.TestResultsServiceLocal,
+				ResultsFailed:  true,
+			}
+			require.NoError(t, tsk.Insert())
+			require.NoError(t, (&build.Build{Id: "b", Version: "v"}).Insert())
+			require.NoError(t, (&Version{Id: "v", Requester: evergreen.RepotrackerVersionRequester, Status: evergreen.VersionStarted}).Insert())
+			require.NoError(t, (&ParserProject{Id: "v", Identifier: utility.ToStringPtr("sample")}).Insert())
+			require.NoError(t, (&flakytest.QuarantinedTest{ProjectID: "project", TestName: "flaky"}).Insert())
+			require.NoError(t, testresult.InsertLocal(ctx, env,
+				testresult.TestResult{TaskID: tsk.Id, TestName: "flaky", Status: evergreen.TestFailedStatus},
+				testresult.TestResult{TaskID: tsk.Id, TestName: "stable", Status: evergreen.TestSucceededStatus},
+			))
+
+			require.NoError(t, MarkEnd(ctx, &evergreen.Settings{}, &tsk, "", time.Now(), &tCase.details, false))
+
+			dbTask, err := task.FindOneId(tsk.Id)
+			require.NoError(t, err)
+			require.NotNil(t, dbTask)
+			assert.Equal(t, tCase.expectedStatus, dbTask.Status)
+			assert.Equal(t, tCase.expectedDescription, dbTask.Details.Description)
+			assert.Equal(t, []string{"flaky"}, dbTask.QuarantinedTests)
+
+			quarantined, err := dbTask.FindQuarantinedTests()
+			require.NoError(t, err)
+			assert.True(t, quarantined.IsQuarantined(testresult.TestResult{TaskID: tsk.Id, TestName: "flaky"}))
+			assert.False(t, quarantined.IsQuarantined(testresult.TestResult{TaskID: tsk.Id, TestName: "stable"}))
+		})
+	}
+}
+
 func TestDisplayTaskUpdates(t *testing.T) {
 	require.NoError(t, db.ClearCollections(task.Collection, event.EventCollection))
 	assert := assert.New(t)
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TaskLogCompactionStatsCollection stores the results of the task log
+// compaction jobs so that they can be reported with the other background
+// stats.
+const TaskLogCompactionStatsCollection = "task_log_compaction_stats"
+
+// TaskLogCompactionStats is the result of compacting and deleting one
+// project's task logs.
+type TaskLogCompactionStats struct {
+	JobID         string    `bson:"_id" json:"job_id"`
+	ProjectID     string    `bson:"project_id" json:"project_id"`
+	DryRun        bool      `bson:"dry_run" json:"dry_run"`
+	RetentionDays int       `bson:"retention_days" json:"retention_days"`
+	NumTasks      int       `bson:"num_tasks" json:"num_tasks"`
+	ChunksMerged  int       `bson:"num_chunks_merged" json:"num_chunks_merged"`
+	ChunksCreated int       `bson:"num_chunks_created" json:"num_chunks_created"`
+	LinesMerged   int       `bson:"num_lines_merged" json:"num_lines_merged"`
+	ChunksDeleted int       `bson:"num_chunks_deleted" json:"num_chunks_deleted"`
+	LinesDeleted  int       `bson:"num_lines_deleted" json:"num_lines_deleted"`
+	HasErrors     bool      `bson:"has_errors" json:"has_errors"`
+	FinishTime    time.Time `bson:"finish_time" json:"finish_time"`
+}
+
+var (
+	taskLogCompactionStatsJobIDKey      = bsonutil.MustHaveTag(TaskLogCompactionStats{}, "JobID")
+	taskLogCompactionStatsFinishTimeKey = bsonutil.MustHaveTag(TaskLogCompactionStats{}, "FinishTime")
+)
+
+// Upsert stores the task log compaction stats, replacing any stats from a
+// previous run of the same job.
+func (s *TaskLogCompactionStats) Upsert() error {
+	_, err := db.Upsert(TaskLogCompactionStatsCollection, bson.M{taskLogCompactionStatsJobIDKey: s.JobID}, s)
+	return errors.Wrapf(err, "upserting task log compaction stats for job '%s'", s.JobID)
+}
+
+// FindTaskLogCompactionStatsFinishedAfter returns the task log compaction
+// stats of the jobs that finished after the given time.
+func FindTaskLogCompactionStatsFinishedAfter(after time.Time) ([]TaskLogCompactionStats, error) {
+	stats := []TaskLogCompactionStats{}
+	err := db.FindAllQ(TaskLogCompactionStatsCollection, db.Query(bson.M{
+		taskLogCompactionStatsFinishTimeKey: bson.M{"$gt": after},
+	}), &stats)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return stats, errors.Wrap(err, "finding task log compaction stats")
+}
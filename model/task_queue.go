@@ -119,6 +119,10 @@ type TaskQueueItem struct {
 	Priority            int64         `bson:"priority" json:"priority"`
 	Dependencies        []string      `bson:"dependencies" json:"dependencies"`
 	ActivatedBy         string        `bson:"activated_by" json:"activated_by"`
+	// SortingValueBreakdown is the breakdown of the value the planner gave
+	// the task when ordering the queue. The legacy planner does not compute
+	// one.
+	SortingValueBreakdown *task.SortingValueBreakdown `bson:"sorting_value_breakdown,omitempty" json:"sorting_value_breakdown,omitempty"`
 }
 
 // must not no-lint these values
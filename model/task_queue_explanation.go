@@ -0,0 +1,281 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TaskQueueExplanation explains why a task is or isn't waiting to run.
+type TaskQueueExplanation struct {
+	TaskID   string `json:"task_id"`
+	Status   string `json:"status"`
+	DistroID string `json:"distro_id"`
+	// InQueue is whether the task is in its distro's queue or one of its
+	// secondary distros' queues.
+	InQueue bool `json:"in_queue"`
+	// QueueDistroID is the distro whose queue the task is in. This may be
+	// one of the task's secondary distros.
+	QueueDistroID string `json:"queue_distro_id,omitempty"`
+	// SecondaryQueue is whether the task is in a secondary queue.
+	SecondaryQueue bool `json:"secondary_queue"`
+	// Position is the task's 1-based position among the tasks in the queue
+	// that have not been dispatched yet.
+	Position int `json:"position,omitempty"`
+	// QueueLength is the number of tasks in the queue that have not been
+	// dispatched yet.
+	QueueLength int `json:"queue_length,omitempty"`
+	// QueueGeneratedAt is when the scheduler last ordered the queue.
+	QueueGeneratedAt time.Time `json:"queue_generated_at,omitempty"`
+	// SortingValueBreakdown is the breakdown of the value the planner gave
+	// the task when it ordered the queue, by factor. This is only available
+	// for the tunable and fair-share planners.
+	SortingValueBreakdown *task.SortingValueBreakdown `json:"sorting_value_breakdown,omitempty"`
+	// UnmetDependencies are the tasks this task depends on whose statuses do
+	// not yet satisfy the dependency.
+	UnmetDependencies []UnmetDependency `json:"unmet_dependencies,omitempty"`
+	DistroDisabled    bool              `json:"distro_disabled"`
+	// NumHosts is the number of hosts currently up in the distro.
+	NumHosts     int `json:"num_hosts"`
+	MaximumHosts int `json:"maximum_hosts"`
+	// AtMaximumHosts is whether the distro cannot start any more hosts.
+	AtMaximumHosts bool `json:"at_maximum_hosts"`
+	// EstimatedStart is how long until the task is estimated to start, or -1
+	// if it can't be estimated.
+	EstimatedStart time.Duration `json:"estimated_start"`
+	// Reasons are human-readable explanations of why the task is not
+	// running yet.
+	Reasons []string `json:"reasons"`
+}
+
+// UnmetDependency is a dependency of a task that has not been met yet.
+type UnmetDependency struct {
+	TaskID      string `json:"task_id"`
+	DisplayName string `json:"display_name"`
+	// Status is the dependency's current status.
+	Status string `json:"status"`
+	// RequiredStatus is the status that the dependency must finish with.
+	RequiredStatus string `json:"required_status"`
+	// Unattainable is whether the dependency can no longer be met.
+	Unattainable bool `json:"unattainable"`
+}
+
+// ExplainTaskQueue returns an explanation of why the host task is or isn't
+// waiting to run: where it is in the queue, how the planner scored it, which of
+// its dependencies are unmet, whether its distro can start more hosts, and
+// when it's estimated to start.
+func ExplainTaskQueue(ctx context.Context, t task.Task) (*TaskQueueExplanation, error) {
+	explanation := &TaskQueueExplanation{
+		TaskID:         t.Id,
+		Status:         t.Status,
+		DistroID:       t.DistroId,
+		EstimatedStart: -1,
+	}
+
+	if !t.IsHostTask() {
+		explanation.Reasons = append(explanation.Reasons, "task does not run on hosts, so it is not in a distro's task queue")
+		return explanation, nil
+	}
+	if t.Status != evergreen.TaskUndispatched {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("task is not waiting to run because its status is '%s'", t.Status))
+		return explanation, nil
+	}
+	if !t.Activated {
+		explanation.Reasons = append(explanation.Reasons, "task is not scheduled")
+		return explanation, nil
+	}
+	if t.Priority <= evergreen.DisabledTaskPriority {
+		explanation.Reasons = append(explanation.Reasons, "task is disabled")
+		return explanation, nil
+	}
+
+	unmetDeps, err := findUnmetDependencies(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding unmet dependencies")
+	}
+	explanation.UnmetDependencies = unmetDeps
+	for _, dep := range unmetDeps {
+		if dep.Unattainable {
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("dependency '%s' finished with status '%s', so the task will not run unless its dependencies are overridden", dep.TaskID, dep.Status))
+		} else {
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("waiting on dependency '%s' with status '%s'", dep.TaskID, dep.Status))
+		}
+	}
+
+	if err = explanation.findQueuePosition(t); err != nil {
+		return nil, errors.Wrap(err, "finding task's position in the task queue")
+	}
+	if !explanation.InQueue {
+		explanation.Reasons = append(explanation.Reasons, "task is not in a task queue yet; the scheduler may not have run since it was scheduled")
+	} else if explanation.Position > 1 {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("there are %d tasks ahead of it in distro '%s'", explanation.Position-1, explanation.QueueDistroID))
+	} else if len(unmetDeps) == 0 {
+		explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("task is next in the queue for distro '%s' and is waiting for a free host", explanation.QueueDistroID))
+	}
+
+	d, err := distro.FindOneId(ctx, explanation.queueDistroOrDefault())
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding distro '%s'", explanation.queueDistroOrDefault())
+	}
+	if d != nil {
+		explanation.DistroDisabled = d.Disabled
+		if d.Disabled {
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("distro '%s' is disabled", d.Id))
+		}
+
+		hosts, err := host.AllActiveHosts(ctx, d.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding active hosts in distro '%s'", d.Id)
+		}
+		explanation.NumHosts = len(hosts)
+		explanation.MaximumHosts = d.HostAllocatorSettings.MaximumHosts
+		if d.IsEphemeral() && explanation.MaximumHosts > 0 && explanation.NumHosts >= explanation.MaximumHosts {
+			explanation.AtMaximumHosts = true
+			explanation.Reasons = append(explanation.Reasons, fmt.Sprintf("distro '%s' is at its maximum of %d hosts", d.Id, explanation.MaximumHosts))
+		}
+	}
+
+	explanation.EstimatedStart, err = GetEstimatedStartTime(ctx, t)
+	if err != nil {
+		return nil, errors.Wrap(err, "estimating start time")
+	}
+
+	return explanation, nil
+}
+
+// findQueuePosition finds the task in its distro's queue, or if it's not
+// there, in one of its secondary distros' queues.
+func (e *TaskQueueExplanation) findQueuePosition(t task.Task) error {
+	type queueCandidate struct {
+		distroID   string
+		collection string
+	}
+	candidates := []queueCandidate{{distroID: t.DistroId, collection: TaskQueuesCollection}}
+	for _, distroID := range t.SecondaryDistros {
+		candidates = append(candidates, queueCandidate{distroID: distroID, collection: TaskSecondaryQueuesCollection})
+	}
+
+	for _, c := range candidates {
+		queue, err := findTaskQueueContainingTask(c.distroID, c.collection, t.Id)
+		if err != nil {
+			return errors.Wrapf(err, "finding task queue for distro '%s'", c.distroID)
+		}
+		if queue == nil {
+			continue
+		}
+
+		var position int
+		for _, item := range queue.Queue {
+			if item.IsDispatched {
+				continue
+			}
+			e.QueueLength++
+			if item.Id == t.Id {
+				position = e.QueueLength
+				e.SortingValueBreakdown = item.SortingValueBreakdown
+			}
+		}
+		if position == 0 {
+			e.QueueLength = 0
+			continue
+		}
+
+		e.InQueue = true
+		e.QueueDistroID = c.distroID
+		e.SecondaryQueue = c.collection == TaskSecondaryQueuesCollection
+		e.Position = position
+		e.QueueGeneratedAt = queue.GeneratedAt
+		return nil
+	}
+
+	return nil
+}
+
+func (e *TaskQueueExplanation) queueDistroOrDefault() string {
+	if e.QueueDistroID != "" {
+		return e.QueueDistroID
+	}
+	return e.DistroID
+}
+
+// findTaskQueueContainingTask returns the distro's task queue if it contains
+// the task.
+func findTaskQueueContainingTask(distroID, collection, taskID string) (*TaskQueue, error) {
+	queue := TaskQueue{}
+	q := db.Query(bson.M{
+		taskQueueDistroKey: distroID,
+		bsonutil.GetDottedKeyName(taskQueueQueueKey, taskQueueItemIdKey): taskID,
+	})
+	if err := db.FindOneQ(collection, q, &queue); err != nil {
+		if adb.ResultsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &queue, nil
+}
+
+// findUnmetDependencies returns the task's dependencies whose statuses do not
+// yet satisfy the dependency.
+func findUnmetDependencies(t task.Task) ([]UnmetDependency, error) {
+	if len(t.DependsOn) == 0 || t.OverrideDependencies {
+		return nil, nil
+	}
+
+	depIDs := make([]string, 0, len(t.DependsOn))
+	for _, dep := range t.DependsOn {
+		depIDs = append(depIDs, dep.TaskId)
+	}
+	depTasks, err := task.FindWithFields(task.ByIds(depIDs), task.IdKey, task.DisplayNameKey, task.StatusKey, task.DependsOnKey, task.ActivatedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding dependency tasks")
+	}
+	depTasksByID := make(map[string]task.Task, len(depTasks))
+	for _, depTask := range depTasks {
+		depTasksByID[depTask.Id] = depTask
+	}
+
+	var unmet []UnmetDependency
+	for _, dep := range t.DependsOn {
+		depTask, ok := depTasksByID[dep.TaskId]
+		if !ok {
+			unmet = append(unmet, UnmetDependency{
+				TaskID:         dep.TaskId,
+				RequiredStatus: requiredDependencyStatus(dep.Status),
+				Unattainable:   dep.Unattainable,
+			})
+			continue
+		}
+		if t.SatisfiesDependency(&depTask) {
+			continue
+		}
+		unmet = append(unmet, UnmetDependency{
+			TaskID:         depTask.Id,
+			DisplayName:    depTask.DisplayName,
+			Status:         depTask.Status,
+			RequiredStatus: requiredDependencyStatus(dep.Status),
+			// A finished dependency that doesn't satisfy the dependency
+			// won't satisfy it unless it's restarted.
+			Unattainable: dep.Unattainable || evergreen.IsFinishedTaskStatus(depTask.Status),
+		})
+	}
+
+	return unmet, nil
+}
+
+func requiredDependencyStatus(status string) string {
+	if status == "" {
+		return evergreen.TaskSucceeded
+	}
+	return status
+}
@@ -0,0 +1,165 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainTaskQueue(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, distro.Collection, host.Collection, TaskQueuesCollection, TaskSecondaryQueuesCollection))
+	}()
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro){
+		"ExplainsPositionInQueue": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			require.NoError(t, tsk.Insert())
+			breakdown := &task.SortingValueBreakdown{TotalValue: 100, Priority: 1, NumTasks: 1, PatchValue: 99}
+			queue := NewTaskQueue(d.Id, []TaskQueueItem{
+				{Id: "dispatched", IsDispatched: true},
+				{Id: "ahead", ExpectedDuration: time.Minute},
+				{Id: tsk.Id, ExpectedDuration: time.Minute, SortingValueBreakdown: breakdown},
+				{Id: "behind", ExpectedDuration: time.Minute},
+			}, DistroQueueInfo{Length: 4})
+			require.NoError(t, queue.Save())
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.True(t, explanation.InQueue)
+			assert.Equal(t, d.Id, explanation.QueueDistroID)
+			assert.False(t, explanation.SecondaryQueue)
+			assert.Equal(t, 2, explanation.Position)
+			assert.Equal(t, 3, explanation.QueueLength)
+			require.NotNil(t, explanation.SortingValueBreakdown)
+			assert.Equal(t, *breakdown, *explanation.SortingValueBreakdown)
+			assert.Empty(t, explanation.UnmetDependencies)
+			assert.False(t, explanation.DistroDisabled)
+			assert.False(t, explanation.AtMaximumHosts)
+			assert.NotEmpty(t, explanation.Reasons)
+		},
+		"FindsTaskInSecondaryQueue": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			tsk.SecondaryDistros = []string{"secondary"}
+			require.NoError(t, tsk.Insert())
+			secondary := distro.Distro{Id: "secondary", Provider: evergreen.ProviderNameEc2Fleet}
+			require.NoError(t, secondary.Insert(ctx))
+			queue := NewTaskQueue(secondary.Id, []TaskQueueItem{{Id: tsk.Id}}, DistroQueueInfo{Length: 1, SecondaryQueue: true})
+			require.NoError(t, queue.Save())
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.True(t, explanation.InQueue)
+			assert.Equal(t, secondary.Id, explanation.QueueDistroID)
+			assert.True(t, explanation.SecondaryQueue)
+			assert.Equal(t, 1, explanation.Position)
+		},
+		"ExplainsTaskNotInQueue": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			require.NoError(t, tsk.Insert())
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.False(t, explanation.InQueue)
+			assert.Zero(t, explanation.Position)
+			assert.Equal(t, time.Duration(-1), explanation.EstimatedStart)
+		},
+		"ExplainsUnmetDependencies": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			running := task.Task{Id: "running", DisplayName: "compile", Status: evergreen.TaskStarted}
+			failed := task.Task{Id: "failed", DisplayName: "lint", Status: evergreen.TaskFailed}
+			succeeded := task.Task{Id: "succeeded", Status: evergreen.TaskSucceeded}
+			for _, dep := range []task.Task{running, failed, succeeded} {
+				require.NoError(t, dep.Insert())
+			}
+			tsk.DependsOn = []task.Dependency{
+				{TaskId: running.Id, Status: evergreen.TaskSucceeded},
+				{TaskId: failed.Id, Status: evergreen.TaskSucceeded},
+				{TaskId: succeeded.Id, Status: evergreen.TaskSucceeded},
+			}
+			require.NoError(t, tsk.Insert())
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			require.Len(t, explanation.UnmetDependencies, 2)
+			assert.Equal(t, UnmetDependency{
+				TaskID:         running.Id,
+				DisplayName:    running.DisplayName,
+				Status:         evergreen.TaskStarted,
+				RequiredStatus: evergreen.TaskSucceeded,
+			}, explanation.UnmetDependencies[0])
+			assert.Equal(t, UnmetDependency{
+				TaskID:         failed.Id,
+				DisplayName:    failed.DisplayName,
+				Status:         evergreen.TaskFailed,
+				RequiredStatus: evergreen.TaskSucceeded,
+				Unattainable:   true,
+			}, explanation.UnmetDependencies[1])
+		},
+		"ExplainsDisabledDistro": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			require.NoError(t, tsk.Insert())
+			d.Disabled = true
+			require.NoError(t, d.ReplaceOne(ctx))
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.True(t, explanation.DistroDisabled)
+		},
+		"ExplainsDistroAtMaximumHosts": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			require.NoError(t, tsk.Insert())
+			for _, id := range []string{"h1", "h2"} {
+				h := host.Host{
+					Id:        id,
+					Distro:    d,
+					Status:    evergreen.HostRunning,
+					StartedBy: evergreen.User,
+				}
+				require.NoError(t, h.Insert(ctx))
+			}
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.Equal(t, 2, explanation.NumHosts)
+			assert.Equal(t, 2, explanation.MaximumHosts)
+			assert.True(t, explanation.AtMaximumHosts)
+		},
+		"SkipsTaskThatIsNotWaiting": func(ctx context.Context, t *testing.T, tsk task.Task, d distro.Distro) {
+			tsk.Status = evergreen.TaskStarted
+			require.NoError(t, tsk.Insert())
+
+			explanation, err := ExplainTaskQueue(ctx, tsk)
+			require.NoError(t, err)
+			assert.False(t, explanation.InQueue)
+			require.Len(t, explanation.Reasons, 1)
+			assert.Contains(t, explanation.Reasons[0], evergreen.TaskStarted)
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			require.NoError(t, db.ClearCollections(task.Collection, distro.Collection, host.Collection, TaskQueuesCollection, TaskSecondaryQueuesCollection))
+
+			d := distro.Distro{
+				Id:       "distro",
+				Provider: evergreen.ProviderNameEc2Fleet,
+				HostAllocatorSettings: distro.HostAllocatorSettings{
+					MaximumHosts: 2,
+				},
+			}
+			require.NoError(t, d.Insert(ctx))
+			tsk := task.Task{
+				Id:        "task",
+				DistroId:  d.Id,
+				Status:    evergreen.TaskUndispatched,
+				Activated: true,
+			}
+
+			tCase(ctx, t, tsk, d)
+		})
+	}
+}
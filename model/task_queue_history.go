@@ -0,0 +1,218 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/mongodb/anser/bsonutil"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	TaskQueueHistoryCollection = "task_queue_history"
+
+	// daysPerWeek is the number of daily buckets in a distro demand
+	// forecast.
+	daysPerWeek = 7
+	// hoursPerWeek is the number of hourly buckets in a distro demand
+	// forecast.
+	hoursPerWeek = daysPerWeek * 24
+	// demandForecastHistoryWeeks is the number of weeks of task queue history
+	// that are used to forecast demand.
+	demandForecastHistoryWeeks = 4
+	// demandForecastMinSamples is the minimum number of weeks that an hour
+	// must have been observed before its demand is forecasted. This prevents
+	// a one-off spike in the queue from being treated as a recurring peak.
+	demandForecastMinSamples = 2
+)
+
+// TaskQueueHistory is an hourly summary of the state of a distro's primary
+// task queue. It is updated each time the scheduler persists the distro's
+// queue, so it records how demand for the distro's hosts varies over time.
+type TaskQueueHistory struct {
+	ID                    TaskQueueHistoryID `bson:"_id" json:"id"`
+	NumSnapshots          int                `bson:"num_snapshots" json:"num_snapshots"`
+	TotalLength           int                `bson:"total_length" json:"total_length"`
+	MaxLength             int                `bson:"max_length" json:"max_length"`
+	TotalExpectedDuration time.Duration      `bson:"total_expected_duration" json:"total_expected_duration"`
+	MaxExpectedDuration   time.Duration      `bson:"max_expected_duration" json:"max_expected_duration"`
+}
+
+// TaskQueueHistoryID identifies the distro and the hour that the task queue
+// history summarizes.
+type TaskQueueHistoryID struct {
+	Distro string    `bson:"distro" json:"distro"`
+	Hour   time.Time `bson:"hour" json:"hour"`
+}
+
+var (
+	taskQueueHistoryIDKey                    = bsonutil.MustHaveTag(TaskQueueHistory{}, "ID")
+	taskQueueHistoryNumSnapshotsKey          = bsonutil.MustHaveTag(TaskQueueHistory{}, "NumSnapshots")
+	taskQueueHistoryTotalLengthKey           = bsonutil.MustHaveTag(TaskQueueHistory{}, "TotalLength")
+	taskQueueHistoryMaxLengthKey             = bsonutil.MustHaveTag(TaskQueueHistory{}, "MaxLength")
+	taskQueueHistoryTotalExpectedDurationKey = bsonutil.MustHaveTag(TaskQueueHistory{}, "TotalExpectedDuration")
+	taskQueueHistoryMaxExpectedDurationKey   = bsonutil.MustHaveTag(TaskQueueHistory{}, "MaxExpectedDuration")
+
+	taskQueueHistoryIDDistroKey = bsonutil.MustHaveTag(TaskQueueHistoryID{}, "Distro")
+	taskQueueHistoryIDHourKey   = bsonutil.MustHaveTag(TaskQueueHistoryID{}, "Hour")
+)
+
+// RecordTaskQueueHistory adds a snapshot of the distro's queue at the given
+// time to the distro's task queue history.
+func RecordTaskQueueHistory(distroID string, info DistroQueueInfo, ts time.Time) error {
+	id := TaskQueueHistoryID{
+		Distro: distroID,
+		Hour:   ts.UTC().Truncate(time.Hour),
+	}
+	_, err := db.Upsert(
+		TaskQueueHistoryCollection,
+		bson.M{taskQueueHistoryIDKey: id},
+		bson.M{
+			"$inc": bson.M{
+				taskQueueHistoryNumSnapshotsKey:          1,
+				taskQueueHistoryTotalLengthKey:           info.Length,
+				taskQueueHistoryTotalExpectedDurationKey: info.ExpectedDuration,
+			},
+			"$max": bson.M{
+				taskQueueHistoryMaxLengthKey:           info.Length,
+				taskQueueHistoryMaxExpectedDurationKey: info.ExpectedDuration,
+			},
+		},
+	)
+	return errors.Wrapf(err, "recording task queue history for distro '%s'", distroID)
+}
+
+// FindTaskQueueHistory returns the distro's task queue history for all hours
+// at or after the given time, sorted by hour.
+func FindTaskQueueHistory(distroID string, after time.Time) ([]TaskQueueHistory, error) {
+	q := db.Query(bson.M{
+		bsonutil.GetDottedKeyName(taskQueueHistoryIDKey, taskQueueHistoryIDDistroKey): distroID,
+		bsonutil.GetDottedKeyName(taskQueueHistoryIDKey, taskQueueHistoryIDHourKey):   bson.M{"$gte": after.UTC()},
+	}).Sort([]string{bsonutil.GetDottedKeyName(taskQueueHistoryIDKey, taskQueueHistoryIDHourKey)})
+
+	history := []TaskQueueHistory{}
+	if err := db.FindAllQ(TaskQueueHistoryCollection, q, &history); err != nil {
+		return nil, errors.Wrapf(err, "finding task queue history for distro '%s'", distroID)
+	}
+	return history, nil
+}
+
+// DistroDemandForecast predicts the demand for a distro's hosts from its task
+// queue history and its daily task stats. Queue demand is grouped by hour of
+// the week (in UTC), so recurring peaks such as nightly periodic builds can be
+// anticipated before the tasks are actually in the queue. The task stats
+// forecast the total work that runs on the distro on each day of the week.
+type DistroDemandForecast struct {
+	Distro string
+	// HourlyExpectedDuration is the forecasted peak expected duration of the
+	// distro's queue for each hour of the week, starting from Sunday at
+	// midnight UTC. Hours without enough history are zero.
+	HourlyExpectedDuration [hoursPerWeek]time.Duration
+	// DailyTaskDuration is the forecasted total runtime of the distro's tasks
+	// for each day of the week, starting from Sunday (in UTC). Days without
+	// enough task stats are zero.
+	DailyTaskDuration [daysPerWeek]time.Duration
+}
+
+// NewDistroDemandForecast creates a demand forecast from the distro's task
+// queue history. The forecasted demand for each hour of the week is the
+// average of that hour's peak queue duration across the weeks in which it was
+// recorded.
+func NewDistroDemandForecast(distroID string, history []TaskQueueHistory) DistroDemandForecast {
+	var totals [hoursPerWeek]time.Duration
+	var samples [hoursPerWeek]int
+	for _, h := range history {
+		if h.ID.Distro != distroID || h.NumSnapshots == 0 {
+			continue
+		}
+		bucket := hourOfWeek(h.ID.Hour)
+		totals[bucket] += h.MaxExpectedDuration
+		samples[bucket]++
+	}
+
+	forecast := DistroDemandForecast{Distro: distroID}
+	for i := range totals {
+		if samples[i] < demandForecastMinSamples {
+			continue
+		}
+		forecast.HourlyExpectedDuration[i] = totals[i] / time.Duration(samples[i])
+	}
+	return forecast
+}
+
+// AddDailyTaskDurations adds the distro's daily task runtimes from the task
+// stats to the forecast. The forecasted runtime for each day of the week is
+// the average of that day's runtime across the weeks in which it was recorded.
+func (f *DistroDemandForecast) AddDailyTaskDurations(durations []taskstats.DistroDailyTaskDuration) {
+	var totals [daysPerWeek]time.Duration
+	var samples [daysPerWeek]int
+	for _, d := range durations {
+		day := int(d.Date.UTC().Weekday())
+		totals[day] += time.Duration(d.TotalDurationSecs * float64(time.Second))
+		samples[day]++
+	}
+
+	for i := range totals {
+		if samples[i] < demandForecastMinSamples {
+			f.DailyTaskDuration[i] = 0
+			continue
+		}
+		f.DailyTaskDuration[i] = totals[i] / time.Duration(samples[i])
+	}
+}
+
+// GetDistroDemandForecast creates a demand forecast for the distro from its
+// recent task queue history and task stats.
+func GetDistroDemandForecast(distroID string, now time.Time) (*DistroDemandForecast, error) {
+	since := now.Add(-demandForecastHistoryWeeks * 7 * 24 * time.Hour)
+	history, err := FindTaskQueueHistory(distroID, since)
+	if err != nil {
+		return nil, err
+	}
+	durations, err := taskstats.GetDistroDailyTaskDurations(distroID, since)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting daily task durations")
+	}
+
+	forecast := NewDistroDemandForecast(distroID, history)
+	forecast.AddDailyTaskDurations(durations)
+	return &forecast, nil
+}
+
+// PeakExpectedDuration returns the highest forecasted queue duration for any
+// hour that overlaps the window starting at the given time.
+func (f *DistroDemandForecast) PeakExpectedDuration(start time.Time, window time.Duration) time.Duration {
+	var peak time.Duration
+	end := start.Add(window)
+	for ts := start.UTC().Truncate(time.Hour); !ts.After(end); ts = ts.Add(time.Hour) {
+		if d := f.HourlyExpectedDuration[hourOfWeek(ts)]; d > peak {
+			peak = d
+		}
+	}
+	return peak
+}
+
+// PeakBusyHosts returns the highest forecasted number of hosts that are busy
+// running the distro's tasks on any day that overlaps the window starting at
+// the given time. This assumes that each day's task runtime is spread evenly
+// across the day.
+func (f *DistroDemandForecast) PeakBusyHosts(start time.Time, window time.Duration) int {
+	var peak time.Duration
+	end := start.Add(window)
+	for ts := start.UTC().Truncate(24 * time.Hour); !ts.After(end); ts = ts.Add(24 * time.Hour) {
+		if d := f.DailyTaskDuration[ts.Weekday()]; d > peak {
+			peak = d
+		}
+	}
+	return int(math.Ceil(float64(peak) / float64(24*time.Hour)))
+}
+
+// hourOfWeek returns the index of the hour within the week (in UTC), starting
+// from Sunday at midnight.
+func hourOfWeek(ts time.Time) int {
+	ts = ts.UTC()
+	return int(ts.Weekday())*24 + ts.Hour()
+}
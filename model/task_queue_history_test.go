@@ -0,0 +1,102 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordTaskQueueHistory(t *testing.T) {
+	require.NoError(t, db.Clear(TaskQueueHistoryCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(TaskQueueHistoryCollection))
+	}()
+
+	hour := time.Date(2023, time.May, 7, 2, 0, 0, 0, time.UTC)
+	require.NoError(t, RecordTaskQueueHistory("d1", DistroQueueInfo{Length: 2, ExpectedDuration: time.Hour}, hour.Add(5*time.Minute)))
+	require.NoError(t, RecordTaskQueueHistory("d1", DistroQueueInfo{Length: 4, ExpectedDuration: 3 * time.Hour}, hour.Add(10*time.Minute)))
+	require.NoError(t, RecordTaskQueueHistory("d1", DistroQueueInfo{Length: 1, ExpectedDuration: time.Minute}, hour.Add(time.Hour)))
+	require.NoError(t, RecordTaskQueueHistory("d2", DistroQueueInfo{Length: 1, ExpectedDuration: time.Minute}, hour))
+
+	history, err := FindTaskQueueHistory("d1", hour)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	assert.Equal(t, "d1", history[0].ID.Distro)
+	assert.True(t, hour.Equal(history[0].ID.Hour))
+	assert.Equal(t, 2, history[0].NumSnapshots)
+	assert.Equal(t, 6, history[0].TotalLength)
+	assert.Equal(t, 4, history[0].MaxLength)
+	assert.Equal(t, 4*time.Hour, history[0].TotalExpectedDuration)
+	assert.Equal(t, 3*time.Hour, history[0].MaxExpectedDuration)
+
+	assert.True(t, hour.Add(time.Hour).Equal(history[1].ID.Hour))
+	assert.Equal(t, 1, history[1].NumSnapshots)
+
+	history, err = FindTaskQueueHistory("d1", hour.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestDistroDemandForecast(t *testing.T) {
+	// Sunday at 02:00 UTC.
+	peak := time.Date(2023, time.May, 7, 2, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	makeHistory := func(hour time.Time, maxDuration time.Duration) TaskQueueHistory {
+		return TaskQueueHistory{
+			ID:                  TaskQueueHistoryID{Distro: "d1", Hour: hour},
+			NumSnapshots:        6,
+			MaxExpectedDuration: maxDuration,
+		}
+	}
+
+	t.Run("AveragesPeaksAcrossWeeks", func(t *testing.T) {
+		forecast := NewDistroDemandForecast("d1", []TaskQueueHistory{
+			makeHistory(peak.Add(-2*week), 4*time.Hour),
+			makeHistory(peak.Add(-week), 6*time.Hour),
+			makeHistory(peak.Add(-week+time.Hour), time.Hour),
+		})
+		assert.Equal(t, "d1", forecast.Distro)
+		assert.Equal(t, 5*time.Hour, forecast.HourlyExpectedDuration[hourOfWeek(peak)])
+		assert.Zero(t, forecast.HourlyExpectedDuration[hourOfWeek(peak.Add(time.Hour))], "hour observed only once should not be forecasted")
+	})
+	t.Run("IgnoresOtherDistros", func(t *testing.T) {
+		other := makeHistory(peak.Add(-week), 6*time.Hour)
+		other.ID.Distro = "d2"
+		forecast := NewDistroDemandForecast("d1", []TaskQueueHistory{
+			makeHistory(peak.Add(-2*week), 4*time.Hour),
+			other,
+		})
+		assert.Zero(t, forecast.HourlyExpectedDuration[hourOfWeek(peak)])
+	})
+	t.Run("PeakExpectedDurationLooksAhead", func(t *testing.T) {
+		forecast := NewDistroDemandForecast("d1", []TaskQueueHistory{
+			makeHistory(peak.Add(-2*week), 4*time.Hour),
+			makeHistory(peak.Add(-week), 4*time.Hour),
+		})
+		assert.Equal(t, 4*time.Hour, forecast.PeakExpectedDuration(peak.Add(-30*time.Minute), 30*time.Minute))
+		assert.Equal(t, 4*time.Hour, forecast.PeakExpectedDuration(peak.Add(30*time.Minute), 30*time.Minute))
+		assert.Zero(t, forecast.PeakExpectedDuration(peak.Add(-2*time.Hour), 30*time.Minute))
+		assert.Zero(t, forecast.PeakExpectedDuration(peak.Add(time.Hour), 30*time.Minute))
+		assert.Equal(t, 4*time.Hour, forecast.PeakExpectedDuration(peak.Add(week-10*time.Minute), 30*time.Minute), "forecast should repeat every week")
+	})
+	t.Run("AveragesDailyTaskDurationsAcrossWeeks", func(t *testing.T) {
+		day := peak.Truncate(24 * time.Hour)
+		forecast := NewDistroDemandForecast("d1", nil)
+		forecast.AddDailyTaskDurations([]taskstats.DistroDailyTaskDuration{
+			{Date: day.Add(-2 * week), TotalDurationSecs: (40 * time.Hour).Seconds()},
+			{Date: day.Add(-week), TotalDurationSecs: (56 * time.Hour).Seconds()},
+			{Date: day.Add(-week + 24*time.Hour), TotalDurationSecs: (24 * time.Hour).Seconds()},
+		})
+		assert.Equal(t, 48*time.Hour, forecast.DailyTaskDuration[time.Sunday])
+		assert.Zero(t, forecast.DailyTaskDuration[time.Monday], "day observed only once should not be forecasted")
+
+		assert.Equal(t, 2, forecast.PeakBusyHosts(peak, 30*time.Minute))
+		assert.Equal(t, 2, forecast.PeakBusyHosts(day.Add(-10*time.Minute), 30*time.Minute), "should look ahead to the next day")
+		assert.Zero(t, forecast.PeakBusyHosts(day.Add(24*time.Hour), 30*time.Minute))
+	})
+}
@@ -0,0 +1,155 @@
+package model
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	adb "github.com/mongodb/anser/db"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// getAutomaticRetryPolicy returns the retry policy that applies to the
+// finished task if the task should be automatically retried. It returns nil
+// if the task should not be retried.
+func getAutomaticRetryPolicy(ctx context.Context, t *task.Task) (*RetryPolicy, error) {
+	if t.Status != evergreen.TaskFailed || t.Aborted {
+		return nil, nil
+	}
+	// Execution tasks and tasks in single host task groups are restarted
+	// along with the rest of their display task or task group, so they can't
+	// be retried on their own.
+	if t.DisplayOnly || t.IsPartOfDisplay() || t.IsPartOfSingleHostTaskGroup() {
+		return nil, nil
+	}
+	if t.Execution >= evergreen.MaxTaskExecution {
+		return nil, nil
+	}
+
+	project, err := FindProjectFromVersionID(t.Version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding project for version '%s'", t.Version)
+	}
+	bvtu := project.FindTaskForVariant(t.DisplayName, t.BuildVariant)
+	if bvtu == nil || bvtu.Retry == nil {
+		return nil, nil
+	}
+	policy := bvtu.Retry
+
+	if t.NumAutomaticRetries+1 >= policy.MaxAttempts {
+		return nil, nil
+	}
+	failureType := getRetryFailureType(t)
+	if !utility.StringSliceContains(policy.FailureTypes, failureType) {
+		return nil, nil
+	}
+	if failureType == RetryFailureTypeTest && len(policy.TestPatterns) > 0 {
+		matches, err := failedTestsMatchPatterns(ctx, t, policy.TestPatterns)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if !matches {
+			return nil, nil
+		}
+	}
+
+	return policy, nil
+}
+
+// getRetryFailureType returns the retry policy failure type of the failed
+// task.
+func getRetryFailureType(t *task.Task) string {
+	switch {
+	case t.Details.Type == evergreen.CommandTypeSystem:
+		return RetryFailureTypeSystem
+	case t.Details.Type == evergreen.CommandTypeSetup:
+		return RetryFailureTypeSetup
+	case t.Details.TimedOut:
+		return RetryFailureTypeTimeout
+	default:
+		return RetryFailureTypeTest
+	}
+}
+
+// failedTestsMatchPatterns returns whether the task has failed tests and all
+// of them match at least one of the patterns.
+func failedTestsMatchPatterns(ctx context.Context, t *task.Task, patterns []string) (bool, error) {
+	failed, err := getFailedTestNames(ctx, t)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if len(failed) == 0 {
+		return false, nil
+	}
+
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, errors.Wrapf(err, "compiling test pattern '%s'", pattern)
+		}
+		regexps = append(regexps, re)
+	}
+
+	for _, testName := range failed {
+		if !anyRegexpMatches(regexps, testName) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func anyRegexpMatches(regexps []*regexp.Regexp, s string) bool {
+	for _, re := range regexps {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryTaskAutomatically restarts the failed task according to its retry
+// policy. Unlike a manual restart, the failed execution's dependents are
+// never blocked and its build and version never see the failure, since the
+// retry is not the task's final outcome.
+func retryTaskAutomatically(ctx context.Context, t *task.Task, policy *RetryPolicy) error {
+	var retryAt time.Time
+	if policy.BackoffSecs > 0 {
+		retryAt = time.Now().Add(time.Duration(policy.BackoffSecs) * time.Second)
+	}
+
+	if err := t.Archive(); err != nil {
+		return errors.Wrap(err, "archiving task")
+	}
+	if err := t.ResetForAutomaticRetry(ctx, retryAt); err != nil {
+		// If the task was already reset, there is nothing left to retry.
+		if adb.ResultsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "resetting task in database")
+	}
+	event.LogTaskRestarted(t.Id, t.Execution, evergreen.AutomaticRetryActivator)
+
+	if err := t.ActivateTask(evergreen.AutomaticRetryActivator); err != nil {
+		return errors.Wrap(err, "activating task")
+	}
+
+	grip.Info(message.Fields{
+		"message":      "automatically retrying failed task",
+		"task_id":      t.Id,
+		"execution":    t.Execution,
+		"project":      t.Project,
+		"attempt":      t.NumAutomaticRetries + 1,
+		"max_attempts": policy.MaxAttempts,
+		"retry_at":     retryAt,
+	})
+
+	return errors.Wrap(UpdateBuildAndVersionStatusForTask(ctx, t), "updating build/version status")
+}
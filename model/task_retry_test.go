@@ -0,0 +1,254 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRetryFailureType(t *testing.T) {
+	for failureType, details := range map[string]apimodels.TaskEndDetail{
+		RetryFailureTypeSystem:  {Type: evergreen.CommandTypeSystem, TimedOut: true},
+		RetryFailureTypeSetup:   {Type: evergreen.CommandTypeSetup},
+		RetryFailureTypeTimeout: {Type: evergreen.CommandTypeTest, TimedOut: true},
+		RetryFailureTypeTest:    {Type: evergreen.CommandTypeTest},
+	} {
+		tsk := &task.Task{Status: evergreen.TaskFailed, Details: details}
+		assert.Equal(t, failureType, getRetryFailureType(tsk))
+	}
+	assert.Equal(t, RetryFailureTypeTest, getRetryFailureType(&task.Task{Status: evergreen.TaskFailed}))
+}
+
+func TestMarkEndWithAutomaticRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, build.Collection, host.Collection, VersionCollection, ParserProjectCollection, ProjectRefCollection, event.EventCollection))
+	}()
+
+	settings := &evergreen.Settings{}
+	details := &apimodels.TaskEndDetail{
+		Status: evergreen.TaskFailed,
+		Type:   evergreen.CommandTypeSystem,
+	}
+	// setup inserts a running task whose retry policy retries system
+	// failures and a task that depends on it.
+	setup := func(t *testing.T, policy RetryPolicy) *task.Task {
+		require.NoError(t, db.ClearCollections(task.Collection, task.OldCollection, build.Collection, host.Collection, VersionCollection, ParserProjectCollection, ProjectRefCollection, event.EventCollection))
+
+		pRef := ProjectRef{Id: "p1"}
+		require.NoError(t, pRef.Insert())
+		v := Version{
+			Id:        "v",
+			Requester: evergreen.RepotrackerVersionRequester,
+			Status:    evergreen.VersionStarted,
+		}
+		require.NoError(t, v.Insert())
+		pp := ParserProject{
+			Id:         v.Id,
+			Identifier: utility.ToStringPtr("p1"),
+			Tasks: []parserTask{
+				{Name: "retried", Retry: &policy},
+				{Name: "dependent"},
+			},
+			BuildVariants: []parserBV{{
+				Name: "bv",
+				Tasks: parserBVTaskUnits{
+					{Name: "retried"},
+					{Name: "dependent"},
+				},
+			}},
+		}
+		require.NoError(t, pp.Insert())
+		b := build.Build{
+			Id:      "b",
+			Version: v.Id,
+			Status:  evergreen.BuildStarted,
+		}
+		require.NoError(t, b.Insert())
+		tsk := task.Task{
+			Id:           "t1",
+			DisplayName:  "retried",
+			BuildVariant: "bv",
+			BuildId:      b.Id,
+			Version:      v.Id,
+			Project:      pRef.Id,
+			Requester:    evergreen.RepotrackerVersionRequester,
+			Status:       evergreen.TaskStarted,
+			Activated:    true,
+			HostId:       "h1",
+		}
+		require.NoError(t, tsk.Insert())
+		dependent := task.Task{
+			Id:           "t2",
+			DisplayName:  "dependent",
+			BuildVariant: "bv",
+			BuildId:      b.Id,
+			Version:      v.Id,
+			Project:      pRef.Id,
+			Requester:    evergreen.RepotrackerVersionRequester,
+			Status:       evergreen.TaskUndispatched,
+			Activated:    true,
+			DependsOn: []task.Dependency{
+				{TaskId: tsk.Id, Status: evergreen.TaskSucceeded},
+			},
+		}
+		require.NoError(t, dependent.Insert())
+		h := host.Host{
+			Id:          "h1",
+			RunningTask: tsk.Id,
+		}
+		require.NoError(t, h.Insert(ctx))
+
+		return &tsk
+	}
+	// startRetry marks the retried execution as running again.
+	startRetry := func(t *testing.T) *task.Task {
+		require.NoError(t, db.Update(task.Collection, bson.M{task.IdKey: "t1"}, bson.M{"$set": bson.M{
+			task.StatusKey: evergreen.TaskStarted,
+			task.HostIdKey: "h1",
+		}}))
+		tsk, err := task.FindOneId("t1")
+		require.NoError(t, err)
+		require.NotNil(t, tsk)
+		return tsk
+	}
+	getDependency := func(t *testing.T) task.Dependency {
+		dependent, err := task.FindOneId("t2")
+		require.NoError(t, err)
+		require.NotNil(t, dependent)
+		require.Len(t, dependent.DependsOn, 1)
+		return dependent.DependsOn[0]
+	}
+
+	t.Run("RetriesUntilMaxAttempts", func(t *testing.T) {
+		tsk := setup(t, RetryPolicy{MaxAttempts: 2, FailureTypes: []string{RetryFailureTypeSystem}})
+
+		require.NoError(t, MarkEnd(ctx, settings, tsk, "", time.Now(), details, false))
+
+		retried, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, retried)
+		assert.Equal(t, 1, retried.Execution)
+		assert.Equal(t, evergreen.TaskUndispatched, retried.Status)
+		assert.True(t, retried.Activated)
+		assert.Equal(t, evergreen.AutomaticRetryActivator, retried.ActivatedBy)
+		assert.Equal(t, 1, retried.NumAutomaticRetries)
+		assert.False(t, retried.AutomaticallyRetried)
+		assert.True(t, utility.IsZeroTime(retried.RetryAt))
+
+		// The failed attempt is marked so that it doesn't notify.
+		firstAttempt, err := task.FindOneOldByIdAndExecution(tsk.Id, 0)
+		require.NoError(t, err)
+		require.NotNil(t, firstAttempt)
+		assert.Equal(t, evergreen.TaskFailed, firstAttempt.Status)
+		assert.True(t, firstAttempt.AutomaticallyRetried)
+
+		// The dependent waits for the retry instead of being blocked.
+		dep := getDependency(t)
+		assert.False(t, dep.Finished)
+		assert.False(t, dep.Unattainable)
+
+		b, err := build.FindOneId(tsk.BuildId)
+		require.NoError(t, err)
+		require.NotNil(t, b)
+		assert.NotEqual(t, evergreen.BuildFailed, b.Status)
+
+		// The last attempt allowed by the policy is the final outcome.
+		tsk = startRetry(t)
+		require.NoError(t, MarkEnd(ctx, settings, tsk, "", time.Now(), details, false))
+
+		lastAttempt, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, lastAttempt)
+		assert.Equal(t, 1, lastAttempt.Execution)
+		assert.Equal(t, evergreen.TaskFailed, lastAttempt.Status)
+		assert.False(t, lastAttempt.AutomaticallyRetried)
+		archived, err := task.FindOneOldByIdAndExecution(tsk.Id, 1)
+		require.NoError(t, err)
+		assert.Nil(t, archived)
+
+		dep = getDependency(t)
+		assert.True(t, dep.Finished)
+		assert.True(t, dep.Unattainable)
+
+		b, err = build.FindOneId(tsk.BuildId)
+		require.NoError(t, err)
+		require.NotNil(t, b)
+		assert.Equal(t, evergreen.BuildFailed, b.Status)
+	})
+	t.Run("BacksOffBeforeRetrying", func(t *testing.T) {
+		tsk := setup(t, RetryPolicy{MaxAttempts: 3, FailureTypes: []string{RetryFailureTypeSystem}, BackoffSecs: 600})
+
+		require.NoError(t, MarkEnd(ctx, settings, tsk, "", time.Now(), details, false))
+
+		retried, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, retried)
+		assert.Equal(t, 1, retried.Execution)
+		assert.WithinDuration(t, time.Now().Add(600*time.Second), retried.RetryAt, time.Minute)
+
+		schedulable, err := task.FindHostSchedulable(ctx, "")
+		require.NoError(t, err)
+		for _, schedulableTask := range schedulable {
+			assert.NotEqual(t, tsk.Id, schedulableTask.Id, "task should not be schedulable until its backoff elapses")
+		}
+
+		require.NoError(t, db.Update(task.Collection, bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{task.RetryAtKey: time.Now().Add(-time.Minute)}}))
+		schedulable, err = task.FindHostSchedulable(ctx, "")
+		require.NoError(t, err)
+		var found bool
+		for _, schedulableTask := range schedulable {
+			found = found || schedulableTask.Id == tsk.Id
+		}
+		assert.True(t, found, "task should be schedulable after its backoff elapses")
+	})
+	t.Run("DoesNotRetryUnmatchedFailureType", func(t *testing.T) {
+		tsk := setup(t, RetryPolicy{MaxAttempts: 3, FailureTypes: []string{RetryFailureTypeSetup}})
+
+		require.NoError(t, MarkEnd(ctx, settings, tsk, "", time.Now(), details, false))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Zero(t, dbTask.Execution)
+		assert.Equal(t, evergreen.TaskFailed, dbTask.Status)
+		assert.False(t, dbTask.AutomaticallyRetried)
+
+		dep := getDependency(t)
+		assert.True(t, dep.Finished)
+		assert.True(t, dep.Unattainable)
+	})
+	t.Run("NoopsIfAlreadyReset", func(t *testing.T) {
+		tsk := setup(t, RetryPolicy{MaxAttempts: 3, FailureTypes: []string{RetryFailureTypeSystem}})
+		// Another caller already restarted the failed execution.
+		require.NoError(t, db.Update(task.Collection, bson.M{task.IdKey: tsk.Id}, bson.M{"$set": bson.M{
+			task.StatusKey:    evergreen.TaskUndispatched,
+			task.ExecutionKey: 1,
+		}}))
+		tsk.Status = evergreen.TaskFailed
+		tsk.Details = *details
+
+		require.NoError(t, retryTaskAutomatically(ctx, tsk, &RetryPolicy{MaxAttempts: 3, FailureTypes: []string{RetryFailureTypeSystem}}))
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotNil(t, dbTask)
+		assert.Equal(t, 1, dbTask.Execution)
+		assert.Zero(t, dbTask.NumAutomaticRetries)
+		assert.NotEqual(t, evergreen.AutomaticRetryActivator, dbTask.ActivatedBy)
+	})
+}
@@ -26,6 +26,9 @@ type estimatedTask struct {
 }
 
 type estimatedHost struct {
+	// index identifies the host in the pool that the simulation started
+	// with.
+	index            int
 	timeToCompletion time.Duration
 }
 
@@ -36,6 +39,9 @@ type estimatedTimeSimulator struct {
 	hosts       estimatedHostPool
 	timeElapsed time.Duration
 	currentPos  int
+	// lastHostIndex is the index of the host that the last task was
+	// dispatched to.
+	lastHostIndex int
 }
 
 func (h *estimatedHost) decrementTime(t time.Duration) {
@@ -70,6 +76,7 @@ func (s *estimatedTimeSimulator) dispatchNextTask() {
 	count := len(s.hosts)
 	// fast forward time until the soonest host completes its task
 	fastForwardTime := s.hosts[0].timeToCompletion
+	freedHost := s.hosts[0]
 	s.timeElapsed += fastForwardTime
 	s.hosts = s.hosts[1:]
 	for i := range s.hosts {
@@ -78,7 +85,8 @@ func (s *estimatedTimeSimulator) dispatchNextTask() {
 
 	// dequeue the next task
 	nextTask := s.tasks.Dequeue()
-	newlyDispatched := estimatedHost{timeToCompletion: nextTask.duration}
+	newlyDispatched := estimatedHost{index: freedHost.index, timeToCompletion: nextTask.duration}
+	s.lastHostIndex = freedHost.index
 
 	// assign it to the host that just completed and move it back into the pool in order
 	for i := 0; i < count; i++ {
@@ -121,6 +129,63 @@ func GetEstimatedStartTime(ctx context.Context, t task.Task) (time.Duration, err
 	return createSimulatorModel(*queue, hosts).simulate(queuePos), nil
 }
 
+// SimulatedDispatch is when a task in a simulated task queue starts and which
+// host runs it.
+type SimulatedDispatch struct {
+	// HostIndex is the index of the host that runs the task.
+	HostIndex int
+	// Start is how long after the start of the simulation the task starts.
+	Start time.Duration
+}
+
+// SimulateTaskQueueDispatch simulates dispatching a task queue in order to a
+// pool of hosts, where each task runs for its duration and each host can run
+// its next task once its time to free has elapsed. It returns when each task in
+// the queue starts and which host runs it, stopping at the first task that
+// would not start before the horizon.
+func SimulateTaskQueueDispatch(taskDurations []time.Duration, hostTimesToFree []time.Duration, horizon time.Duration) []SimulatedDispatch {
+	if len(taskDurations) == 0 || len(hostTimesToFree) == 0 {
+		return nil
+	}
+
+	estimator := estimatedTimeSimulator{}
+	for _, d := range taskDurations {
+		_ = estimator.tasks.Enqueue(estimatedTask{duration: d})
+	}
+	for i, d := range hostTimesToFree {
+		if d < 0 {
+			d = 0
+		}
+		estimator.hosts = append(estimator.hosts, estimatedHost{index: i, timeToCompletion: d})
+	}
+
+	var dispatches []SimulatedDispatch
+	for pos := range taskDurations {
+		start := estimator.simulate(pos)
+		if start < 0 || start >= horizon {
+			break
+		}
+		dispatches = append(dispatches, SimulatedDispatch{HostIndex: estimator.lastHostIndex, Start: start})
+	}
+
+	return dispatches
+}
+
+// EstimatedHostReadyDelay returns roughly how long it takes a host in the
+// given status to be ready to run tasks.
+func EstimatedHostReadyDelay(status string) time.Duration {
+	switch status {
+	case evergreen.HostUninitialized, evergreen.HostBuilding:
+		return hostInitializingDelay
+	case evergreen.HostStarting:
+		return hostStartingDelay
+	case evergreen.HostProvisioning:
+		return hostProvisiongingDelay
+	default:
+		return 0
+	}
+}
+
 func createSimulatorModel(taskQueue TaskQueue, hosts []host.Host) *estimatedTimeSimulator {
 	estimator := estimatedTimeSimulator{}
 	for i := 0; i < len(taskQueue.Queue); i++ {
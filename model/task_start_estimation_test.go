@@ -174,6 +174,22 @@ func (s *estimatorSuite) TestEvenDistribution() {
 	}
 }
 
+func (s *estimatorSuite) TestSimulateTaskQueueDispatch() {
+	durations := []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second}
+	hostTimesToFree := []time.Duration{5 * time.Second, 0}
+
+	dispatches := SimulateTaskQueueDispatch(durations, hostTimesToFree, 12*time.Second)
+	s.Equal([]SimulatedDispatch{
+		{HostIndex: 1, Start: 0},
+		{HostIndex: 0, Start: 5 * time.Second},
+		{HostIndex: 1, Start: 10 * time.Second},
+	}, dispatches)
+
+	s.Len(SimulateTaskQueueDispatch(durations, hostTimesToFree, 10*time.Second), 2)
+	s.Empty(SimulateTaskQueueDispatch(durations, nil, time.Minute))
+	s.Empty(SimulateTaskQueueDispatch(nil, hostTimesToFree, time.Minute))
+}
+
 type QueueSuite struct {
 	q estimatedTaskQueue
 	suite.Suite
@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 const (
@@ -205,3 +206,38 @@ func GetTaskStats(filter StatsFilter) ([]TaskStats, error) {
 	}
 	return stats, nil
 }
+
+// DistroDailyTaskDuration is the total time spent running a distro's tasks on
+// a single day.
+type DistroDailyTaskDuration struct {
+	Date time.Time `bson:"_id"`
+	// TotalDurationSecs is the total time spent running the distro's tasks in
+	// seconds.
+	TotalDurationSecs float64 `bson:"total_duration_secs"`
+}
+
+// GetDistroDailyTaskDurations returns the total runtime of the distro's tasks
+// for each day at or after the given date, sorted by date. The runtime is
+// estimated from the precomputed task statistics by assuming that each
+// finished task took the average successful duration of that task.
+func GetDistroDailyTaskDurations(distroID string, after time.Time) ([]DistroDailyTaskDuration, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			DBTaskStatsIDDistroKeyFull: distroID,
+			DBTaskStatsIDDateKeyFull:   bson.M{"$gte": utility.GetUTCDay(after)},
+		}},
+		{"$group": bson.M{
+			"_id": "$" + DBTaskStatsIDDateKeyFull,
+			"total_duration_secs": bson.M{"$sum": bson.M{"$multiply": Array{
+				bson.M{"$add": Array{"$" + DBTaskStatsNumSuccessKey, "$" + DBTaskStatsNumFailedKey}},
+				"$" + DBTaskStatsAvgDurationSuccessKey,
+			}}},
+		}},
+		{"$sort": bson.M{"_id": 1}},
+	}
+	durations := []DistroDailyTaskDuration{}
+	if err := db.Aggregate(DailyTaskStatsCollection, pipeline, &durations); err != nil {
+		return nil, errors.Wrapf(err, "aggregating daily task durations for distro '%s'", distroID)
+	}
+	return durations, nil
+}
@@ -532,6 +532,32 @@ func (s *statsQuerySuite) TestGetTaskStatsPaginationGroupNumDays() {
 	s.checkTaskStats(docs[1], "task2", "v1", "d1", day8, 1, 1, 1, 0, 0, 0, float64(10))
 }
 
+func (s *statsQuerySuite) TestGetDistroDailyTaskDurations() {
+	require := s.Require()
+
+	s.insertDailyTaskStats("p1", "r1", "task1", "v1", "d1", day1, 10, 2, 1, 1, 0, 0, 60)
+	s.insertDailyTaskStats("p2", "r1", "task2", "v2", "d1", day1, 1, 0, 0, 0, 0, 0, 30)
+	s.insertDailyTaskStats("p1", "r1", "task1", "v1", "d1", day2, 1, 1, 0, 0, 0, 0, 100)
+	s.insertDailyTaskStats("p1", "r1", "task1", "v1", "d2", day2, 5, 0, 0, 0, 0, 0, 100)
+
+	durations, err := GetDistroDailyTaskDurations("d1", day1)
+	require.NoError(err)
+	require.Len(durations, 2)
+	require.WithinDuration(day1, durations[0].Date, 0)
+	require.Equal(float64(12*60+30), durations[0].TotalDurationSecs)
+	require.WithinDuration(day2, durations[1].Date, 0)
+	require.Equal(float64(200), durations[1].TotalDurationSecs)
+
+	durations, err = GetDistroDailyTaskDurations("d1", day2)
+	require.NoError(err)
+	require.Len(durations, 1)
+	require.WithinDuration(day2, durations[0].Date, 0)
+
+	durations, err = GetDistroDailyTaskDurations("nonexistent", day1)
+	require.NoError(err)
+	require.Empty(durations)
+}
+
 func (s *statsQuerySuite) checkTaskStats(stats TaskStats, task, variant, distro string, date time.Time, numSuccess, numFailed, numTimeout, numTestFailed, numSystemFailed, numSetupFailed int, avgDuration float64) {
 	require := s.Require()
 	require.Equal(task, stats.TaskName)
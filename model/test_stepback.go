@@ -0,0 +1,300 @@
+package model
+
+import (
+	"context"
+	"sort"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/annotations"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+// finishBisectStepback records the culprit that bisect stepback found for
+// the task that started stepback. If test bisect is enabled for the project,
+// it also records the culprit for each test that failed in both tasks and
+// starts bisecting the tests that failed in the original task but not in the
+// culprit, since those must have been broken by a later commit. Linear
+// stepback doesn't track the task that started it, so culprits are only
+// recorded for bisect stepback.
+func finishBisectStepback(ctx context.Context, s task.StepbackInfo) error {
+	// Stepbacks that started before the original task was tracked have
+	// nothing to record the culprit on.
+	if s.OriginalStepbackTaskId == "" {
+		return nil
+	}
+
+	culprit, err := task.FindOneId(s.LastFailingStepbackTaskId)
+	if err != nil {
+		return errors.Wrapf(err, "finding culprit task '%s'", s.LastFailingStepbackTaskId)
+	}
+	if culprit == nil {
+		return errors.Errorf("culprit task '%s' not found", s.LastFailingStepbackTaskId)
+	}
+	original, err := task.FindOneIdAndExecution(s.OriginalStepbackTaskId, s.OriginalStepbackExecution)
+	if err != nil {
+		return errors.Wrapf(err, "finding original stepback task '%s' execution %d", s.OriginalStepbackTaskId, s.OriginalStepbackExecution)
+	}
+	if original == nil {
+		return errors.Errorf("original stepback task '%s' execution %d not found", s.OriginalStepbackTaskId, s.OriginalStepbackExecution)
+	}
+
+	culprits := []annotations.StepbackCulprit{newStepbackCulprit(culprit, "")}
+
+	projectRef, err := FindMergedProjectRef(original.Project, "", false)
+	if err != nil {
+		return errors.Wrapf(err, "finding merged project ref for task '%s'", original.Id)
+	}
+	// Display tasks only get a culprit for the task as a whole, since their
+	// tests are bisected by their execution tasks.
+	if projectRef == nil || !projectRef.IsStepbackTestBisect() || original.DisplayOnly {
+		return recordStepbackCulprits(original.Id, original.Execution, culprits)
+	}
+
+	originalFailed, err := getFailedTestNames(ctx, original)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	culpritFailed, err := getFailedTestNames(ctx, culprit)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	failing, passing := partitionFailedTests(originalFailed, culpritFailed)
+	for _, testName := range failing {
+		culprits = append(culprits, newStepbackCulprit(culprit, testName))
+	}
+
+	catcher := grip.NewBasicCatcher()
+	catcher.Add(recordStepbackCulprits(original.Id, original.Execution, culprits))
+	if len(passing) > 0 && culprit.Id != original.Id {
+		catcher.Wrap(advanceTestStepback(ctx, task.TestStepbackInfo{
+			OriginalTaskId:            original.Id,
+			OriginalExecution:         original.Execution,
+			LastFailingStepbackTaskId: original.Id,
+			LastPassingStepbackTaskId: culprit.Id,
+			TestNames:                 passing,
+		}), "starting test stepback")
+	}
+
+	return catcher.Resolve()
+}
+
+// evalTestStepback continues the test bisect stepbacks that the finished task
+// was activated for.
+func evalTestStepback(ctx context.Context, t *task.Task) error {
+	if len(t.TestStepbackInfo) == 0 {
+		return nil
+	}
+
+	// Clear the stepback info first so that it isn't evaluated again if the
+	// task is restarted.
+	infos := t.TestStepbackInfo
+	if err := t.ClearTestStepbackInfo(); err != nil {
+		return errors.Wrapf(err, "clearing test stepback info for task '%s'", t.Id)
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, s := range infos {
+		catcher.Add(doTestStepback(ctx, t, s))
+	}
+
+	return catcher.Resolve()
+}
+
+// doTestStepback splits the tests being bisected by whether they failed in
+// the finished task and continues bisecting each group. The tests can only be
+// bisected if the task's test results are trustworthy, so test stepback stops
+// if the task didn't produce results or failed for a reason other than its
+// tests.
+func doTestStepback(ctx context.Context, t *task.Task, s task.TestStepbackInfo) error {
+	var reason string
+	switch {
+	case t.Aborted:
+		reason = "task was aborted"
+	case t.Status != evergreen.TaskSucceeded && t.Status != evergreen.TaskFailed:
+		reason = "task did not finish"
+	case t.Status == evergreen.TaskFailed && t.Details.Type != evergreen.CommandTypeTest:
+		reason = "task failed for a reason other than its tests"
+	case !t.HasResults():
+		reason = "task has no test results"
+	}
+	if reason != "" {
+		grip.Info(message.Fields{
+			"message":          "stopping test bisect stepback",
+			"reason":           reason,
+			"original_task_id": s.OriginalTaskId,
+			"task_id":          t.Id,
+			"status":           t.Status,
+			"failure_type":     t.Details.Type,
+			"num_tests":        len(s.TestNames),
+			"project_id":       t.Project,
+		})
+		return nil
+	}
+
+	failed, err := getFailedTestNames(ctx, t)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	failing, passing := partitionFailedTests(s.TestNames, failed)
+
+	catcher := grip.NewBasicCatcher()
+	if len(failing) > 0 {
+		next := s
+		next.LastFailingStepbackTaskId = t.Id
+		next.TestNames = failing
+		catcher.Add(advanceTestStepback(ctx, next))
+	}
+	if len(passing) > 0 {
+		next := s
+		next.LastPassingStepbackTaskId = t.Id
+		next.TestNames = passing
+		catcher.Add(advanceTestStepback(ctx, next))
+	}
+
+	return catcher.Resolve()
+}
+
+// advanceTestStepback moves the test bisect stepback on to the task midway
+// between the last passing and last failing tasks. If there are no tasks left
+// between them, the last failing task is the culprit for the tests.
+func advanceTestStepback(ctx context.Context, s task.TestStepbackInfo) error {
+	nextTask, err := task.FindMidwayTaskFromIds(s.LastFailingStepbackTaskId, s.LastPassingStepbackTaskId)
+	if err != nil {
+		return errors.Wrapf(err, "finding midway task between tasks '%s' and '%s'", s.LastFailingStepbackTaskId, s.LastPassingStepbackTaskId)
+	}
+	if nextTask == nil {
+		return errors.Errorf("midway task could not be found for tasks '%s' '%s'", s.LastFailingStepbackTaskId, s.LastPassingStepbackTaskId)
+	}
+
+	if nextTask.Id == s.LastFailingStepbackTaskId || nextTask.Id == s.LastPassingStepbackTaskId {
+		culprit, err := task.FindOneId(s.LastFailingStepbackTaskId)
+		if err != nil {
+			return errors.Wrapf(err, "finding culprit task '%s'", s.LastFailingStepbackTaskId)
+		}
+		if culprit == nil {
+			return errors.Errorf("culprit task '%s' not found", s.LastFailingStepbackTaskId)
+		}
+		culprits := make([]annotations.StepbackCulprit, 0, len(s.TestNames))
+		for _, testName := range s.TestNames {
+			culprits = append(culprits, newStepbackCulprit(culprit, testName))
+		}
+		return recordStepbackCulprits(s.OriginalTaskId, s.OriginalExecution, culprits)
+	}
+
+	// If the next task already ran, its results can be used right away.
+	if nextTask.IsFinished() {
+		return doTestStepback(ctx, nextTask, s)
+	}
+	// Disabled tasks will never run, so the tests can't be bisected further.
+	if nextTask.Priority < 0 {
+		return nil
+	}
+
+	if err = nextTask.AddTestStepbackInfo(s); err != nil {
+		return errors.Wrapf(err, "adding test stepback info for task '%s'", nextTask.Id)
+	}
+
+	grip.Info(message.Fields{
+		"message":                       "test bisect stepback",
+		"original_task_id":              s.OriginalTaskId,
+		"last_failing_stepback_task_id": s.LastFailingStepbackTaskId,
+		"last_passing_stepback_task_id": s.LastPassingStepbackTaskId,
+		"num_tests":                     len(s.TestNames),
+		"next_task_id":                  nextTask.Id,
+		"next_task_display_name":        nextTask.DisplayName,
+		"project_id":                    nextTask.Project,
+	})
+
+	if nextTask.Activated {
+		return nil
+	}
+	if err = SetActiveState(ctx, evergreen.StepbackTaskActivator, true, *nextTask); err != nil {
+		return errors.Wrapf(err, "setting task '%s' active", nextTask.Id)
+	}
+	if nextTask.GenerateTask {
+		if err = nextTask.SetGeneratedTasksToActivate(nextTask.BuildVariant, nextTask.DisplayName); err != nil {
+			return errors.Wrap(err, "setting generated tasks to activate")
+		}
+	}
+
+	return nil
+}
+
+// recordStepbackCulprits adds the culprits to the original task's annotation
+// and logs an event on each culprit task so that subscribers (e.g. JIRA issue
+// subscribers) are notified of it.
+func recordStepbackCulprits(originalTaskId string, originalExecution int, culprits []annotations.StepbackCulprit) error {
+	if err := annotations.AddStepbackCulprits(originalTaskId, originalExecution, culprits...); err != nil {
+		return errors.Wrapf(err, "recording stepback culprits for task '%s'", originalTaskId)
+	}
+	for _, c := range culprits {
+		event.LogTaskStepbackCulprit(c.TaskId, c.Execution, c.TestName)
+		grip.Info(message.Fields{
+			"message":            "stepback found culprit",
+			"original_task_id":   originalTaskId,
+			"original_execution": originalExecution,
+			"culprit_task_id":    c.TaskId,
+			"culprit_revision":   c.Revision,
+			"test_name":          c.TestName,
+		})
+	}
+
+	return nil
+}
+
+func newStepbackCulprit(t *task.Task, testName string) annotations.StepbackCulprit {
+	return annotations.StepbackCulprit{
+		TestName:  testName,
+		TaskId:    t.Id,
+		Execution: t.Execution,
+		Version:   t.Version,
+		Revision:  t.Revision,
+	}
+}
+
+// getFailedTestNames returns the sorted names of the task's failed tests.
+func getFailedTestNames(ctx context.Context, t *task.Task) ([]string, error) {
+	results, err := t.GetTestResults(ctx, evergreen.GetEnvironment(), &testresult.FilterOptions{
+		Statuses: []string{evergreen.TestFailedStatus},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting failed test results for task '%s'", t.Id)
+	}
+
+	seen := map[string]bool{}
+	var names []string
+	for _, result := range results.Results {
+		name := result.GetDisplayTestName()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// partitionFailedTests splits the test names into those that are in the set
+// of failed tests and those that are not, preserving their order.
+func partitionFailedTests(testNames, failed []string) (failing, passing []string) {
+	failedSet := make(map[string]bool, len(failed))
+	for _, name := range failed {
+		failedSet[name] = true
+	}
+	for _, name := range testNames {
+		if failedSet[name] {
+			failing = append(failing, name)
+		} else {
+			passing = append(passing, name)
+		}
+	}
+
+	return failing, passing
+}
@@ -0,0 +1,313 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/apimodels"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/annotations"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionFailedTests(t *testing.T) {
+	failing, passing := partitionFailedTests([]string{"a", "b", "c", "d"}, []string{"d", "b", "e"})
+	assert.Equal(t, []string{"b", "d"}, failing)
+	assert.Equal(t, []string{"a", "c"}, passing)
+
+	failing, passing = partitionFailedTests([]string{"a"}, nil)
+	assert.Empty(t, failing)
+	assert.Equal(t, []string{"a"}, passing)
+}
+
+func TestTestStepback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	clear := func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, build.Collection, VersionCollection, ProjectRefCollection, annotations.Collection, event.EventCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}
+	clear()
+	defer clear()
+
+	// Task data for tests is:
+	// ('-' is failed, '?' is undispatched, '+' is succeeded).
+	// t1  t2  t3  t4  t5  t6  t7  t8  t9
+	// +   ?   ?   +   -   ?   ?   ?   -
+	// Bisect stepback has found t5 to be the culprit for t9. t5 fails test1,
+	// while t9 fails test1 and test2.
+	pRef := ProjectRef{
+		Id:                 "proj",
+		StepbackBisect:     utility.TruePtr(),
+		StepbackTestBisect: utility.TruePtr(),
+	}
+	require.NoError(t, pRef.Insert())
+	v := Version{
+		Id:        "v",
+		Requester: evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t, v.Insert())
+	statuses := map[int]string{1: evergreen.TaskSucceeded, 4: evergreen.TaskSucceeded, 5: evergreen.TaskFailed, 9: evergreen.TaskFailed}
+	for i := 1; i <= 9; i++ {
+		status, ok := statuses[i]
+		if !ok {
+			status = evergreen.TaskUndispatched
+		}
+		tsk := task.Task{
+			Id:                  fmt.Sprintf("t%d", i),
+			BuildId:             fmt.Sprintf("b%d", i),
+			Status:              status,
+			Activated:           ok,
+			BuildVariant:        "bv",
+			DisplayName:         "task",
+			Project:             pRef.Id,
+			Revision:            fmt.Sprintf("r%d", i),
+			RevisionOrderNumber: i,
+			Requester:           evergreen.RepotrackerVersionRequester,
+			Version:             v.Id,
+			ResultsService:      testresult.TestResultsServiceLocal,
+		}
+		require.NoError(t, tsk.Insert())
+		b := build.Build{
+			Id:           tsk.BuildId,
+			BuildVariant: "bv",
+		}
+		require.NoError(t, b.Insert())
+	}
+	require.NoError(t, testresult.InsertLocal(ctx, env,
+		testresult.TestResult{TaskID: "t5", TestName: "test1", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TaskID: "t5", TestName: "test2", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: "t9", TestName: "test1", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TaskID: "t9", TestName: "test2", Status: evergreen.TestFailedStatus},
+	))
+
+	require.NoError(t, finishBisectStepback(ctx, task.StepbackInfo{
+		LastFailingStepbackTaskId: "t5",
+		LastPassingStepbackTaskId: "t4",
+		OriginalStepbackTaskId:    "t9",
+	}))
+
+	annotation, err := annotations.FindOneByTaskIdAndExecution("t9", 0)
+	require.NoError(t, err)
+	require.NotNil(t, annotation)
+	require.Len(t, annotation.StepbackCulprits, 2)
+	assert.Empty(t, annotation.StepbackCulprits[0].TestName)
+	assert.Equal(t, "t5", annotation.StepbackCulprits[0].TaskId)
+	assert.Equal(t, "r5", annotation.StepbackCulprits[0].Revision)
+	assert.Equal(t, "test1", annotation.StepbackCulprits[1].TestName)
+	assert.Equal(t, "t5", annotation.StepbackCulprits[1].TaskId)
+	require.NotNil(t, annotation.StepbackCulprits[1].Source)
+	assert.Equal(t, annotations.StepbackRequester, annotation.StepbackCulprits[1].Source.Requester)
+
+	// test2 is bisected between t5 and t9.
+	t7, err := task.FindOneId("t7")
+	require.NoError(t, err)
+	require.NotNil(t, t7)
+	assert.True(t, t7.Activated)
+	assert.Equal(t, evergreen.StepbackTaskActivator, t7.ActivatedBy)
+	require.Len(t, t7.TestStepbackInfo, 1)
+	assert.Equal(t, task.TestStepbackInfo{
+		OriginalTaskId:            "t9",
+		LastFailingStepbackTaskId: "t9",
+		LastPassingStepbackTaskId: "t5",
+		TestNames:                 []string{"test2"},
+	}, t7.TestStepbackInfo[0])
+
+	// t7 fails test2, so test2 is bisected between t5 and t7.
+	t7.Status = evergreen.TaskFailed
+	t7.Details.Type = evergreen.CommandTypeTest
+	require.NoError(t, testresult.InsertLocal(ctx, env,
+		testresult.TestResult{TaskID: "t7", TestName: "test2", Status: evergreen.TestFailedStatus},
+	))
+	require.NoError(t, evalTestStepback(ctx, t7))
+	t7, err = task.FindOneId("t7")
+	require.NoError(t, err)
+	require.NotNil(t, t7)
+	assert.Empty(t, t7.TestStepbackInfo)
+
+	t6, err := task.FindOneId("t6")
+	require.NoError(t, err)
+	require.NotNil(t, t6)
+	assert.True(t, t6.Activated)
+	require.Len(t, t6.TestStepbackInfo, 1)
+	assert.Equal(t, "t7", t6.TestStepbackInfo[0].LastFailingStepbackTaskId)
+	assert.Equal(t, "t5", t6.TestStepbackInfo[0].LastPassingStepbackTaskId)
+
+	// t6 passes test2, so t7 introduced the failure.
+	t6.Status = evergreen.TaskSucceeded
+	require.NoError(t, evalTestStepback(ctx, t6))
+
+	annotation, err = annotations.FindOneByTaskIdAndExecution("t9", 0)
+	require.NoError(t, err)
+	require.NotNil(t, annotation)
+	require.Len(t, annotation.StepbackCulprits, 3)
+	assert.Equal(t, "test2", annotation.StepbackCulprits[2].TestName)
+	assert.Equal(t, "t7", annotation.StepbackCulprits[2].TaskId)
+	assert.Equal(t, "r7", annotation.StepbackCulprits[2].Revision)
+
+	t8, err := task.FindOneId("t8")
+	require.NoError(t, err)
+	require.NotNil(t, t8)
+	assert.False(t, t8.Activated)
+}
+
+func TestDoTestStepbackStops(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := evergreen.GetEnvironment()
+
+	clear := func(t *testing.T) {
+		assert.NoError(t, db.ClearCollections(task.Collection, build.Collection, VersionCollection, annotations.Collection, event.EventCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}
+	defer clear(t)
+
+	// Task data for tests is:
+	// ('-' is failed, '?' is undispatched, '+' is succeeded).
+	// t1  t2  t3  t4
+	// +   ?   ?   -
+	// test1 is being bisected between t1 and t4, and t2 has just finished.
+	setup := func(t *testing.T, t2 task.Task) {
+		clear(t)
+		v := Version{
+			Id:        "v",
+			Requester: evergreen.RepotrackerVersionRequester,
+		}
+		require.NoError(t, v.Insert())
+		for i := 1; i <= 4; i++ {
+			tsk := task.Task{
+				Id:        fmt.Sprintf("t%d", i),
+				Status:    evergreen.TaskUndispatched,
+				Activated: i != 3,
+			}
+			switch i {
+			case 1:
+				tsk.Status = evergreen.TaskSucceeded
+			case 2:
+				tsk = t2
+				tsk.Id = "t2"
+				tsk.Activated = true
+			case 4:
+				tsk.Status = evergreen.TaskFailed
+			}
+			tsk.BuildId = fmt.Sprintf("b%d", i)
+			tsk.BuildVariant = "bv"
+			tsk.DisplayName = "task"
+			tsk.Project = "proj"
+			tsk.Revision = fmt.Sprintf("r%d", i)
+			tsk.RevisionOrderNumber = i
+			tsk.Requester = evergreen.RepotrackerVersionRequester
+			tsk.Version = v.Id
+			require.NoError(t, tsk.Insert())
+			b := build.Build{
+				Id:           tsk.BuildId,
+				BuildVariant: "bv",
+			}
+			require.NoError(t, b.Insert())
+		}
+		testStatus := evergreen.TestSucceededStatus
+		if t2.Status == evergreen.TaskFailed {
+			testStatus = evergreen.TestFailedStatus
+		}
+		require.NoError(t, testresult.InsertLocal(ctx, env,
+			testresult.TestResult{TaskID: "t2", TestName: "test1", Status: testStatus},
+		))
+	}
+	s := task.TestStepbackInfo{
+		OriginalTaskId:            "t4",
+		LastFailingStepbackTaskId: "t4",
+		LastPassingStepbackTaskId: "t1",
+		TestNames:                 []string{"test1"},
+	}
+
+	for tName, tCase := range map[string]struct {
+		t2              task.Task
+		expectedCulprit string
+		expectedNext    bool
+	}{
+		"BisectsTestFailure": {
+			t2: task.Task{
+				Status:         evergreen.TaskFailed,
+				Details:        apimodels.TaskEndDetail{Type: evergreen.CommandTypeTest},
+				ResultsService: testresult.TestResultsServiceLocal,
+			},
+			expectedCulprit: "t2",
+		},
+		"BisectsSuccess": {
+			t2: task.Task{
+				Status:         evergreen.TaskSucceeded,
+				ResultsService: testresult.TestResultsServiceLocal,
+			},
+			expectedNext: true,
+		},
+		"StopsForSetupFailure": {
+			t2: task.Task{
+				Status:         evergreen.TaskFailed,
+				Details:        apimodels.TaskEndDetail{Type: evergreen.CommandTypeSetup},
+				ResultsService: testresult.TestResultsServiceLocal,
+			},
+		},
+		"StopsForSystemFailure": {
+			t2: task.Task{
+				Status:         evergreen.TaskFailed,
+				Details:        apimodels.TaskEndDetail{Type: evergreen.CommandTypeSystem},
+				ResultsService: testresult.TestResultsServiceLocal,
+			},
+		},
+		"StopsWithoutResults": {
+			t2: task.Task{
+				Status:  evergreen.TaskSucceeded,
+				Details: apimodels.TaskEndDetail{Type: evergreen.CommandTypeTest},
+			},
+		},
+		"StopsForAbortedTask": {
+			t2: task.Task{
+				Status:         evergreen.TaskFailed,
+				Aborted:        true,
+				Details:        apimodels.TaskEndDetail{Type: evergreen.CommandTypeTest},
+				ResultsService: testresult.TestResultsServiceLocal,
+			},
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			setup(t, tCase.t2)
+			t2, err := task.FindOneId("t2")
+			require.NoError(t, err)
+			require.NotNil(t, t2)
+
+			require.NoError(t, doTestStepback(ctx, t2, s))
+
+			annotation, err := annotations.FindOneByTaskIdAndExecution("t4", 0)
+			require.NoError(t, err)
+			if tCase.expectedCulprit != "" {
+				require.NotNil(t, annotation)
+				require.Len(t, annotation.StepbackCulprits, 1)
+				assert.Equal(t, "test1", annotation.StepbackCulprits[0].TestName)
+				assert.Equal(t, tCase.expectedCulprit, annotation.StepbackCulprits[0].TaskId)
+			} else {
+				assert.Nil(t, annotation)
+			}
+
+			t3, err := task.FindOneId("t3")
+			require.NoError(t, err)
+			require.NotNil(t, t3)
+			assert.Equal(t, tCase.expectedNext, t3.Activated)
+			if tCase.expectedNext {
+				require.Len(t, t3.TestStepbackInfo, 1)
+				assert.Equal(t, "t2", t3.TestStepbackInfo[0].LastPassingStepbackTaskId)
+			} else {
+				assert.Empty(t, t3.TestStepbackInfo)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package teststats
+
+// This file provides database layer logic for pre-computed test execution
+// statistics.
+// The database schema is the following:
+// *daily_test_stats_status*
+// {
+//   "_id": <Project Id (string)>,
+//   "last_job_run": <Date of the last successful job run that updated the project stats (date)>
+//   "processed_tasks_until": <Date before which finished tasks have been processed by a successful job (date)>
+//   "runtime": <Duration (ns) of the last successful job run that updated the project stats>
+// }
+// *daily_test_stats*
+// {
+//   "_id": {
+//     "test_name": <Test display name (string)>,
+//     "task_name": <Task display name (string)>,
+//     "variant": <Build variant (string)>,
+//     "project": <Project Id (string)>,
+//     "requester": <Requester (string)>,
+//     "date": <UTC day period this document covers (date)>,
+//   },
+//   "num_pass": <Number of times the test passed (int)>,
+//   "num_fail": <Number of times the test failed (int)>,
+//   "avg_duration_pass": <Average duration in seconds of the passing tests (double)>,
+//   "last_update": <Date of the job run that last updated this document (date)>
+// }
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/anser/bsonutil"
+	adb "github.com/mongodb/anser/db"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DailyTestStatsCollection       = "daily_test_stats"
+	DailyTestStatsStatusCollection = "daily_test_stats_status"
+	bulkSize                       = 1000
+)
+
+//////////////////
+// Stats Status //
+//////////////////
+
+// statsStatusQuery returns a query to find a stats status document by project id.
+func statsStatusQuery(projectID string) bson.M {
+	return bson.M{"_id": projectID}
+}
+
+// createDefaultStatsStatus creates a StatsStatus for projects that don't have
+// a test stats status in the DB yet.
+func createDefaultStatsStatus(projectID string) taskstats.StatsStatus {
+	defaultBackFillStart := utility.GetUTCDay(time.Now().Add(-defaultBackFillPeriod))
+	return taskstats.StatsStatus{
+		ProjectID:           projectID,
+		LastJobRun:          defaultBackFillStart,
+		ProcessedTasksUntil: defaultBackFillStart,
+	}
+}
+
+// GetStatsStatus retrieves the status of the test stats pre-computations for a
+// project.
+func GetStatsStatus(projectID string) (taskstats.StatsStatus, error) {
+	status := taskstats.StatsStatus{}
+	q := db.Query(statsStatusQuery(projectID))
+	err := db.FindOneQ(DailyTestStatsStatusCollection, q, &status)
+	if adb.ResultsNotFound(err) {
+		return createDefaultStatsStatus(projectID), nil
+	}
+	if err != nil {
+		return status, errors.Wrap(err, "retrieving test stats status")
+	}
+	return status, nil
+}
+
+// UpdateStatsStatus updates the status of the test stats pre-computations for
+// a project.
+func UpdateStatsStatus(projectID string, lastJobRun, processedTasksUntil time.Time, runtime time.Duration) error {
+	status := taskstats.StatsStatus{
+		ProjectID:           projectID,
+		LastJobRun:          lastJobRun,
+		ProcessedTasksUntil: processedTasksUntil,
+		Runtime:             runtime,
+	}
+	_, err := db.Upsert(DailyTestStatsStatusCollection, statsStatusQuery(projectID), status)
+	if err != nil {
+		return errors.Wrap(err, "updating test stats status")
+	}
+	return nil
+}
+
+//////////////////////
+// Daily Test Stats //
+//////////////////////
+
+// DBTestStatsID represents the _id field for daily_test_stats documents.
+type DBTestStatsID struct {
+	TestName     string    `bson:"test_name"`
+	TaskName     string    `bson:"task_name"`
+	BuildVariant string    `bson:"variant"`
+	Project      string    `bson:"project"`
+	Requester    string    `bson:"requester"`
+	Date         time.Time `bson:"date"`
+}
+
+// DBTestStats represents the daily_test_stats documents.
+type DBTestStats struct {
+	Id              DBTestStatsID `bson:"_id"`
+	NumPass         int           `bson:"num_pass"`
+	NumFail         int           `bson:"num_fail"`
+	AvgDurationPass float64       `bson:"avg_duration_pass"`
+	LastUpdate      time.Time     `bson:"last_update"`
+}
+
+func (d *DBTestStats) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(d) }
+func (d *DBTestStats) UnmarshalBSON(in []byte) error { return mgobson.Unmarshal(in, d) }
+
+var (
+	// BSON fields for the test stats ID struct.
+	DBTestStatsIDTestNameKey     = bsonutil.MustHaveTag(DBTestStatsID{}, "TestName")
+	DBTestStatsIDTaskNameKey     = bsonutil.MustHaveTag(DBTestStatsID{}, "TaskName")
+	DBTestStatsIDBuildVariantKey = bsonutil.MustHaveTag(DBTestStatsID{}, "BuildVariant")
+	DBTestStatsIDProjectKey      = bsonutil.MustHaveTag(DBTestStatsID{}, "Project")
+	DBTestStatsIDRequesterKey    = bsonutil.MustHaveTag(DBTestStatsID{}, "Requester")
+	DBTestStatsIDDateKey         = bsonutil.MustHaveTag(DBTestStatsID{}, "Date")
+
+	// BSON fields for the test stats struct.
+	DBTestStatsIDKey              = bsonutil.MustHaveTag(DBTestStats{}, "Id")
+	DBTestStatsNumPassKey         = bsonutil.MustHaveTag(DBTestStats{}, "NumPass")
+	DBTestStatsNumFailKey         = bsonutil.MustHaveTag(DBTestStats{}, "NumFail")
+	DBTestStatsAvgDurationPassKey = bsonutil.MustHaveTag(DBTestStats{}, "AvgDurationPass")
+	DBTestStatsLastUpdateKey      = bsonutil.MustHaveTag(DBTestStats{}, "LastUpdate")
+
+	// BSON dotted field names for test stats ID elements.
+	DBTestStatsIDTestNameKeyFull     = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDTestNameKey)
+	DBTestStatsIDTaskNameKeyFull     = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDTaskNameKey)
+	DBTestStatsIDBuildVariantKeyFull = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDBuildVariantKey)
+	DBTestStatsIDProjectKeyFull      = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDProjectKey)
+	DBTestStatsIDRequesterKeyFull    = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDRequesterKey)
+	DBTestStatsIDDateKeyFull         = bsonutil.GetDottedKeyName(DBTestStatsIDKey, DBTestStatsIDDateKey)
+)
+
+// writeStats bulk upserts the given daily test stats documents, replacing any
+// existing documents with the same ID.
+func writeStats(ctx context.Context, env evergreen.Environment, stats []DBTestStats) error {
+	for start := 0; start < len(stats); start += bulkSize {
+		end := start + bulkSize
+		if end > len(stats) {
+			end = len(stats)
+		}
+
+		models := make([]mongo.WriteModel, 0, end-start)
+		for i := start; i < end; i++ {
+			models = append(models, mongo.NewReplaceOneModel().
+				SetUpsert(true).
+				SetFilter(bson.M{DBTestStatsIDKey: stats[i].Id}).
+				SetReplacement(&stats[i]))
+		}
+
+		if _, err := env.DB().Collection(DailyTestStatsCollection).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return errors.Wrapf(err, "bulk writing to collection '%s'", DailyTestStatsCollection)
+		}
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////
+// Functions to access pre-computed stats documents for testing. //
+///////////////////////////////////////////////////////////////////
+
+func GetDailyTestDoc(id DBTestStatsID) (*DBTestStats, error) {
+	doc := DBTestStats{}
+	q := db.Query(bson.M{DBTestStatsIDKey: id})
+	err := db.FindOneQ(DailyTestStatsCollection, q, &doc)
+	if adb.ResultsNotFound(err) {
+		return nil, nil
+	}
+	return &doc, err
+}
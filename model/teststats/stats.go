@@ -0,0 +1,167 @@
+// Package teststats provides functions to generate pre-computed test
+// statistics from the results stored in the local test results service. The
+// statistics are aggregated per day and a combination of (project, variant,
+// task, test, requester).
+package teststats
+
+import (
+	"context"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	mgobson "github.com/evergreen-ci/evergreen/db/mgo/bson"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	defaultBackFillPeriod = 4 * 7 * 24 * time.Hour
+	// resultsBatchSize is the maximum number of tasks whose test results
+	// are fetched at once.
+	resultsBatchSize = 100
+)
+
+// TestStats represents test execution statistics aggregated over a period of
+// one or more days.
+type TestStats struct {
+	TestName     string    `bson:"test_name"`
+	TaskName     string    `bson:"task_name"`
+	BuildVariant string    `bson:"variant"`
+	Date         time.Time `bson:"date"`
+
+	NumTotal        int       `bson:"num_total"`
+	NumPass         int       `bson:"num_pass"`
+	NumFail         int       `bson:"num_fail"`
+	AvgDurationPass float64   `bson:"avg_duration_pass"`
+	LastUpdate      time.Time `bson:"last_update"`
+}
+
+func (s *TestStats) MarshalBSON() ([]byte, error)  { return mgobson.Marshal(s) }
+func (s *TestStats) UnmarshalBSON(in []byte) error { return mgobson.Unmarshal(in, s) }
+
+///////////////////////////////////////////
+// Daily test stats generation functions //
+///////////////////////////////////////////
+
+// GenerateStats computes the test stats documents for the tests run by the
+// given project, requester, day, and tasks specified, using the test results
+// stored in the local test results service. The day covered is the UTC day
+// corresponding to the given day parameter.
+func GenerateStats(ctx context.Context, env evergreen.Environment, opts taskstats.GenerateStatsOptions) error {
+	grip.Info(message.Fields{
+		"message":   "generating daily test stats",
+		"project":   opts.ProjectID,
+		"requester": opts.Requester,
+		"day":       opts.Date,
+		"tasks":     opts.Tasks,
+	})
+	start := utility.GetUTCDay(opts.Date)
+	end := start.Add(24 * time.Hour)
+
+	tasks, err := task.Find(bson.M{
+		task.ProjectKey:        opts.ProjectID,
+		task.RequesterKey:      opts.Requester,
+		task.CreateTimeKey:     bson.M{"$gte": start, "$lt": end},
+		task.DisplayNameKey:    bson.M{"$in": opts.Tasks},
+		task.StatusKey:         bson.M{"$in": evergreen.TaskCompletedStatuses},
+		task.ResultsServiceKey: testresult.TestResultsServiceLocal,
+	})
+	if err != nil {
+		return errors.Wrap(err, "finding tasks")
+	}
+
+	lastUpdate := time.Now()
+	stats := map[DBTestStatsID]*testStatsAccumulator{}
+	var order []DBTestStatsID
+	for batchStart := 0; batchStart < len(tasks); batchStart += resultsBatchSize {
+		batchEnd := batchStart + resultsBatchSize
+		if batchEnd > len(tasks) {
+			batchEnd = len(tasks)
+		}
+
+		tasksByID := map[string]task.Task{}
+		taskOpts := make([]testresult.TaskOptions, 0, batchEnd-batchStart)
+		for _, t := range tasks[batchStart:batchEnd] {
+			tasksByID[t.Id] = t
+			taskOpts = append(taskOpts, testresult.TaskOptions{
+				TaskID:         t.Id,
+				Execution:      t.Execution,
+				ResultsService: t.ResultsService,
+			})
+		}
+
+		results, err := testresult.GetMergedTaskTestResults(ctx, env, taskOpts, nil)
+		if err != nil {
+			return errors.Wrap(err, "getting test results")
+		}
+
+		for _, res := range results.Results {
+			if res.Status != evergreen.TestSucceededStatus && res.Status != evergreen.TestFailedStatus {
+				continue
+			}
+			t, ok := tasksByID[res.TaskID]
+			if !ok {
+				continue
+			}
+
+			id := DBTestStatsID{
+				TestName:     res.GetDisplayTestName(),
+				TaskName:     t.DisplayName,
+				BuildVariant: t.BuildVariant,
+				Project:      t.Project,
+				Requester:    t.Requester,
+				Date:         start,
+			}
+			acc, ok := stats[id]
+			if !ok {
+				acc = &testStatsAccumulator{}
+				stats[id] = acc
+				order = append(order, id)
+			}
+			acc.add(res)
+		}
+	}
+
+	docs := make([]DBTestStats, 0, len(order))
+	for _, id := range order {
+		docs = append(docs, stats[id].export(id, lastUpdate))
+	}
+
+	return errors.Wrap(writeStats(ctx, env, docs), "writing daily test stats")
+}
+
+// testStatsAccumulator accumulates the results of a single test over a day.
+type testStatsAccumulator struct {
+	numPass           int
+	numFail           int
+	totalDurationPass time.Duration
+}
+
+func (a *testStatsAccumulator) add(res testresult.TestResult) {
+	if res.Status == evergreen.TestSucceededStatus {
+		a.numPass++
+		a.totalDurationPass += res.Duration()
+	} else {
+		a.numFail++
+	}
+}
+
+func (a *testStatsAccumulator) export(id DBTestStatsID, lastUpdate time.Time) DBTestStats {
+	doc := DBTestStats{
+		Id:         id,
+		NumPass:    a.numPass,
+		NumFail:    a.numFail,
+		LastUpdate: lastUpdate,
+	}
+	if a.numPass > 0 {
+		doc.AvgDurationPass = a.totalDurationPass.Seconds() / float64(a.numPass)
+	}
+
+	return doc
+}
@@ -0,0 +1,137 @@
+package teststats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var baseDay = time.Date(2018, 7, 15, 0, 0, 0, 0, time.UTC)
+
+func TestStatsStatus(t *testing.T) {
+	require.NoError(t, db.Clear(DailyTestStatsStatusCollection))
+	defer func() {
+		assert.NoError(t, db.Clear(DailyTestStatsStatusCollection))
+	}()
+
+	status, err := GetStatsStatus("p1")
+	require.NoError(t, err)
+	expected := time.Now().Add(-defaultBackFillPeriod)
+	assert.WithinDuration(t, expected, status.ProcessedTasksUntil, 24*time.Hour+time.Minute)
+
+	require.NoError(t, UpdateStatsStatus("p1", baseDay.Add(time.Hour), baseDay, time.Minute))
+	status, err = GetStatsStatus("p1")
+	require.NoError(t, err)
+	assert.True(t, baseDay.Add(time.Hour).Equal(status.LastJobRun))
+	assert.True(t, baseDay.Equal(status.ProcessedTasksUntil))
+	assert.Equal(t, time.Minute, status.Runtime)
+}
+
+func TestGenerateStats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection, DailyTestStatsCollection))
+	require.NoError(t, testresult.ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, DailyTestStatsCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	insertTask := func(t *testing.T, id string, createTime time.Time, resultsService string, results ...testresult.TestResult) {
+		tsk := task.Task{
+			Id:             id,
+			Project:        "p1",
+			Requester:      evergreen.RepotrackerVersionRequester,
+			DisplayName:    "task1",
+			BuildVariant:   "v1",
+			Status:         evergreen.TaskFailed,
+			CreateTime:     createTime,
+			ResultsService: resultsService,
+		}
+		require.NoError(t, tsk.Insert())
+		for i := range results {
+			results[i].TaskID = id
+		}
+		if len(results) > 0 {
+			require.NoError(t, testresult.InsertLocal(ctx, env, results...))
+		}
+	}
+	testStart := baseDay.Add(time.Hour)
+	insertTask(t, "t1", baseDay.Add(time.Hour), testresult.TestResultsServiceLocal,
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestSucceededStatus, TestStartTime: testStart, TestEndTime: testStart.Add(10 * time.Second)},
+		testresult.TestResult{TestName: "test2", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TestName: "test3", Status: evergreen.TestSkippedStatus},
+	)
+	insertTask(t, "t2", baseDay.Add(2*time.Hour), testresult.TestResultsServiceLocal,
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestSucceededStatus, TestStartTime: testStart, TestEndTime: testStart.Add(20 * time.Second)},
+		testresult.TestResult{TestName: "test2", Status: evergreen.TestSucceededStatus},
+	)
+	// This task was created on a different day.
+	insertTask(t, "t3", baseDay.Add(-time.Hour), testresult.TestResultsServiceLocal,
+		testresult.TestResult{TestName: "test1", Status: evergreen.TestFailedStatus},
+	)
+
+	require.NoError(t, GenerateStats(ctx, env, taskstats.GenerateStatsOptions{
+		ProjectID: "p1",
+		Requester: evergreen.RepotrackerVersionRequester,
+		Tasks:     []string{"task1"},
+		Date:      baseDay.Add(12 * time.Hour),
+	}))
+
+	id := DBTestStatsID{
+		TestName:     "test1",
+		TaskName:     "task1",
+		BuildVariant: "v1",
+		Project:      "p1",
+		Requester:    evergreen.RepotrackerVersionRequester,
+		Date:         baseDay,
+	}
+	doc, err := GetDailyTestDoc(id)
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+	assert.Equal(t, 2, doc.NumPass)
+	assert.Equal(t, 0, doc.NumFail)
+	assert.Equal(t, 15.0, doc.AvgDurationPass)
+
+	id.TestName = "test2"
+	doc, err = GetDailyTestDoc(id)
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+	assert.Equal(t, 1, doc.NumPass)
+	assert.Equal(t, 1, doc.NumFail)
+
+	id.TestName = "test3"
+	doc, err = GetDailyTestDoc(id)
+	require.NoError(t, err)
+	assert.Nil(t, doc, "skipped tests should not have stats")
+
+	// Regenerating the stats for the same day should replace the existing
+	// documents rather than add to them.
+	require.NoError(t, GenerateStats(ctx, env, taskstats.GenerateStatsOptions{
+		ProjectID: "p1",
+		Requester: evergreen.RepotrackerVersionRequester,
+		Tasks:     []string{"task1"},
+		Date:      baseDay,
+	}))
+	id.TestName = "test1"
+	doc, err = GetDailyTestDoc(id)
+	require.NoError(t, err)
+	require.NotNil(t, doc)
+	assert.Equal(t, 2, doc.NumPass)
+
+	count, err := db.Count(DailyTestStatsCollection, bson.M{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
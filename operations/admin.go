@@ -39,6 +39,8 @@ func Admin() cli.Command {
 			updateServiceUser(),
 			getServiceUsers(),
 			deleteServiceUser(),
+			schedulerSnapshot(),
+			schedulerSimulate(),
 		},
 	}
 }
@@ -0,0 +1,275 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/scheduler"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/level"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func schedulerSnapshot() cli.Command {
+	const (
+		dbFlagName     = "db"
+		distroFlagName = "distro"
+		outputFlagName = "output"
+		urlFlagName    = "url"
+	)
+
+	return cli.Command{
+		Name:  "scheduler-snapshot",
+		Usage: "export the scheduler's tasks, hosts, and distros from a MongoDB database for scheduler-simulate",
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  joinFlagNames(distroFlagName, "d"),
+				Usage: "export this distro (can be specified multiple times, defaults to all distros)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(outputFlagName, "o"),
+				Usage: "write the snapshot to this file (required)",
+			},
+			cli.StringFlag{
+				Name:  urlFlagName,
+				Usage: "specify the MongoDB URL",
+				Value: "mongodb://127.0.0.1:27017",
+			},
+			cli.StringFlag{
+				Name:  dbFlagName,
+				Usage: "read data from this database",
+				Value: "mci",
+			},
+		},
+		Before: requireStringFlag(outputFlagName),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			outfn := c.String(outputFlagName)
+			if _, err := os.Stat(outfn); !os.IsNotExist(err) {
+				return errors.Errorf("cannot export to file '%s', file already exists", outfn)
+			}
+
+			client, err := mongo.NewClient(options.Client().ApplyURI(c.String(urlFlagName)))
+			if err != nil {
+				return errors.Wrap(err, "creating MongoDB client")
+			}
+			connCtx, connCancel := context.WithTimeout(ctx, 10*time.Second)
+			defer connCancel()
+			if err = client.Connect(connCtx); err != nil {
+				return errors.Wrap(err, "connecting to MongoDB")
+			}
+			defer func() {
+				grip.Warning(errors.Wrap(client.Disconnect(ctx), "disconnecting from MongoDB"))
+			}()
+
+			snapshot, err := getSchedulerSnapshot(ctx, client.Database(c.String(dbFlagName)), c.StringSlice(distroFlagName))
+			if err != nil {
+				return errors.Wrap(err, "getting scheduler snapshot")
+			}
+
+			b, err := json.MarshalIndent(snapshot, "", "\t")
+			if err != nil {
+				return errors.Wrap(err, "marshalling snapshot to JSON")
+			}
+			if err = os.WriteFile(outfn, b, 0644); err != nil {
+				return errors.Wrapf(err, "writing snapshot to file '%s'", outfn)
+			}
+
+			fmt.Printf("Exported %d distros, %d hosts, and %d tasks to '%s'.\n", len(snapshot.Distros), len(snapshot.Hosts), len(snapshot.Tasks), outfn)
+			return nil
+		},
+	}
+}
+
+// getSchedulerSnapshot reads the distros, along with their hosts that are up
+// and their tasks that are waiting to run or running, from the database. The
+// tasks that those tasks depend on are included so that the simulation knows
+// whether their dependencies have finished.
+func getSchedulerSnapshot(ctx context.Context, db *mongo.Database, distroIDs []string) (*scheduler.SchedulerSnapshot, error) {
+	snapshot := &scheduler.SchedulerSnapshot{CapturedAt: time.Now()}
+
+	err := db.Collection(evergreen.ConfigCollection).FindOne(ctx, bson.M{"_id": snapshot.SchedulerConfig.SectionId()}).Decode(&snapshot.SchedulerConfig)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, errors.Wrap(err, "finding scheduler config")
+	}
+
+	distroFilter := bson.M{}
+	if len(distroIDs) > 0 {
+		distroFilter = distro.ByIds(distroIDs)
+	}
+	cur, err := db.Collection(distro.Collection).Find(ctx, distroFilter)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding distros")
+	}
+	if err = cur.All(ctx, &snapshot.Distros); err != nil {
+		return nil, errors.Wrap(err, "decoding distros")
+	}
+	if len(snapshot.Distros) == 0 {
+		return nil, errors.New("no distros found")
+	}
+	distroIDs = make([]string, 0, len(snapshot.Distros))
+	for _, d := range snapshot.Distros {
+		distroIDs = append(distroIDs, d.Id)
+	}
+
+	cur, err = db.Collection(host.Collection).Find(ctx, host.ByDistroIDs(distroIDs...))
+	if err != nil {
+		return nil, errors.Wrap(err, "finding hosts")
+	}
+	if err = cur.All(ctx, &snapshot.Hosts); err != nil {
+		return nil, errors.Wrap(err, "decoding hosts")
+	}
+
+	cur, err = db.Collection(task.Collection).Find(ctx, bson.M{
+		task.DistroIdKey:  bson.M{"$in": distroIDs},
+		task.ActivatedKey: true,
+		task.StatusKey: bson.M{"$in": []string{
+			evergreen.TaskUndispatched,
+			evergreen.TaskDispatched,
+			evergreen.TaskStarted,
+		}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "finding tasks")
+	}
+	if err = cur.All(ctx, &snapshot.Tasks); err != nil {
+		return nil, errors.Wrap(err, "decoding tasks")
+	}
+
+	found := make(map[string]bool, len(snapshot.Tasks))
+	for _, t := range snapshot.Tasks {
+		found[t.Id] = true
+	}
+	var depIDs []string
+	for _, t := range snapshot.Tasks {
+		for _, dep := range t.DependsOn {
+			if !found[dep.TaskId] {
+				found[dep.TaskId] = true
+				depIDs = append(depIDs, dep.TaskId)
+			}
+		}
+	}
+	if len(depIDs) > 0 {
+		var deps []task.Task
+		cur, err = db.Collection(task.Collection).Find(ctx, task.ByIds(depIDs))
+		if err != nil {
+			return nil, errors.Wrap(err, "finding dependencies")
+		}
+		if err = cur.All(ctx, &deps); err != nil {
+			return nil, errors.Wrap(err, "decoding dependencies")
+		}
+		snapshot.Tasks = append(snapshot.Tasks, deps...)
+	}
+
+	return snapshot, nil
+}
+
+func schedulerSimulate() cli.Command {
+	const (
+		snapshotFlagName        = "snapshot"
+		stepFlagName            = "step"
+		hostStartupTimeFlagName = "host-startup-time"
+		maxDurationFlagName     = "max-duration"
+	)
+
+	return cli.Command{
+		Name:  "scheduler-simulate",
+		Usage: "simulate the scheduler's planners and host allocators on a snapshot from scheduler-snapshot",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(snapshotFlagName, "s"),
+				Usage: "the path to the snapshot file (required)",
+			},
+			cli.DurationFlag{
+				Name:  stepFlagName,
+				Usage: "how often the scheduler runs in the simulation",
+				Value: time.Minute,
+			},
+			cli.DurationFlag{
+				Name:  hostStartupTimeFlagName,
+				Usage: "how long it takes a new host to be ready to run tasks (defaults to a typical host startup time)",
+			},
+			cli.DurationFlag{
+				Name:  maxDurationFlagName,
+				Usage: "the most time to simulate",
+				Value: 24 * time.Hour,
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(jsonFlagName, "j"),
+				Usage: "output JSON instead of text",
+			},
+		},
+		Before: requireStringFlag(snapshotFlagName),
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			b, err := os.ReadFile(c.String(snapshotFlagName))
+			if err != nil {
+				return errors.Wrap(err, "reading snapshot file")
+			}
+			opts := scheduler.SchedulerSimulationOptions{
+				Step:            c.Duration(stepFlagName),
+				HostStartupTime: c.Duration(hostStartupTimeFlagName),
+				MaxDuration:     c.Duration(maxDurationFlagName),
+			}
+			if err = json.Unmarshal(b, &opts.Snapshot); err != nil {
+				return errors.Wrap(err, "unmarshalling snapshot from JSON")
+			}
+
+			// The planners and host allocators log every time they run, which
+			// is too noisy to show for every step of the simulation.
+			sender := grip.GetSender()
+			levelInfo := sender.Level()
+			if levelInfo.Threshold < level.Warning {
+				levelInfo.Threshold = level.Warning
+				grip.Warning(errors.Wrap(sender.SetLevel(levelInfo), "setting log level"))
+			}
+
+			res, err := scheduler.SimulateScheduler(ctx, opts)
+			if err != nil {
+				return errors.Wrap(err, "simulating scheduler")
+			}
+
+			if c.Bool(jsonFlagName) {
+				out, err := json.MarshalIndent(res, "", "\t")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(out))
+				return nil
+			}
+
+			fmt.Printf("Simulated %s from %s.\n", res.SimulatedDuration, opts.Snapshot.CapturedAt.Format(time.RFC3339))
+			for _, dr := range res.Distros {
+				fmt.Printf("\n%s (planner: %s, host allocator: %s)\n", dr.Distro, dr.Planner, dr.HostAllocator)
+				fmt.Printf("\tTasks dispatched:      %d\n", dr.TasksDispatched)
+				fmt.Printf("\tTasks not dispatched:  %d\n", dr.TasksNotDispatched)
+				fmt.Printf("\tQueue latency:         mean %s, p50 %s, p90 %s, max %s\n",
+					dr.MeanQueueLatency.Round(time.Second), dr.P50QueueLatency.Round(time.Second),
+					dr.P90QueueLatency.Round(time.Second), dr.MaxQueueLatency.Round(time.Second))
+				fmt.Printf("\tHost hours:            %.2f\n", dr.HostHours)
+				fmt.Printf("\tBusy host hours:       %.2f\n", dr.BusyHostHours)
+				fmt.Printf("\tUtilization:           %.1f%%\n", 100*dr.Utilization)
+				fmt.Printf("\tHosts requested:       %d\n", dr.HostsRequested)
+				fmt.Printf("\tPeak hosts:            %d\n", dr.PeakHosts)
+			}
+
+			return nil
+		},
+	}
+}
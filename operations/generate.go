@@ -0,0 +1,136 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func Generate() cli.Command {
+	return cli.Command{
+		Name:  "generate",
+		Usage: "operations on generate.tasks",
+		Subcommands: []cli.Command{
+			generatePreview(),
+		},
+	}
+}
+
+func generatePreview() cli.Command {
+	const (
+		fileFlagName      = "file"
+		versionIDFlagName = "version"
+		taskIDFlagName    = "task"
+	)
+
+	return cli.Command{
+		Name:  "preview",
+		Usage: "show the builds, tasks, and dependencies that generate.tasks would add to a version without adding them",
+		Flags: []cli.Flag{
+			cli.StringSliceFlag{
+				Name:  joinFlagNames(fileFlagName, "f"),
+				Usage: "a JSON file that would be passed to generate.tasks (can be specified multiple times)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(versionIDFlagName, "v"),
+				Usage: "the ID of the version to generate tasks in (required)",
+			},
+			cli.StringFlag{
+				Name:  joinFlagNames(taskIDFlagName, "t"),
+				Usage: "the ID of the generator task, so that tasks depending on it are shown depending on the generated tasks",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(jsonFlagName, "j"),
+				Usage: "output JSON instead of text",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(versionIDFlagName), requireAtLeastOneFlag(fileFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			fileNames := c.StringSlice(fileFlagName)
+			versionID := c.String(versionIDFlagName)
+			taskID := c.String(taskIDFlagName)
+			outputJSON := c.Bool(jsonFlagName)
+
+			files := make([]json.RawMessage, 0, len(fileNames))
+			for _, fileName := range fileNames {
+				content, err := os.ReadFile(fileName)
+				if err != nil {
+					return errors.Wrapf(err, "reading file '%s'", fileName)
+				}
+				if !json.Valid(content) {
+					return errors.Errorf("file '%s' does not contain valid JSON", fileName)
+				}
+				files = append(files, content)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+
+			comm, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer comm.Close()
+
+			preview, err := comm.PreviewGeneratedTasks(ctx, versionID, taskID, files)
+			if err != nil {
+				return errors.Wrapf(err, "previewing generated tasks for version '%s'", versionID)
+			}
+
+			if outputJSON {
+				b, err := json.MarshalIndent(preview, "", "\t")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(b))
+				return nil
+			}
+
+			printGeneratedProjectPreview(os.Stdout, preview)
+			return nil
+		},
+	}
+}
+
+// printGeneratedProjectPreview prints the builds, tasks, and dependencies that
+// generate.tasks would add.
+func printGeneratedProjectPreview(out io.Writer, preview *restModel.APIGeneratedProjectPreview) {
+	fmt.Fprintf(out, "New builds (%d):\n", len(preview.NewBuildVariants))
+	for _, bv := range preview.NewBuildVariants {
+		fmt.Fprintf(out, "\t%s\n", bv)
+	}
+	fmt.Fprintf(out, "New tasks (%d):\n", len(preview.NewTasks))
+	for _, t := range preview.NewTasks {
+		fmt.Fprintf(out, "\t%s (%s)\n", generatedTaskPreviewName(t), utility.FromStringPtr(t.TaskID))
+	}
+	fmt.Fprintf(out, "New display tasks (%d):\n", len(preview.NewDisplayTasks))
+	for _, t := range preview.NewDisplayTasks {
+		fmt.Fprintf(out, "\t%s (%s)\n", generatedTaskPreviewName(t), utility.FromStringPtr(t.TaskID))
+	}
+	fmt.Fprintf(out, "New dependencies (%d):\n", len(preview.NewDependencies))
+	for _, dep := range preview.NewDependencies {
+		fmt.Fprintf(out, "\t%s depends on %s", generatedTaskPreviewName(dep.Task), generatedTaskPreviewName(dep.DependsOn))
+		if status := utility.FromStringPtr(dep.Status); status != "" {
+			fmt.Fprintf(out, " (%s)", status)
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+func generatedTaskPreviewName(t restModel.APIGeneratedTaskPreview) string {
+	return fmt.Sprintf("%s/%s", utility.FromStringPtr(t.BuildVariant), utility.FromStringPtr(t.DisplayName))
+}
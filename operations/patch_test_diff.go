@@ -0,0 +1,109 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func PatchTestDiff() cli.Command {
+	const baseVersionFlagName = "base-version"
+
+	return cli.Command{
+		Name:  "patch-test-diff",
+		Usage: "compare the test results of a finished patch against its base commit",
+		Flags: addPatchIDFlag(
+			cli.StringFlag{
+				Name:  baseVersionFlagName,
+				Usage: "compare against this version instead of the patch's base commit",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(jsonFlagName, "j"),
+				Usage: "output JSON instead of text",
+			}),
+		Before: requirePatchIDFlag,
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().String(confFlagName)
+			patchID := c.String(patchIDFlagName)
+			baseVersionID := c.String(baseVersionFlagName)
+			outputJSON := c.Bool(jsonFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+
+			client, err := conf.setupRestCommunicator(ctx, !outputJSON)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer client.Close()
+
+			ac, _, err := conf.getLegacyClients()
+			if err != nil {
+				return errors.Wrap(err, "setting up legacy Evergreen client")
+			}
+
+			p, err := ac.GetPatch(patchID)
+			if err != nil {
+				return errors.Wrapf(err, "getting patch '%s'", patchID)
+			}
+			if !p.Activated || p.Version == "" {
+				return errors.Errorf("patch '%s' has not been finalized", patchID)
+			}
+			if !evergreen.IsFinishedVersionStatus(p.Status) {
+				return errors.Errorf("patch '%s' has not finished yet", patchID)
+			}
+
+			diff, err := client.GetVersionTestDiff(ctx, p.Version, baseVersionID)
+			if err != nil {
+				return errors.Wrapf(err, "getting test diff for patch '%s'", patchID)
+			}
+
+			if outputJSON {
+				b, err := json.MarshalIndent(diff, "", "\t")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(b))
+				return nil
+			}
+
+			printTestDiffCategory("New failures", diff.NewFailures)
+			printTestDiffCategory("Fixed", diff.Fixed)
+			printTestDiffCategory("Still failing", diff.StillFailing)
+			printTestDiffCategory("Added", diff.Added)
+			printTestDiffCategory("Removed", diff.Removed)
+
+			return nil
+		},
+	}
+}
+
+func printTestDiffCategory(title string, diffs []restModel.APITestResultDiff) {
+	fmt.Printf("%s (%d):\n", title, len(diffs))
+	for _, d := range diffs {
+		fmt.Printf("\t%s/%s: %s", utility.FromStringPtr(d.BuildVariant), utility.FromStringPtr(d.TaskName), utility.FromStringPtr(d.TestName))
+		baseStatus := utility.FromStringPtr(d.BaseStatus)
+		status := utility.FromStringPtr(d.Status)
+		switch {
+		case baseStatus != "" && status != "":
+			fmt.Printf(" (%s -> %s)", baseStatus, status)
+		case status != "":
+			fmt.Printf(" (%s)", status)
+		case baseStatus != "":
+			fmt.Printf(" (%s)", baseStatus)
+		}
+		fmt.Println()
+	}
+}
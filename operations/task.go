@@ -0,0 +1,253 @@
+package operations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/rest/client"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+func Task() cli.Command {
+	return cli.Command{
+		Name:  "task",
+		Usage: "operations on tasks",
+		Subcommands: []cli.Command{
+			taskLogs(),
+			taskExplain(),
+		},
+	}
+}
+
+func taskLogs() cli.Command {
+	const (
+		taskIDFlagName        = "task_id"
+		executionFlagName     = "execution"
+		typeFlagName          = "type"
+		followFlagName        = "follow"
+		printTimeFlagName     = "print_time"
+		printPriorityFlagName = "print_priority"
+	)
+
+	return cli.Command{
+		Name:  "logs",
+		Usage: "print the logs of a task",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(taskIDFlagName, "t"),
+				Usage: "the ID of the task (required)",
+			},
+			cli.IntFlag{
+				Name:  executionFlagName,
+				Usage: "the execution of the task, defaults to the latest execution",
+			},
+			cli.StringFlag{
+				Name:  typeFlagName,
+				Usage: "the type of task log to print: 'all_logs', 'agent_log', 'system_log', or 'task_log'",
+				Value: "all_logs",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(followFlagName, "f"),
+				Usage: "keep printing new log lines as they are logged until the task finishes",
+			},
+			cli.BoolFlag{
+				Name:  printTimeFlagName,
+				Usage: "print the timestamp of each log line",
+			},
+			cli.BoolFlag{
+				Name:  printPriorityFlagName,
+				Usage: "print the priority of each log line",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(taskIDFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+
+			comm, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer comm.Close()
+
+			opts := client.TaskLogsOptions{
+				TaskID:        c.String(taskIDFlagName),
+				Type:          c.String(typeFlagName),
+				PrintTime:     c.Bool(printTimeFlagName),
+				PrintPriority: c.Bool(printPriorityFlagName),
+			}
+			if c.IsSet(executionFlagName) {
+				opts.Execution = utility.ToIntPtr(c.Int(executionFlagName))
+			}
+
+			if !c.Bool(followFlagName) {
+				r, err := comm.GetTaskLogs(ctx, opts)
+				if err != nil {
+					return errors.Wrap(err, "getting task logs")
+				}
+				defer r.Close()
+
+				_, err = io.Copy(os.Stdout, r)
+				return errors.Wrap(err, "printing task logs")
+			}
+
+			return followTaskLogs(ctx, comm, opts, os.Stdout)
+		},
+	}
+}
+
+// followTaskLogs prints the task's log lines to the writer as they are logged
+// until the task finishes.
+func followTaskLogs(ctx context.Context, comm client.Communicator, opts client.TaskLogsOptions, out io.Writer) error {
+	it, err := comm.FollowTaskLogs(ctx, opts)
+	if err != nil {
+		return errors.Wrap(err, "following task logs")
+	}
+	defer it.Close()
+
+	formatOpts := log.LogIteratorReaderOptions{
+		PrintTime:     opts.PrintTime,
+		PrintPriority: opts.PrintPriority,
+	}
+	for it.Next() {
+		if _, err = fmt.Fprint(out, formatOpts.FormatLine(it.Item())); err != nil {
+			return errors.Wrap(err, "printing task log line")
+		}
+	}
+
+	return errors.Wrap(it.Err(), "following task logs")
+}
+
+func taskExplain() cli.Command {
+	const taskIDFlagName = "task_id"
+
+	return cli.Command{
+		Name:  "explain",
+		Usage: "explain why a task is not running yet",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  joinFlagNames(taskIDFlagName, "t"),
+				Usage: "the ID of the task (required)",
+			},
+			cli.BoolFlag{
+				Name:  joinFlagNames(jsonFlagName, "j"),
+				Usage: "output JSON instead of text",
+			},
+		},
+		Before: mergeBeforeFuncs(setPlainLogger, requireStringFlag(taskIDFlagName)),
+		Action: func(c *cli.Context) error {
+			confPath := c.Parent().Parent().String(confFlagName)
+			taskID := c.String(taskIDFlagName)
+			outputJSON := c.Bool(jsonFlagName)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			conf, err := NewClientSettings(confPath)
+			if err != nil {
+				return errors.Wrap(err, "loading configuration")
+			}
+
+			comm, err := conf.setupRestCommunicator(ctx, false)
+			if err != nil {
+				return errors.Wrap(err, "setting up REST communicator")
+			}
+			defer comm.Close()
+
+			explanation, err := comm.GetTaskQueueExplanation(ctx, taskID)
+			if err != nil {
+				return errors.Wrapf(err, "getting task queue explanation for task '%s'", taskID)
+			}
+
+			if outputJSON {
+				b, err := json.MarshalIndent(explanation, "", "\t")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(b))
+				return nil
+			}
+
+			printTaskQueueExplanation(os.Stdout, explanation)
+			return nil
+		},
+	}
+}
+
+// printTaskQueueExplanation prints a human-readable explanation of why the
+// task is or isn't waiting to run.
+func printTaskQueueExplanation(out io.Writer, e *restModel.APITaskQueueExplanation) {
+	fmt.Fprintf(out, "Task:   %s\n", utility.FromStringPtr(e.TaskID))
+	fmt.Fprintf(out, "Status: %s\n", utility.FromStringPtr(e.Status))
+	fmt.Fprintf(out, "Distro: %s\n", utility.FromStringPtr(e.DistroID))
+
+	if e.InQueue {
+		queueDistro := utility.FromStringPtr(e.QueueDistroID)
+		if e.SecondaryQueue {
+			queueDistro += " (secondary)"
+		}
+		fmt.Fprintf(out, "Queue:  position %d of %d in %s\n", e.Position, e.QueueLength, queueDistro)
+		if e.QueueGeneratedAt != nil && !utility.IsZeroTime(*e.QueueGeneratedAt) {
+			fmt.Fprintf(out, "        last planned at %s\n", e.QueueGeneratedAt.Format(time.RFC3339))
+		}
+	}
+	if e.MaximumHosts > 0 {
+		fmt.Fprintf(out, "Hosts:  %d of %d maximum\n", e.NumHosts, e.MaximumHosts)
+	} else {
+		fmt.Fprintf(out, "Hosts:  %d\n", e.NumHosts)
+	}
+	if e.EstimatedStart != nil {
+		fmt.Fprintf(out, "Estimated time to start: %s\n", e.EstimatedStart.ToDuration().Round(time.Second))
+	}
+
+	if b := e.SortingValueBreakdown; b != nil {
+		fmt.Fprintf(out, "\nScheduling value: %d\n", b.TotalValue)
+		fmt.Fprintf(out, "\tpriority:                %d\n", b.Priority)
+		fmt.Fprintf(out, "\tnumber of tasks in unit: %d\n", b.NumTasks)
+		fmt.Fprintf(out, "\tpatch:                   %d\n", b.PatchValue)
+		fmt.Fprintf(out, "\tpatch time in queue:     %d\n", b.PatchTimeInQueueValue)
+		fmt.Fprintf(out, "\tcommit queue:            %d\n", b.CommitQueueValue)
+		fmt.Fprintf(out, "\tmainline time in queue:  %d\n", b.MainlineTimeInQueueValue)
+		fmt.Fprintf(out, "\tstepback:                %d\n", b.StepbackValue)
+		fmt.Fprintf(out, "\tdependents:              %d\n", b.DependentsValue)
+		fmt.Fprintf(out, "\texpected runtime:        %d\n", b.ExpectedRuntimeValue)
+	}
+
+	if len(e.UnmetDependencies) > 0 {
+		fmt.Fprintf(out, "\nUnmet dependencies (%d):\n", len(e.UnmetDependencies))
+		for _, dep := range e.UnmetDependencies {
+			name := utility.FromStringPtr(dep.DisplayName)
+			if name == "" {
+				name = utility.FromStringPtr(dep.TaskID)
+			}
+			fmt.Fprintf(out, "\t%s: %s, needs %s", name, utility.FromStringPtr(dep.Status), utility.FromStringPtr(dep.RequiredStatus))
+			if dep.Unattainable {
+				fmt.Fprint(out, " (unattainable)")
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if len(e.Reasons) > 0 {
+		fmt.Fprintln(out, "\nReasons:")
+		for _, reason := range e.Reasons {
+			fmt.Fprintf(out, "\t- %s\n", reason)
+		}
+	}
+}
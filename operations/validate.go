@@ -29,7 +29,7 @@ func Validate() cli.Command {
 			Usage: "include long validation checks (only applies if the check is over some threshold, in which case a warning is issued)",
 		}, cli.StringSliceFlag{
 			Name:  joinFlagNames(localModulesFlagName, "lm"),
-			Usage: "specify local modules as MODULE_NAME=PATH pairs",
+			Usage: "specify local modules as MODULE_NAME=PATH pairs, or local checkouts of remotely included repositories as OWNER/REPO=PATH pairs",
 		}, cli.StringFlag{
 			Name:  joinFlagNames(projectFlagName, "p"),
 			Usage: "specify project identifier in order to run validation requiring project settings",
@@ -35,6 +35,9 @@ mciModule.controller('DistrosCtrl', function ($scope, $window, $http, $location,
   }, {
     'id': 'tunable',
     'display': 'Tunable '
+  }, {
+    'id': 'fair-share',
+    'display': 'Fair Share '
   }];
 
   $scope.dispatcherVersions = [{
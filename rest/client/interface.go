@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
 )
@@ -132,4 +135,24 @@ type Communicator interface {
 
 	// GetRawPatchWithModules fetches the raw patch and module diffs for a given patch ID.
 	GetRawPatchWithModules(ctx context.Context, patchId string) (*restmodel.APIRawPatch, error)
+
+	// GetVersionTestDiff returns the difference between the test results of
+	// the given version and those of the base version. If the base version ID
+	// is empty, the version's base version is used.
+	GetVersionTestDiff(ctx context.Context, versionID, baseVersionID string) (*restmodel.APITestResultsDiff, error)
+
+	// GetTaskQueueExplanation returns an explanation of why the task is or
+	// isn't waiting to run.
+	GetTaskQueueExplanation(ctx context.Context, taskID string) (*restmodel.APITaskQueueExplanation, error)
+
+	// PreviewGeneratedTasks returns the builds, tasks, and dependencies that
+	// the given generate.tasks JSON files would add to the version without
+	// saving anything.
+	PreviewGeneratedTasks(ctx context.Context, versionID, generatorTaskID string, files []json.RawMessage) (*restmodel.APIGeneratedProjectPreview, error)
+
+	// GetTaskLogs returns the current logs of a task as plain text.
+	GetTaskLogs(ctx context.Context, opts TaskLogsOptions) (io.ReadCloser, error)
+	// FollowTaskLogs returns an iterator over a task's log lines that
+	// streams new lines as they are logged until the task finishes.
+	FollowTaskLogs(ctx context.Context, opts TaskLogsOptions) (log.LogIterator, error)
 }
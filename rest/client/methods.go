@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
@@ -13,6 +15,7 @@ import (
 	serviceModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
@@ -1622,3 +1625,179 @@ func (c *communicatorImpl) GetRawPatchWithModules(ctx context.Context, patchId s
 	}
 	return &rp, nil
 }
+
+// GetVersionTestDiff returns the difference between the test results of the
+// given version and those of the base version.
+func (c *communicatorImpl) GetVersionTestDiff(ctx context.Context, versionID, baseVersionID string) (*restmodel.APITestResultsDiff, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("versions/%s/test_diff", versionID),
+	}
+	if baseVersionID != "" {
+		info.path += "?" + url.Values{"base_version_id": []string{baseVersionID}}.Encode()
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get test diff for version '%s'", versionID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting test diff for version '%s'", versionID)
+	}
+
+	diff := restmodel.APITestResultsDiff{}
+	if err = utility.ReadJSON(resp.Body, &diff); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return &diff, nil
+}
+
+// GetTaskQueueExplanation returns an explanation of why the task is or isn't
+// waiting to run.
+func (c *communicatorImpl) GetTaskQueueExplanation(ctx context.Context, taskID string) (*restmodel.APITaskQueueExplanation, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("tasks/%s/queue_explanation", taskID),
+	}
+
+	resp, err := c.request(ctx, info, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to get task queue explanation for task '%s'", taskID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "getting task queue explanation for task '%s'", taskID)
+	}
+
+	explanation := restmodel.APITaskQueueExplanation{}
+	if err = utility.ReadJSON(resp.Body, &explanation); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return &explanation, nil
+}
+
+// PreviewGeneratedTasks returns the builds, tasks, and dependencies that the
+// given generate.tasks JSON files would add to the version without saving
+// anything. If the generator task ID is given, tasks that depend on it are
+// previewed as depending on the generated tasks.
+func (c *communicatorImpl) PreviewGeneratedTasks(ctx context.Context, versionID, generatorTaskID string, files []json.RawMessage) (*restmodel.APIGeneratedProjectPreview, error) {
+	info := requestInfo{
+		method: http.MethodPost,
+		path:   fmt.Sprintf("versions/%s/generate_preview", versionID),
+	}
+	if generatorTaskID != "" {
+		info.path += "?" + url.Values{"task_id": []string{generatorTaskID}}.Encode()
+	}
+
+	resp, err := c.request(ctx, info, files)
+	if err != nil {
+		return nil, errors.Wrapf(err, "sending request to preview generated tasks for version '%s'", versionID)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, util.RespErrorf(resp, AuthError)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, util.RespErrorf(resp, "previewing generated tasks for version '%s'", versionID)
+	}
+
+	preview := restmodel.APIGeneratedProjectPreview{}
+	if err = utility.ReadJSON(resp.Body, &preview); err != nil {
+		return nil, errors.Wrap(err, "reading JSON response body")
+	}
+	return &preview, nil
+}
+
+// TaskLogsOptions represents the arguments for fetching a task's logs.
+type TaskLogsOptions struct {
+	// TaskID is the ID of the task.
+	TaskID string
+	// Execution is the execution of the task. Defaults to the latest
+	// execution.
+	Execution *int
+	// Type is the type of task log to fetch. Defaults to all logs.
+	Type string
+	// PrintTime, when true, prints the timestamp of each log line. Only
+	// applies to GetTaskLogs.
+	PrintTime bool
+	// PrintPriority, when true, prints the priority of each log line.
+	// Only applies to GetTaskLogs.
+	PrintPriority bool
+}
+
+func (opts TaskLogsOptions) query() url.Values {
+	vals := url.Values{}
+	if opts.Execution != nil {
+		vals.Set("execution", strconv.Itoa(*opts.Execution))
+	}
+	if opts.Type != "" {
+		vals.Set("type", opts.Type)
+	}
+	if opts.PrintTime {
+		vals.Set("print_time", "true")
+	}
+	if opts.PrintPriority {
+		vals.Set("print_priority", "true")
+	}
+
+	return vals
+}
+
+// GetTaskLogs returns the current logs of the task as plain text. It is the
+// responsibility of the caller to close the returned reader.
+func (c *communicatorImpl) GetTaskLogs(ctx context.Context, opts TaskLogsOptions) (io.ReadCloser, error) {
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("tasks/%s/logs/search?%s", opts.TaskID, opts.query().Encode()),
+	}
+
+	resp, err := c.streamRequest(ctx, info)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending request to get task logs")
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, util.RespErrorf(resp, AuthError)
+		}
+		return nil, util.RespErrorf(resp, "getting task logs")
+	}
+
+	return resp.Body, nil
+}
+
+// FollowTaskLogs returns an iterator over the task's log lines that streams
+// new lines as they are logged until the task finishes. It is the
+// responsibility of the caller to close the returned iterator.
+func (c *communicatorImpl) FollowTaskLogs(ctx context.Context, opts TaskLogsOptions) (log.LogIterator, error) {
+	opts.PrintTime = false
+	opts.PrintPriority = false
+	info := requestInfo{
+		method: http.MethodGet,
+		path:   fmt.Sprintf("tasks/%s/logs/follow?%s", opts.TaskID, opts.query().Encode()),
+	}
+
+	resp, err := c.streamRequest(ctx, info)
+	if err != nil {
+		return nil, errors.Wrap(err, "sending request to follow task logs")
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, util.RespErrorf(resp, AuthError)
+		}
+		return nil, util.RespErrorf(resp, "following task logs")
+	}
+
+	return newTaskLogStreamIterator(resp.Body), nil
+}
@@ -2,12 +2,16 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	serviceModel "github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/rest/model"
 	restmodel "github.com/evergreen-ci/evergreen/rest/model"
@@ -350,3 +354,23 @@ func (c *Mock) GetHostProvisioningOptions(context.Context, string, string) (*res
 func (c *Mock) GetRawPatchWithModules(context.Context, string) (*restmodel.APIRawPatch, error) {
 	return nil, nil
 }
+
+func (c *Mock) GetVersionTestDiff(context.Context, string, string) (*restmodel.APITestResultsDiff, error) {
+	return &restmodel.APITestResultsDiff{}, nil
+}
+
+func (c *Mock) GetTaskQueueExplanation(context.Context, string) (*restmodel.APITaskQueueExplanation, error) {
+	return &restmodel.APITaskQueueExplanation{}, nil
+}
+
+func (c *Mock) PreviewGeneratedTasks(context.Context, string, string, []json.RawMessage) (*restmodel.APIGeneratedProjectPreview, error) {
+	return &restmodel.APIGeneratedProjectPreview{}, nil
+}
+
+func (c *Mock) GetTaskLogs(context.Context, TaskLogsOptions) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (c *Mock) FollowTaskLogs(context.Context, TaskLogsOptions) (log.LogIterator, error) {
+	return nil, nil
+}
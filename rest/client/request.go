@@ -103,6 +103,26 @@ func (c *communicatorImpl) doRequest(ctx context.Context, r *http.Request) (*htt
 	return response, nil
 }
 
+// streamRequest sends a request whose response body is read incrementally,
+// such as a long-lived stream, so the request is not subject to the client's
+// overall timeout. It is the responsibility of the caller to close the
+// response body.
+func (c *communicatorImpl) streamRequest(ctx context.Context, info requestInfo) (*http.Response, error) {
+	r, err := c.createRequest(info, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(r.WithContext(ctx))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return resp, nil
+}
+
 func (c *communicatorImpl) retryRequest(ctx context.Context, info requestInfo, data interface{}) (*http.Response, error) {
 	var err error
 
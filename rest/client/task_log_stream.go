@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/mongodb/grip"
+)
+
+// maxTaskLogStreamLineSize is the maximum size of a single line of the task
+// log stream.
+const maxTaskLogStreamLineSize = 10 * 1024 * 1024
+
+// taskLogStreamIterator is a log.LogIterator over the server-sent events of
+// a followed task log.
+type taskLogStreamIterator struct {
+	body      io.ReadCloser
+	scanner   *bufio.Scanner
+	item      log.LogLine
+	catcher   grip.Catcher
+	exhausted bool
+	closed    bool
+}
+
+func newTaskLogStreamIterator(body io.ReadCloser) *taskLogStreamIterator {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxTaskLogStreamLineSize)
+
+	return &taskLogStreamIterator{
+		body:    body,
+		scanner: scanner,
+		catcher: grip.NewBasicCatcher(),
+	}
+}
+
+func (it *taskLogStreamIterator) Next() bool {
+	if it.closed || it.exhausted || it.catcher.HasErrors() {
+		return false
+	}
+
+	var (
+		event string
+		data  []string
+	)
+	for it.scanner.Scan() {
+		line := it.scanner.Text()
+		switch {
+		case line == "":
+			if event == "" && len(data) == 0 {
+				continue
+			}
+			return it.handleEvent(event, strings.Join(data, "\n"))
+		case strings.HasPrefix(line, ":"):
+			// Comment lines are ignored.
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := it.scanner.Err(); err != nil {
+		it.catcher.Wrap(err, "reading task log stream")
+	} else {
+		it.catcher.New("task log stream ended before the task finished")
+	}
+
+	return false
+}
+
+// handleEvent processes a single event from the stream and returns whether
+// it contained a log line.
+func (it *taskLogStreamIterator) handleEvent(event, data string) bool {
+	switch event {
+	case model.TaskLogFollowLineEvent:
+		var line model.APILogLine
+		if err := json.Unmarshal([]byte(data), &line); err != nil {
+			it.catcher.Wrap(err, "unmarshalling task log line")
+			return false
+		}
+		it.item = line.ToService()
+
+		return true
+	case model.TaskLogFollowEndEvent:
+		it.exhausted = true
+		return false
+	case model.TaskLogFollowErrorEvent:
+		var msg string
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			msg = data
+		}
+		it.catcher.Errorf("following task logs: %s", msg)
+
+		return false
+	default:
+		it.catcher.Errorf("unrecognized task log stream event '%s'", event)
+		return false
+	}
+}
+
+func (it *taskLogStreamIterator) Exhausted() bool { return it.exhausted }
+
+func (it *taskLogStreamIterator) Err() error { return it.catcher.Resolve() }
+
+func (it *taskLogStreamIterator) Item() log.LogLine { return it.item }
+
+func (it *taskLogStreamIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+
+	return it.body.Close()
+}
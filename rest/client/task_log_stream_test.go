@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollowTaskLogs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for testName, testCase := range map[string]struct {
+		stream        string
+		expectedLines []log.LogLine
+		hasErr        bool
+	}{
+		"StreamsLinesUntilEnd": {
+			stream: "event: line\ndata: {\"log_name\":\"task\",\"priority\":40,\"timestamp\":1,\"data\":\"hello\"}\n\n" +
+				": keepalive\n\n" +
+				"event: line\ndata: {\"log_name\":\"task\",\"priority\":70,\"timestamp\":2,\"data\":\"world\",\"attributes\":{\"step\":\"compile\"}}\n\n" +
+				"event: end\ndata: \"success\"\n\n",
+			expectedLines: []log.LogLine{
+				{LogName: "task", Priority: level.Info, Timestamp: 1, Data: "hello"},
+				{LogName: "task", Priority: level.Error, Timestamp: 2, Data: "world", Attributes: map[string]string{"step": "compile"}},
+			},
+		},
+		"ErrorEvent": {
+			stream: "event: line\ndata: {\"log_name\":\"task\",\"priority\":40,\"timestamp\":1,\"data\":\"hello\"}\n\n" +
+				"event: error\ndata: \"finding task\"\n\n",
+			expectedLines: []log.LogLine{
+				{LogName: "task", Priority: level.Info, Timestamp: 1, Data: "hello"},
+			},
+			hasErr: true,
+		},
+		"StreamEndsEarly": {
+			stream: "event: line\ndata: {\"log_name\":\"task\",\"priority\":40,\"timestamp\":1,\"data\":\"hello\"}\n\n",
+			expectedLines: []log.LogLine{
+				{LogName: "task", Priority: level.Info, Timestamp: 1, Data: "hello"},
+			},
+			hasErr: true,
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/rest/v2/tasks/t1/logs/follow", r.URL.Path)
+				assert.Equal(t, "task_log", r.URL.Query().Get("type"))
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, testCase.stream)
+			}))
+			defer srv.Close()
+
+			comm, err := NewCommunicator(srv.URL)
+			require.NoError(t, err)
+			defer comm.Close()
+
+			it, err := comm.FollowTaskLogs(ctx, TaskLogsOptions{TaskID: "t1", Type: "task_log"})
+			require.NoError(t, err)
+
+			var lines []log.LogLine
+			for it.Next() {
+				lines = append(lines, it.Item())
+			}
+			assert.Equal(t, testCase.expectedLines, lines)
+			if testCase.hasErr {
+				assert.Error(t, it.Err())
+				assert.False(t, it.Exhausted())
+			} else {
+				assert.NoError(t, it.Err())
+				assert.True(t, it.Exhausted())
+			}
+			assert.NoError(t, it.Close())
+		})
+	}
+	t.Run("ErrorStatus", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "task not found", http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		comm, err := NewCommunicator(srv.URL)
+		require.NoError(t, err)
+		defer comm.Close()
+
+		_, err = comm.FollowTaskLogs(ctx, TaskLogsOptions{TaskID: "t1"})
+		assert.Error(t, err)
+	})
+}
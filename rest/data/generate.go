@@ -3,11 +3,15 @@ package data
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/validator"
 	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
 	"github.com/pkg/errors"
 )
 
@@ -49,3 +53,101 @@ func GeneratePoll(ctx context.Context, taskID string) (bool, string, error) {
 
 	return t.GeneratedTasks, t.GenerateTasksError, nil
 }
+
+// PreviewGeneratedTasks parses JSON files for `generate.tasks` and returns
+// the builds, tasks, and dependencies that they would add to the version
+// without saving anything. If a generator task is given, tasks that depend on
+// the generator are previewed as depending on the generated tasks.
+func PreviewGeneratedTasks(ctx context.Context, settings *evergreen.Settings, versionID, generatorTaskID string, jsonBytes []json.RawMessage) (*model.GeneratedProjectPreview, error) {
+	v, err := model.VersionFindOneId(versionID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding version '%s'", versionID)
+	}
+	if v == nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("version '%s' not found", versionID),
+		}
+	}
+
+	generator := &task.Task{
+		Version:   v.Id,
+		Project:   v.Identifier,
+		Requester: v.Requester,
+	}
+	if generatorTaskID != "" {
+		generator, err = task.FindOneId(generatorTaskID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "finding task '%s'", generatorTaskID)
+		}
+		if generator == nil || generator.Version != v.Id {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusNotFound,
+				Message:    fmt.Sprintf("task '%s' not found in version '%s'", generatorTaskID, v.Id),
+			}
+		}
+	}
+
+	catcher := grip.NewBasicCatcher()
+	projects := make([]model.GeneratedProject, 0, len(jsonBytes))
+	for _, f := range jsonBytes {
+		p, err := model.ParseProjectFromJSONString(string(f))
+		catcher.Add(err)
+		projects = append(projects, p)
+	}
+	if catcher.HasErrors() {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(catcher.Resolve(), "parsing JSON from `generate.tasks`").Error(),
+		}
+	}
+	g, err := model.MergeGeneratedProjects(ctx, projects)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "merging generated projects").Error(),
+		}
+	}
+	g.Task = generator
+
+	project, parserProject, err := model.FindAndTranslateProjectForVersion(ctx, settings, v)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading project for version '%s'", v.Id)
+	}
+	p, _, v, err := g.NewVersion(ctx, project, parserProject, v)
+	if err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+
+	pref, err := model.FindMergedProjectRef(v.Identifier, v.Id, true)
+	if err != nil {
+		return nil, errors.Wrapf(err, "finding project ref '%s'", v.Identifier)
+	}
+	if pref == nil {
+		return nil, errors.Errorf("project ref '%s' not found", v.Identifier)
+	}
+	if err = validator.CheckProjectConfigurationIsValid(ctx, settings, p, pref); err != nil {
+		return nil, gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}
+	}
+	if err = g.CheckForCycles(ctx, v, p, pref); err != nil {
+		if errors.Cause(err) == model.DependencyCycleError {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "checking new dependency graph for cycles").Error(),
+			}
+		}
+		return nil, errors.Wrap(err, "checking new dependency graph for cycles")
+	}
+
+	preview, err := g.Preview(ctx, v, p, pref)
+	if err != nil {
+		return nil, errors.Wrap(err, "previewing generated tasks")
+	}
+	return preview, nil
+}
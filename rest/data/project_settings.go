@@ -243,6 +243,14 @@ func SaveProjectSettingsForSection(ctx context.Context, projectId string, change
 				return nil, err
 			}
 		}
+		// Test bisect continues from the culprit that bisect stepback finds,
+		// so it can't be enabled without bisect stepback.
+		if mergedSection.IsStepbackTestBisect() && !mergedSection.IsStepbackBisect() {
+			return nil, gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    "test bisect stepback requires bisect stepback to be enabled",
+			}
+		}
 
 		// Validate owner/repo if the project is enabled or owner/repo is populated.
 		// This validation is cheap so it makes sense to be strict about this.
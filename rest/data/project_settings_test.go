@@ -295,6 +295,28 @@ func TestSaveProjectSettingsForSection(t *testing.T) {
 			assert.NotEmpty(t, pRefFromDB.SpawnHostScriptPath)
 			assert.NotEqual(t, pRefFromDB.Owner, "something different") // because use repo settings is true, we don't change this
 		},
+		"test bisect requires bisect stepback": func(t *testing.T, ref model.ProjectRef) {
+			ref.StepbackTestBisect = utility.TruePtr()
+			apiProjectRef := restModel.APIProjectRef{}
+			assert.NoError(t, apiProjectRef.BuildFromService(ref))
+			apiChanges := &restModel.APIProjectSettings{
+				ProjectRef: apiProjectRef,
+			}
+			settings, err := SaveProjectSettingsForSection(ctx, ref.Id, apiChanges, model.ProjectPageGeneralSection, false, "me")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "requires bisect stepback")
+			assert.Nil(t, settings)
+
+			ref.StepbackBisect = utility.TruePtr()
+			assert.NoError(t, apiProjectRef.BuildFromService(ref))
+			apiChanges.ProjectRef = apiProjectRef
+			_, err = SaveProjectSettingsForSection(ctx, ref.Id, apiChanges, model.ProjectPageGeneralSection, false, "me")
+			require.NoError(t, err)
+			pRefFromDB, err := model.FindBranchProjectRef(ref.Id)
+			require.NoError(t, err)
+			require.NotNil(t, pRefFromDB)
+			assert.True(t, pRefFromDB.IsStepbackTestBisect())
+		},
 		"github conflicts with enabling": func(t *testing.T, ref model.ProjectRef) {
 			conflictingRef := model.ProjectRef{
 				Identifier:          "conflicting-project",
@@ -30,3 +30,26 @@ func GetTaskReliabilityScores(filter reliability.TaskReliabilityFilter) ([]restM
 	}
 	return apiStatsResult, nil
 }
+
+// GetTestReliabilityScores queries the service backend to retrieve the test
+// reliability scores that match the given filter.
+func GetTestReliabilityScores(filter reliability.TestReliabilityFilter) ([]restModel.APITestReliability, error) {
+	if filter.Project != "" {
+		projectID, err := model.GetIdForProject(filter.Project)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting project ref ID for identifier '%s'", filter.Project)
+		}
+		filter.Project = projectID
+	}
+
+	serviceScores, err := reliability.GetTestReliabilityScores(filter)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting test reliability scores")
+	}
+
+	apiScores := make([]restModel.APITestReliability, len(serviceScores))
+	for i, score := range serviceScores {
+		apiScores[i].BuildFromService(score)
+	}
+	return apiScores, nil
+}
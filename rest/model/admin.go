@@ -19,6 +19,7 @@ func NewConfigModel() *APIAdminSettings {
 		Cedar:             &APICedarConfig{},
 		CommitQueue:       &APICommitQueueConfig{},
 		ContainerPools:    &APIContainerPoolsConfig{},
+		Cost:              &APICostConfig{},
 		Credentials:       map[string]string{},
 		DataPipes:         &APIDataPipesConfig{},
 		Expansions:        map[string]string{},
@@ -63,6 +64,7 @@ type APIAdminSettings struct {
 	CommitQueue         *APICommitQueueConfig             `json:"commit_queue,omitempty"`
 	ConfigDir           *string                           `json:"configdir,omitempty"`
 	ContainerPools      *APIContainerPoolsConfig          `json:"container_pools,omitempty"`
+	Cost                *APICostConfig                    `json:"cost,omitempty"`
 	Credentials         map[string]string                 `json:"credentials,omitempty"`
 	DomainName          *string                           `json:"domain_name,omitempty"`
 	DataPipes           *APIDataPipesConfig               `json:"data_pipes,omitempty"`
@@ -647,7 +649,8 @@ func (a *APIAuthConfig) ToService() (interface{}, error) {
 }
 
 type APIBucketsConfig struct {
-	LogBucket APIBucketConfig `json:"log_bucket"`
+	LogBucket         APIBucketConfig `json:"log_bucket"`
+	TaskOutputVersion int             `json:"task_output_version"`
 }
 
 type APIBucketConfig struct {
@@ -662,6 +665,7 @@ func (a *APIBucketsConfig) BuildFromService(h interface{}) error {
 		a.LogBucket.Name = utility.ToStringPtr(v.LogBucket.Name)
 		a.LogBucket.Type = utility.ToStringPtr(string(v.LogBucket.Type))
 		a.LogBucket.DBName = utility.ToStringPtr(v.LogBucket.DBName)
+		a.TaskOutputVersion = v.TaskOutputVersion
 	default:
 		return errors.Errorf("programmatic error: expected bucket config but got type %T", h)
 	}
@@ -675,6 +679,7 @@ func (a *APIBucketsConfig) ToService() (interface{}, error) {
 			Type:   evergreen.BucketType(utility.FromStringPtr(a.LogBucket.Type)),
 			DBName: utility.FromStringPtr(a.LogBucket.DBName),
 		},
+		TaskOutputVersion: a.TaskOutputVersion,
 	}, nil
 }
 
@@ -1338,11 +1343,13 @@ func (a *APIProjectCreationConfig) ToService() (interface{}, error) {
 }
 
 type APICloudProviders struct {
-	AWS       *APIAWSConfig       `json:"aws"`
-	Docker    *APIDockerConfig    `json:"docker"`
-	GCE       *APIGCEConfig       `json:"gce"`
-	OpenStack *APIOpenStackConfig `json:"openstack"`
-	VSphere   *APIVSphereConfig   `json:"vsphere"`
+	AWS        *APIAWSConfig        `json:"aws"`
+	Docker     *APIDockerConfig     `json:"docker"`
+	GCE        *APIGCEConfig        `json:"gce"`
+	OpenStack  *APIOpenStackConfig  `json:"openstack"`
+	VSphere    *APIVSphereConfig    `json:"vsphere"`
+	Libvirt    *APILibvirtConfig    `json:"libvirt"`
+	Kubernetes *APIKubernetesConfig `json:"kubernetes"`
 }
 
 func (a *APICloudProviders) BuildFromService(h interface{}) error {
@@ -1353,6 +1360,8 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		a.GCE = &APIGCEConfig{}
 		a.OpenStack = &APIOpenStackConfig{}
 		a.VSphere = &APIVSphereConfig{}
+		a.Libvirt = &APILibvirtConfig{}
+		a.Kubernetes = &APIKubernetesConfig{}
 		if err := a.AWS.BuildFromService(v.AWS); err != nil {
 			return err
 		}
@@ -1368,6 +1377,12 @@ func (a *APICloudProviders) BuildFromService(h interface{}) error {
 		if err := a.VSphere.BuildFromService(v.VSphere); err != nil {
 			return err
 		}
+		if err := a.Libvirt.BuildFromService(v.Libvirt); err != nil {
+			return err
+		}
+		if err := a.Kubernetes.BuildFromService(v.Kubernetes); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("programmatic error: expected cloud provider config but got type %T", h)
 	}
@@ -1395,12 +1410,22 @@ func (a *APICloudProviders) ToService() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	libvirt, err := a.Libvirt.ToService()
+	if err != nil {
+		return nil, err
+	}
+	kubernetes, err := a.Kubernetes.ToService()
+	if err != nil {
+		return nil, err
+	}
 	return evergreen.CloudProviders{
-		AWS:       aws.(evergreen.AWSConfig),
-		Docker:    docker.(evergreen.DockerConfig),
-		GCE:       gce.(evergreen.GCEConfig),
-		OpenStack: openstack.(evergreen.OpenStackConfig),
-		VSphere:   vsphere.(evergreen.VSphereConfig),
+		AWS:        aws.(evergreen.AWSConfig),
+		Docker:     docker.(evergreen.DockerConfig),
+		GCE:        gce.(evergreen.GCEConfig),
+		OpenStack:  openstack.(evergreen.OpenStackConfig),
+		VSphere:    vsphere.(evergreen.VSphereConfig),
+		Libvirt:    libvirt.(evergreen.LibvirtConfig),
+		Kubernetes: kubernetes.(evergreen.KubernetesConfig),
 	}, nil
 }
 
@@ -2115,6 +2140,99 @@ func (a *APIVSphereConfig) ToService() (interface{}, error) {
 	}, nil
 }
 
+type APILibvirtConfig struct {
+	URI         *string `json:"uri"`
+	StoragePool *string `json:"storage_pool"`
+}
+
+func (a *APILibvirtConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.LibvirtConfig:
+		a.URI = utility.ToStringPtr(v.URI)
+		a.StoragePool = utility.ToStringPtr(v.StoragePool)
+	default:
+		return errors.Errorf("programmatic error: expected libvirt config but got type %T", h)
+	}
+	return nil
+}
+
+func (a *APILibvirtConfig) ToService() (interface{}, error) {
+	return evergreen.LibvirtConfig{
+		URI:         utility.FromStringPtr(a.URI),
+		StoragePool: utility.FromStringPtr(a.StoragePool),
+	}, nil
+}
+
+type APIKubernetesConfig struct {
+	Kubeconfig     *string                      `json:"kubeconfig"`
+	Context        *string                      `json:"context"`
+	APIServerURL   *string                      `json:"api_server_url"`
+	Namespace      *string                      `json:"namespace"`
+	Token          *string                      `json:"token"`
+	CACert         *string                      `json:"ca_cert"`
+	ContainerPools []APIKubernetesContainerPool `json:"container_pools"`
+	ClientType     *string                      `json:"client_type"`
+}
+
+func (a *APIKubernetesConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.KubernetesConfig:
+		a.Kubeconfig = utility.ToStringPtr(v.Kubeconfig)
+		a.Context = utility.ToStringPtr(v.Context)
+		a.APIServerURL = utility.ToStringPtr(v.APIServerURL)
+		a.Namespace = utility.ToStringPtr(v.Namespace)
+		a.Token = utility.ToStringPtr(v.Token)
+		a.CACert = utility.ToStringPtr(v.CACert)
+		a.ContainerPools = nil
+		for _, pool := range v.ContainerPools {
+			var apiPool APIKubernetesContainerPool
+			apiPool.BuildFromService(pool)
+			a.ContainerPools = append(a.ContainerPools, apiPool)
+		}
+		a.ClientType = utility.ToStringPtr(string(v.ClientType))
+	default:
+		return errors.Errorf("programmatic error: expected Kubernetes config but got type %T", h)
+	}
+	return nil
+}
+
+func (a *APIKubernetesConfig) ToService() (interface{}, error) {
+	var pools []evergreen.KubernetesContainerPool
+	for _, pool := range a.ContainerPools {
+		pools = append(pools, pool.ToService())
+	}
+	return evergreen.KubernetesConfig{
+		Kubeconfig:     utility.FromStringPtr(a.Kubeconfig),
+		Context:        utility.FromStringPtr(a.Context),
+		APIServerURL:   utility.FromStringPtr(a.APIServerURL),
+		Namespace:      utility.FromStringPtr(a.Namespace),
+		Token:          utility.FromStringPtr(a.Token),
+		CACert:         utility.FromStringPtr(a.CACert),
+		ContainerPools: pools,
+		ClientType:     evergreen.KubernetesClientType(utility.FromStringPtr(a.ClientType)),
+	}, nil
+}
+
+type APIKubernetesContainerPool struct {
+	Name         *string           `json:"name"`
+	Namespace    *string           `json:"namespace"`
+	NodeSelector map[string]string `json:"node_selector"`
+}
+
+func (a *APIKubernetesContainerPool) BuildFromService(pool evergreen.KubernetesContainerPool) {
+	a.Name = utility.ToStringPtr(pool.Name)
+	a.Namespace = utility.ToStringPtr(pool.Namespace)
+	a.NodeSelector = pool.NodeSelector
+}
+
+func (a *APIKubernetesContainerPool) ToService() evergreen.KubernetesContainerPool {
+	return evergreen.KubernetesContainerPool{
+		Name:         utility.FromStringPtr(a.Name),
+		Namespace:    utility.FromStringPtr(a.Namespace),
+		NodeSelector: a.NodeSelector,
+	}
+}
+
 type APIRepoTrackerConfig struct {
 	NumNewRepoRevisionsToFetch int `json:"revs_to_fetch"`
 	MaxRepoRevisionsToSearch   int `json:"max_revs_to_search"`
@@ -2160,6 +2278,17 @@ type APISchedulerConfig struct {
 	ExpectedRuntimeFactor         int64   `json:"expected_runtime_factor"`
 	GenerateTaskFactor            int64   `json:"generate_task_factor"`
 	StepbackTaskFactor            int64   `json:"stepback_task_factor"`
+	// FairShareProjectWeights are the relative shares of a distro's hosts
+	// that projects get with the fair-share planner.
+	FairShareProjectWeights     []APIFairShareProjectWeight `json:"fair_share_project_weights"`
+	FairShareUsageWindowSeconds int                         `json:"fair_share_usage_window_seconds"`
+}
+
+// APIFairShareProjectWeight is a project's relative share of a distro's hosts
+// with the fair-share planner.
+type APIFairShareProjectWeight struct {
+	ProjectID *string `json:"project_id"`
+	Weight    float64 `json:"weight"`
 }
 
 func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
@@ -2182,6 +2311,14 @@ func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
 		a.ExpectedRuntimeFactor = v.ExpectedRuntimeFactor
 		a.GenerateTaskFactor = v.GenerateTaskFactor
 		a.StepbackTaskFactor = v.StepbackTaskFactor
+		a.FairShareProjectWeights = make([]APIFairShareProjectWeight, 0, len(v.FairShareProjectWeights))
+		for _, w := range v.FairShareProjectWeights {
+			a.FairShareProjectWeights = append(a.FairShareProjectWeights, APIFairShareProjectWeight{
+				ProjectID: utility.ToStringPtr(w.ProjectID),
+				Weight:    w.Weight,
+			})
+		}
+		a.FairShareUsageWindowSeconds = v.FairShareUsageWindowSeconds
 	default:
 		return errors.Errorf("programmatic error: expected host scheduler config but got type %T", h)
 	}
@@ -2189,6 +2326,13 @@ func (a *APISchedulerConfig) BuildFromService(h interface{}) error {
 }
 
 func (a *APISchedulerConfig) ToService() (interface{}, error) {
+	var weights []evergreen.FairShareProjectWeight
+	for _, w := range a.FairShareProjectWeights {
+		weights = append(weights, evergreen.FairShareProjectWeight{
+			ProjectID: utility.FromStringPtr(w.ProjectID),
+			Weight:    w.Weight,
+		})
+	}
 	return evergreen.SchedulerConfig{
 		TaskFinder:                    utility.FromStringPtr(a.TaskFinder),
 		HostAllocator:                 utility.FromStringPtr(a.HostAllocator),
@@ -2207,6 +2351,8 @@ func (a *APISchedulerConfig) ToService() (interface{}, error) {
 		MainlineTimeInQueueFactor:     a.MainlineTimeInQueueFactor,
 		GenerateTaskFactor:            a.GenerateTaskFactor,
 		StepbackTaskFactor:            a.StepbackTaskFactor,
+		FairShareProjectWeights:       weights,
+		FairShareUsageWindowSeconds:   a.FairShareUsageWindowSeconds,
 	}, nil
 }
 
@@ -2239,6 +2385,7 @@ type APIServiceFlags struct {
 	LegacyUIPublicAccessDisabled   bool `json:"legacy_ui_public_access_disabled"`
 	GlobalGitHubTokenDisabled      bool `json:"global_github_token_disabled"`
 	UnsetFunctionVarsDisabled      bool `json:"unset_function_vars_disabled"`
+	TaskLogCompactionDryRun        bool `json:"task_log_compaction_dry_run"`
 
 	// Notifications Flags
 	EventProcessingDisabled      bool `json:"event_processing_disabled"`
@@ -2531,6 +2678,7 @@ func (as *APIServiceFlags) BuildFromService(h interface{}) error {
 		as.LegacyUIPublicAccessDisabled = v.LegacyUIPublicAccessDisabled
 		as.GlobalGitHubTokenDisabled = v.GlobalGitHubTokenDisabled
 		as.UnsetFunctionVarsDisabled = v.UnsetFunctionVarsDisabled
+		as.TaskLogCompactionDryRun = v.TaskLogCompactionDryRun
 	default:
 		return errors.Errorf("programmatic error: expected service flags config but got type %T", h)
 	}
@@ -2573,6 +2721,7 @@ func (as *APIServiceFlags) ToService() (interface{}, error) {
 		LegacyUIPublicAccessDisabled:   as.LegacyUIPublicAccessDisabled,
 		GlobalGitHubTokenDisabled:      as.GlobalGitHubTokenDisabled,
 		UnsetFunctionVarsDisabled:      as.UnsetFunctionVarsDisabled,
+		TaskLogCompactionDryRun:        as.TaskLogCompactionDryRun,
 	}, nil
 }
 
@@ -2714,6 +2863,46 @@ func (j *APIJIRANotificationsProject) ToService() (interface{}, error) {
 	return service, nil
 }
 
+type APICostConfig struct {
+	DefaultHourlyCost       *float64                    `json:"default_hourly_cost"`
+	InstanceTypeHourlyCosts []APIInstanceTypeHourlyCost `json:"instance_type_hourly_costs"`
+}
+
+type APIInstanceTypeHourlyCost struct {
+	InstanceType *string  `json:"instance_type"`
+	HourlyCost   *float64 `json:"hourly_cost"`
+}
+
+func (c *APICostConfig) BuildFromService(h interface{}) error {
+	switch v := h.(type) {
+	case evergreen.CostConfig:
+		c.DefaultHourlyCost = utility.ToFloat64Ptr(v.DefaultHourlyCost)
+		c.InstanceTypeHourlyCosts = []APIInstanceTypeHourlyCost{}
+		for _, cost := range v.InstanceTypeHourlyCosts {
+			c.InstanceTypeHourlyCosts = append(c.InstanceTypeHourlyCosts, APIInstanceTypeHourlyCost{
+				InstanceType: utility.ToStringPtr(cost.InstanceType),
+				HourlyCost:   utility.ToFloat64Ptr(cost.HourlyCost),
+			})
+		}
+	default:
+		return errors.Errorf("programmatic error: expected cost config but got type %T", h)
+	}
+	return nil
+}
+
+func (c *APICostConfig) ToService() (interface{}, error) {
+	config := evergreen.CostConfig{
+		DefaultHourlyCost: utility.FromFloat64Ptr(c.DefaultHourlyCost),
+	}
+	for _, cost := range c.InstanceTypeHourlyCosts {
+		config.InstanceTypeHourlyCosts = append(config.InstanceTypeHourlyCosts, evergreen.InstanceTypeHourlyCost{
+			InstanceType: utility.FromStringPtr(cost.InstanceType),
+			HourlyCost:   utility.FromFloat64Ptr(cost.HourlyCost),
+		})
+	}
+	return config, nil
+}
+
 type APITriggerConfig struct {
 	GenerateTaskDistro *string `json:"generate_distro"`
 }
@@ -122,6 +122,7 @@ func TestModelConversion(t *testing.T) {
 	assert.Equal(testSettings.Buckets.LogBucket.Name, utility.FromStringPtr(apiSettings.Buckets.LogBucket.Name))
 	assert.EqualValues(testSettings.Buckets.LogBucket.Type, utility.FromStringPtr(apiSettings.Buckets.LogBucket.Type))
 	assert.Equal(testSettings.Buckets.LogBucket.DBName, utility.FromStringPtr(apiSettings.Buckets.LogBucket.DBName))
+	assert.Equal(testSettings.Buckets.TaskOutputVersion, apiSettings.Buckets.TaskOutputVersion)
 	assert.Equal(testSettings.Cedar.BaseURL, utility.FromStringPtr(apiSettings.Cedar.BaseURL))
 	assert.Equal(testSettings.Cedar.RPCPort, utility.FromStringPtr(apiSettings.Cedar.RPCPort))
 	assert.Equal(testSettings.Cedar.User, utility.FromStringPtr(apiSettings.Cedar.User))
@@ -206,11 +207,25 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, utility.FromStringPtr(apiSettings.Providers.GCE.ClientEmail))
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, utility.FromStringPtr(apiSettings.Providers.OpenStack.IdentityEndpoint))
 	assert.EqualValues(testSettings.Providers.VSphere.Host, utility.FromStringPtr(apiSettings.Providers.VSphere.Host))
+	assert.EqualValues(testSettings.Providers.Libvirt.URI, utility.FromStringPtr(apiSettings.Providers.Libvirt.URI))
+	assert.EqualValues(testSettings.Providers.Libvirt.StoragePool, utility.FromStringPtr(apiSettings.Providers.Libvirt.StoragePool))
+	assert.EqualValues(testSettings.Providers.Kubernetes.APIServerURL, utility.FromStringPtr(apiSettings.Providers.Kubernetes.APIServerURL))
+	assert.EqualValues(testSettings.Providers.Kubernetes.Namespace, utility.FromStringPtr(apiSettings.Providers.Kubernetes.Namespace))
+	require.Len(apiSettings.Providers.Kubernetes.ContainerPools, len(testSettings.Providers.Kubernetes.ContainerPools))
+	assert.EqualValues(testSettings.Providers.Kubernetes.ContainerPools[0].Name, utility.FromStringPtr(apiSettings.Providers.Kubernetes.ContainerPools[0].Name))
+	assert.EqualValues(testSettings.Providers.Kubernetes.ContainerPools[0].NodeSelector, apiSettings.Providers.Kubernetes.ContainerPools[0].NodeSelector)
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, apiSettings.RepoTracker.MaxConcurrentRequests)
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, utility.FromStringPtr(apiSettings.Scheduler.TaskFinder))
+	require.Len(apiSettings.Scheduler.FairShareProjectWeights, len(testSettings.Scheduler.FairShareProjectWeights))
+	for i, w := range testSettings.Scheduler.FairShareProjectWeights {
+		assert.Equal(w.ProjectID, utility.FromStringPtr(apiSettings.Scheduler.FairShareProjectWeights[i].ProjectID))
+		assert.Equal(w.Weight, apiSettings.Scheduler.FairShareProjectWeights[i].Weight)
+	}
+	assert.EqualValues(testSettings.Scheduler.FairShareUsageWindowSeconds, apiSettings.Scheduler.FairShareUsageWindowSeconds)
 	assert.EqualValues(testSettings.ServiceFlags.HostInitDisabled, apiSettings.ServiceFlags.HostInitDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.PodInitDisabled, apiSettings.ServiceFlags.PodInitDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.UnsetFunctionVarsDisabled, apiSettings.ServiceFlags.UnsetFunctionVarsDisabled)
+	assert.EqualValues(testSettings.ServiceFlags.TaskLogCompactionDryRun, apiSettings.ServiceFlags.TaskLogCompactionDryRun)
 	assert.EqualValues(testSettings.ServiceFlags.PodAllocatorDisabled, apiSettings.ServiceFlags.PodAllocatorDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.UnrecognizedPodCleanupDisabled, apiSettings.ServiceFlags.UnrecognizedPodCleanupDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.S3BinaryDownloadsDisabled, apiSettings.ServiceFlags.S3BinaryDownloadsDisabled)
@@ -225,6 +240,10 @@ func TestModelConversion(t *testing.T) {
 	assert.Equal(testSettings.Tracer.Enabled, *apiSettings.Tracer.Enabled)
 	assert.Equal(testSettings.Tracer.CollectorEndpoint, *apiSettings.Tracer.CollectorEndpoint)
 	assert.Equal(testSettings.GitHubCheckRun.CheckRunLimit, *apiSettings.GitHubCheckRun.CheckRunLimit)
+	assert.Equal(testSettings.Cost.DefaultHourlyCost, *apiSettings.Cost.DefaultHourlyCost)
+	require.Len(apiSettings.Cost.InstanceTypeHourlyCosts, len(testSettings.Cost.InstanceTypeHourlyCosts))
+	assert.Equal(testSettings.Cost.InstanceTypeHourlyCosts[0].InstanceType, utility.FromStringPtr(apiSettings.Cost.InstanceTypeHourlyCosts[0].InstanceType))
+	assert.Equal(testSettings.Cost.InstanceTypeHourlyCosts[0].HourlyCost, utility.FromFloat64Ptr(apiSettings.Cost.InstanceTypeHourlyCosts[0].HourlyCost))
 
 	// test converting from the API model back to a DB model
 	dbInterface, err := apiSettings.ToService()
@@ -258,6 +277,7 @@ func TestModelConversion(t *testing.T) {
 	assert.Equal(testSettings.Buckets.LogBucket.Name, utility.FromStringPtr(apiSettings.Buckets.LogBucket.Name))
 	assert.EqualValues(testSettings.Buckets.LogBucket.Type, utility.FromStringPtr(apiSettings.Buckets.LogBucket.Type))
 	assert.Equal(testSettings.Buckets.LogBucket.DBName, utility.FromStringPtr(apiSettings.Buckets.LogBucket.DBName))
+	assert.Equal(testSettings.Buckets.TaskOutputVersion, apiSettings.Buckets.TaskOutputVersion)
 	assert.Equal(testSettings.Cedar.BaseURL, utility.FromStringPtr(apiSettings.Cedar.BaseURL))
 	assert.Equal(testSettings.Cedar.RPCPort, utility.FromStringPtr(apiSettings.Cedar.RPCPort))
 	assert.Equal(testSettings.Cedar.User, utility.FromStringPtr(apiSettings.Cedar.User))
@@ -308,8 +328,13 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Providers.GCE.ClientEmail, dbSettings.Providers.GCE.ClientEmail)
 	assert.EqualValues(testSettings.Providers.OpenStack.IdentityEndpoint, dbSettings.Providers.OpenStack.IdentityEndpoint)
 	assert.EqualValues(testSettings.Providers.VSphere.Host, dbSettings.Providers.VSphere.Host)
+	assert.EqualValues(testSettings.Providers.Libvirt.URI, dbSettings.Providers.Libvirt.URI)
+	assert.EqualValues(testSettings.Providers.Kubernetes.APIServerURL, dbSettings.Providers.Kubernetes.APIServerURL)
+	assert.EqualValues(testSettings.Providers.Kubernetes.ContainerPools, dbSettings.Providers.Kubernetes.ContainerPools)
 	assert.EqualValues(testSettings.RepoTracker.MaxConcurrentRequests, dbSettings.RepoTracker.MaxConcurrentRequests)
 	assert.EqualValues(testSettings.Scheduler.TaskFinder, dbSettings.Scheduler.TaskFinder)
+	assert.EqualValues(testSettings.Scheduler.FairShareProjectWeights, dbSettings.Scheduler.FairShareProjectWeights)
+	assert.EqualValues(testSettings.Scheduler.FairShareUsageWindowSeconds, dbSettings.Scheduler.FairShareUsageWindowSeconds)
 	assert.EqualValues(testSettings.ServiceFlags.HostInitDisabled, dbSettings.ServiceFlags.HostInitDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.PodInitDisabled, dbSettings.ServiceFlags.PodInitDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.PodAllocatorDisabled, dbSettings.ServiceFlags.PodAllocatorDisabled)
@@ -317,6 +342,7 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.ServiceFlags.CloudCleanupDisabled, dbSettings.ServiceFlags.CloudCleanupDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.UnrecognizedPodCleanupDisabled, dbSettings.ServiceFlags.UnrecognizedPodCleanupDisabled)
 	assert.EqualValues(testSettings.ServiceFlags.UnsetFunctionVarsDisabled, apiSettings.ServiceFlags.UnsetFunctionVarsDisabled)
+	assert.EqualValues(testSettings.ServiceFlags.TaskLogCompactionDryRun, apiSettings.ServiceFlags.TaskLogCompactionDryRun)
 	require.Len(dbSettings.SSHKeyPairs, len(testSettings.SSHKeyPairs))
 	for i := 0; i < len(testSettings.SSHKeyPairs); i++ {
 		assert.Equal(dbSettings.SSHKeyPairs[i].Name, testSettings.SSHKeyPairs[i].Name)
@@ -334,6 +360,7 @@ func TestModelConversion(t *testing.T) {
 	assert.EqualValues(testSettings.Tracer.Enabled, dbSettings.Tracer.Enabled)
 	assert.EqualValues(testSettings.Tracer.CollectorEndpoint, dbSettings.Tracer.CollectorEndpoint)
 	assert.EqualValues(testSettings.GitHubCheckRun.CheckRunLimit, dbSettings.GitHubCheckRun.CheckRunLimit)
+	assert.EqualValues(testSettings.Cost, dbSettings.Cost)
 }
 
 func TestRestart(t *testing.T) {
@@ -72,6 +72,7 @@ type APIHostAllocatorSettings struct {
 	HostsOverallocatedRule *string     `json:"hosts_overallocated_rule"`
 	AcceptableHostIdleTime APIDuration `json:"acceptable_host_idle_time"`
 	FutureHostFraction     float64     `json:"future_host_fraction"`
+	WarmPoolSize           int         `json:"warm_pool_size"`
 }
 
 // BuildFromService converts from service level distro.HostAllocatorSettings to an APIHostAllocatorSettings
@@ -88,6 +89,7 @@ func (s *APIHostAllocatorSettings) BuildFromService(settings distro.HostAllocato
 	s.FeedbackRule = utility.ToStringPtr(settings.FeedbackRule)
 	s.HostsOverallocatedRule = utility.ToStringPtr(settings.HostsOverallocatedRule)
 	s.FutureHostFraction = settings.FutureHostFraction
+	s.WarmPoolSize = settings.WarmPoolSize
 }
 
 // ToService returns a service layer distro.HostAllocatorSettings using the data from APIHostAllocatorSettings
@@ -105,6 +107,7 @@ func (s *APIHostAllocatorSettings) ToService() distro.HostAllocatorSettings {
 	settings.FeedbackRule = utility.FromStringPtr(s.FeedbackRule)
 	settings.HostsOverallocatedRule = utility.FromStringPtr(s.HostsOverallocatedRule)
 	settings.FutureHostFraction = s.FutureHostFraction
+	settings.WarmPoolSize = s.WarmPoolSize
 
 	return settings
 }
@@ -334,6 +337,20 @@ func (s *APIIceCreamSettings) ToService() distro.IceCreamSettings {
 	}
 }
 
+type APICostData struct {
+	HourlyCost *float64 `json:"hourly_cost"`
+}
+
+func (c *APICostData) BuildFromService(data distro.CostData) {
+	c.HourlyCost = utility.ToFloat64Ptr(data.HourlyCost)
+}
+
+func (c *APICostData) ToService() distro.CostData {
+	return distro.CostData{
+		HourlyCost: utility.FromFloat64Ptr(c.HourlyCost),
+	}
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 //
 // APIDistro is the model to be returned by the API whenever distros are fetched
@@ -364,6 +381,7 @@ type APIDistro struct {
 	DisableShallowClone   bool                     `json:"disable_shallow_clone"`
 	HomeVolumeSettings    APIHomeVolumeSettings    `json:"home_volume_settings"`
 	IcecreamSettings      APIIceCreamSettings      `json:"icecream_settings"`
+	CostData              APICostData              `json:"cost_data"`
 	IsVirtualWorkstation  bool                     `json:"is_virtual_workstation"`
 	IsCluster             bool                     `json:"is_cluster"`
 	Note                  *string                  `json:"note"`
@@ -426,6 +444,9 @@ func (apiDistro *APIDistro) BuildFromService(d distro.Distro) {
 	icecreamSettings := APIIceCreamSettings{}
 	icecreamSettings.BuildFromService(d.IceCreamSettings)
 	apiDistro.IcecreamSettings = icecreamSettings
+	costData := APICostData{}
+	costData.BuildFromService(d.CostData)
+	apiDistro.CostData = costData
 	apiDistro.IsVirtualWorkstation = d.IsVirtualWorkstation
 	apiDistro.IsCluster = d.IsCluster
 
@@ -468,6 +489,7 @@ func (apiDistro *APIDistro) ToService() *distro.Distro {
 	d.DispatcherSettings = apiDistro.DispatcherSettings.ToService()
 	d.HomeVolumeSettings = apiDistro.HomeVolumeSettings.ToService()
 	d.IceCreamSettings = apiDistro.IcecreamSettings.ToService()
+	d.CostData = apiDistro.CostData.ToService()
 
 	d.DisableShallowClone = apiDistro.DisableShallowClone
 	d.Note = utility.FromStringPtr(apiDistro.Note)
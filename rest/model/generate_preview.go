@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIGeneratedTaskPreview is a task that generate.tasks would create.
+type APIGeneratedTaskPreview struct {
+	TaskID       *string `json:"task_id"`
+	DisplayName  *string `json:"display_name"`
+	BuildVariant *string `json:"build_variant"`
+}
+
+// BuildFromService converts from service level task.TaskNode to an
+// APIGeneratedTaskPreview.
+func (t *APIGeneratedTaskPreview) BuildFromService(node task.TaskNode) {
+	t.TaskID = utility.ToStringPtr(node.ID)
+	t.DisplayName = utility.ToStringPtr(node.Name)
+	t.BuildVariant = utility.ToStringPtr(node.Variant)
+}
+
+// APIGeneratedDependencyPreview is a dependency that generate.tasks would add.
+type APIGeneratedDependencyPreview struct {
+	Task      APIGeneratedTaskPreview `json:"task"`
+	DependsOn APIGeneratedTaskPreview `json:"depends_on"`
+	Status    *string                 `json:"status"`
+}
+
+// BuildFromService converts from service level task.DependencyEdge to an
+// APIGeneratedDependencyPreview.
+func (d *APIGeneratedDependencyPreview) BuildFromService(edge task.DependencyEdge) {
+	d.Task.BuildFromService(edge.From)
+	d.DependsOn.BuildFromService(edge.To)
+	d.Status = utility.ToStringPtr(edge.Status)
+}
+
+// APIGeneratedProjectPreview describes the builds, tasks, and dependencies
+// that generate.tasks would add to a version.
+type APIGeneratedProjectPreview struct {
+	NewBuildVariants []string                        `json:"new_build_variants"`
+	NewTasks         []APIGeneratedTaskPreview       `json:"new_tasks"`
+	NewDisplayTasks  []APIGeneratedTaskPreview       `json:"new_display_tasks"`
+	NewDependencies  []APIGeneratedDependencyPreview `json:"new_dependencies"`
+}
+
+// BuildFromService converts from service level model.GeneratedProjectPreview
+// to an APIGeneratedProjectPreview.
+func (p *APIGeneratedProjectPreview) BuildFromService(preview model.GeneratedProjectPreview) {
+	p.NewBuildVariants = append([]string{}, preview.NewBuildVariants...)
+	p.NewTasks = buildAPIGeneratedTaskPreviews(preview.NewTasks)
+	p.NewDisplayTasks = buildAPIGeneratedTaskPreviews(preview.NewDisplayTasks)
+	p.NewDependencies = []APIGeneratedDependencyPreview{}
+	for _, edge := range preview.NewDependencies {
+		apiDep := APIGeneratedDependencyPreview{}
+		apiDep.BuildFromService(edge)
+		p.NewDependencies = append(p.NewDependencies, apiDep)
+	}
+}
+
+func buildAPIGeneratedTaskPreviews(nodes []task.TaskNode) []APIGeneratedTaskPreview {
+	apiTasks := []APIGeneratedTaskPreview{}
+	for _, node := range nodes {
+		apiTask := APIGeneratedTaskPreview{}
+		apiTask.BuildFromService(node)
+		apiTasks = append(apiTasks, apiTask)
+	}
+	return apiTasks
+}
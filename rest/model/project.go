@@ -9,6 +9,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/patch"
+	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/util"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/grip"
@@ -542,8 +543,11 @@ type APIProjectRef struct {
 	DispatchingDisabled         *bool                     `json:"dispatching_disabled"`
 	StepbackDisabled            *bool                     `json:"stepback_disabled"`
 	StepbackBisect              *bool                     `json:"stepback_bisect"`
+	StepbackTestBisect          *bool                     `json:"stepback_test_bisect"`
 	VersionControlEnabled       *bool                     `json:"version_control_enabled"`
 	DisabledStatsCache          *bool                     `json:"disabled_stats_cache"`
+	TaskLogRetentionDays        *int                      `json:"task_log_retention_days"`
+	MonthlyBudget               *float64                  `json:"monthly_budget"`
 	Admins                      []*string                 `json:"admins"`
 	DeleteAdmins                []*string                 `json:"delete_admins,omitempty"`
 	GitTagAuthorizedUsers       []*string                 `json:"git_tag_authorized_users" bson:"git_tag_authorized_users"`
@@ -565,6 +569,8 @@ type APIProjectRef struct {
 	PeriodicBuilds           []APIPeriodicBuildDefinition `json:"periodic_builds,omitempty"`
 	ContainerSizeDefinitions []APIContainerResources      `json:"container_size_definitions"`
 	ContainerSecrets         []APIContainerSecret         `json:"container_secrets,omitempty"`
+	PodPlatform              *string                      `json:"pod_platform,omitempty"`
+	PodContainerPool         *string                      `json:"pod_container_pool,omitempty"`
 	// DeleteContainerSecrets contains names of container secrets to be deleted.
 	DeleteContainerSecrets []string                `json:"delete_container_secrets,omitempty"`
 	ExternalLinks          []APIExternalLink       `json:"external_links"`
@@ -606,8 +612,11 @@ func (p *APIProjectRef) ToService() (*model.ProjectRef, error) {
 		DispatchingDisabled:    utility.BoolPtrCopy(p.DispatchingDisabled),
 		StepbackDisabled:       utility.BoolPtrCopy(p.StepbackDisabled),
 		StepbackBisect:         utility.BoolPtrCopy(p.StepbackBisect),
+		StepbackTestBisect:     utility.BoolPtrCopy(p.StepbackTestBisect),
 		VersionControlEnabled:  utility.BoolPtrCopy(p.VersionControlEnabled),
 		DisabledStatsCache:     utility.BoolPtrCopy(p.DisabledStatsCache),
+		TaskLogRetentionDays:   utility.FromIntPtr(p.TaskLogRetentionDays),
+		MonthlyBudget:          utility.FromFloat64Ptr(p.MonthlyBudget),
 		NotifyOnBuildFailure:   utility.BoolPtrCopy(p.NotifyOnBuildFailure),
 		SpawnHostScriptPath:    utility.FromStringPtr(p.SpawnHostScriptPath),
 		Admins:                 utility.FromStringPtrSlice(p.Admins),
@@ -669,6 +678,8 @@ func (p *APIProjectRef) ToService() (*model.ProjectRef, error) {
 	for _, size := range p.ContainerSizeDefinitions {
 		projectRef.ContainerSizeDefinitions = append(projectRef.ContainerSizeDefinitions, size.ToService())
 	}
+	projectRef.PodPlatform = pod.Platform(utility.FromStringPtr(p.PodPlatform))
+	projectRef.PodContainerPool = utility.FromStringPtr(p.PodContainerPool)
 
 	for idx, secret := range p.ContainerSecrets {
 		if utility.StringSliceContains(p.DeleteContainerSecrets, utility.FromStringPtr(secret.Name)) {
@@ -715,8 +726,11 @@ func (p *APIProjectRef) BuildPublicFields(projectRef model.ProjectRef) error {
 	p.DispatchingDisabled = utility.BoolPtrCopy(projectRef.DispatchingDisabled)
 	p.StepbackDisabled = utility.BoolPtrCopy(projectRef.StepbackDisabled)
 	p.StepbackBisect = utility.BoolPtrCopy(projectRef.StepbackBisect)
+	p.StepbackTestBisect = utility.BoolPtrCopy(projectRef.StepbackTestBisect)
 	p.VersionControlEnabled = utility.BoolPtrCopy(projectRef.VersionControlEnabled)
 	p.DisabledStatsCache = utility.BoolPtrCopy(projectRef.DisabledStatsCache)
+	p.TaskLogRetentionDays = utility.ToIntPtr(projectRef.TaskLogRetentionDays)
+	p.MonthlyBudget = utility.ToFloat64Ptr(projectRef.MonthlyBudget)
 	p.NotifyOnBuildFailure = utility.BoolPtrCopy(projectRef.NotifyOnBuildFailure)
 	p.SpawnHostScriptPath = utility.ToStringPtr(projectRef.SpawnHostScriptPath)
 	p.GitTagAuthorizedUsers = utility.ToStringPtrSlice(projectRef.GitTagAuthorizedUsers)
@@ -783,6 +797,8 @@ func (p *APIProjectRef) BuildPublicFields(projectRef model.ProjectRef) error {
 		apiSize.BuildFromService(size)
 		p.ContainerSizeDefinitions = append(p.ContainerSizeDefinitions, apiSize)
 	}
+	p.PodPlatform = utility.ToStringPtr(string(projectRef.PodPlatform))
+	p.PodContainerPool = utility.ToStringPtr(projectRef.PodContainerPool)
 
 	// copy external links
 	if projectRef.ExternalLinks != nil {
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/cost"
+	"github.com/evergreen-ci/utility"
+)
+
+// APIProjectCost is the estimated cost of the tasks in a project that
+// finished within a time range.
+type APIProjectCost struct {
+	ProjectID *string    `json:"project_id"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	TotalCost float64    `json:"total_cost"`
+	NumTasks  int        `json:"num_tasks"`
+	// MonthlyBudget is the project's monthly budget, if it has one.
+	MonthlyBudget         *float64           `json:"monthly_budget,omitempty"`
+	ByVariant             []APICostBreakdown `json:"by_variant"`
+	ByRequester           []APICostBreakdown `json:"by_requester"`
+	ByVariantAndRequester []APICostBreakdown `json:"by_variant_and_requester"`
+}
+
+// BuildFromService converts from service level cost.ProjectCost to an
+// APIProjectCost.
+func (c *APIProjectCost) BuildFromService(pc cost.ProjectCost) {
+	c.ProjectID = utility.ToStringPtr(pc.ProjectID)
+	c.StartTime = ToTimePtr(pc.StartTime)
+	c.EndTime = ToTimePtr(pc.EndTime)
+	c.TotalCost = pc.TotalCost
+	c.NumTasks = pc.NumTasks
+	c.ByVariant = buildAPICostBreakdowns(pc.ByVariant)
+	c.ByRequester = buildAPICostBreakdowns(pc.ByRequester)
+	c.ByVariantAndRequester = buildAPICostBreakdowns(pc.ByVariantAndRequester)
+}
+
+// APICostBreakdown is the estimated cost of a group of a project's tasks.
+type APICostBreakdown struct {
+	BuildVariant *string `json:"build_variant"`
+	Requester    *string `json:"requester"`
+	Cost         float64 `json:"cost"`
+	NumTasks     int     `json:"num_tasks"`
+}
+
+// BuildFromService converts from service level cost.CostBreakdown to an
+// APICostBreakdown.
+func (b *APICostBreakdown) BuildFromService(breakdown cost.CostBreakdown) {
+	b.BuildVariant = utility.ToStringPtr(breakdown.BuildVariant)
+	b.Requester = utility.ToStringPtr(breakdown.Requester)
+	b.Cost = breakdown.Cost
+	b.NumTasks = breakdown.NumTasks
+}
+
+func buildAPICostBreakdowns(breakdowns []cost.CostBreakdown) []APICostBreakdown {
+	apiBreakdowns := make([]APICostBreakdown, len(breakdowns))
+	for i, b := range breakdowns {
+		apiBreakdowns[i].BuildFromService(b)
+	}
+	return apiBreakdowns
+}
@@ -71,6 +71,10 @@ type apiHostStatsForDistro struct {
 	NumHosts int     `json:"num_hosts"`
 	NumTasks int     `json:"running_tasks"`
 	MaxHosts int     `json:"max_hosts"`
+	// NumWarmPool is the number of the hosts that are in the distro's warm
+	// pool, which are not counted as task capacity.
+	NumWarmPool  int `json:"warm_pool_hosts"`
+	WarmPoolSize int `json:"warm_pool_size"`
 }
 
 // BuildFromService takes the slice of stats returned by GetHostStatsByDistro and embeds
@@ -83,6 +87,9 @@ func (s *APIHostStatsByDistro) BuildFromService(stats []host.StatsByDistro) {
 			NumHosts: entry.Count,
 			NumTasks: entry.NumTasks,
 			MaxHosts: entry.MaxHosts,
+
+			NumWarmPool:  entry.NumWarmPool,
+			WarmPoolSize: entry.WarmPoolSize,
 		}
 
 		s.Distros = append(s.Distros, d)
@@ -84,6 +84,9 @@ type APITask struct {
 	MustHaveResults             bool                `json:"must_have_test_results"`
 	BaseTask                    APIBaseTaskInfo     `json:"base_task"`
 	ResetWhenFinished           bool                `json:"reset_when_finished"`
+	IsAutomaticRetry            bool                `json:"is_automatic_retry"`
+	AutomaticallyRetried        bool                `json:"automatically_retried"`
+	ResourceUsage               *APIResourceUsage   `json:"resource_usage,omitempty"`
 	// These fields are used by graphql gen, but do not need to be exposed
 	// via Evergreen's user-facing API.
 	OverrideDependencies bool   `json:"-"`
@@ -164,6 +167,66 @@ func (ad *APIOomTrackerInfo) ToService() *apimodels.OOMTrackerInfo {
 	}
 }
 
+// APIResourceUsage is the summary of the resources used by a task's processes
+// while it ran. Network I/O is measured for the whole host.
+type APIResourceUsage struct {
+	SampleIntervalSecs   float64                  `json:"sample_interval_secs"`
+	NumCPUs              int                      `json:"num_cpus"`
+	TotalMemoryBytes     int64                    `json:"total_memory_bytes"`
+	MaxCPUPercent        float64                  `json:"max_cpu_percent"`
+	AvgCPUPercent        float64                  `json:"avg_cpu_percent"`
+	MaxMemoryBytes       int64                    `json:"max_memory_bytes"`
+	AvgMemoryBytes       int64                    `json:"avg_memory_bytes"`
+	DiskReadBytes        int64                    `json:"disk_read_bytes"`
+	DiskWriteBytes       int64                    `json:"disk_write_bytes"`
+	HostNetworkSentBytes int64                    `json:"host_network_sent_bytes"`
+	HostNetworkRecvBytes int64                    `json:"host_network_recv_bytes"`
+	ExceededMemory       bool                     `json:"exceeded_memory"`
+	Samples              []APIResourceUsageSample `json:"samples"`
+}
+
+// APIResourceUsageSample is a single point in a task's resource usage time
+// series.
+type APIResourceUsageSample struct {
+	Time                 *time.Time `json:"time"`
+	CPUPercent           float64    `json:"cpu_percent"`
+	MemoryBytes          int64      `json:"memory_bytes"`
+	DiskReadBytes        int64      `json:"disk_read_bytes"`
+	DiskWriteBytes       int64      `json:"disk_write_bytes"`
+	HostNetworkSentBytes int64      `json:"host_network_sent_bytes"`
+	HostNetworkRecvBytes int64      `json:"host_network_recv_bytes"`
+}
+
+func (ru *APIResourceUsage) BuildFromService(info *apimodels.ResourceUsageInfo) {
+	if info == nil {
+		return
+	}
+	ru.SampleIntervalSecs = info.SampleIntervalSecs
+	ru.NumCPUs = info.NumCPUs
+	ru.TotalMemoryBytes = info.TotalMemoryBytes
+	ru.MaxCPUPercent = info.MaxCPUPercent
+	ru.AvgCPUPercent = info.AvgCPUPercent
+	ru.MaxMemoryBytes = info.MaxMemoryBytes
+	ru.AvgMemoryBytes = info.AvgMemoryBytes
+	ru.DiskReadBytes = info.DiskReadBytes
+	ru.DiskWriteBytes = info.DiskWriteBytes
+	ru.HostNetworkSentBytes = info.HostNetworkSentBytes
+	ru.HostNetworkRecvBytes = info.HostNetworkRecvBytes
+	ru.ExceededMemory = info.ExceededMemory()
+	ru.Samples = make([]APIResourceUsageSample, 0, len(info.Samples))
+	for _, sample := range info.Samples {
+		ru.Samples = append(ru.Samples, APIResourceUsageSample{
+			Time:                 ToTimePtr(sample.Time),
+			CPUPercent:           sample.CPUPercent,
+			MemoryBytes:          sample.MemoryBytes,
+			DiskReadBytes:        sample.DiskReadBytes,
+			DiskWriteBytes:       sample.DiskWriteBytes,
+			HostNetworkSentBytes: sample.HostNetworkSentBytes,
+			HostNetworkRecvBytes: sample.HostNetworkRecvBytes,
+		})
+	}
+}
+
 // BuildPreviousExecutions adds the given previous executions to the given API task.
 func (at *APITask) BuildPreviousExecutions(ctx context.Context, tasks []task.Task, logURL, parsleyURL string) error {
 	at.PreviousExecutions = make([]APITask, len(tasks))
@@ -242,6 +305,8 @@ func (at *APITask) buildTask(t *task.Task) error {
 		ResultsFailed:               t.ResultsFailed,
 		MustHaveResults:             t.MustHaveResults,
 		ResetWhenFinished:           t.ResetWhenFinished,
+		IsAutomaticRetry:            t.IsAutomaticRetry(),
+		AutomaticallyRetried:        t.AutomaticallyRetried,
 		ParentTaskId:                utility.FromStringPtr(t.DisplayTaskId),
 		SyncAtEndOpts: APISyncAtEndOptions{
 			Enabled:  t.SyncAtEndOpts.Enabled,
@@ -300,6 +365,11 @@ func (at *APITask) buildTask(t *task.Task) error {
 		at.DependsOn = dependsOn
 	}
 
+	if t.ResourceUsage != nil {
+		at.ResourceUsage = &APIResourceUsage{}
+		at.ResourceUsage.BuildFromService(t.ResourceUsage)
+	}
+
 	at.OverrideDependencies = t.OverrideDependencies
 	at.Archived = t.Archived
 
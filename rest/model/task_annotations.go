@@ -25,6 +25,9 @@ type APITaskAnnotation struct {
 	SuspectedIssues []APIIssueLink    `bson:"suspected_issues,omitempty" json:"suspected_issues,omitempty"`
 	CreatedIssues   []APIIssueLink    `bson:"created_issues,omitempty" json:"created_issues,omitempty"`
 	MetadataLinks   []APIMetadataLink `bson:"metadata_links,omitempty" json:"metadata_links,omitempty"`
+	// StepbackCulprits are the commits that stepback found to have
+	// introduced the task's failures.
+	StepbackCulprits []APIStepbackCulprit `bson:"stepback_culprits,omitempty" json:"stepback_culprits,omitempty"`
 }
 
 type APINote struct {
@@ -47,6 +50,14 @@ type APIMetadataLink struct {
 	Text   *string    `bson:"text" json:"text"`
 	Source *APISource `bson:"source,omitempty" json:"source,omitempty"`
 }
+type APIStepbackCulprit struct {
+	TestName  *string    `bson:"test_name,omitempty" json:"test_name,omitempty"`
+	TaskId    *string    `bson:"task_id" json:"task_id"`
+	Execution *int       `bson:"execution" json:"execution"`
+	Version   *string    `bson:"version" json:"version"`
+	Revision  *string    `bson:"revision" json:"revision"`
+	Source    *APISource `bson:"source,omitempty" json:"source,omitempty"`
+}
 
 // APISourceBuildFromService takes the annotations.Source DB struct and
 // returns the REST struct *APISource with the corresponding fields populated
@@ -98,6 +109,32 @@ func APIMetadataLinkToService(m APIMetadataLink) *annotations.MetadataLink {
 	return out
 }
 
+// APIStepbackCulpritBuildFromService takes the annotations.StepbackCulprit DB struct and
+// returns the REST struct *APIStepbackCulprit with the corresponding fields populated
+func APIStepbackCulpritBuildFromService(t annotations.StepbackCulprit) *APIStepbackCulprit {
+	m := APIStepbackCulprit{}
+	m.TestName = StringStringPtr(t.TestName)
+	m.TaskId = StringStringPtr(t.TaskId)
+	m.Execution = utility.ToIntPtr(t.Execution)
+	m.Version = StringStringPtr(t.Version)
+	m.Revision = StringStringPtr(t.Revision)
+	m.Source = APISourceBuildFromService(t.Source)
+	return &m
+}
+
+// APIStepbackCulpritToService takes the APIStepbackCulprit REST struct and returns the DB struct
+// *annotations.StepbackCulprit with the corresponding fields populated
+func APIStepbackCulpritToService(m APIStepbackCulprit) *annotations.StepbackCulprit {
+	out := &annotations.StepbackCulprit{}
+	out.TestName = StringPtrString(m.TestName)
+	out.TaskId = StringPtrString(m.TaskId)
+	out.Execution = utility.FromIntPtr(m.Execution)
+	out.Version = StringPtrString(m.Version)
+	out.Revision = StringPtrString(m.Revision)
+	out.Source = APISourceToService(m.Source)
+	return out
+}
+
 // APIIssueLinkBuildFromService takes the annotations.IssueLink DB struct and
 // returns the REST struct *APIIssueLink with the corresponding fields populated
 func APIIssueLinkBuildFromService(t annotations.IssueLink) *APIIssueLink {
@@ -156,6 +193,7 @@ func APITaskAnnotationBuildFromService(t annotations.TaskAnnotation) *APITaskAnn
 	m.SuspectedIssues = BuildAPIIssueLinks(t.SuspectedIssues)
 	m.CreatedIssues = BuildAPIIssueLinks(t.CreatedIssues)
 	m.MetadataLinks = BuildAPIMetadataLinks(t.MetadataLinks)
+	m.StepbackCulprits = BuildAPIStepbackCulprits(t.StepbackCulprits)
 	m.Note = APINoteBuildFromService(t.Note)
 	return &m
 }
@@ -176,6 +214,7 @@ func APITaskAnnotationToService(m APITaskAnnotation) *annotations.TaskAnnotation
 		apiMetadataLinks = append(apiMetadataLinks, &link)
 	}
 	out.MetadataLinks = APIMetadataLinksToService(apiMetadataLinks)
+	out.StepbackCulprits = BuildStepbackCulprits(m.StepbackCulprits)
 	out.Note = APINoteToService(m.Note)
 	return out
 }
@@ -228,6 +267,30 @@ func APIMetadataLinksToService(t []*APIMetadataLink) []annotations.MetadataLink
 	return m
 }
 
+// BuildAPIStepbackCulprits converts a slice of annotations.StepbackCulprit to a slice of APIStepbackCulprit
+func BuildAPIStepbackCulprits(t []annotations.StepbackCulprit) []APIStepbackCulprit {
+	if t == nil {
+		return nil
+	}
+	m := []APIStepbackCulprit{}
+	for _, e := range t {
+		m = append(m, *APIStepbackCulpritBuildFromService(e))
+	}
+	return m
+}
+
+// BuildStepbackCulprits converts a slice of APIStepbackCulprit to a slice of annotations.StepbackCulprit
+func BuildStepbackCulprits(t []APIStepbackCulprit) []annotations.StepbackCulprit {
+	if t == nil {
+		return nil
+	}
+	m := []annotations.StepbackCulprit{}
+	for _, e := range t {
+		m = append(m, *APIStepbackCulpritToService(e))
+	}
+	return m
+}
+
 func GetJiraTicketFromURL(jiraURL string) (*thirdparty.JiraTicket, error) {
 	settings := evergreen.GetEnvironment().Settings()
 	jiraHandler := thirdparty.NewJiraHandler(*settings.Jira.Export())
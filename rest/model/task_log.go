@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip/level"
+)
+
+const (
+	// TaskLogFollowLineEvent is the server-sent event that contains a
+	// single task log line when following a task's logs.
+	TaskLogFollowLineEvent = "line"
+	// TaskLogFollowEndEvent is the server-sent event sent once the task
+	// has finished and every log line has been streamed. Its data is the
+	// task's final status.
+	TaskLogFollowEndEvent = "end"
+	// TaskLogFollowErrorEvent is the server-sent event sent if following a
+	// task's logs fails after the stream has started. Its data is the
+	// error message.
+	TaskLogFollowErrorEvent = "error"
+)
+
+// APILogLine is the model to be returned by the API whenever a single task
+// log line is streamed.
+type APILogLine struct {
+	LogName *string `json:"log_name"`
+	// Priority is the grip priority of the line.
+	Priority int `json:"priority"`
+	// Timestamp is the time the line was logged, represented as a Unix
+	// timestamp in nanoseconds.
+	Timestamp  int64             `json:"timestamp"`
+	Data       *string           `json:"data"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// BuildFromService converts from a service level log line to an APILogLine.
+func (l *APILogLine) BuildFromService(line log.LogLine) {
+	l.LogName = utility.ToStringPtr(line.LogName)
+	l.Priority = int(line.Priority)
+	l.Timestamp = line.Timestamp
+	l.Data = utility.ToStringPtr(line.Data)
+	l.Attributes = line.Attributes
+}
+
+// ToService returns a service level log line using the data from the
+// APILogLine.
+func (l *APILogLine) ToService() log.LogLine {
+	return log.LogLine{
+		LogName:    utility.FromStringPtr(l.LogName),
+		Priority:   level.Priority(l.Priority),
+		Timestamp:  l.Timestamp,
+		Data:       utility.FromStringPtr(l.Data),
+		Attributes: l.Attributes,
+	}
+}
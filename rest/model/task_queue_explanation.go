@@ -0,0 +1,119 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/utility"
+)
+
+// APITaskQueueExplanation explains why a task is or isn't waiting to run.
+type APITaskQueueExplanation struct {
+	TaskID         *string `json:"task_id"`
+	Status         *string `json:"status"`
+	DistroID       *string `json:"distro_id"`
+	InQueue        bool    `json:"in_queue"`
+	QueueDistroID  *string `json:"queue_distro_id"`
+	SecondaryQueue bool    `json:"secondary_queue"`
+	// Position is the task's 1-based position among the undispatched tasks
+	// in the queue.
+	Position         int        `json:"position"`
+	QueueLength      int        `json:"queue_length"`
+	QueueGeneratedAt *time.Time `json:"queue_generated_at"`
+	// SortingValueBreakdown is the planner's score for the task, broken
+	// down by factor. It's only available for the tunable and fair-share
+	// planners.
+	SortingValueBreakdown *APISortingValueBreakdown `json:"sorting_value_breakdown,omitempty"`
+	UnmetDependencies     []APIUnmetDependency      `json:"unmet_dependencies"`
+	DistroDisabled        bool                      `json:"distro_disabled"`
+	NumHosts              int                       `json:"num_hosts"`
+	MaximumHosts          int                       `json:"maximum_hosts"`
+	AtMaximumHosts        bool                      `json:"at_maximum_hosts"`
+	// EstimatedStart is how long until the task is estimated to start. It's
+	// not set if the start can't be estimated.
+	EstimatedStart *APIDuration `json:"est_wait_to_start_ms,omitempty"`
+	Reasons        []string     `json:"reasons"`
+}
+
+// BuildFromService converts from service level model.TaskQueueExplanation to
+// an APITaskQueueExplanation.
+func (e *APITaskQueueExplanation) BuildFromService(explanation model.TaskQueueExplanation) {
+	e.TaskID = utility.ToStringPtr(explanation.TaskID)
+	e.Status = utility.ToStringPtr(explanation.Status)
+	e.DistroID = utility.ToStringPtr(explanation.DistroID)
+	e.InQueue = explanation.InQueue
+	e.QueueDistroID = utility.ToStringPtr(explanation.QueueDistroID)
+	e.SecondaryQueue = explanation.SecondaryQueue
+	e.Position = explanation.Position
+	e.QueueLength = explanation.QueueLength
+	e.QueueGeneratedAt = ToTimePtr(explanation.QueueGeneratedAt)
+	if explanation.SortingValueBreakdown != nil {
+		e.SortingValueBreakdown = &APISortingValueBreakdown{}
+		e.SortingValueBreakdown.BuildFromService(*explanation.SortingValueBreakdown)
+	}
+	e.UnmetDependencies = make([]APIUnmetDependency, 0, len(explanation.UnmetDependencies))
+	for _, dep := range explanation.UnmetDependencies {
+		apiDep := APIUnmetDependency{}
+		apiDep.BuildFromService(dep)
+		e.UnmetDependencies = append(e.UnmetDependencies, apiDep)
+	}
+	e.DistroDisabled = explanation.DistroDisabled
+	e.NumHosts = explanation.NumHosts
+	e.MaximumHosts = explanation.MaximumHosts
+	e.AtMaximumHosts = explanation.AtMaximumHosts
+	if explanation.EstimatedStart >= 0 {
+		estimatedStart := NewAPIDuration(explanation.EstimatedStart)
+		e.EstimatedStart = &estimatedStart
+	}
+	e.Reasons = append([]string{}, explanation.Reasons...)
+}
+
+// APISortingValueBreakdown is the breakdown of the value that the planner
+// gave a task when ordering its queue, by factor.
+type APISortingValueBreakdown struct {
+	TotalValue               int64 `json:"total_value"`
+	Priority                 int64 `json:"priority"`
+	NumTasks                 int64 `json:"num_tasks"`
+	PatchValue               int64 `json:"patch_value"`
+	PatchTimeInQueueValue    int64 `json:"patch_time_in_queue_value"`
+	CommitQueueValue         int64 `json:"commit_queue_value"`
+	MainlineTimeInQueueValue int64 `json:"mainline_time_in_queue_value"`
+	StepbackValue            int64 `json:"stepback_value"`
+	DependentsValue          int64 `json:"dependents_value"`
+	ExpectedRuntimeValue     int64 `json:"expected_runtime_value"`
+}
+
+// BuildFromService converts from service level task.SortingValueBreakdown to
+// an APISortingValueBreakdown.
+func (b *APISortingValueBreakdown) BuildFromService(breakdown task.SortingValueBreakdown) {
+	b.TotalValue = breakdown.TotalValue
+	b.Priority = breakdown.Priority
+	b.NumTasks = breakdown.NumTasks
+	b.PatchValue = breakdown.PatchValue
+	b.PatchTimeInQueueValue = breakdown.PatchTimeInQueueValue
+	b.CommitQueueValue = breakdown.CommitQueueValue
+	b.MainlineTimeInQueueValue = breakdown.MainlineTimeInQueueValue
+	b.StepbackValue = breakdown.StepbackValue
+	b.DependentsValue = breakdown.DependentsValue
+	b.ExpectedRuntimeValue = breakdown.ExpectedRuntimeValue
+}
+
+// APIUnmetDependency is a dependency of a task that has not been met yet.
+type APIUnmetDependency struct {
+	TaskID         *string `json:"task_id"`
+	DisplayName    *string `json:"display_name"`
+	Status         *string `json:"status"`
+	RequiredStatus *string `json:"required_status"`
+	Unattainable   bool    `json:"unattainable"`
+}
+
+// BuildFromService converts from service level model.UnmetDependency to an
+// APIUnmetDependency.
+func (d *APIUnmetDependency) BuildFromService(dep model.UnmetDependency) {
+	d.TaskID = utility.ToStringPtr(dep.TaskID)
+	d.DisplayName = utility.ToStringPtr(dep.DisplayName)
+	d.Status = utility.ToStringPtr(dep.Status)
+	d.RequiredStatus = utility.ToStringPtr(dep.RequiredStatus)
+	d.Unattainable = dep.Unattainable
+}
@@ -24,6 +24,9 @@ type APITest struct {
 	EndTime    *time.Time `json:"end_time"`
 	Duration   float64    `json:"duration"`
 	ExitCode   int        `json:"-"`
+	// Quarantined indicates that the test failed while it was quarantined
+	// in its project when its task finished, so its failure was ignored.
+	Quarantined bool `json:"quarantined"`
 }
 
 // TestLogs is a struct for storing the information about logs that will be
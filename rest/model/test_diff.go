@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/utility"
+)
+
+// APITestResultDiff describes how a single test's result changed between a
+// base task and the task compared against it.
+type APITestResultDiff struct {
+	TestName     *string `json:"test_name"`
+	TaskName     *string `json:"task_name"`
+	BuildVariant *string `json:"build_variant"`
+	BaseStatus   *string `json:"base_status"`
+	Status       *string `json:"status"`
+	BaseTaskID   *string `json:"base_task_id"`
+	TaskID       *string `json:"task_id"`
+}
+
+// BuildFromService converts from service level model.TestResultDiff to an
+// APITestResultDiff.
+func (d *APITestResultDiff) BuildFromService(diff model.TestResultDiff) {
+	d.TestName = utility.ToStringPtr(diff.TestName)
+	d.TaskName = utility.ToStringPtr(diff.TaskName)
+	d.BuildVariant = utility.ToStringPtr(diff.BuildVariant)
+	d.BaseStatus = utility.ToStringPtr(diff.BaseStatus)
+	d.Status = utility.ToStringPtr(diff.Status)
+	d.BaseTaskID = utility.ToStringPtr(diff.BaseTaskID)
+	d.TaskID = utility.ToStringPtr(diff.TaskID)
+}
+
+// APITestResultsDiff is the categorized difference between the test results
+// of a task or version and those of its base.
+type APITestResultsDiff struct {
+	NewFailures  []APITestResultDiff `json:"new_failures"`
+	Fixed        []APITestResultDiff `json:"fixed"`
+	StillFailing []APITestResultDiff `json:"still_failing"`
+	Added        []APITestResultDiff `json:"added"`
+	Removed      []APITestResultDiff `json:"removed"`
+}
+
+// BuildFromService converts from service level model.TestResultsDiff to an
+// APITestResultsDiff.
+func (d *APITestResultsDiff) BuildFromService(diff model.TestResultsDiff) {
+	d.NewFailures = buildAPITestResultDiffs(diff.NewFailures)
+	d.Fixed = buildAPITestResultDiffs(diff.Fixed)
+	d.StillFailing = buildAPITestResultDiffs(diff.StillFailing)
+	d.Added = buildAPITestResultDiffs(diff.Added)
+	d.Removed = buildAPITestResultDiffs(diff.Removed)
+}
+
+func buildAPITestResultDiffs(diffs []model.TestResultDiff) []APITestResultDiff {
+	apiDiffs := []APITestResultDiff{}
+	for _, diff := range diffs {
+		apiDiff := APITestResultDiff{}
+		apiDiff.BuildFromService(diff)
+		apiDiffs = append(apiDiffs, apiDiff)
+	}
+	return apiDiffs
+}
@@ -0,0 +1,81 @@
+package model
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/utility"
+)
+
+// APITestFlakiness is how often a test in a task flipped between passing and
+// failing across recent mainline task executions.
+type APITestFlakiness struct {
+	ProjectID    *string    `json:"project_id"`
+	BuildVariant *string    `json:"build_variant"`
+	TaskName     *string    `json:"task_name"`
+	TestName     *string    `json:"test_name"`
+	NumRuns      int        `json:"num_runs"`
+	NumFailed    int        `json:"num_failed"`
+	NumFlips     int        `json:"num_flips"`
+	FlipRate     float64    `json:"flip_rate"`
+	Flaky        bool       `json:"flaky"`
+	LastTaskID   *string    `json:"last_task_id"`
+	LastUpdated  *time.Time `json:"last_updated"`
+}
+
+// BuildFromService converts from service level flakytest.TestFlakiness to an
+// APITestFlakiness.
+func (f *APITestFlakiness) BuildFromService(flakiness flakytest.TestFlakiness) {
+	f.ProjectID = utility.ToStringPtr(flakiness.ID.Project)
+	f.BuildVariant = utility.ToStringPtr(flakiness.ID.BuildVariant)
+	f.TaskName = utility.ToStringPtr(flakiness.ID.TaskName)
+	f.TestName = utility.ToStringPtr(flakiness.ID.TestName)
+	f.NumRuns = flakiness.NumRuns
+	f.NumFailed = flakiness.NumFailed
+	f.NumFlips = flakiness.NumFlips
+	f.FlipRate = flakiness.FlipRate
+	f.Flaky = flakiness.Flaky
+	f.LastTaskID = utility.ToStringPtr(flakiness.LastTaskID)
+	f.LastUpdated = ToTimePtr(flakiness.LastUpdated)
+}
+
+// APIQuarantinedTest is a test whose failures are ignored when determining
+// the status of the project's tasks that run it.
+type APIQuarantinedTest struct {
+	ID            *string    `json:"id"`
+	ProjectID     *string    `json:"project_id"`
+	TestName      *string    `json:"test_name"`
+	TaskName      *string    `json:"task_name"`
+	BuildVariant  *string    `json:"build_variant"`
+	Reason        *string    `json:"reason"`
+	QuarantinedBy *string    `json:"quarantined_by"`
+	QuarantinedAt *time.Time `json:"quarantined_at"`
+}
+
+// BuildFromService converts from service level flakytest.QuarantinedTest to
+// an APIQuarantinedTest.
+func (q *APIQuarantinedTest) BuildFromService(quarantined flakytest.QuarantinedTest) {
+	q.ID = utility.ToStringPtr(quarantined.ID)
+	q.ProjectID = utility.ToStringPtr(quarantined.ProjectID)
+	q.TestName = utility.ToStringPtr(quarantined.TestName)
+	q.TaskName = utility.ToStringPtr(quarantined.TaskName)
+	q.BuildVariant = utility.ToStringPtr(quarantined.BuildVariant)
+	q.Reason = utility.ToStringPtr(quarantined.Reason)
+	q.QuarantinedBy = utility.ToStringPtr(quarantined.QuarantinedBy)
+	q.QuarantinedAt = ToTimePtr(quarantined.QuarantinedAt)
+}
+
+// ToService returns a service layer flakytest.QuarantinedTest using the data
+// from the APIQuarantinedTest.
+func (q *APIQuarantinedTest) ToService() flakytest.QuarantinedTest {
+	return flakytest.QuarantinedTest{
+		ID:            utility.FromStringPtr(q.ID),
+		ProjectID:     utility.FromStringPtr(q.ProjectID),
+		TestName:      utility.FromStringPtr(q.TestName),
+		TaskName:      utility.FromStringPtr(q.TaskName),
+		BuildVariant:  utility.FromStringPtr(q.BuildVariant),
+		Reason:        utility.FromStringPtr(q.Reason),
+		QuarantinedBy: utility.FromStringPtr(q.QuarantinedBy),
+		QuarantinedAt: utility.FromTimePtr(q.QuarantinedAt),
+	}
+}
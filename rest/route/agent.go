@@ -14,10 +14,12 @@ import (
 	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/evergreen/model/manifest"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
@@ -698,6 +700,62 @@ func (h *setTaskResultsInfoHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewTextResponse("Results info set in task")
 }
 
+// POST /rest/v2/task/{task_id}/resource_usage
+type setTaskResourceUsageHandler struct {
+	taskID string
+	usage  apimodels.ResourceUsageInfo
+}
+
+func makeSetTaskResourceUsage() gimlet.RouteHandler {
+	return &setTaskResourceUsageHandler{}
+}
+
+func (h *setTaskResourceUsageHandler) Factory() gimlet.RouteHandler {
+	return &setTaskResourceUsageHandler{}
+}
+
+func (h *setTaskResourceUsageHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.taskID = gimlet.GetVars(r)["task_id"]
+
+	if err := gimlet.GetJSON(r.Body, &h.usage); err != nil {
+		return errors.Wrap(err, "reading resource usage from JSON request body")
+	}
+
+	return nil
+}
+
+func (h *setTaskResourceUsageHandler) Run(ctx context.Context) gimlet.Responder {
+	t, err := task.FindOneId(h.taskID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+		})
+	}
+
+	if err = t.SetResourceUsage(&h.usage); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "setting resource usage for task '%s'", h.taskID))
+	}
+
+	grip.WarningWhen(h.usage.ExceededMemory(), message.Fields{
+		"message":            "task exceeded memory on distro",
+		"task_id":            t.Id,
+		"execution":          t.Execution,
+		"project":            t.Project,
+		"build_variant":      t.BuildVariant,
+		"display_name":       t.DisplayName,
+		"distro_id":          t.DistroId,
+		"host_id":            t.HostId,
+		"max_memory_bytes":   h.usage.MaxMemoryBytes,
+		"total_memory_bytes": h.usage.TotalMemoryBytes,
+	})
+
+	return gimlet.NewTextResponse("Resource usage set in task")
+}
+
 // POST /task/{task_id}/test_logs
 type attachTestLogHandler struct {
 	settings *evergreen.Settings
@@ -855,19 +913,22 @@ func (h *fetchTaskHandler) Run(ctx context.Context) gimlet.Responder {
 
 // POST /task/{task_id}/log
 type appendTaskLogHandler struct {
+	env      evergreen.Environment
 	settings *evergreen.Settings
 	taskID   string
 	taskLog  model.TaskLog
 }
 
-func makeAppendTaskLog(settings *evergreen.Settings) gimlet.RouteHandler {
+func makeAppendTaskLog(env evergreen.Environment, settings *evergreen.Settings) gimlet.RouteHandler {
 	return &appendTaskLogHandler{
+		env:      env,
 		settings: settings,
 	}
 }
 
 func (h *appendTaskLogHandler) Factory() gimlet.RouteHandler {
 	return &appendTaskLogHandler{
+		env:      h.env,
 		settings: h.settings,
 	}
 }
@@ -882,7 +943,8 @@ func (h *appendTaskLogHandler) Parse(ctx context.Context, r *http.Request) error
 	return nil
 }
 
-// Run appends the received logs to the task's internal logs.
+// Run appends the received logs to the task's logs, either in the Evergreen
+// log service or in the database depending on how the task stores its logs.
 func (h *appendTaskLogHandler) Run(ctx context.Context) gimlet.Responder {
 	if h.settings.ServiceFlags.TaskLoggingDisabled {
 		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
@@ -901,6 +963,13 @@ func (h *appendTaskLogHandler) Run(ctx context.Context) gimlet.Responder {
 		})
 	}
 
+	if t.UsesLogServiceForTaskLogs() {
+		if err = h.appendToLogService(ctx, t); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "appending logs for task '%s'", t.Id))
+		}
+		return gimlet.NewJSONResponse("Logs added")
+	}
+
 	h.taskLog.TaskId = t.Id
 	h.taskLog.Execution = t.Execution
 
@@ -911,6 +980,35 @@ func (h *appendTaskLogHandler) Run(ctx context.Context) gimlet.Responder {
 	return gimlet.NewJSONResponse("Logs added")
 }
 
+// appendToLogService appends the received logs, including the attributes of
+// structured log messages, to the task's logs in the Evergreen log service.
+func (h *appendTaskLogHandler) appendToLogService(ctx context.Context, t *task.Task) error {
+	linesByType := map[taskoutput.TaskLogType][]log.LogLine{}
+	for _, msg := range h.taskLog.Messages {
+		logType := taskoutput.TaskLogTypeTask
+		switch msg.Type {
+		case apimodels.AgentLogPrefix:
+			logType = taskoutput.TaskLogTypeAgent
+		case apimodels.SystemLogPrefix:
+			logType = taskoutput.TaskLogTypeSystem
+		}
+
+		linesByType[logType] = append(linesByType[logType], log.LogLine{
+			Priority:   apimodels.GetPriorityMapping(msg.Severity),
+			Timestamp:  msg.Timestamp.UnixNano(),
+			Data:       msg.Message,
+			Attributes: msg.Attributes,
+		})
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for logType, lines := range linesByType {
+		catcher.Wrapf(t.AppendTaskLogs(ctx, h.env, logType, lines), "appending %s logs", logType)
+	}
+
+	return catcher.Resolve()
+}
+
 // POST /task/{task_id}/start
 type startTaskHandler struct {
 	env           evergreen.Environment
@@ -3,6 +3,7 @@ package route
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -18,12 +19,15 @@ import (
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/patch"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
 	"github.com/evergreen-ci/evergreen/thirdparty"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/mongodb/amboy/queue"
+	"github.com/mongodb/grip/level"
 	"github.com/mongodb/grip/send"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
 )
 
 var (
@@ -612,3 +616,211 @@ func TestCreateInstallationToken(t *testing.T) {
 		})
 	}
 }
+
+func TestAppendTaskLog(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection, model.TaskLogCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, model.TaskLogCollection))
+	}()
+
+	ts := time.Now()
+	taskLog := model.TaskLog{
+		Messages: []apimodels.LogMessage{
+			{Type: apimodels.TaskLogPrefix, Severity: apimodels.LogInfoPrefix, Message: "compiling", Timestamp: ts, Attributes: map[string]string{"step": "compile"}},
+			{Type: apimodels.TaskLogPrefix, Severity: apimodels.LogErrorPrefix, Message: "testing", Timestamp: ts.Add(time.Millisecond), Attributes: map[string]string{"step": "test"}},
+			{Type: apimodels.AgentLogPrefix, Severity: apimodels.LogDebugPrefix, Message: "running command", Timestamp: ts.Add(2 * time.Millisecond)},
+		},
+	}
+	appendTaskLog := func(t *testing.T, env evergreen.Environment, taskID string) gimlet.Responder {
+		body, err := json.Marshal(taskLog)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/rest/v2/task/"+taskID+"/log", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"task_id": taskID})
+
+		rh := makeAppendTaskLog(env, env.Settings())
+		require.NoError(t, rh.Parse(ctx, req))
+
+		return rh.Run(ctx)
+	}
+
+	t.Run("LogServiceV1PersistsAttributes", func(t *testing.T) {
+		env := &mock.Environment{EvergreenSettings: &evergreen.Settings{
+			Buckets: evergreen.BucketsConfig{
+				LogBucket: evergreen.BucketConfig{
+					Name: t.TempDir(),
+					Type: evergreen.BucketTypeLocal,
+				},
+				TaskOutputVersion: taskoutput.TaskOutputVersionLogServiceV1,
+			},
+		}}
+		tsk := task.Task{
+			Id:      "v1",
+			Project: "project",
+			Status:  evergreen.TaskStarted,
+		}
+		tsk.TaskOutputInfo = taskoutput.InitializeTaskOutput(env, taskoutput.TaskOptions{ProjectID: tsk.Project, TaskID: tsk.Id})
+		require.Equal(t, taskoutput.TaskOutputVersionLogServiceV1, tsk.TaskOutputInfo.TaskLogs.Version)
+		require.NoError(t, tsk.Insert())
+
+		resp := appendTaskLog(t, env, tsk.Id)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		it, err := tsk.GetTaskLogs(ctx, env, taskoutput.TaskLogGetOptions{
+			LogType:    taskoutput.TaskLogTypeTask,
+			Attributes: map[string]string{"step": "test"},
+		})
+		require.NoError(t, err)
+		require.True(t, it.Next())
+		line := it.Item()
+		assert.Equal(t, "testing", line.Data)
+		assert.Equal(t, level.Error, line.Priority)
+		assert.Equal(t, map[string]string{"step": "test"}, line.Attributes)
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+		assert.NoError(t, it.Close())
+
+		it, err = tsk.GetTaskLogs(ctx, env, taskoutput.TaskLogGetOptions{LogType: taskoutput.TaskLogTypeAgent})
+		require.NoError(t, err)
+		require.True(t, it.Next())
+		assert.Equal(t, "running command", it.Item().Data)
+		assert.Equal(t, level.Debug, it.Item().Priority)
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Close())
+
+		count, err := db.Count(model.TaskLogCollection, bson.M{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+	t.Run("TagsLinesWithAttemptAfterHostInterruption", func(t *testing.T) {
+		env := &mock.Environment{EvergreenSettings: &evergreen.Settings{
+			Buckets: evergreen.BucketsConfig{
+				LogBucket: evergreen.BucketConfig{
+					Name: t.TempDir(),
+					Type: evergreen.BucketTypeLocal,
+				},
+				TaskOutputVersion: taskoutput.TaskOutputVersionLogServiceV1,
+			},
+		}}
+		tsk := task.Task{
+			Id:               "interrupted",
+			Project:          "project",
+			Status:           evergreen.TaskStarted,
+			NumInterruptions: 1,
+		}
+		tsk.TaskOutputInfo = taskoutput.InitializeTaskOutput(env, taskoutput.TaskOptions{ProjectID: tsk.Project, TaskID: tsk.Id})
+		require.NoError(t, tsk.Insert())
+
+		resp := appendTaskLog(t, env, tsk.Id)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		it, err := tsk.GetTaskLogs(ctx, env, taskoutput.TaskLogGetOptions{
+			LogType:    taskoutput.TaskLogTypeTask,
+			Attributes: map[string]string{task.TaskLogAttemptAttribute: "1"},
+		})
+		require.NoError(t, err)
+		require.True(t, it.Next())
+		assert.Equal(t, "compiling", it.Item().Data)
+		assert.Equal(t, map[string]string{"step": "compile", task.TaskLogAttemptAttribute: "1"}, it.Item().Attributes)
+		require.True(t, it.Next())
+		assert.Equal(t, "testing", it.Item().Data)
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Close())
+	})
+	t.Run("CedarTaskOutputStoresLogsInDatabase", func(t *testing.T) {
+		env := &mock.Environment{EvergreenSettings: &evergreen.Settings{}}
+		tsk := task.Task{
+			Id:      "cedar",
+			Project: "project",
+			Status:  evergreen.TaskStarted,
+		}
+		tsk.TaskOutputInfo = taskoutput.InitializeTaskOutput(env, taskoutput.TaskOptions{ProjectID: tsk.Project, TaskID: tsk.Id})
+		require.Equal(t, taskoutput.TaskOutputVersionCedar, tsk.TaskOutputInfo.TaskLogs.Version)
+		require.NoError(t, tsk.Insert())
+
+		resp := appendTaskLog(t, env, tsk.Id)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		count, err := db.Count(model.TaskLogCollection, bson.M{"t_id": tsk.Id})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestSetTaskResourceUsage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection))
+	}()
+
+	tsk := task.Task{
+		Id:      "t1",
+		Project: "project",
+		Status:  evergreen.TaskStarted,
+	}
+	require.NoError(t, tsk.Insert())
+
+	usage := apimodels.ResourceUsageInfo{
+		SampleIntervalSecs:   15,
+		NumCPUs:              4,
+		TotalMemoryBytes:     1000,
+		MaxCPUPercent:        150,
+		AvgCPUPercent:        75,
+		MaxMemoryBytes:       950,
+		AvgMemoryBytes:       500,
+		DiskReadBytes:        300,
+		DiskWriteBytes:       20,
+		HostNetworkSentBytes: 100,
+		HostNetworkRecvBytes: 10,
+		Samples: []apimodels.ResourceUsageSample{
+			{Time: time.Now(), CPUPercent: 150, MemoryBytes: 950, DiskReadBytes: 300, DiskWriteBytes: 20},
+		},
+	}
+	setResourceUsage := func(t *testing.T, taskID string, body []byte) gimlet.Responder {
+		req, err := http.NewRequest(http.MethodPost, "https://example.com/rest/v2/task/"+taskID+"/resource_usage", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"task_id": taskID})
+
+		rh := makeSetTaskResourceUsage()
+		if err = rh.Parse(ctx, req); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+		return rh.Run(ctx)
+	}
+
+	t.Run("StoresUsageWithTask", func(t *testing.T) {
+		body, err := json.Marshal(usage)
+		require.NoError(t, err)
+
+		resp := setResourceUsage(t, tsk.Id, body)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		dbTask, err := task.FindOneId(tsk.Id)
+		require.NoError(t, err)
+		require.NotZero(t, dbTask)
+		require.NotZero(t, dbTask.ResourceUsage)
+		assert.Equal(t, usage.MaxCPUPercent, dbTask.ResourceUsage.MaxCPUPercent)
+		assert.Equal(t, usage.MaxMemoryBytes, dbTask.ResourceUsage.MaxMemoryBytes)
+		assert.Equal(t, usage.DiskReadBytes, dbTask.ResourceUsage.DiskReadBytes)
+		assert.Equal(t, usage.HostNetworkSentBytes, dbTask.ResourceUsage.HostNetworkSentBytes)
+		require.Len(t, dbTask.ResourceUsage.Samples, 1)
+		assert.WithinDuration(t, usage.Samples[0].Time, dbTask.ResourceUsage.Samples[0].Time, time.Millisecond)
+		assert.Equal(t, usage.Samples[0].MemoryBytes, dbTask.ResourceUsage.Samples[0].MemoryBytes)
+		assert.True(t, dbTask.ResourceUsage.ExceededMemory())
+	})
+	t.Run("FailsWithInvalidBody", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, setResourceUsage(t, tsk.Id, []byte("not json")).Status())
+	})
+	t.Run("FailsWithNonexistentTask", func(t *testing.T) {
+		body, err := json.Marshal(usage)
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusNotFound, setResourceUsage(t, "DNE", body).Status())
+	})
+}
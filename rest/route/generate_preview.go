@@ -0,0 +1,65 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+//
+// POST /versions/{version_id}/generate_preview
+
+type generatePreviewHandler struct {
+	versionID string
+	taskID    string
+	files     []json.RawMessage
+
+	env evergreen.Environment
+}
+
+func makeGeneratePreviewHandler(env evergreen.Environment) gimlet.RouteHandler {
+	return &generatePreviewHandler{env: env}
+}
+
+func (h *generatePreviewHandler) Factory() gimlet.RouteHandler {
+	return &generatePreviewHandler{env: h.env}
+}
+
+func (h *generatePreviewHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.versionID = gimlet.GetVars(r)["version_id"]
+	if h.versionID == "" {
+		return errors.New("missing version ID")
+	}
+	h.taskID = r.URL.Query().Get("task_id")
+
+	var err error
+	if h.files, err = parseJson(r); err != nil {
+		return errors.Wrap(err, "reading raw JSON from request body")
+	}
+	if len(h.files) == 0 {
+		return errors.New("must specify at least one generated project")
+	}
+
+	return nil
+}
+
+// Run returns the builds, tasks, and dependencies that the generate.tasks JSON
+// files would add to the version. Nothing is saved.
+func (h *generatePreviewHandler) Run(ctx context.Context) gimlet.Responder {
+	preview, err := data.PreviewGeneratedTasks(ctx, h.env.Settings(), h.versionID, h.taskID, h.files)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "previewing generated tasks for version '%s'", h.versionID))
+	}
+
+	apiPreview := restModel.APIGeneratedProjectPreview{}
+	apiPreview.BuildFromService(*preview)
+
+	return gimlet.NewJSONResponse(apiPreview)
+}
@@ -0,0 +1,215 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatePreviewConfig = `
+buildvariants:
+- name: bv0
+  run_on:
+  - d0
+  tasks:
+  - name: generator
+  - name: dependent
+
+tasks:
+- name: generator
+  commands:
+  - command: generate.tasks
+    params:
+      files:
+      - generated.json
+- name: dependent
+  depends_on:
+  - name: generator
+  commands:
+  - command: shell.exec
+    params:
+      script: echo dependent
+`
+
+const generatePreviewJSON = `
+{
+  "buildvariants": [
+    {
+      "name": "bv0",
+      "tasks": [{"name": "generated"}]
+    },
+    {
+      "name": "bv1",
+      "run_on": ["d0"],
+      "tasks": [{"name": "generated"}]
+    }
+  ],
+  "tasks": [
+    {
+      "name": "generated",
+      "commands": [{"command": "shell.exec", "params": {"script": "echo generated"}}]
+    }
+  ]
+}
+`
+
+func TestGeneratePreviewHandlerParse(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for tName, tCase := range map[string]struct {
+		vars    map[string]string
+		query   string
+		body    string
+		isValid bool
+	}{
+		"Succeeds": {
+			vars:    map[string]string{"version_id": "v0"},
+			query:   "?task_id=t0",
+			body:    "[{}]",
+			isValid: true,
+		},
+		"FailsWithoutVersion": {
+			body: "[{}]",
+		},
+		"FailsWithoutFiles": {
+			vars: map[string]string{"version_id": "v0"},
+			body: "[]",
+		},
+		"FailsWithInvalidJSON": {
+			vars: map[string]string{"version_id": "v0"},
+			body: "{",
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/versions/v0/generate_preview"+tCase.query, bytes.NewBufferString(tCase.body))
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, tCase.vars)
+
+			h := makeGeneratePreviewHandler(nil).(*generatePreviewHandler)
+			err = h.Parse(ctx, req)
+			if !tCase.isValid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "v0", h.versionID)
+			assert.Equal(t, "t0", h.taskID)
+			assert.Len(t, h.files, 1)
+		})
+	}
+}
+
+func TestGeneratePreviewHandlerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	collections := []string{serviceModel.ProjectRefCollection, serviceModel.VersionCollection, serviceModel.ParserProjectCollection, build.Collection, task.Collection}
+	require.NoError(t, db.ClearCollections(collections...))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(collections...))
+	}()
+
+	projectRef := serviceModel.ProjectRef{Id: "mci", Identifier: "mci"}
+	require.NoError(t, projectRef.Insert())
+	v := serviceModel.Version{
+		Id:         "v0",
+		Identifier: projectRef.Id,
+		Requester:  evergreen.RepotrackerVersionRequester,
+		BuildIds:   []string{"b0"},
+	}
+	require.NoError(t, v.Insert())
+	pp := serviceModel.ParserProject{}
+	require.NoError(t, util.UnmarshalYAMLWithFallback([]byte(generatePreviewConfig), &pp))
+	pp.Id = v.Id
+	require.NoError(t, pp.Insert())
+	b0 := build.Build{Id: "b0", Version: v.Id, BuildVariant: "bv0", Activated: true}
+	require.NoError(t, b0.Insert())
+	generator := task.Task{Id: "generator", Version: v.Id, BuildId: b0.Id, BuildVariant: "bv0", DisplayName: "generator", Project: projectRef.Id}
+	require.NoError(t, generator.Insert())
+	dependent := task.Task{
+		Id:           "dependent",
+		Version:      v.Id,
+		BuildId:      b0.Id,
+		BuildVariant: "bv0",
+		DisplayName:  "dependent",
+		Project:      projectRef.Id,
+		DependsOn:    []task.Dependency{{TaskId: generator.Id, Status: evergreen.TaskSucceeded}},
+	}
+	require.NoError(t, dependent.Insert())
+
+	t.Run("PreviewsGeneratedTasks", func(t *testing.T) {
+		h := makeGeneratePreviewHandler(env).(*generatePreviewHandler)
+		h.versionID = v.Id
+		h.taskID = generator.Id
+		h.files = []json.RawMessage{json.RawMessage(generatePreviewJSON)}
+
+		resp := h.Run(ctx)
+		require.Equal(t, http.StatusOK, resp.Status(), resp.Data())
+		preview, ok := resp.Data().(model.APIGeneratedProjectPreview)
+		require.True(t, ok)
+		assert.Equal(t, []string{"bv1"}, preview.NewBuildVariants)
+		require.Len(t, preview.NewTasks, 2)
+		for _, newTask := range preview.NewTasks {
+			assert.Equal(t, "generated", utility.FromStringPtr(newTask.DisplayName))
+		}
+		dependsOnGenerated := 0
+		for _, dep := range preview.NewDependencies {
+			if utility.FromStringPtr(dep.Task.TaskID) == dependent.Id {
+				dependsOnGenerated++
+			}
+		}
+		assert.Equal(t, 2, dependsOnGenerated)
+
+		tasks, err := task.Find(task.ByVersion(v.Id))
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+		builds, err := build.Find(build.ByVersion(v.Id))
+		require.NoError(t, err)
+		assert.Len(t, builds, 1)
+	})
+	t.Run("FailsWithRedefinedTask", func(t *testing.T) {
+		h := makeGeneratePreviewHandler(env).(*generatePreviewHandler)
+		h.versionID = v.Id
+		h.files = []json.RawMessage{json.RawMessage(`{"tasks": [{"name": "dependent"}]}`)}
+		assert.Equal(t, http.StatusBadRequest, h.Run(ctx).Status())
+	})
+	t.Run("FailsWithTooManyVariants", func(t *testing.T) {
+		h := makeGeneratePreviewHandler(env).(*generatePreviewHandler)
+		h.versionID = v.Id
+		for i := 0; i < 201; i++ {
+			h.files = append(h.files, json.RawMessage(fmt.Sprintf(`{"buildvariants": [{"name": "bv%d"}]}`, i+2)))
+		}
+		assert.Equal(t, http.StatusBadRequest, h.Run(ctx).Status())
+	})
+	t.Run("NonexistentVersion", func(t *testing.T) {
+		h := makeGeneratePreviewHandler(env).(*generatePreviewHandler)
+		h.versionID = "nonexistent"
+		h.files = []json.RawMessage{json.RawMessage(generatePreviewJSON)}
+		assert.Equal(t, http.StatusNotFound, h.Run(ctx).Status())
+	})
+	t.Run("GeneratorInOtherVersion", func(t *testing.T) {
+		h := makeGeneratePreviewHandler(env).(*generatePreviewHandler)
+		h.versionID = v.Id
+		h.taskID = "nonexistent"
+		h.files = []json.RawMessage{json.RawMessage(generatePreviewJSON)}
+		assert.Equal(t, http.StatusNotFound, h.Run(ctx).Status())
+	})
+}
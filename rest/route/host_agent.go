@@ -183,6 +183,12 @@ func (h *hostAgentNextTask) Run(ctx context.Context) gimlet.Responder {
 		return sendBackRunningTask(ctx, h.env, h.host, nextTaskResponse)
 	}
 
+	// Hosts in the warm pool wait for the host allocator to take them out of
+	// the pool before they can run tasks.
+	if h.host.InWarmPool {
+		return gimlet.NewJSONResponse(nextTaskResponse)
+	}
+
 	var nextTask *task.Task
 	var shouldRunTeardown bool
 
@@ -100,6 +100,20 @@ func TestHostNextTask(t *testing.T) {
 			require.NoError(t, err)
 			assert.False(t, utility.IsZeroTime(dbHost.AgentStartTime))
 		},
+		"ShouldNotAssignTaskToWarmPoolHost": func(ctx context.Context, t *testing.T, rh *hostAgentNextTask) {
+			require.NoError(t, host.UpdateOne(ctx, bson.M{host.IdKey: rh.host.Id}, bson.M{"$set": bson.M{host.InWarmPoolKey: true}}))
+			rh.host.InWarmPool = true
+			resp := rh.Run(ctx)
+			assert.NotNil(t, resp)
+			assert.Equal(t, resp.Status(), http.StatusOK)
+			taskResp, ok := resp.Data().(apimodels.NextTaskResponse)
+			require.True(t, ok, resp.Data())
+			assert.Empty(t, taskResp.TaskId)
+			assert.False(t, taskResp.ShouldExit)
+			dbHost, err := host.FindOneId(ctx, rh.host.Id)
+			require.NoError(t, err)
+			assert.Empty(t, dbHost.RunningTask)
+		},
 		"ShouldExitWithOutOfDateRevisionAndTaskGroup": func(ctx context.Context, t *testing.T, rh *hostAgentNextTask) {
 			sampleHost, err := host.FindOneId(ctx, "h1")
 			require.NoError(t, err)
@@ -0,0 +1,99 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/cost"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/cost
+
+type projectCostGetHandler struct {
+	projectName string
+	startTime   time.Time
+	endTime     time.Time
+}
+
+func makeGetProjectCost() gimlet.RouteHandler {
+	return &projectCostGetHandler{}
+}
+
+func (h *projectCostGetHandler) Factory() gimlet.RouteHandler {
+	return &projectCostGetHandler{}
+}
+
+// Parse parses the optional start_time and end_time query parameters, which
+// default to the start of the current month and the current time.
+func (h *projectCostGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+
+	vals := r.URL.Query()
+	h.endTime = time.Now()
+	if endTime := vals.Get("end_time"); endTime != "" {
+		t, err := time.Parse(time.RFC3339, endTime)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrapf(err, "parsing end time '%s' in RFC3339 format", endTime).Error(),
+			}
+		}
+		h.endTime = t
+	}
+	h.startTime = cost.MonthStart(h.endTime)
+	if startTime := vals.Get("start_time"); startTime != "" {
+		t, err := time.Parse(time.RFC3339, startTime)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrapf(err, "parsing start time '%s' in RFC3339 format", startTime).Error(),
+			}
+		}
+		h.startTime = t
+	}
+	if !h.endTime.After(h.startTime) {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "end time must be after start time",
+		}
+	}
+
+	return nil
+}
+
+// Run returns the estimated cost of the project's tasks that finished in the
+// time range, broken down by build variant and requester.
+func (h *projectCostGetHandler) Run(ctx context.Context) gimlet.Responder {
+	pRef, err := dbModel.FindMergedProjectRef(h.projectName, "", false)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding project '%s'", h.projectName))
+	}
+	if pRef == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", h.projectName),
+		})
+	}
+
+	projectCost, err := cost.GetProjectCost(pRef.Id, h.startTime, h.endTime)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "getting cost for project '%s'", pRef.Id))
+	}
+
+	apiCost := model.APIProjectCost{}
+	apiCost.BuildFromService(*projectCost)
+	if pRef.MonthlyBudget > 0 {
+		apiCost.MonthlyBudget = utility.ToFloat64Ptr(pRef.MonthlyBudget)
+	}
+
+	return gimlet.NewJSONResponse(apiCost)
+}
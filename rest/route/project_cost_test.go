@@ -0,0 +1,58 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/cost"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectCostGetHandlerParse(t *testing.T) {
+	for testName, testCase := range map[string]func(ctx context.Context, t *testing.T, h *projectCostGetHandler){
+		"DefaultsToCurrentMonth": func(ctx context.Context, t *testing.T, h *projectCostGetHandler) {
+			req, err := http.NewRequest(http.MethodGet, "/projects/project/cost", nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "project"})
+
+			require.NoError(t, h.Parse(ctx, req))
+			assert.Equal(t, "project", h.projectName)
+			assert.WithinDuration(t, time.Now(), h.endTime, time.Minute)
+			assert.Equal(t, cost.MonthStart(h.endTime), h.startTime)
+		},
+		"ParsesTimeRange": func(ctx context.Context, t *testing.T, h *projectCostGetHandler) {
+			req, err := http.NewRequest(http.MethodGet, "/projects/project/cost?start_time=2023-01-01T00:00:00Z&end_time=2023-01-15T00:00:00Z", nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "project"})
+
+			require.NoError(t, h.Parse(ctx, req))
+			assert.True(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Equal(h.startTime))
+			assert.True(t, time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC).Equal(h.endTime))
+		},
+		"FailsWithInvalidTime": func(ctx context.Context, t *testing.T, h *projectCostGetHandler) {
+			req, err := http.NewRequest(http.MethodGet, "/projects/project/cost?start_time=yesterday", nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "project"})
+
+			assert.Error(t, h.Parse(ctx, req))
+		},
+		"FailsWithEndBeforeStart": func(ctx context.Context, t *testing.T, h *projectCostGetHandler) {
+			req, err := http.NewRequest(http.MethodGet, "/projects/project/cost?start_time=2023-01-15T00:00:00Z&end_time=2023-01-01T00:00:00Z", nil)
+			require.NoError(t, err)
+			req = gimlet.SetURLVars(req, map[string]string{"project_id": "project"})
+
+			assert.Error(t, h.Parse(ctx, req))
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			testCase(ctx, t, makeGetProjectCost().(*projectCostGetHandler))
+		})
+	}
+}
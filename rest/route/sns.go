@@ -15,6 +15,7 @@ import (
 	"github.com/evergreen-ci/cocoa/ecs"
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/pod"
 	"github.com/evergreen-ci/evergreen/units"
@@ -236,6 +237,26 @@ func (sns *ec2SNS) handleInstanceInterruptionWarning(ctx context.Context, instan
 		"host_id":               h.Id,
 	})
 
+	// Ignore non-agent hosts (e.g. spawn hosts, host.create hosts).
+	if h.UserHost || h.StartedBy != evergreen.User {
+		return nil
+	}
+	if h.Status == evergreen.HostTerminated {
+		return nil
+	}
+
+	// AWS will reclaim the instance shortly, so drain the host so that it
+	// won't be given any more tasks and requeue the task it's running so that
+	// it can start on another host without waiting for the host to be
+	// terminated.
+	if err := h.SetDecommissioned(ctx, evergreen.User, false, "SNS notification indicates spot instance will be interrupted"); err != nil {
+		return errors.Wrap(err, "decommissioning host")
+	}
+
+	if err := model.ResetInterruptedHostTask(ctx, sns.env.Settings(), h); err != nil {
+		return errors.Wrap(err, "requeueing task from interrupted host")
+	}
+
 	return nil
 }
 
@@ -12,8 +12,11 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/db"
 	"github.com/evergreen-ci/evergreen/mock"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/build"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/pod"
+	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/evergreen-ci/gimlet"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/amboy/queue"
@@ -113,9 +116,9 @@ func TestEC2SNSNotificationHandlers(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	defer func() {
-		assert.NoError(t, db.Clear(host.Collection))
+		assert.NoError(t, db.ClearCollections(host.Collection, task.Collection, task.OldCollection, build.Collection, model.VersionCollection))
 	}()
-	assert.NoError(t, db.Clear(host.Collection))
+	assert.NoError(t, db.ClearCollections(host.Collection, task.Collection, task.OldCollection, build.Collection, model.VersionCollection))
 
 	agentHost := host.Host{
 		Id:        "agent_host",
@@ -130,11 +133,32 @@ func TestEC2SNSNotificationHandlers(t *testing.T) {
 		UserHost:  true,
 		Status:    evergreen.HostRunning,
 	}
+	spotTask := task.Task{
+		Id:            "spot_task",
+		Status:        evergreen.TaskStarted,
+		Activated:     true,
+		ActivatedTime: time.Now(),
+		HostId:        "spot_host",
+		BuildId:       "spot_build",
+		Version:       "spot_version",
+	}
+	spotHost := host.Host{
+		Id:          "spot_host",
+		StartedBy:   evergreen.User,
+		Provider:    evergreen.ProviderNameEc2Fleet,
+		Status:      evergreen.HostRunning,
+		RunningTask: spotTask.Id,
+	}
 	messageID := "m0"
 	rh := ec2SNS{}
+	rh.env = evergreen.GetEnvironment()
 	rh.payload.MessageId = messageID
 	assert.NoError(t, agentHost.Insert(ctx))
 	assert.NoError(t, spawnHost.Insert(ctx))
+	assert.NoError(t, spotHost.Insert(ctx))
+	assert.NoError(t, spotTask.Insert())
+	assert.NoError(t, (&build.Build{Id: spotTask.BuildId, Version: spotTask.Version}).Insert())
+	assert.NoError(t, (&model.Version{Id: spotTask.Version}).Insert())
 
 	checkStatus := func(t *testing.T, hostID, status string) {
 		dbHost, err := host.FindOneId(ctx, hostID)
@@ -154,6 +178,29 @@ func TestEC2SNSNotificationHandlers(t *testing.T) {
 			checkStatus(t, agentHost.Id, evergreen.HostDecommissioned)
 			require.Equal(t, 1, rh.queue.Stats(ctx).Total)
 		},
+		"InstanceInterruptionWarningDrainsHostAndRequeuesTask": func(ctx context.Context, t *testing.T) {
+			require.NoError(t, rh.handleInstanceInterruptionWarning(ctx, spotHost.Id))
+			checkStatus(t, spotHost.Id, evergreen.HostDecommissioned)
+
+			dbHost, err := host.FindOneId(ctx, spotHost.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbHost)
+			assert.Zero(t, dbHost.RunningTask)
+
+			dbTask, err := task.FindOneId(spotTask.Id)
+			require.NoError(t, err)
+			require.NotZero(t, dbTask)
+			assert.Equal(t, evergreen.TaskUndispatched, dbTask.Status)
+			assert.Equal(t, spotTask.Execution, dbTask.Execution)
+			assert.Equal(t, 1, dbTask.NumInterruptions)
+			require.Len(t, dbTask.HostInterruptions, 1)
+			assert.Equal(t, spotHost.Id, dbTask.HostInterruptions[0].HostID)
+		},
+		"InstanceInterruptionWarningWithSpawnHostNoops": func(ctx context.Context, t *testing.T) {
+			originalStatus := spawnHost.Status
+			require.NoError(t, rh.handleInstanceInterruptionWarning(ctx, spawnHost.Id))
+			checkStatus(t, spawnHost.Id, originalStatus)
+		},
 		"InstanceStoppedWithSpawnHostNoops": func(ctx context.Context, t *testing.T) {
 			originalStatus := spawnHost.Status
 			require.NoError(t, rh.handleInstanceStopped(ctx, spawnHost.Id))
@@ -0,0 +1,181 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const taskLogFollowPollInterval = 2 * time.Second
+
+// taskLogFollowHandler implements the route GET /tasks/{task_id}/logs/follow.
+// It streams the task's logs as server-sent events, pushing new lines as they
+// are appended, until the task finishes or the client disconnects. Since the
+// response is written incrementally, this is a plain HTTP handler rather than
+// a gimlet.RouteHandler.
+type taskLogFollowHandler struct {
+	env evergreen.Environment
+}
+
+func makeFollowTaskLogs(env evergreen.Environment) http.Handler {
+	return &taskLogFollowHandler{env: env}
+}
+
+func (h *taskLogFollowHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	taskID := gimlet.GetVars(r)["task_id"]
+	vals := r.URL.Query()
+
+	var execution *int
+	if exec := vals.Get("execution"); exec != "" {
+		parsed, err := strconv.Atoi(exec)
+		if err != nil {
+			gimlet.WriteResponse(w, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing execution").Error(),
+			}))
+			return
+		}
+		execution = &parsed
+	}
+
+	var (
+		t   *task.Task
+		err error
+	)
+	if execution == nil {
+		t, err = task.FindOneId(taskID)
+	} else {
+		t, err = task.FindOneIdAndExecution(taskID, *execution)
+	}
+	if err != nil {
+		gimlet.WriteResponse(w, gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", taskID)))
+		return
+	}
+	if t == nil {
+		gimlet.WriteResponse(w, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", taskID),
+		}))
+		return
+	}
+	if t.DisplayOnly {
+		gimlet.WriteResponse(w, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("cannot follow the logs of display task '%s'", taskID),
+		}))
+		return
+	}
+
+	logType := taskoutput.TaskLogTypeAll
+	if typ := vals.Get("type"); typ != "" {
+		logType = taskoutput.TaskLogType(typ)
+	}
+	if err = logType.Validate(); err != nil {
+		gimlet.WriteResponse(w, gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    err.Error(),
+		}))
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		gimlet.WriteResponse(w, gimlet.MakeJSONInternalErrorResponder(errors.New("response writer does not support streaming")))
+		return
+	}
+
+	status := t.Status
+	it, err := log.NewFollowIterator(ctx, log.FollowOptions{
+		Get: func(ctx context.Context, logStarts map[string]int64) (log.LogIterator, error) {
+			return t.GetTaskLogs(ctx, h.env, taskoutput.TaskLogGetOptions{
+				LogType:   logType,
+				LogStarts: logStarts,
+			})
+		},
+		IsComplete: func(ctx context.Context) (bool, error) {
+			dbTask, err := task.FindOneIdAndExecution(t.Id, t.Execution)
+			if err != nil {
+				return false, errors.Wrapf(err, "finding task '%s'", t.Id)
+			}
+			if dbTask == nil {
+				return false, errors.Errorf("task '%s' not found", t.Id)
+			}
+			status = dbTask.Status
+
+			return dbTask.IsFinished(), nil
+		},
+		PollInterval: taskLogFollowPollInterval,
+	})
+	if err != nil {
+		gimlet.WriteResponse(w, gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "following task logs")))
+		return
+	}
+	defer func() {
+		grip.Warning(message.WrapError(it.Close(), message.Fields{
+			"message": "closing task log follow iterator",
+			"task_id": t.Id,
+		}))
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disable response buffering by proxies so that lines are delivered
+	// as soon as they are flushed.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for it.Next() {
+		var line model.APILogLine
+		line.BuildFromService(it.Item())
+		if err = writeServerSentEvent(w, model.TaskLogFollowLineEvent, line); err != nil {
+			// The client most likely disconnected.
+			return
+		}
+		flusher.Flush()
+	}
+
+	if err = it.Err(); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		grip.Error(message.WrapError(err, message.Fields{
+			"message":   "following task logs",
+			"task_id":   t.Id,
+			"execution": t.Execution,
+		}))
+		grip.Warning(writeServerSentEvent(w, model.TaskLogFollowErrorEvent, err.Error()))
+		flusher.Flush()
+		return
+	}
+
+	grip.Warning(writeServerSentEvent(w, model.TaskLogFollowEndEvent, status))
+	flusher.Flush()
+}
+
+// writeServerSentEvent writes the event with the given name and JSON-encoded
+// data to the writer.
+func writeServerSentEvent(w io.Writer, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "marshalling event data")
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return errors.Wrap(err, "writing event")
+}
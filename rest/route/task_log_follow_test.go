@@ -0,0 +1,98 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogFollowHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection))
+	}()
+
+	bucketDir := t.TempDir()
+	tsk := task.Task{
+		Id:        "t1",
+		Project:   "project",
+		Execution: 0,
+		Status:    evergreen.TaskSucceeded,
+		TaskOutputInfo: &taskoutput.TaskOutput{
+			TaskLogs: taskoutput.TaskLogOutput{
+				Version: 1,
+				BucketConfig: evergreen.BucketConfig{
+					Name: bucketDir,
+					Type: evergreen.BucketTypeLocal,
+				},
+			},
+		},
+	}
+	require.NoError(t, tsk.Insert())
+	displayTask := task.Task{Id: "dt", DisplayOnly: true}
+	require.NoError(t, displayTask.Insert())
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: bucketDir})
+	require.NoError(t, err)
+	ts := time.Now().UnixNano()
+	require.NoError(t, log.NewLogServiceV0(bucket).Append(ctx, "project/t1/0/task_logs/task", []log.LogLine{
+		{Priority: level.Info, Timestamp: ts, Data: "hello"},
+		{Priority: level.Error, Timestamp: ts + 1, Data: "world"},
+	}))
+
+	serve := func(t *testing.T, taskID, query string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/tasks/"+taskID+"/logs/follow?"+query, nil)
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"task_id": taskID})
+		req = req.WithContext(ctx)
+
+		rw := httptest.NewRecorder()
+		makeFollowTaskLogs(env).ServeHTTP(rw, req)
+
+		return rw
+	}
+
+	t.Run("StreamsLogsOfFinishedTask", func(t *testing.T) {
+		rw := serve(t, "t1", "type=task_log")
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "text/event-stream", rw.Header().Get("Content-Type"))
+
+		events := strings.Split(strings.TrimSpace(rw.Body.String()), "\n\n")
+		require.Len(t, events, 3)
+		assert.Contains(t, events[0], "event: line\n")
+		assert.Contains(t, events[0], `"data":"hello"`)
+		assert.Contains(t, events[1], `"data":"world"`)
+		assert.Equal(t, "event: end\ndata: \"success\"", events[2])
+	})
+	t.Run("InvalidLogType", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(t, "t1", "type=DNE").Code)
+	})
+	t.Run("InvalidExecution", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(t, "t1", "execution=one").Code)
+	})
+	t.Run("DisplayTask", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(t, "dt", "").Code)
+	})
+	t.Run("NonexistentTask", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(t, "DNE", "").Code)
+	})
+}
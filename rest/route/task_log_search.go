@@ -0,0 +1,137 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/mongodb/grip/level"
+	"github.com/pkg/errors"
+)
+
+// taskLogSearchHandler implements the route GET /tasks/{task_id}/logs/search.
+// It streams the lines of the task's logs that match the search as plain
+// text.
+type taskLogSearchHandler struct {
+	taskID        string
+	execution     *int
+	logType       taskoutput.TaskLogType
+	search        log.SearchOptions
+	printTime     bool
+	printPriority bool
+
+	env evergreen.Environment
+}
+
+func makeSearchTaskLogs(env evergreen.Environment) gimlet.RouteHandler {
+	return &taskLogSearchHandler{env: env}
+}
+
+func (h *taskLogSearchHandler) Factory() gimlet.RouteHandler {
+	return &taskLogSearchHandler{env: h.env}
+}
+
+func (h *taskLogSearchHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.taskID = gimlet.GetVars(r)["task_id"]
+	vals := r.URL.Query()
+
+	if execution := vals.Get("execution"); execution != "" {
+		exec, err := strconv.Atoi(execution)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing execution").Error(),
+			}
+		}
+		h.execution = &exec
+	}
+
+	h.logType = taskoutput.TaskLogTypeAll
+	if logType := vals.Get("type"); logType != "" {
+		h.logType = taskoutput.TaskLogType(logType)
+	}
+
+	h.search.Pattern = vals.Get("pattern")
+	h.search.Regex = vals.Get("regex") == "true"
+	if minPriority := vals.Get("min_priority"); minPriority != "" {
+		priority, err := strconv.Atoi(minPriority)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing minimum priority").Error(),
+			}
+		}
+		h.search.MinPriority = level.Priority(priority)
+	}
+	if contextLines := vals.Get("context_lines"); contextLines != "" {
+		var err error
+		h.search.ContextLines, err = strconv.Atoi(contextLines)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing context lines").Error(),
+			}
+		}
+	}
+	if limit := vals.Get("limit"); limit != "" {
+		var err error
+		h.search.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return gimlet.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				Message:    errors.Wrap(err, "parsing limit").Error(),
+			}
+		}
+	}
+	if err := h.search.Validate(); err != nil {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    errors.Wrap(err, "invalid search").Error(),
+		}
+	}
+
+	h.printTime = vals.Get("print_time") == "true"
+	h.printPriority = vals.Get("print_priority") == "true"
+
+	return nil
+}
+
+func (h *taskLogSearchHandler) Run(ctx context.Context) gimlet.Responder {
+	var (
+		t   *task.Task
+		err error
+	)
+	if h.execution == nil {
+		t, err = task.FindOneId(h.taskID)
+	} else {
+		t, err = task.FindOneIdAndExecution(h.taskID, *h.execution)
+	}
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s'", h.taskID))
+	}
+	if t == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("task '%s' not found", h.taskID),
+		})
+	}
+
+	it, err := t.GetTaskLogs(ctx, h.env, taskoutput.TaskLogGetOptions{
+		LogType: h.logType,
+		Search:  &h.search,
+	})
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "searching task logs for task '%s'", h.taskID))
+	}
+
+	return gimlet.NewTextResponse(log.NewLogIteratorReader(it, log.LogIteratorReaderOptions{
+		PrintTime:     h.printTime,
+		PrintPriority: h.printPriority,
+	}))
+}
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/pail"
+	"github.com/mongodb/grip/level"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogSearchHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection))
+	}()
+
+	bucketDir := t.TempDir()
+	tsk := task.Task{
+		Id:        "t1",
+		Project:   "project",
+		Execution: 0,
+		Status:    evergreen.TaskSucceeded,
+		TaskOutputInfo: &taskoutput.TaskOutput{
+			TaskLogs: taskoutput.TaskLogOutput{
+				Version: 1,
+				BucketConfig: evergreen.BucketConfig{
+					Name: bucketDir,
+					Type: evergreen.BucketTypeLocal,
+				},
+			},
+		},
+	}
+	require.NoError(t, tsk.Insert())
+
+	bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: bucketDir})
+	require.NoError(t, err)
+	ts := time.Now().UnixNano()
+	require.NoError(t, log.NewLogServiceV0(bucket).Append(ctx, "project/t1/0/task_logs/task", []log.LogLine{
+		{Priority: level.Info, Timestamp: ts, Data: "compiling"},
+		{Priority: level.Error, Timestamp: ts + 1, Data: "error: first"},
+		{Priority: level.Info, Timestamp: ts + 2, Data: "retrying"},
+		{Priority: level.Error, Timestamp: ts + 3, Data: "error: second"},
+	}))
+
+	search := func(t *testing.T, taskID, query string) gimlet.Responder {
+		req, err := http.NewRequest(http.MethodGet, "https://example.com/rest/v2/tasks/"+taskID+"/logs/search?"+query, nil)
+		require.NoError(t, err)
+		req = gimlet.SetURLVars(req, map[string]string{"task_id": taskID})
+
+		rh := makeSearchTaskLogs(env)
+		if err = rh.Parse(ctx, req); err != nil {
+			return gimlet.MakeJSONErrorResponder(err)
+		}
+
+		return rh.Run(ctx)
+	}
+	readBody := func(t *testing.T, resp gimlet.Responder) string {
+		require.Equal(t, http.StatusOK, resp.Status())
+		r, ok := resp.Data().(io.Reader)
+		require.True(t, ok)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	t.Run("Substring", func(t *testing.T) {
+		assert.Equal(t, "error: first\nerror: second\n", readBody(t, search(t, "t1", "type=task_log&pattern=error")))
+	})
+	t.Run("RegexWithContextLines", func(t *testing.T) {
+		assert.Equal(t, "retrying\nerror: second\n", readBody(t, search(t, "t1", "type=task_log&pattern=%5Eerror.*second%24&regex=true&context_lines=1")))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		assert.Equal(t, "error: first\n", readBody(t, search(t, "t1", "type=task_log&pattern=error&limit=1")))
+	})
+	t.Run("EmptyPattern", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, search(t, "t1", "type=task_log").Status())
+	})
+	t.Run("InvalidRegex", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, search(t, "t1", "pattern=(&regex=true").Status())
+	})
+	t.Run("InvalidLimit", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, search(t, "t1", "pattern=error&limit=one").Status())
+	})
+	t.Run("NonexistentTask", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, search(t, "DNE", "pattern=error").Status())
+	})
+}
@@ -0,0 +1,57 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+//
+// GET /tasks/{task_id}/queue_explanation
+
+type taskQueueExplanationHandler struct {
+	task *task.Task
+}
+
+func makeGetTaskQueueExplanation() gimlet.RouteHandler {
+	return &taskQueueExplanationHandler{}
+}
+
+func (h *taskQueueExplanationHandler) Factory() gimlet.RouteHandler {
+	return &taskQueueExplanationHandler{}
+}
+
+func (h *taskQueueExplanationHandler) Parse(ctx context.Context, r *http.Request) error {
+	projCtx := MustHaveProjectContext(ctx)
+	if projCtx.Task == nil {
+		return gimlet.ErrorResponse{
+			Message:    fmt.Sprintf("task '%s' not found", gimlet.GetVars(r)["task_id"]),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	h.task = projCtx.Task
+
+	return nil
+}
+
+// Run explains why the task is or isn't waiting to run: its position in the
+// task queue, how the planner scored it, its unmet dependencies and whether
+// its distro can start more hosts.
+func (h *taskQueueExplanationHandler) Run(ctx context.Context) gimlet.Responder {
+	explanation, err := model.ExplainTaskQueue(ctx, *h.task)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "explaining task queue for task '%s'", h.task.Id))
+	}
+
+	apiExplanation := restModel.APITaskQueueExplanation{}
+	apiExplanation.BuildFromService(*explanation)
+
+	return gimlet.NewJSONResponse(apiExplanation)
+}
@@ -0,0 +1,71 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskQueueExplanationHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, db.ClearCollections(task.Collection, distro.Collection, host.Collection, serviceModel.TaskQueuesCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, distro.Collection, host.Collection, serviceModel.TaskQueuesCollection))
+	}()
+
+	d := distro.Distro{Id: "distro", Provider: evergreen.ProviderNameMock}
+	require.NoError(t, d.Insert(ctx))
+	dep := task.Task{
+		Id:          "dep",
+		DisplayName: "compile",
+		Status:      evergreen.TaskStarted,
+		Activated:   true,
+	}
+	require.NoError(t, dep.Insert())
+	tsk := task.Task{
+		Id:        "task",
+		DistroId:  d.Id,
+		Status:    evergreen.TaskUndispatched,
+		Activated: true,
+		DependsOn: []task.Dependency{{TaskId: dep.Id, Status: evergreen.TaskSucceeded}},
+	}
+	require.NoError(t, tsk.Insert())
+	queue := serviceModel.NewTaskQueue(d.Id, []serviceModel.TaskQueueItem{
+		{Id: "ahead"},
+		{Id: tsk.Id, SortingValueBreakdown: &task.SortingValueBreakdown{TotalValue: 10}},
+	}, serviceModel.DistroQueueInfo{Length: 2})
+	require.NoError(t, queue.Save())
+
+	h := makeGetTaskQueueExplanation().(*taskQueueExplanationHandler)
+	h.task = &tsk
+	resp := h.Run(ctx)
+	require.Equal(t, http.StatusOK, resp.Status())
+
+	explanation, ok := resp.Data().(model.APITaskQueueExplanation)
+	require.True(t, ok)
+	assert.Equal(t, tsk.Id, utility.FromStringPtr(explanation.TaskID))
+	assert.True(t, explanation.InQueue)
+	assert.Equal(t, d.Id, utility.FromStringPtr(explanation.QueueDistroID))
+	assert.Equal(t, 2, explanation.Position)
+	assert.Equal(t, 2, explanation.QueueLength)
+	require.NotNil(t, explanation.SortingValueBreakdown)
+	assert.EqualValues(t, 10, explanation.SortingValueBreakdown.TotalValue)
+	require.Len(t, explanation.UnmetDependencies, 1)
+	assert.Equal(t, dep.Id, utility.FromStringPtr(explanation.UnmetDependencies[0].TaskID))
+	assert.Equal(t, evergreen.TaskStarted, utility.FromStringPtr(explanation.UnmetDependencies[0].Status))
+	assert.False(t, explanation.UnmetDependencies[0].Unattainable)
+	assert.NotEmpty(t, explanation.Reasons)
+}
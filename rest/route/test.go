@@ -29,9 +29,10 @@ type testGetHandler struct {
 	limit      int
 	latest     bool
 
-	task *task.Task
-	env  evergreen.Environment
-	sc   data.Connector
+	task        *task.Task
+	quarantined task.QuarantinedTestsByTask
+	env         evergreen.Environment
+	sc          data.Connector
 }
 
 func makeFetchTestsForTask(env evergreen.Environment, sc data.Connector) gimlet.RouteHandler {
@@ -129,6 +130,10 @@ func (tgh *testGetHandler) Run(ctx context.Context) gimlet.Responder {
 	if err != nil {
 		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting test results"))
 	}
+	tgh.quarantined, err = tgh.task.FindQuarantinedTests()
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting quarantined tests"))
+	}
 
 	var nextKey string
 	if tgh.key*tgh.limit < utility.FromIntPtr(results.Stats.FilteredCount) {
@@ -169,7 +174,7 @@ func (tgh *testGetHandler) buildResponse(results []testresult.TestResult, key st
 	return resp
 }
 
-func (tgh *testGetHandler) addDataToResponse(resp gimlet.Responder, result interface{}) error {
+func (tgh *testGetHandler) addDataToResponse(resp gimlet.Responder, result *testresult.TestResult) error {
 	at := &model.APITest{}
 	if err := at.BuildFromService(tgh.taskID); err != nil {
 		return gimlet.ErrorResponse{
@@ -183,6 +188,7 @@ func (tgh *testGetHandler) addDataToResponse(resp gimlet.Responder, result inter
 			StatusCode: http.StatusInternalServerError,
 		}
 	}
+	at.Quarantined = tgh.quarantined.IsQuarantined(*result)
 
 	if err := resp.AddData(at); err != nil {
 		return gimlet.ErrorResponse{
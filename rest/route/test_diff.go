@@ -0,0 +1,146 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	restModel "github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/pkg/errors"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+//
+// GET /tasks/{task_id}/test_diff
+
+type taskTestDiffHandler struct {
+	task       *task.Task
+	baseTaskID string
+
+	env evergreen.Environment
+}
+
+func makeGetTaskTestDiff(env evergreen.Environment) gimlet.RouteHandler {
+	return &taskTestDiffHandler{env: env}
+}
+
+func (h *taskTestDiffHandler) Factory() gimlet.RouteHandler {
+	return &taskTestDiffHandler{env: h.env}
+}
+
+func (h *taskTestDiffHandler) Parse(ctx context.Context, r *http.Request) error {
+	projCtx := MustHaveProjectContext(ctx)
+	if projCtx.Task == nil {
+		return gimlet.ErrorResponse{
+			Message:    fmt.Sprintf("task '%s' not found", gimlet.GetVars(r)["task_id"]),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	h.task = projCtx.Task
+	h.baseTaskID = r.URL.Query().Get("base_task_id")
+
+	return nil
+}
+
+// Run returns the diff between the task's test results and the test results
+// of the base task. If no base task is given, a patch task is compared against
+// the same task on its base commit and a mainline task is compared against the
+// same task on the previous commit.
+func (h *taskTestDiffHandler) Run(ctx context.Context) gimlet.Responder {
+	var (
+		baseTask *task.Task
+		err      error
+	)
+	switch {
+	case h.baseTaskID != "":
+		baseTask, err = task.FindOneId(h.baseTaskID)
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding base task '%s'", h.baseTaskID))
+		}
+		if baseTask == nil {
+			return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+				Message:    fmt.Sprintf("base task '%s' not found", h.baseTaskID),
+				StatusCode: http.StatusNotFound,
+			})
+		}
+	case evergreen.IsPatchRequester(h.task.Requester):
+		baseTask, err = h.task.FindTaskOnBaseCommit()
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s' on base commit", h.task.Id))
+		}
+	default:
+		baseTask, err = h.task.FindTaskOnPreviousCommit()
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding task '%s' on previous commit", h.task.Id))
+		}
+	}
+
+	diff, err := model.DiffTaskTestResults(ctx, h.env, baseTask, h.task)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "diffing test results for task '%s'", h.task.Id))
+	}
+
+	apiDiff := restModel.APITestResultsDiff{}
+	apiDiff.BuildFromService(diff)
+
+	return gimlet.NewJSONResponse(apiDiff)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+//
+// GET /versions/{version_id}/test_diff
+
+type versionTestDiffHandler struct {
+	versionID     string
+	baseVersionID string
+
+	env evergreen.Environment
+}
+
+func makeGetVersionTestDiff(env evergreen.Environment) gimlet.RouteHandler {
+	return &versionTestDiffHandler{env: env}
+}
+
+func (h *versionTestDiffHandler) Factory() gimlet.RouteHandler {
+	return &versionTestDiffHandler{env: h.env}
+}
+
+func (h *versionTestDiffHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.versionID = gimlet.GetVars(r)["version_id"]
+	if h.versionID == "" {
+		return errors.New("missing version ID")
+	}
+	h.baseVersionID = r.URL.Query().Get("base_version_id")
+
+	return nil
+}
+
+// Run returns the diff between the test results of the version's finished
+// tasks and those of the base version. If no base version is given, a patch is
+// compared against the mainline version it was created from.
+func (h *versionTestDiffHandler) Run(ctx context.Context) gimlet.Responder {
+	v, err := model.VersionFindOneId(h.versionID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding version '%s'", h.versionID))
+	}
+	if v == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			Message:    fmt.Sprintf("version '%s' not found", h.versionID),
+			StatusCode: http.StatusNotFound,
+		})
+	}
+
+	diff, err := model.DiffVersionTestResults(ctx, h.env, h.baseVersionID, h.versionID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "diffing test results for version '%s'", h.versionID))
+	}
+
+	apiDiff := restModel.APITestResultsDiff{}
+	apiDiff.BuildFromService(diff)
+
+	return gimlet.NewJSONResponse(apiDiff)
+}
@@ -0,0 +1,116 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	serviceModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestDiffHandlers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	require.NoError(t, db.ClearCollections(task.Collection, serviceModel.VersionCollection))
+	require.NoError(t, testresult.ClearLocal(ctx, env))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(task.Collection, serviceModel.VersionCollection))
+		assert.NoError(t, testresult.ClearLocal(ctx, env))
+	}()
+
+	baseVersion := serviceModel.Version{
+		Id:         "base_version",
+		Identifier: "project",
+		Revision:   "abc",
+		Requester:  evergreen.RepotrackerVersionRequester,
+	}
+	require.NoError(t, baseVersion.Insert())
+	patchVersion := serviceModel.Version{
+		Id:         "patch_version",
+		Identifier: "project",
+		Revision:   "abc",
+		Requester:  evergreen.PatchVersionRequester,
+	}
+	require.NoError(t, patchVersion.Insert())
+
+	baseTask := task.Task{
+		Id:             "base_task",
+		Version:        baseVersion.Id,
+		Project:        "project",
+		Revision:       "abc",
+		Requester:      evergreen.RepotrackerVersionRequester,
+		BuildVariant:   "v1",
+		DisplayName:    "task",
+		Status:         evergreen.TaskSucceeded,
+		ResultsService: testresult.TestResultsServiceLocal,
+	}
+	require.NoError(t, baseTask.Insert())
+	patchTask := baseTask
+	patchTask.Id = "patch_task"
+	patchTask.Version = patchVersion.Id
+	patchTask.Requester = evergreen.PatchVersionRequester
+	patchTask.Status = evergreen.TaskFailed
+	require.NoError(t, patchTask.Insert())
+	require.NoError(t, testresult.InsertLocal(ctx, env,
+		testresult.TestResult{TaskID: baseTask.Id, TestName: "test1", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: baseTask.Id, TestName: "test2", Status: evergreen.TestSucceededStatus},
+		testresult.TestResult{TaskID: patchTask.Id, TestName: "test1", Status: evergreen.TestFailedStatus},
+		testresult.TestResult{TaskID: patchTask.Id, TestName: "test3", Status: evergreen.TestSucceededStatus},
+	))
+
+	checkDiff := func(t *testing.T, resp gimlet.Responder) {
+		require.Equal(t, http.StatusOK, resp.Status())
+		diff, ok := resp.Data().(model.APITestResultsDiff)
+		require.True(t, ok)
+		require.Len(t, diff.NewFailures, 1)
+		assert.Equal(t, "test1", utility.FromStringPtr(diff.NewFailures[0].TestName))
+		assert.Equal(t, baseTask.Id, utility.FromStringPtr(diff.NewFailures[0].BaseTaskID))
+		assert.Equal(t, patchTask.Id, utility.FromStringPtr(diff.NewFailures[0].TaskID))
+		require.Len(t, diff.Added, 1)
+		assert.Equal(t, "test3", utility.FromStringPtr(diff.Added[0].TestName))
+		require.Len(t, diff.Removed, 1)
+		assert.Equal(t, "test2", utility.FromStringPtr(diff.Removed[0].TestName))
+		assert.Empty(t, diff.Fixed)
+		assert.Empty(t, diff.StillFailing)
+	}
+
+	t.Run("TaskDefaultsToBaseCommit", func(t *testing.T) {
+		h := makeGetTaskTestDiff(env).(*taskTestDiffHandler)
+		h.task = &patchTask
+		checkDiff(t, h.Run(ctx))
+	})
+	t.Run("TaskWithExplicitBase", func(t *testing.T) {
+		h := makeGetTaskTestDiff(env).(*taskTestDiffHandler)
+		h.task = &patchTask
+		h.baseTaskID = baseTask.Id
+		checkDiff(t, h.Run(ctx))
+	})
+	t.Run("TaskWithNonexistentBase", func(t *testing.T) {
+		h := makeGetTaskTestDiff(env).(*taskTestDiffHandler)
+		h.task = &patchTask
+		h.baseTaskID = "nonexistent"
+		assert.Equal(t, http.StatusNotFound, h.Run(ctx).Status())
+	})
+	t.Run("VersionDefaultsToBaseVersion", func(t *testing.T) {
+		h := makeGetVersionTestDiff(env).(*versionTestDiffHandler)
+		h.versionID = patchVersion.Id
+		checkDiff(t, h.Run(ctx))
+	})
+	t.Run("NonexistentVersion", func(t *testing.T) {
+		h := makeGetVersionTestDiff(env).(*versionTestDiffHandler)
+		h.versionID = "nonexistent"
+		assert.Equal(t, http.StatusNotFound, h.Run(ctx).Status())
+	})
+}
@@ -0,0 +1,219 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	dbModel "github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/evergreen-ci/evergreen/rest/model"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/flaky_tests
+
+type flakyTestsGetHandler struct {
+	projectName string
+	all         bool
+}
+
+func makeGetFlakyTests() gimlet.RouteHandler {
+	return &flakyTestsGetHandler{}
+}
+
+func (h *flakyTestsGetHandler) Factory() gimlet.RouteHandler {
+	return &flakyTestsGetHandler{}
+}
+
+func (h *flakyTestsGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	h.all = r.URL.Query().Get("all") == "true"
+	return nil
+}
+
+// Run returns the flakiness of the project's tests. By default only the tests
+// that are currently considered flaky are returned.
+func (h *flakyTestsGetHandler) Run(ctx context.Context) gimlet.Responder {
+	id, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", h.projectName),
+		})
+	}
+
+	flakiness, err := flakytest.FindTestFlakiness(id, !h.all)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding flaky tests for project '%s'", id))
+	}
+
+	apiFlakiness := make([]model.APITestFlakiness, len(flakiness))
+	for i, f := range flakiness {
+		apiFlakiness[i].BuildFromService(f)
+	}
+
+	return gimlet.NewJSONResponse(apiFlakiness)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// GET /rest/v2/projects/{project_id}/quarantined_tests
+
+type quarantinedTestsGetHandler struct {
+	projectName string
+}
+
+func makeGetQuarantinedTests() gimlet.RouteHandler {
+	return &quarantinedTestsGetHandler{}
+}
+
+func (h *quarantinedTestsGetHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestsGetHandler{}
+}
+
+func (h *quarantinedTestsGetHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	return nil
+}
+
+// Run returns the project's quarantine list.
+func (h *quarantinedTestsGetHandler) Run(ctx context.Context) gimlet.Responder {
+	id, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", h.projectName),
+		})
+	}
+
+	quarantined, err := flakytest.FindQuarantinedTests(id)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding quarantined tests for project '%s'", id))
+	}
+
+	apiQuarantined := make([]model.APIQuarantinedTest, len(quarantined))
+	for i, q := range quarantined {
+		apiQuarantined[i].BuildFromService(q)
+	}
+
+	return gimlet.NewJSONResponse(apiQuarantined)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// POST /rest/v2/projects/{project_id}/quarantined_tests
+
+type quarantinedTestPostHandler struct {
+	projectName string
+	quarantined model.APIQuarantinedTest
+	user        gimlet.User
+}
+
+func makePostQuarantinedTest() gimlet.RouteHandler {
+	return &quarantinedTestPostHandler{}
+}
+
+func (h *quarantinedTestPostHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestPostHandler{}
+}
+
+func (h *quarantinedTestPostHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.projectName = gimlet.GetVars(r)["project_id"]
+	h.user = MustHaveUser(ctx)
+
+	if err := utility.ReadJSON(r.Body, &h.quarantined); err != nil {
+		return errors.Wrap(err, "reading quarantined test from JSON request body")
+	}
+	if utility.FromStringPtr(h.quarantined.TestName) == "" {
+		return gimlet.ErrorResponse{
+			StatusCode: http.StatusBadRequest,
+			Message:    "must specify a test name",
+		}
+	}
+
+	return nil
+}
+
+// Run adds the test to the project's quarantine list, so that its failures no
+// longer fail the project's tasks.
+func (h *quarantinedTestPostHandler) Run(ctx context.Context) gimlet.Responder {
+	id, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", h.projectName),
+		})
+	}
+
+	quarantined := h.quarantined.ToService()
+	quarantined.ID = ""
+	quarantined.ProjectID = id
+	quarantined.QuarantinedBy = h.user.Username()
+	quarantined.QuarantinedAt = time.Time{}
+	if err := quarantined.Insert(); err != nil {
+		return gimlet.MakeJSONErrorResponder(errors.Wrap(err, "quarantining test"))
+	}
+
+	apiQuarantined := model.APIQuarantinedTest{}
+	apiQuarantined.BuildFromService(quarantined)
+
+	return gimlet.NewJSONResponse(apiQuarantined)
+}
+
+////////////////////////////////////////////////////////////////////////
+//
+// DELETE /rest/v2/projects/{project_id}/quarantined_tests/{quarantine_id}
+
+type quarantinedTestDeleteHandler struct {
+	projectName  string
+	quarantineID string
+}
+
+func makeDeleteQuarantinedTest() gimlet.RouteHandler {
+	return &quarantinedTestDeleteHandler{}
+}
+
+func (h *quarantinedTestDeleteHandler) Factory() gimlet.RouteHandler {
+	return &quarantinedTestDeleteHandler{}
+}
+
+func (h *quarantinedTestDeleteHandler) Parse(ctx context.Context, r *http.Request) error {
+	vars := gimlet.GetVars(r)
+	h.projectName = vars["project_id"]
+	h.quarantineID = vars["quarantine_id"]
+	return nil
+}
+
+// Run removes the test from the project's quarantine list.
+func (h *quarantinedTestDeleteHandler) Run(ctx context.Context) gimlet.Responder {
+	id, err := dbModel.GetIdForProject(h.projectName)
+	if err != nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("project '%s' not found", h.projectName),
+		})
+	}
+
+	quarantined, err := flakytest.FindQuarantinedTestByID(id, h.quarantineID)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "finding quarantined test '%s'", h.quarantineID))
+	}
+	if quarantined == nil {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("quarantined test '%s' not found in project '%s'", h.quarantineID, h.projectName),
+		})
+	}
+
+	if err := flakytest.RemoveQuarantinedTest(id, h.quarantineID); err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(err)
+	}
+
+	return gimlet.NewJSONResponse(struct{}{})
+}
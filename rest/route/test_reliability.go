@@ -0,0 +1,202 @@
+package route
+
+// This file defines the handler for the endpoint to query test reliability.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/reliability"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/rest/data"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+/////////////////////////////////////////////////////
+// /projects/<project_id>/test_reliability handler //
+/////////////////////////////////////////////////////
+
+type testReliabilityHandler struct {
+	// taskReliabilityHandler provides the parameter parsing helpers shared
+	// with the task reliability endpoint.
+	taskReliabilityHandler
+	testFilter reliability.TestReliabilityFilter
+}
+
+func makeGetProjectTestReliability(url string) gimlet.RouteHandler {
+	return &testReliabilityHandler{taskReliabilityHandler: taskReliabilityHandler{url: url}}
+}
+
+func (h *testReliabilityHandler) Factory() gimlet.RouteHandler {
+	return &testReliabilityHandler{taskReliabilityHandler: taskReliabilityHandler{url: h.url}}
+}
+
+// parseTestReliabilityFilter parses the query parameter values and fills the
+// test filter field.
+func (h *testReliabilityHandler) parseTestReliabilityFilter(vals url.Values) error {
+	var err error
+
+	h.testFilter.Requesters, err = h.readRequesters(h.readStringList(vals["requesters"]))
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    errors.Wrap(err, "invalid requesters").Error(),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	h.testFilter.BuildVariants = h.readStringList(vals["variants"])
+	h.testFilter.Tasks = h.readStringList(vals["tasks"])
+
+	// tests
+	h.testFilter.Tests = h.readStringList(vals["tests"])
+	if len(h.testFilter.Tests) == 0 {
+		return gimlet.ErrorResponse{
+			Message:    "must specify at least one test",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+	if len(h.testFilter.Tests) > reliabilityAPIMaxNumTasksLimit {
+		return gimlet.ErrorResponse{
+			Message:    fmt.Sprintf("cannot request more than %d tests", reliabilityAPIMaxNumTasksLimit),
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	h.testFilter.GroupNumDays, err = h.readInt(vals.Get("group_num_days"), 1, statsAPIMaxGroupNumDays, 1)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid grouping by number of days",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// limit
+	h.testFilter.Limit, err = h.readInt(vals.Get("limit"), 1, reliabilityAPIMaxNumTasksLimit, reliabilityAPIMaxNumTasksLimit)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid limit",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// before_date, defaults to tomorrow
+	beforeDate := h.readString(vals.Get("before_date"), getDefaultBeforeDate())
+	h.testFilter.BeforeDate, err = time.ParseInLocation(statsAPIDateFormat, beforeDate, time.UTC)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid 'before' date",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// after_date
+	afterDate := h.readString(vals.Get("after_date"), beforeDate)
+	h.testFilter.AfterDate, err = time.ParseInLocation(statsAPIDateFormat, afterDate, time.UTC)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid 'after' date",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// sort
+	h.testFilter.Sort, err = h.readSort(vals.Get("sort"))
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid sort",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// significance
+	h.testFilter.Significance, err = h.readFloat(vals.Get("significance"), 0.0, 1.0, reliability.DefaultSignificance)
+	if err != nil {
+		return gimlet.ErrorResponse{
+			Message:    "invalid significance value",
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	// start_at, in the form date|variant|task|test
+	startAt, err := h.readStartAt(vals.Get("start_at"))
+	if err != nil {
+		return err
+	}
+	if startAt != nil {
+		h.testFilter.StartAtTest = startAt.Distro
+		startAt.Distro = ""
+		h.testFilter.StartAt = startAt
+	}
+
+	return nil
+}
+
+func (h *testReliabilityHandler) Parse(ctx context.Context, r *http.Request) error {
+	h.testFilter = reliability.TestReliabilityFilter{
+		TaskReliabilityFilter: reliability.TaskReliabilityFilter{
+			StatsFilter: taskstats.StatsFilter{
+				Project: gimlet.GetVars(r)["project_id"],
+				GroupBy: taskstats.GroupByVariant,
+			},
+			Significance: reliability.DefaultSignificance,
+		},
+	}
+
+	if err := h.parseTestReliabilityFilter(r.URL.Query()); err != nil {
+		return errors.Wrap(err, "parsing test reliability parameters")
+	}
+	if err := h.testFilter.ValidateForTestReliability(); err != nil {
+		return errors.Wrap(err, "invalid test reliability parameters")
+	}
+	return nil
+}
+
+func (h *testReliabilityHandler) Run(ctx context.Context) gimlet.Responder {
+	flags, err := evergreen.GetServiceFlags(ctx)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "retrieving service flags"))
+	}
+	if flags.TaskReliabilityDisabled {
+		return gimlet.MakeJSONErrorResponder(gimlet.ErrorResponse{
+			Message:    "endpoint is disabled",
+			StatusCode: http.StatusServiceUnavailable,
+		})
+	}
+
+	testReliabilityResult, err := data.GetTestReliabilityScores(h.testFilter)
+	if err != nil {
+		return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "getting test reliability stats"))
+	}
+
+	resp := gimlet.NewResponseBuilder()
+	requestLimit := h.testFilter.Limit
+	if len(testReliabilityResult) == requestLimit {
+		last := testReliabilityResult[len(testReliabilityResult)-1]
+		err = resp.SetPages(&gimlet.ResponsePages{
+			Next: &gimlet.Page{
+				Relation:        "next",
+				LimitQueryParam: "limit",
+				KeyQueryParam:   "start_at",
+				BaseURL:         h.url,
+				Key:             last.StartAtKey(),
+				Limit:           requestLimit,
+			},
+		})
+		if err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrap(err, "paginating response"))
+		}
+	}
+
+	for _, apiTestStats := range testReliabilityResult {
+		if err = resp.AddData(apiTestStats); err != nil {
+			return gimlet.MakeJSONInternalErrorResponder(errors.Wrapf(err, "adding response data for test '%s' in task '%s'", utility.FromStringPtr(apiTestStats.TestName), utility.FromStringPtr(apiTestStats.TaskName)))
+		}
+	}
+
+	return resp
+}
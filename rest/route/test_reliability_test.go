@@ -0,0 +1,111 @@
+package route
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/reliability"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/gimlet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestReliabilityParse(t *testing.T) {
+	for testName, testCase := range map[string]func(t *testing.T, handler *testReliabilityHandler){
+		"Defaults": func(t *testing.T, handler *testReliabilityHandler) {
+			values := url.Values{
+				"tests": []string{"jstests/core/foo.js"},
+			}
+
+			require.NoError(t, handler.parseTestReliabilityFilter(values))
+			assert.Equal(t, values["tests"], handler.testFilter.Tests)
+			assert.Empty(t, handler.testFilter.Tasks)
+			assert.Empty(t, handler.testFilter.BuildVariants)
+			assert.Equal(t, []string{evergreen.RepotrackerVersionRequester}, handler.testFilter.Requesters)
+			assert.Equal(t, taskstats.SortLatestFirst, handler.testFilter.Sort)
+			assert.Equal(t, reliability.DefaultSignificance, handler.testFilter.Significance)
+			assert.Equal(t, 1, handler.testFilter.GroupNumDays)
+			assert.Equal(t, reliabilityAPIMaxNumTasksLimit, handler.testFilter.Limit)
+			assert.Equal(t, truncatedTime(0), handler.testFilter.BeforeDate)
+			assert.Equal(t, truncatedTime(0), handler.testFilter.AfterDate)
+			assert.Nil(t, handler.testFilter.StartAt)
+			assert.Empty(t, handler.testFilter.StartAtTest)
+		},
+		"AllValues": func(t *testing.T, handler *testReliabilityHandler) {
+			values := url.Values{
+				"requesters":     []string{statsAPIRequesterMainline},
+				"after_date":     []string{"2018-07-01"},
+				"before_date":    []string{"2018-07-15"},
+				"tests":          []string{"test1,test2", "test3"},
+				"tasks":          []string{"jsCore"},
+				"variants":       []string{"linux,windows"},
+				"significance":   []string{"0.1"},
+				"group_num_days": []string{"7"},
+				"limit":          []string{"10"},
+				"sort":           []string{statsAPISortEarliest},
+				"start_at":       []string{"2018-07-08|linux|jsCore|test2"},
+			}
+
+			require.NoError(t, handler.parseTestReliabilityFilter(values))
+			assert.Equal(t, []string{"test1", "test2", "test3"}, handler.testFilter.Tests)
+			assert.Equal(t, []string{"jsCore"}, handler.testFilter.Tasks)
+			assert.Equal(t, []string{"linux", "windows"}, handler.testFilter.BuildVariants)
+			assert.Equal(t, time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC), handler.testFilter.AfterDate)
+			assert.Equal(t, time.Date(2018, 7, 15, 0, 0, 0, 0, time.UTC), handler.testFilter.BeforeDate)
+			assert.Equal(t, 0.1, handler.testFilter.Significance)
+			assert.Equal(t, 7, handler.testFilter.GroupNumDays)
+			assert.Equal(t, 10, handler.testFilter.Limit)
+			assert.Equal(t, taskstats.SortEarliestFirst, handler.testFilter.Sort)
+			require.NotNil(t, handler.testFilter.StartAt)
+			assert.Equal(t, time.Date(2018, 7, 8, 0, 0, 0, 0, time.UTC), handler.testFilter.StartAt.Date)
+			assert.Equal(t, "linux", handler.testFilter.StartAt.BuildVariant)
+			assert.Equal(t, "jsCore", handler.testFilter.StartAt.Task)
+			assert.Empty(t, handler.testFilter.StartAt.Distro)
+			assert.Equal(t, "test2", handler.testFilter.StartAtTest)
+		},
+		"FailsWithoutTests": func(t *testing.T, handler *testReliabilityHandler) {
+			assert.Error(t, handler.parseTestReliabilityFilter(url.Values{"tasks": []string{"jsCore"}}))
+		},
+		"FailsWithInvalidStartAt": func(t *testing.T, handler *testReliabilityHandler) {
+			assert.Error(t, handler.parseTestReliabilityFilter(url.Values{
+				"tests":    []string{"test1"},
+				"start_at": []string{"2018-07-08|linux|jsCore"},
+			}))
+		},
+		"FailsWithInvalidSignificance": func(t *testing.T, handler *testReliabilityHandler) {
+			assert.Error(t, handler.parseTestReliabilityFilter(url.Values{
+				"tests":        []string{"test1"},
+				"significance": []string{"2"},
+			}))
+		},
+		"ParseSetsProjectAndValidates": func(t *testing.T, handler *testReliabilityHandler) {
+			request, err := http.NewRequest(http.MethodGet, "https://example.net/rest/v2/projects/project/test_reliability?tests=test1&after_date=2018-07-01&before_date=2018-07-15", bytes.NewReader(nil))
+			require.NoError(t, err)
+			request = gimlet.SetURLVars(request, map[string]string{"project_id": "project"})
+
+			require.NoError(t, handler.Parse(context.Background(), request))
+			assert.Equal(t, "project", handler.testFilter.Project)
+			assert.Equal(t, []string{"test1"}, handler.testFilter.Tests)
+		},
+		"ParseFailsWithAfterDateAfterBeforeDate": func(t *testing.T, handler *testReliabilityHandler) {
+			request, err := http.NewRequest(http.MethodGet, "https://example.net/rest/v2/projects/project/test_reliability?tests=test1&after_date=2018-07-15&before_date=2018-07-01", bytes.NewReader(nil))
+			require.NoError(t, err)
+			request = gimlet.SetURLVars(request, map[string]string{"project_id": "project"})
+
+			assert.Error(t, handler.Parse(context.Background(), request))
+		},
+	} {
+		t.Run(testName, func(t *testing.T) {
+			handler, ok := makeGetProjectTestReliability("https://example.net").(*testReliabilityHandler)
+			require.True(t, ok)
+
+			testCase(t, handler)
+		})
+	}
+}
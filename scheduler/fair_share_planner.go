@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultFairShareUsageWindow is how far back the fair-share planner
+	// looks at projects' host usage if the usage window is not set.
+	defaultFairShareUsageWindow = time.Hour
+	// fairShareMinTaskCost is the least host time that the fair-share planner
+	// charges a project for a task in the queue, so that tasks without any
+	// runtime history still count toward the project's share.
+	fairShareMinTaskCost = time.Minute
+)
+
+// runFairSharePlanner orders the distro's queue so that each project gets a
+// share of the distro's hosts in proportion to its weight. The tasks are first
+// ordered with the tunable planner, which preserves the relative order of each
+// project's own tasks. Then the projects' tasks are interleaved so that
+// projects that have used less host time recently, relative to their weight,
+// go first.
+func runFairSharePlanner(d *distro.Distro, tasks []task.Task, opts TaskPlannerOptions) ([]task.Task, error) {
+	var err error
+
+	tasks, err = PopulateCaches(opts.ID, tasks)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	now := opts.StartedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	window := opts.FairShareUsageWindow
+	if window <= 0 {
+		window = defaultFairShareUsageWindow
+	}
+	usage, err := task.GetProjectHostUsage(d.Id, now.Add(-window), now)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting project host usage for distro '%s'", d.Id)
+	}
+
+	plan := FairShareOrder(PrepareTasksForPlanning(d, tasks).Export(), usage, opts.ProjectWeights)
+	info := GetDistroQueueInfo(d.Id, plan, d.GetTargetTime(), opts)
+	info.SecondaryQueue = opts.IsSecondaryQueue
+	info.PlanCreatedAt = opts.StartedAt
+
+	grip.Debug(message.Fields{
+		"runner":        RunnerName,
+		"message":       "planned fair-share queue",
+		"distro":        d.Id,
+		"instance":      opts.ID,
+		"project_usage": usage,
+		"queue_length":  len(plan),
+	})
+
+	if err = PersistTaskQueue(d.Id, plan, info); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return plan, nil
+}
+
+// FairShareOrder interleaves the tasks by project using weighted fair
+// queueing. Each project is charged for the host time it has already used
+// and for the expected duration of each of its tasks that is placed in the
+// queue. The next task in the queue always comes from the project with the
+// least charged host time relative to its weight, so a project that enqueues
+// many tasks cannot starve other projects. The relative order of each
+// project's tasks is preserved. Projects without a weight have a weight of 1.
+func FairShareOrder(tasks []task.Task, usage map[string]time.Duration, weights map[string]float64) []task.Task {
+	type projectQueue struct {
+		project string
+		tasks   []task.Task
+		charged time.Duration
+		weight  float64
+	}
+
+	queues := []*projectQueue{}
+	queuesByProject := map[string]*projectQueue{}
+	for _, t := range tasks {
+		q, ok := queuesByProject[t.Project]
+		if !ok {
+			weight, ok := weights[t.Project]
+			if !ok || weight <= 0 {
+				weight = 1
+			}
+			q = &projectQueue{
+				project: t.Project,
+				charged: usage[t.Project],
+				weight:  weight,
+			}
+			queuesByProject[t.Project] = q
+			queues = append(queues, q)
+		}
+		q.tasks = append(q.tasks, t)
+	}
+
+	ordered := make([]task.Task, 0, len(tasks))
+	for len(ordered) < len(tasks) {
+		// Ties go to the project whose first task came first in the
+		// original order.
+		var next *projectQueue
+		for _, q := range queues {
+			if len(q.tasks) == 0 {
+				continue
+			}
+			if next == nil || q.charged.Seconds()/q.weight < next.charged.Seconds()/next.weight {
+				next = q
+			}
+		}
+
+		t := next.tasks[0]
+		next.tasks = next.tasks[1:]
+		ordered = append(ordered, t)
+
+		cost := t.FetchExpectedDuration().Average
+		if cost < fairShareMinTaskCost {
+			cost = fairShareMinTaskCost
+		}
+		next.charged += cost
+	}
+
+	return ordered
+}
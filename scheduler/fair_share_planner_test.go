@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFairShareOrder(t *testing.T) {
+	makeTasks := func(project string, num int, duration time.Duration) []task.Task {
+		tasks := make([]task.Task, 0, num)
+		for i := 0; i < num; i++ {
+			tasks = append(tasks, task.Task{
+				Id:      fmt.Sprintf("%s-%d", project, i),
+				Project: project,
+				DurationPrediction: util.CachedDurationValue{
+					Value:       duration,
+					CollectedAt: time.Now(),
+					TTL:         time.Hour,
+				},
+			})
+		}
+		return tasks
+	}
+	countByProject := func(tasks []task.Task) map[string]int {
+		counts := map[string]int{}
+		for _, t := range tasks {
+			counts[t.Project]++
+		}
+		return counts
+	}
+
+	for testName, testCase := range map[string]func(t *testing.T){
+		"EmptyQueue": func(t *testing.T) {
+			assert.Empty(t, FairShareOrder(nil, nil, nil))
+		},
+		"LargeProjectDoesNotStarveOtherProjects": func(t *testing.T) {
+			tasks := makeTasks("big", 5000, 10*time.Minute)
+			tasks = append(tasks, makeTasks("small", 10, 10*time.Minute)...)
+
+			ordered := FairShareOrder(tasks, nil, nil)
+			require.Len(t, ordered, len(tasks))
+			// Without fair-share, the small project's tasks would all be
+			// behind the big project's 5000 tasks. With equal weights, the
+			// projects alternate until the small project runs out of tasks.
+			assert.Equal(t, map[string]int{"big": 10, "small": 10}, countByProject(ordered[:20]))
+		},
+		"ProjectsShareInProportionToWeight": func(t *testing.T) {
+			tasks := makeTasks("p1", 100, 10*time.Minute)
+			tasks = append(tasks, makeTasks("p2", 100, 10*time.Minute)...)
+
+			ordered := FairShareOrder(tasks, nil, map[string]float64{"p1": 3})
+			require.Len(t, ordered, len(tasks))
+			counts := countByProject(ordered[:40])
+			assert.Equal(t, 30, counts["p1"])
+			assert.Equal(t, 10, counts["p2"])
+		},
+		"ProjectsShareByHostTimeRatherThanNumberOfTasks": func(t *testing.T) {
+			tasks := makeTasks("long", 20, time.Hour)
+			tasks = append(tasks, makeTasks("short", 100, 10*time.Minute)...)
+
+			ordered := FairShareOrder(tasks, nil, nil)
+			require.Len(t, ordered, len(tasks))
+			counts := countByProject(ordered[:14])
+			assert.Equal(t, 2, counts["long"])
+			assert.Equal(t, 12, counts["short"])
+		},
+		"RecentUsageDeprioritizesProject": func(t *testing.T) {
+			tasks := makeTasks("busy", 10, 10*time.Minute)
+			tasks = append(tasks, makeTasks("idle", 10, 10*time.Minute)...)
+
+			ordered := FairShareOrder(tasks, map[string]time.Duration{"busy": time.Hour}, nil)
+			require.Len(t, ordered, len(tasks))
+			// The idle project catches up to the busy project's hour of usage
+			// before the busy project gets any more hosts.
+			assert.Equal(t, map[string]int{"idle": 6}, countByProject(ordered[:6]))
+			assert.Equal(t, map[string]int{"busy": 1, "idle": 1}, countByProject(ordered[6:8]))
+		},
+		"TasksWithoutHistoryStillCount": func(t *testing.T) {
+			tasks := makeTasks("p1", 10, 0)
+			tasks = append(tasks, makeTasks("p2", 10, 0)...)
+
+			ordered := FairShareOrder(tasks, nil, nil)
+			require.Len(t, ordered, len(tasks))
+			assert.Equal(t, map[string]int{"p1": 5, "p2": 5}, countByProject(ordered[:10]))
+		},
+		"PreservesOrderWithinProject": func(t *testing.T) {
+			tasks := makeTasks("p1", 10, 10*time.Minute)
+			tasks = append(tasks, makeTasks("p2", 10, 5*time.Minute)...)
+
+			ordered := FairShareOrder(tasks, nil, nil)
+			require.Len(t, ordered, len(tasks))
+			lastIndex := map[string]int{"p1": -1, "p2": -1}
+			for _, tsk := range ordered {
+				var idx int
+				_, err := fmt.Sscanf(tsk.Id[len(tsk.Project)+1:], "%d", &idx)
+				require.NoError(t, err)
+				assert.Equal(t, lastIndex[tsk.Project]+1, idx, "task '%s' is out of order", tsk.Id)
+				lastIndex[tsk.Project] = idx
+			}
+		},
+	} {
+		t.Run(testName, testCase)
+	}
+}
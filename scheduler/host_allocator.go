@@ -2,11 +2,13 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
 )
 
 // HostAllocator is responsible for determining how many new hosts should be
@@ -20,6 +22,15 @@ type HostAllocatorData struct {
 	UsesContainers  bool
 	ContainerPool   *evergreen.ContainerPool
 	DistroQueueInfo model.DistroQueueInfo
+	// DemandForecast is the distro's forecasted demand, which is only used by
+	// the predictive host allocator.
+	DemandForecast *model.DistroDemandForecast
+	// AllocationTime is the time that hosts are being allocated for. If it is
+	// zero, hosts are allocated for the current time.
+	AllocationTime time.Time
+	// RunningTasks are the tasks running on the existing hosts, by ID. If it
+	// is nil, the running tasks are looked up in the database.
+	RunningTasks map[string]task.Task
 }
 
 func GetHostAllocator(name string) HostAllocator {
@@ -28,6 +39,8 @@ func GetHostAllocator(name string) HostAllocator {
 		return DeficitBasedHostAllocator
 	case evergreen.HostAllocatorUtilization:
 		return UtilizationBasedHostAllocator
+	case evergreen.HostAllocatorPredictive:
+		return PredictiveHostAllocator
 	default:
 		return UtilizationBasedHostAllocator
 	}
@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/pkg/errors"
+)
+
+// QueueSnapshot is the state of a distro's queue at a point in time.
+type QueueSnapshot struct {
+	Time time.Time
+	Info model.DistroQueueInfo
+}
+
+// QueueSnapshotsFromHistory converts recorded task queue history into queue
+// snapshots that can be replayed in a host allocator simulation. Each hour of
+// history becomes one snapshot at the peak of that hour's queue.
+func QueueSnapshotsFromHistory(history []model.TaskQueueHistory, maxDurationThreshold time.Duration) []QueueSnapshot {
+	snapshots := make([]QueueSnapshot, 0, len(history))
+	for _, h := range history {
+		snapshots = append(snapshots, QueueSnapshot{
+			Time: h.ID.Hour,
+			Info: model.DistroQueueInfo{
+				Length:               h.MaxLength,
+				ExpectedDuration:     h.MaxExpectedDuration,
+				MaxDurationThreshold: maxDurationThreshold,
+			},
+		})
+	}
+	return snapshots
+}
+
+// HostAllocatorSimulationOptions configure a simulation that compares host
+// allocators on the same recorded queues.
+type HostAllocatorSimulationOptions struct {
+	// Distro is the distro to allocate hosts for.
+	Distro distro.Distro
+	// Allocators are the names of the host allocators to compare.
+	Allocators []string
+	// Snapshots are the queue states to replay, in chronological order.
+	Snapshots []QueueSnapshot
+	// History is the task queue history used to forecast demand for the
+	// predictive host allocator. This should only include history from
+	// before the replayed snapshots.
+	History []model.TaskQueueHistory
+	// DailyTaskDurations are the distro's daily task runtimes from the task
+	// stats used to forecast demand for the predictive host allocator. Like
+	// the history, this should only include days before the replayed
+	// snapshots.
+	DailyTaskDurations []taskstats.DistroDailyTaskDuration
+	// HostStartupTime is how long it takes a requested host to be ready to
+	// run tasks.
+	HostStartupTime time.Duration
+}
+
+// Validate checks that the simulation options are valid.
+func (o *HostAllocatorSimulationOptions) Validate() error {
+	if len(o.Allocators) == 0 {
+		return errors.New("must specify at least one host allocator")
+	}
+	for _, name := range o.Allocators {
+		if name != evergreen.HostAllocatorDeficit && name != evergreen.HostAllocatorUtilization && name != evergreen.HostAllocatorPredictive {
+			return errors.Errorf("unrecognized host allocator '%s'", name)
+		}
+	}
+	if len(o.Snapshots) < 2 {
+		return errors.New("must have at least two queue snapshots to replay")
+	}
+	for i := 1; i < len(o.Snapshots); i++ {
+		if !o.Snapshots[i].Time.After(o.Snapshots[i-1].Time) {
+			return errors.New("queue snapshots must be in chronological order")
+		}
+	}
+	if o.HostStartupTime < 0 {
+		return errors.New("host startup time cannot be negative")
+	}
+	return nil
+}
+
+// HostAllocatorSimulationResult summarizes how a host allocator performed in
+// a simulation.
+type HostAllocatorSimulationResult struct {
+	Allocator string
+	// HostHours is the total time that hosts were up, including the time
+	// spent starting up.
+	HostHours float64
+	// ShortfallHostHours is the total demand that could not be served because
+	// not enough hosts were ready.
+	ShortfallHostHours float64
+	// HostsRequested is the total number of hosts that the allocator
+	// requested.
+	HostsRequested int
+	// PeakHosts is the most hosts that were up at once.
+	PeakHosts int
+}
+
+// simulatedHost is a host in a host allocator simulation.
+type simulatedHost struct {
+	id       string
+	readyAt  time.Time
+	lastBusy time.Time
+}
+
+// SimulateHostAllocators replays the queue snapshots against each of the host
+// allocators and reports how well each one provisioned hosts for the queue.
+// In the simulation, the demand at each snapshot is the number of hosts
+// needed to run the queue within its max duration threshold, requested hosts
+// become ready after the host startup time, and hosts are terminated once
+// they've been idle for the distro's acceptable idle time. Like the idle host
+// job, hosts are not terminated below the predictive host allocator's
+// forecasted hosts.
+func SimulateHostAllocators(ctx context.Context, opts HostAllocatorSimulationOptions) ([]HostAllocatorSimulationResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid simulation options")
+	}
+
+	forecast := model.NewDistroDemandForecast(opts.Distro.Id, opts.History)
+	forecast.AddDailyTaskDurations(opts.DailyTaskDurations)
+
+	results := make([]HostAllocatorSimulationResult, 0, len(opts.Allocators))
+	for _, name := range opts.Allocators {
+		res, err := simulateHostAllocator(ctx, opts, name, &forecast)
+		if err != nil {
+			return nil, errors.Wrapf(err, "simulating host allocator '%s'", name)
+		}
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+func simulateHostAllocator(ctx context.Context, opts HostAllocatorSimulationOptions, name string, forecast *model.DistroDemandForecast) (*HostAllocatorSimulationResult, error) {
+	allocator := GetHostAllocator(name)
+	settings := opts.Distro.HostAllocatorSettings
+	res := &HostAllocatorSimulationResult{Allocator: name}
+
+	var hosts []simulatedHost
+	var numHostsCreated int
+	addHost := func(readyAt time.Time) {
+		numHostsCreated++
+		hosts = append(hosts, simulatedHost{
+			id:       fmt.Sprintf("simulated-host-%d", numHostsCreated),
+			readyAt:  readyAt,
+			lastBusy: readyAt,
+		})
+	}
+	for i := 0; i < settings.MinimumHosts; i++ {
+		addHost(opts.Snapshots[0].Time)
+	}
+
+	for i, snapshot := range opts.Snapshots {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		now := snapshot.Time
+		var step time.Duration
+		if i+1 < len(opts.Snapshots) {
+			step = opts.Snapshots[i+1].Time.Sub(now)
+		}
+
+		maxDurationThreshold := snapshot.Info.MaxDurationThreshold
+		if maxDurationThreshold <= 0 {
+			maxDurationThreshold = evergreen.MaxDurationPerDistroHost
+		}
+		numHostsNeeded := int(math.Ceil(float64(snapshot.Info.ExpectedDuration) / float64(maxDurationThreshold)))
+
+		// Ready hosts serve the demand. Any other ready hosts are idle and
+		// are terminated once they've been idle for too long, unless they're
+		// needed to meet the minimum hosts.
+		minimumHosts := settings.MinimumHosts
+		if name == evergreen.HostAllocatorPredictive {
+			if numForecastedHosts := NumForecastedHosts(opts.Distro, *forecast, now, maxDurationThreshold); numForecastedHosts > minimumHosts {
+				minimumHosts = numForecastedHosts
+			}
+		}
+		numBusy := 0
+		remaining := hosts[:0]
+		for _, h := range hosts {
+			if h.readyAt.After(now) {
+				remaining = append(remaining, h)
+				continue
+			}
+			if numBusy < numHostsNeeded {
+				numBusy++
+				h.lastBusy = now
+				remaining = append(remaining, h)
+				continue
+			}
+			if len(remaining) >= minimumHosts && now.Sub(h.lastBusy) > settings.AcceptableHostIdleTime {
+				continue
+			}
+			remaining = append(remaining, h)
+		}
+		hosts = remaining
+		if numBusy < numHostsNeeded {
+			res.ShortfallHostHours += float64(numHostsNeeded-numBusy) * step.Hours()
+		}
+
+		existingHosts := make([]host.Host, 0, len(hosts))
+		for _, h := range hosts {
+			existingHosts = append(existingHosts, host.Host{Id: h.id, Distro: opts.Distro, Status: evergreen.HostRunning})
+		}
+		info := snapshot.Info
+		info.MaxDurationThreshold = maxDurationThreshold
+		info.TaskGroupInfos = append([]model.TaskGroupInfo{}, info.TaskGroupInfos...)
+		if len(info.TaskGroupInfos) == 0 && info.Length > 0 {
+			info.TaskGroupInfos = []model.TaskGroupInfo{{
+				Count:            info.Length,
+				ExpectedDuration: info.ExpectedDuration,
+			}}
+		}
+
+		numNewHosts, _, err := allocator(ctx, &HostAllocatorData{
+			Distro:          opts.Distro,
+			ExistingHosts:   existingHosts,
+			DistroQueueInfo: info,
+			DemandForecast:  forecast,
+			AllocationTime:  now,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "allocating hosts at %s", now)
+		}
+		for j := 0; j < numNewHosts; j++ {
+			addHost(now.Add(opts.HostStartupTime))
+			res.HostsRequested++
+		}
+
+		res.HostHours += float64(len(hosts)) * step.Hours()
+		if len(hosts) > res.PeakHosts {
+			res.PeakHosts = len(hosts)
+		}
+	}
+
+	return res, nil
+}
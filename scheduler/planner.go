@@ -92,10 +92,11 @@ func (cache UnitCache) Export() TaskPlan {
 // and their dependencies, or even all tasks of a version. All tasks
 // in a Unit must be unique with regards to their ID.
 type Unit struct {
-	tasks       map[string]task.Task
-	cachedValue int64
-	id          string
-	distro      *distro.Distro
+	tasks           map[string]task.Task
+	cachedValue     int64
+	cachedBreakdown task.SortingValueBreakdown
+	id              string
+	distro          *distro.Distro
 }
 
 // MakeuUnit constructs a new unit, caching a reference to the distro
@@ -196,7 +197,13 @@ type unitInfo struct {
 }
 
 func (u *unitInfo) value() int64 {
-	var value int64
+	return u.valueBreakdown().TotalValue
+}
+
+// valueBreakdown computes the unit's value, broken down by the factors that
+// contribute to it.
+func (u *unitInfo) valueBreakdown() task.SortingValueBreakdown {
+	var breakdown task.SortingValueBreakdown
 
 	length := int64(len(u.TaskIDs))
 	priority := 1 + (u.TotalPriority / length)
@@ -215,37 +222,34 @@ func (u *unitInfo) value() int64 {
 
 	if u.ContainsInPatch {
 		// give patches a bump, over non-patches.
-		value += priority * u.Settings.GetPatchFactor()
+		breakdown.PatchValue = priority * u.Settings.GetPatchFactor()
 		// patches that have spent more time in the queue
 		// should get worked on first (because people are
 		// waiting on the results), and because FIFO feels
 		// fair in this context.
-		value += priority * u.Settings.GetPatchTimeInQueueFactor() * int64(math.Floor(u.TimeInQueue.Minutes()/float64(length)))
+		breakdown.PatchTimeInQueueValue = priority * u.Settings.GetPatchTimeInQueueFactor() * int64(math.Floor(u.TimeInQueue.Minutes()/float64(length)))
 	} else if u.ContainsInCommitQueue {
 		// give commit queue patches a boost over everything else
 		priority += 200
-		value += priority * u.Settings.GetCommitQueueFactor()
+		breakdown.CommitQueueValue = priority * u.Settings.GetCommitQueueFactor()
 	} else {
 		// for mainline builds that are more recent, give them a bit
 		// of a bump, to avoid running older builds first.
 		avgLifeTime := u.TimeInQueue / time.Duration(length)
 
-		var mainlinePriority int64
 		if avgLifeTime < time.Duration(7*24)*time.Hour {
-			mainlinePriority += u.Settings.GetMainlineTimeInQueueFactor() * int64((7*24*time.Hour - avgLifeTime).Hours())
+			breakdown.MainlineTimeInQueueValue = priority * u.Settings.GetMainlineTimeInQueueFactor() * int64((7*24*time.Hour - avgLifeTime).Hours())
 		}
 		if u.ContainsStepbackTask {
-			mainlinePriority += u.Settings.GetStepbackTaskFactor()
+			breakdown.StepbackValue = priority * u.Settings.GetStepbackTaskFactor()
 		}
-
-		value += priority * mainlinePriority
 	}
 
 	// Start with the number of tasks so that units with more
 	// tasks get sorted above one-offs, and then add the priority
 	// setting as a base.
-	value += length
-	value += priority
+	breakdown.NumTasks = length
+	breakdown.Priority = priority
 
 	// The remaining values are normalized per tasks, to avoid
 	// situations where larger units are always prioritized above
@@ -258,15 +262,25 @@ func (u *unitInfo) value() int64 {
 	// Increase the value for the number of dependencies, so that
 	// tasks (and units) which block other tasks run before tasks
 	// that don't block other tasks.
-	value += priority * (u.NumDeps / length)
+	breakdown.DependentsValue = priority * (u.NumDeps / length)
 
 	// Increase the value for tasks with longer runtimes, given
 	// that most of our workloads have different runtimes, and we
 	// don't want to have longer makespans if longer running tasks
 	// have to execute after shorter running tasks.
-	value += priority * u.Settings.GetExpectedRuntimeFactor() * int64(math.Floor(u.ExpectedRuntime.Minutes()/float64(length)))
-
-	return value
+	breakdown.ExpectedRuntimeValue = priority * u.Settings.GetExpectedRuntimeFactor() * int64(math.Floor(u.ExpectedRuntime.Minutes()/float64(length)))
+
+	breakdown.TotalValue = breakdown.PatchValue +
+		breakdown.PatchTimeInQueueValue +
+		breakdown.CommitQueueValue +
+		breakdown.MainlineTimeInQueueValue +
+		breakdown.StepbackValue +
+		breakdown.NumTasks +
+		breakdown.Priority +
+		breakdown.DependentsValue +
+		breakdown.ExpectedRuntimeValue
+
+	return breakdown
 }
 
 func (unit *Unit) info() unitInfo {
@@ -312,11 +326,19 @@ func (unit *Unit) RankValue() int64 {
 	}
 
 	info := unit.info()
-	unit.cachedValue = info.value()
+	unit.cachedBreakdown = info.valueBreakdown()
+	unit.cachedValue = unit.cachedBreakdown.TotalValue
 
 	return unit.cachedValue
 }
 
+// SortingValueBreakdown returns the breakdown of the unit's rank value by
+// factor.
+func (unit *Unit) SortingValueBreakdown() task.SortingValueBreakdown {
+	_ = unit.RankValue()
+	return unit.cachedBreakdown
+}
+
 // StringSet provides simple tools for managing sets of strings.
 type StringSet map[string]struct{}
 
@@ -422,6 +444,7 @@ func (tpl TaskPlan) Export() []task.Task {
 	output := []task.Task{}
 	seen := StringSet{}
 	for _, unit := range tpl {
+		breakdown := unit.SortingValueBreakdown()
 		tasks := unit.Export()
 		sort.Sort(tasks)
 		for _, t := range tasks {
@@ -429,6 +452,7 @@ func (tpl TaskPlan) Export() []task.Task {
 				continue
 			}
 
+			t.SortingValueBreakdown = &breakdown
 			output = append(output, t)
 		}
 	}
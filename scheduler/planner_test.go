@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/task"
 	"github.com/stretchr/testify/assert"
@@ -433,3 +435,49 @@ func TestPlanner(t *testing.T) {
 		})
 	})
 }
+
+func TestPrioritizeTasksSortingValueBreakdown(t *testing.T) {
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.TaskQueuesCollection, model.VersionCollection))
+	}()
+
+	tasks := []task.Task{
+		{Id: "t1", DistroId: "d", Version: "v", Priority: 10},
+		{Id: "t2", DistroId: "d", Version: "v"},
+	}
+	loadBreakdowns := func(t *testing.T, d *distro.Distro) map[string]*task.SortingValueBreakdown {
+		_, err := PrioritizeTasks(d, tasks, TaskPlannerOptions{ID: "breakdown"})
+		require.NoError(t, err)
+		queue, err := model.FindDistroTaskQueue(d.Id)
+		require.NoError(t, err)
+		require.Len(t, queue.Queue, len(tasks))
+
+		breakdowns := map[string]*task.SortingValueBreakdown{}
+		for _, item := range queue.Queue {
+			breakdowns[item.Id] = item.SortingValueBreakdown
+		}
+		return breakdowns
+	}
+
+	for _, version := range []string{evergreen.PlannerVersionTunable, evergreen.PlannerVersionFairShare} {
+		t.Run(version, func(t *testing.T) {
+			require.NoError(t, db.ClearCollections(model.TaskQueuesCollection, model.VersionCollection))
+			require.NoError(t, (&model.Version{Id: "v"}).Insert())
+
+			d := &distro.Distro{Id: "d", PlannerSettings: distro.PlannerSettings{Version: version}}
+			for id, breakdown := range loadBreakdowns(t, d) {
+				require.NotNil(t, breakdown, "task '%s'", id)
+				assert.NotZero(t, breakdown.TotalValue, "task '%s'", id)
+			}
+		})
+	}
+	t.Run("LegacyPlannerHasNoBreakdown", func(t *testing.T) {
+		require.NoError(t, db.ClearCollections(model.TaskQueuesCollection, model.VersionCollection))
+		require.NoError(t, (&model.Version{Id: "v"}).Insert())
+
+		d := &distro.Distro{Id: "d", PlannerSettings: distro.PlannerSettings{Version: evergreen.PlannerVersionLegacy}}
+		for id, breakdown := range loadBreakdowns(t, d) {
+			assert.Nil(t, breakdown, "task '%s'", id)
+		}
+	})
+}
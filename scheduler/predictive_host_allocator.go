@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+// predictiveLookahead is how far ahead the predictive host allocator looks
+// for forecasted demand. This should be long enough for newly-requested hosts
+// to be ready by the time the forecasted tasks are in the queue.
+const predictiveLookahead = 30 * time.Minute
+
+// PredictiveHostAllocator allocates hosts for the current queue the same way
+// as the utilization-based host allocator, but also pre-spins hosts when the
+// distro's demand forecast predicts a peak in the near future. It never
+// requests more than the distro's maximum hosts.
+func PredictiveHostAllocator(ctx context.Context, hostAllocatorData *HostAllocatorData) (int, int, error) {
+	numNewHosts, numFreeHosts, err := UtilizationBasedHostAllocator(ctx, hostAllocatorData)
+	if err != nil {
+		return numNewHosts, numFreeHosts, err
+	}
+
+	d := hostAllocatorData.Distro
+	forecast := hostAllocatorData.DemandForecast
+	// Container pools are sized by their parent distro, so they only
+	// allocate for the current queue.
+	if forecast == nil || d.Disabled || !d.IsEphemeral() || hostAllocatorData.ContainerPool != nil {
+		return numNewHosts, numFreeHosts, nil
+	}
+
+	now := hostAllocatorData.AllocationTime
+	if now.IsZero() {
+		now = time.Now()
+	}
+	numForecastedHosts := NumForecastedHosts(d, *forecast, now, hostAllocatorData.DistroQueueInfo.MaxDurationThreshold)
+
+	numExistingHosts := len(hostAllocatorData.ExistingHosts)
+	numPreSpunHosts := numForecastedHosts - numExistingHosts - numNewHosts
+	if numPreSpunHosts <= 0 {
+		return numNewHosts, numFreeHosts, nil
+	}
+
+	grip.Info(message.Fields{
+		"runner":                     RunnerName,
+		"message":                    "requesting additional hosts for forecasted demand",
+		"distro":                     d.Id,
+		"num_forecasted_hosts":       numForecastedHosts,
+		"num_existing_hosts":         numExistingHosts,
+		"num_new_hosts_required":     numNewHosts,
+		"num_pre_spun_hosts":         numPreSpunHosts,
+		"total_new_hosts_to_request": numNewHosts + numPreSpunHosts,
+	})
+
+	return numNewHosts + numPreSpunHosts, numFreeHosts, nil
+}
+
+// NumForecastedHosts returns the number of hosts that the distro is forecasted
+// to need within the predictive host allocator's lookahead window, which is
+// capped at the distro's maximum hosts. This is the larger of the hosts needed
+// to run the forecasted peak queue within the max duration threshold and the
+// hosts forecasted to be busy from the distro's daily task stats. If the max
+// duration threshold is not set, it defaults to the max duration per distro
+// host.
+func NumForecastedHosts(d distro.Distro, forecast model.DistroDemandForecast, now time.Time, maxDurationThreshold time.Duration) int {
+	if maxDurationThreshold <= 0 {
+		maxDurationThreshold = evergreen.MaxDurationPerDistroHost
+	}
+
+	forecastedDuration := forecast.PeakExpectedDuration(now, predictiveLookahead)
+	numForecastedHosts := int(math.Ceil(float64(forecastedDuration) / float64(maxDurationThreshold)))
+	if numBusyHosts := forecast.PeakBusyHosts(now, predictiveLookahead); numBusyHosts > numForecastedHosts {
+		numForecastedHosts = numBusyHosts
+	}
+	if numForecastedHosts > d.HostAllocatorSettings.MaximumHosts {
+		numForecastedHosts = d.HostAllocatorSettings.MaximumHosts
+	}
+	return numForecastedHosts
+}
+
+// GetHostAllocatorName returns the name of the host allocator to use for the
+// distro. The global scheduler setting is the default for all distros, and a
+// distro can only override it to opt into the predictive host allocator.
+func GetHostAllocatorName(schedulerConfig evergreen.SchedulerConfig, d distro.Distro) string {
+	if d.HostAllocatorSettings.Version == evergreen.HostAllocatorPredictive {
+		return evergreen.HostAllocatorPredictive
+	}
+	if schedulerConfig.HostAllocator == "" {
+		return evergreen.HostAllocatorUtilization
+	}
+	return schedulerConfig.HostAllocator
+}
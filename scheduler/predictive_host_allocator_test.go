@@ -0,0 +1,253 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nightlyPeak is a Sunday at 02:00 UTC, which is when the test distro has a
+// recurring peak in its queue.
+var nightlyPeak = time.Date(2023, time.May, 7, 2, 0, 0, 0, time.UTC)
+
+func makePredictiveTestDistro() distro.Distro {
+	return distro.Distro{
+		Id:       "d1",
+		Provider: evergreen.ProviderNameEc2Fleet,
+		HostAllocatorSettings: distro.HostAllocatorSettings{
+			Version:                evergreen.HostAllocatorPredictive,
+			MinimumHosts:           0,
+			MaximumHosts:           20,
+			AcceptableHostIdleTime: 10 * time.Minute,
+		},
+	}
+}
+
+// makeNightlyPeakHistory creates task queue history in which the queue peaks
+// during the same hour every night for the given number of weeks before the
+// nightly peak.
+func makeNightlyPeakHistory(numWeeks int, peakDuration time.Duration) []model.TaskQueueHistory {
+	var history []model.TaskQueueHistory
+	for week := numWeeks; week > 0; week-- {
+		weekStart := nightlyPeak.Add(-time.Duration(week) * 7 * 24 * time.Hour)
+		for hour := -2; hour <= 2; hour++ {
+			var maxLength int
+			var maxDuration time.Duration
+			if hour == 0 {
+				maxLength = 20
+				maxDuration = peakDuration
+			}
+			history = append(history, model.TaskQueueHistory{
+				ID: model.TaskQueueHistoryID{
+					Distro: "d1",
+					Hour:   weekStart.Add(time.Duration(hour) * time.Hour),
+				},
+				NumSnapshots:        6,
+				MaxLength:           maxLength,
+				MaxExpectedDuration: maxDuration,
+			})
+		}
+	}
+	return history
+}
+
+func TestPredictiveHostAllocator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	forecast := model.NewDistroDemandForecast("d1", makeNightlyPeakHistory(2, 5*time.Hour))
+
+	t.Run("PreSpinsHostsBeforeForecastedPeak", func(t *testing.T) {
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         makePredictiveTestDistro(),
+			DemandForecast: &forecast,
+			AllocationTime: nightlyPeak.Add(-20 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 10, numNewHosts)
+	})
+	t.Run("DoesNotRequestHostsOutsideForecastedPeak", func(t *testing.T) {
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         makePredictiveTestDistro(),
+			DemandForecast: &forecast,
+			AllocationTime: nightlyPeak.Add(-2 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.Zero(t, numNewHosts)
+	})
+	t.Run("CountsExistingHostsTowardForecastedPeak", func(t *testing.T) {
+		var existingHosts []host.Host
+		for i := 0; i < 4; i++ {
+			existingHosts = append(existingHosts, host.Host{Id: fmt.Sprintf("h%d", i)})
+		}
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         makePredictiveTestDistro(),
+			ExistingHosts:  existingHosts,
+			DemandForecast: &forecast,
+			AllocationTime: nightlyPeak.Add(-20 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 6, numNewHosts)
+	})
+	t.Run("RespectsMaximumHosts", func(t *testing.T) {
+		bigForecast := model.NewDistroDemandForecast("d1", makeNightlyPeakHistory(2, 100*time.Hour))
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         makePredictiveTestDistro(),
+			DemandForecast: &bigForecast,
+			AllocationTime: nightlyPeak.Add(-20 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 20, numNewHosts)
+	})
+	t.Run("RespectsMinimumHostsWithoutForecast", func(t *testing.T) {
+		d := makePredictiveTestDistro()
+		d.HostAllocatorSettings.MinimumHosts = 3
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         d,
+			AllocationTime: nightlyPeak.Add(-20 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, numNewHosts)
+	})
+	t.Run("OnlyMeetsMinimumHostsForDisabledDistro", func(t *testing.T) {
+		d := makePredictiveTestDistro()
+		d.Disabled = true
+		numNewHosts, _, err := PredictiveHostAllocator(ctx, &HostAllocatorData{
+			Distro:         d,
+			DemandForecast: &forecast,
+			AllocationTime: nightlyPeak.Add(-20 * time.Minute),
+		})
+		require.NoError(t, err)
+		assert.Zero(t, numNewHosts)
+	})
+}
+
+func TestNumForecastedHosts(t *testing.T) {
+	d := makePredictiveTestDistro()
+	forecast := model.NewDistroDemandForecast("d1", makeNightlyPeakHistory(2, 5*time.Hour))
+
+	t.Run("UsesMaxDurationThreshold", func(t *testing.T) {
+		assert.Equal(t, 10, NumForecastedHosts(d, forecast, nightlyPeak.Add(-20*time.Minute), 0), "should default to max duration per distro host")
+		assert.Equal(t, 5, NumForecastedHosts(d, forecast, nightlyPeak.Add(-20*time.Minute), time.Hour))
+	})
+	t.Run("UsesBusyHostsFromTaskStats", func(t *testing.T) {
+		statsForecast := forecast
+		var durations []taskstats.DistroDailyTaskDuration
+		for week := 1; week <= 2; week++ {
+			durations = append(durations, taskstats.DistroDailyTaskDuration{
+				Date:              nightlyPeak.Truncate(24*time.Hour).AddDate(0, 0, -7*week),
+				TotalDurationSecs: (72 * time.Hour).Seconds(),
+			})
+		}
+		statsForecast.AddDailyTaskDurations(durations)
+
+		assert.Equal(t, 3, NumForecastedHosts(d, statsForecast, nightlyPeak.Add(6*time.Hour), 0), "should keep hosts for the day's task runtime outside of queue peaks")
+		assert.Equal(t, 10, NumForecastedHosts(d, statsForecast, nightlyPeak.Add(-20*time.Minute), 0), "queue peak should take precedence when it needs more hosts")
+		assert.Zero(t, NumForecastedHosts(d, statsForecast, nightlyPeak.Add(24*time.Hour), 0), "should not forecast busy hosts on days without task stats")
+	})
+	t.Run("CapsAtMaximumHosts", func(t *testing.T) {
+		bigForecast := model.NewDistroDemandForecast("d1", makeNightlyPeakHistory(2, 100*time.Hour))
+		assert.Equal(t, d.HostAllocatorSettings.MaximumHosts, NumForecastedHosts(d, bigForecast, nightlyPeak, 0))
+	})
+}
+
+func TestGetHostAllocatorName(t *testing.T) {
+	schedulerConfig := evergreen.SchedulerConfig{HostAllocator: evergreen.HostAllocatorUtilization}
+	d := makePredictiveTestDistro()
+	assert.Equal(t, evergreen.HostAllocatorPredictive, GetHostAllocatorName(schedulerConfig, d), "distro should be able to opt into predictive host allocator")
+
+	d.HostAllocatorSettings.Version = evergreen.HostAllocatorDeficit
+	assert.Equal(t, evergreen.HostAllocatorUtilization, GetHostAllocatorName(schedulerConfig, d), "global host allocator should be the default")
+
+	d.HostAllocatorSettings.Version = ""
+	assert.Equal(t, evergreen.HostAllocatorUtilization, GetHostAllocatorName(schedulerConfig, d))
+	assert.Equal(t, evergreen.HostAllocatorPredictive, GetHostAllocatorName(evergreen.SchedulerConfig{HostAllocator: evergreen.HostAllocatorPredictive}, d))
+	assert.Equal(t, evergreen.HostAllocatorUtilization, GetHostAllocatorName(evergreen.SchedulerConfig{}, d))
+}
+
+func TestSimulateHostAllocators(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Replay the night of the peak in 10 minute increments.
+	var snapshots []QueueSnapshot
+	for ts := nightlyPeak.Add(-2 * time.Hour); ts.Before(nightlyPeak.Add(3 * time.Hour)); ts = ts.Add(10 * time.Minute) {
+		var info model.DistroQueueInfo
+		if !ts.Before(nightlyPeak) && ts.Before(nightlyPeak.Add(time.Hour)) {
+			info = model.DistroQueueInfo{
+				Length:           20,
+				ExpectedDuration: 5 * time.Hour,
+			}
+		}
+		snapshots = append(snapshots, QueueSnapshot{Time: ts, Info: info})
+	}
+
+	opts := HostAllocatorSimulationOptions{
+		Distro:          makePredictiveTestDistro(),
+		Allocators:      []string{evergreen.HostAllocatorUtilization, evergreen.HostAllocatorPredictive},
+		Snapshots:       snapshots,
+		History:         makeNightlyPeakHistory(2, 5*time.Hour),
+		HostStartupTime: 20 * time.Minute,
+	}
+
+	t.Run("PredictiveAllocatorAnticipatesPeak", func(t *testing.T) {
+		results, err := SimulateHostAllocators(ctx, opts)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		utilization, predictive := results[0], results[1]
+		assert.Equal(t, evergreen.HostAllocatorUtilization, utilization.Allocator)
+		assert.Equal(t, evergreen.HostAllocatorPredictive, predictive.Allocator)
+
+		assert.NotZero(t, utilization.ShortfallHostHours, "reactive allocator should fall behind while hosts start up")
+		assert.Zero(t, predictive.ShortfallHostHours, "predictive allocator should have hosts ready for the peak")
+		assert.True(t, predictive.HostHours >= utilization.HostHours, "pre-spinning hosts should not be cheaper than allocating reactively")
+
+		for _, res := range results {
+			assert.Equal(t, 10, res.PeakHosts)
+			assert.True(t, res.PeakHosts <= opts.Distro.HostAllocatorSettings.MaximumHosts)
+		}
+	})
+	t.Run("MatchesReactiveAllocatorWithoutHistory", func(t *testing.T) {
+		noHistoryOpts := opts
+		noHistoryOpts.History = nil
+		results, err := SimulateHostAllocators(ctx, noHistoryOpts)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, results[0].HostHours, results[1].HostHours)
+		assert.Equal(t, results[0].ShortfallHostHours, results[1].ShortfallHostHours)
+	})
+	t.Run("ReplaysRecordedHistory", func(t *testing.T) {
+		recorded := makeNightlyPeakHistory(3, 5*time.Hour)
+		historyOpts := opts
+		historyOpts.History = recorded[:10]
+		historyOpts.Snapshots = QueueSnapshotsFromHistory(recorded[10:], evergreen.MaxDurationPerDistroHost)
+		results, err := SimulateHostAllocators(ctx, historyOpts)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.True(t, results[1].ShortfallHostHours <= results[0].ShortfallHostHours)
+		for _, res := range results {
+			assert.NotZero(t, res.HostsRequested)
+		}
+	})
+	t.Run("FailsWithInvalidOptions", func(t *testing.T) {
+		invalidOpts := opts
+		invalidOpts.Allocators = []string{"nonexistent"}
+		_, err := SimulateHostAllocators(ctx, invalidOpts)
+		assert.Error(t, err)
+
+		invalidOpts = opts
+		invalidOpts.Snapshots = []QueueSnapshot{snapshots[1], snapshots[0]}
+		_, err = SimulateHostAllocators(ctx, invalidOpts)
+		assert.Error(t, err)
+	})
+}
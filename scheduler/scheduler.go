@@ -19,6 +19,12 @@ type TaskPlannerOptions struct {
 	IsSecondaryQueue     bool
 	IncludesDependencies bool
 	StartedAt            time.Time
+	// ProjectWeights are the relative shares of the distro's hosts that
+	// projects get with the fair-share planner.
+	ProjectWeights map[string]float64
+	// FairShareUsageWindow is how far back the fair-share planner looks at
+	// projects' host usage.
+	FairShareUsageWindow time.Duration
 }
 
 type TaskPlanner func(*distro.Distro, []task.Task, TaskPlannerOptions) ([]task.Task, error)
@@ -29,6 +35,8 @@ func PrioritizeTasks(d *distro.Distro, tasks []task.Task, opts TaskPlannerOption
 	switch d.PlannerSettings.Version {
 	case evergreen.PlannerVersionTunable:
 		return runTunablePlanner(d, tasks, opts)
+	case evergreen.PlannerVersionFairShare:
+		return runFairSharePlanner(d, tasks, opts)
 	default:
 		return runLegacyPlanner(d, tasks, opts)
 	}
@@ -289,6 +297,36 @@ func SpawnHosts(ctx context.Context, d distro.Distro, newHostsNeeded int, pool *
 	return hostsSpawned, nil
 }
 
+// SpawnWarmPoolHosts inserts intent documents for new hosts in the distro's
+// warm pool. Container distros don't have warm pools.
+func SpawnWarmPoolHosts(ctx context.Context, d distro.Distro, numHosts int) ([]host.Host, error) {
+	if numHosts <= 0 {
+		return []host.Host{}, nil
+	}
+
+	hostsSpawned := make([]host.Host, 0, numHosts)
+	for i := 0; i < numHosts; i++ {
+		intent := host.NewIntent(host.CreateOptions{
+			Distro:     d,
+			UserName:   evergreen.User,
+			InWarmPool: true,
+		})
+		hostsSpawned = append(hostsSpawned, *intent)
+	}
+
+	if err := host.InsertMany(ctx, hostsSpawned); err != nil {
+		return nil, errors.Wrap(err, "inserting warm pool intent host documents")
+	}
+
+	grip.Info(message.Fields{
+		"runner":    RunnerName,
+		"distro":    d.Id,
+		"operation": "spawning warm pool instances",
+		"num_hosts": len(hostsSpawned),
+	})
+	return hostsSpawned, nil
+}
+
 func getCreateOptionsFromDistro(d distro.Distro) (*host.CreateOptions, error) {
 	dockerOptions := &host.DockerOptions{}
 	if err := dockerOptions.FromDistroSettings(d, ""); err != nil {
@@ -0,0 +1,594 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/util"
+	"github.com/evergreen-ci/utility"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSchedulerSimulationStep        = time.Minute
+	defaultSchedulerSimulationMaxDuration = 24 * time.Hour
+	// defaultSimulatedTaskDuration is how long a task without an expected
+	// duration runs for in the simulation.
+	defaultSimulatedTaskDuration = 10 * time.Minute
+	// simulatedDurationTTL is how long the simulation's expected durations
+	// are cached for, so that the planner and host allocators never refresh
+	// them from the database.
+	simulatedDurationTTL = 365 * 24 * time.Hour
+)
+
+// SchedulerSnapshot is the state of the scheduler's inputs at a point in time,
+// which can be replayed in a scheduler simulation.
+type SchedulerSnapshot struct {
+	// CapturedAt is when the snapshot was taken.
+	CapturedAt time.Time `json:"captured_at"`
+	// SchedulerConfig is the scheduler's admin settings, which provide the
+	// defaults for the distros' settings.
+	SchedulerConfig evergreen.SchedulerConfig `json:"scheduler_config"`
+	Distros         []distro.Distro           `json:"distros"`
+	// Hosts are the distros' hosts that are up.
+	Hosts []host.Host `json:"hosts"`
+	// Tasks are the distros' tasks that are waiting to run or running, along
+	// with the tasks that they depend on.
+	Tasks []task.Task `json:"tasks"`
+}
+
+// SchedulerSimulationOptions configure a simulation of the scheduler over a
+// snapshot.
+type SchedulerSimulationOptions struct {
+	Snapshot SchedulerSnapshot
+	// Step is how often the scheduler runs in the simulation. Defaults to one
+	// minute.
+	Step time.Duration
+	// HostStartupTime is how long it takes a requested host to be ready to
+	// run tasks. Defaults to roughly how long a new host takes to start.
+	HostStartupTime time.Duration
+	// MaxDuration is the longest amount of time to simulate. The simulation
+	// ends early once every task has finished. Defaults to one day.
+	MaxDuration time.Duration
+}
+
+// Validate checks that the simulation options are valid and sets defaults.
+func (o *SchedulerSimulationOptions) Validate() error {
+	if o.Snapshot.CapturedAt.IsZero() {
+		return errors.New("snapshot must have a capture time")
+	}
+	if len(o.Snapshot.Distros) == 0 {
+		return errors.New("snapshot must have at least one distro")
+	}
+	if o.Step < 0 || o.HostStartupTime < 0 || o.MaxDuration < 0 {
+		return errors.New("durations cannot be negative")
+	}
+	if o.Step == 0 {
+		o.Step = defaultSchedulerSimulationStep
+	}
+	if o.HostStartupTime == 0 {
+		o.HostStartupTime = model.EstimatedHostReadyDelay(evergreen.HostUninitialized)
+	}
+	if o.MaxDuration == 0 {
+		o.MaxDuration = defaultSchedulerSimulationMaxDuration
+	}
+	if o.Step > o.MaxDuration {
+		return errors.New("step cannot be longer than the max duration")
+	}
+	return errors.Wrap(o.Snapshot.SchedulerConfig.ValidateAndDefault(), "invalid scheduler config")
+}
+
+// SchedulerSimulationResult summarizes how the scheduler performed in a
+// simulation.
+type SchedulerSimulationResult struct {
+	// SimulatedDuration is how much time was simulated.
+	SimulatedDuration time.Duration
+	Distros           []DistroSimulationResult
+}
+
+// DistroSimulationResult summarizes how the scheduler performed for one distro
+// in a simulation.
+type DistroSimulationResult struct {
+	Distro        string
+	Planner       string
+	HostAllocator string
+	// TasksDispatched is the number of waiting tasks that started in the
+	// simulation.
+	TasksDispatched int
+	// TasksNotDispatched is the number of waiting tasks that had not started
+	// by the end of the simulation.
+	TasksNotDispatched int
+	// The queue latencies are how long tasks waited to start after their
+	// dependencies were met.
+	MeanQueueLatency time.Duration
+	P50QueueLatency  time.Duration
+	P90QueueLatency  time.Duration
+	MaxQueueLatency  time.Duration
+	// HostHours is the total time that hosts were up, including the time
+	// spent starting up.
+	HostHours float64
+	// BusyHostHours is the total time that hosts spent running tasks.
+	BusyHostHours float64
+	// Utilization is the fraction of host hours that were spent running
+	// tasks.
+	Utilization float64
+	// HostsRequested is the total number of hosts that the host allocator
+	// requested.
+	HostsRequested int
+	// PeakHosts is the most hosts that were up at once.
+	PeakHosts int
+}
+
+// simulatedTask is a task in a scheduler simulation.
+type simulatedTask struct {
+	task     task.Task
+	duration time.Duration
+	// waiting is whether the task was waiting to run when the snapshot was
+	// taken.
+	waiting    bool
+	dispatched bool
+	// runnableAt is when the task's dependencies were met.
+	runnableAt time.Time
+	startAt    time.Time
+	finishAt   time.Time
+}
+
+// simulatedSchedulerHost is a host in a scheduler simulation.
+type simulatedSchedulerHost struct {
+	id          string
+	createdAt   time.Time
+	readyAt     time.Time
+	busyUntil   time.Time
+	runningTask string
+}
+
+// freeAt returns when the host can run its next task.
+func (h *simulatedSchedulerHost) freeAt() time.Time {
+	if h.busyUntil.After(h.readyAt) {
+		return h.busyUntil
+	}
+	return h.readyAt
+}
+
+// simulatedDistro is the state of a distro in a scheduler simulation.
+type simulatedDistro struct {
+	distro     distro.Distro
+	planner    string
+	allocator  string
+	idleTime   time.Duration
+	forecast   model.DistroDemandForecast
+	hosts      []*simulatedSchedulerHost
+	hostHours  float64
+	numCreated int
+	result     DistroSimulationResult
+}
+
+// schedulerSimulation is the state of a scheduler simulation.
+type schedulerSimulation struct {
+	opts    SchedulerSimulationOptions
+	tasks   map[string]*simulatedTask
+	distros []*simulatedDistro
+	weights map[string]float64
+	window  time.Duration
+}
+
+// SimulateScheduler replays the snapshot through the scheduler's planners and
+// host allocators over simulated time and reports the queue latency, host
+// hours, and utilization for each distro. Each step, every distro's runnable
+// tasks are planned, the distro's host allocator requests hosts for the
+// queue, and the queue is dispatched in order to the distro's free hosts
+// using the same model as task start time estimation. Tasks run for their
+// expected durations and are assumed to succeed. Requested hosts become
+// ready after the host startup time, and hosts are terminated once they've
+// been idle for longer than the distro's acceptable idle time. Task groups and
+// secondary distros are not simulated, and distros using the legacy planner
+// are simulated with the tunable planner.
+func SimulateScheduler(ctx context.Context, opts SchedulerSimulationOptions) (*SchedulerSimulationResult, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid simulation options")
+	}
+
+	sim, err := newSchedulerSimulation(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "setting up simulation")
+	}
+
+	start := opts.Snapshot.CapturedAt
+	end := start.Add(opts.MaxDuration)
+	now := start
+	for ; now.Before(end); now = now.Add(opts.Step) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if sim.isDone(now) {
+			break
+		}
+		for _, d := range sim.distros {
+			if err := sim.runDistro(ctx, d, now); err != nil {
+				return nil, errors.Wrapf(err, "simulating distro '%s' at %s", d.distro.Id, now)
+			}
+		}
+	}
+
+	return sim.result(start, now), nil
+}
+
+func newSchedulerSimulation(opts SchedulerSimulationOptions) (*schedulerSimulation, error) {
+	settings := &evergreen.Settings{Scheduler: opts.Snapshot.SchedulerConfig}
+	sim := &schedulerSimulation{
+		opts:    opts,
+		tasks:   make(map[string]*simulatedTask, len(opts.Snapshot.Tasks)),
+		weights: opts.Snapshot.SchedulerConfig.GetFairShareProjectWeights(),
+		window:  time.Duration(opts.Snapshot.SchedulerConfig.FairShareUsageWindowSeconds) * time.Second,
+	}
+	capturedAt := opts.Snapshot.CapturedAt
+
+	for _, t := range opts.Snapshot.Tasks {
+		st := &simulatedTask{
+			task:     t,
+			duration: t.ExpectedDuration,
+			waiting:  t.Status == evergreen.TaskUndispatched && t.Activated && t.Priority > evergreen.DisabledTaskPriority,
+		}
+		if st.duration <= 0 {
+			st.duration = defaultSimulatedTaskDuration
+		}
+		switch {
+		case evergreen.IsFinishedTaskStatus(t.Status):
+			st.finishAt = capturedAt
+		case t.Status == evergreen.TaskDispatched || t.Status == evergreen.TaskStarted:
+			startedAt := t.StartTime
+			if utility.IsZeroTime(startedAt) {
+				startedAt = t.DispatchTime
+			}
+			if utility.IsZeroTime(startedAt) || startedAt.After(capturedAt) {
+				startedAt = capturedAt
+			}
+			st.startAt = startedAt
+			st.finishAt = startedAt.Add(st.duration)
+			if st.finishAt.Before(capturedAt) {
+				st.finishAt = capturedAt
+			}
+		}
+		sim.tasks[t.Id] = st
+	}
+
+	for _, d := range opts.Snapshot.Distros {
+		hostAllocatorSettings, err := d.GetResolvedHostAllocatorSettings(settings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving host allocator settings for distro '%s'", d.Id)
+		}
+		plannerSettings, err := d.GetResolvedPlannerSettings(settings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving planner settings for distro '%s'", d.Id)
+		}
+		d.HostAllocatorSettings = hostAllocatorSettings
+		d.PlannerSettings = plannerSettings
+
+		sd := &simulatedDistro{
+			distro:    d,
+			planner:   d.PlannerSettings.Version,
+			allocator: GetHostAllocatorName(settings.Scheduler, d),
+			idleTime:  d.HostAllocatorSettings.AcceptableHostIdleTime,
+			forecast:  model.NewDistroDemandForecast(d.Id, nil),
+		}
+		if sd.planner != evergreen.PlannerVersionFairShare {
+			sd.planner = evergreen.PlannerVersionTunable
+		}
+		sd.result = DistroSimulationResult{
+			Distro:        d.Id,
+			Planner:       sd.planner,
+			HostAllocator: sd.allocator,
+		}
+
+		for _, h := range opts.Snapshot.Hosts {
+			if h.Distro.Id != d.Id || !utility.StringSliceContains(evergreen.UpHostStatus, h.Status) {
+				continue
+			}
+			sh := &simulatedSchedulerHost{
+				id:        h.Id,
+				createdAt: capturedAt,
+				readyAt:   capturedAt.Add(model.EstimatedHostReadyDelay(h.Status)),
+			}
+			if st, ok := sim.tasks[h.RunningTask]; ok && h.RunningTask != "" && st.finishAt.After(capturedAt) {
+				sh.runningTask = h.RunningTask
+				sh.busyUntil = st.finishAt
+			}
+			sd.hosts = append(sd.hosts, sh)
+		}
+		sd.result.PeakHosts = len(sd.hosts)
+
+		sim.distros = append(sim.distros, sd)
+	}
+
+	return sim, nil
+}
+
+// isDone returns whether every waiting task in the simulated distros has
+// finished.
+func (s *schedulerSimulation) isDone(now time.Time) bool {
+	distroIDs := make(map[string]bool, len(s.distros))
+	for _, d := range s.distros {
+		distroIDs[d.distro.Id] = true
+	}
+	for _, st := range s.tasks {
+		if st.waiting && distroIDs[st.task.DistroId] && (!st.dispatched || st.finishAt.After(now)) {
+			return false
+		}
+	}
+	return true
+}
+
+// finishedBy returns when the dependency finished, and whether it has
+// finished by the given time. Dependencies that are not in the snapshot are
+// assumed to have finished.
+func (s *schedulerSimulation) finishedBy(id string, now time.Time) (time.Time, bool) {
+	st, ok := s.tasks[id]
+	if !ok {
+		return s.opts.Snapshot.CapturedAt, true
+	}
+	if utility.IsZeroTime(st.finishAt) || st.finishAt.After(now) {
+		return time.Time{}, false
+	}
+	return st.finishAt, true
+}
+
+// runnableTasks returns the distro's waiting tasks whose dependencies have
+// finished.
+func (s *schedulerSimulation) runnableTasks(d *simulatedDistro, now time.Time) []*simulatedTask {
+	var runnable []*simulatedTask
+	for _, st := range s.tasks {
+		if !st.waiting || st.dispatched || st.task.DistroId != d.distro.Id {
+			continue
+		}
+		runnableAt := s.opts.Snapshot.CapturedAt
+		depsMet := true
+		if !st.task.OverrideDependencies {
+			for _, dep := range st.task.DependsOn {
+				finishedAt, ok := s.finishedBy(dep.TaskId, now)
+				if !ok {
+					depsMet = false
+					break
+				}
+				if finishedAt.After(runnableAt) {
+					runnableAt = finishedAt
+				}
+			}
+		}
+		if !depsMet {
+			continue
+		}
+		if utility.IsZeroTime(st.runnableAt) {
+			st.runnableAt = runnableAt
+		}
+		runnable = append(runnable, st)
+	}
+
+	// Sort the tasks so the simulation is deterministic.
+	sort.Slice(runnable, func(i, j int) bool { return runnable[i].task.Id < runnable[j].task.Id })
+
+	return runnable
+}
+
+// simulatedPlanningTask returns a copy of the task that can be planned at the
+// simulated time. The planner and host allocators measure time relative to the
+// current time, so the task's times are shifted so that they're the same
+// relative to the current time as they are to the simulated time. Its expected
+// duration is cached so that it's never refreshed from the database.
+func simulatedPlanningTask(st *simulatedTask, now time.Time) task.Task {
+	t := st.task
+	offset := time.Since(now)
+	shift := func(ts time.Time) time.Time {
+		if utility.IsZeroTime(ts) {
+			return ts
+		}
+		return ts.Add(offset)
+	}
+	t.ActivatedTime = shift(t.ActivatedTime)
+	t.IngestTime = shift(t.IngestTime)
+	t.ScheduledTime = shift(st.runnableAt)
+	t.DependenciesMetTime = shift(st.runnableAt)
+	t.StartTime = shift(st.startAt)
+	t.ExpectedDuration = st.duration
+	t.DurationPrediction = util.CachedDurationValue{
+		Value:       st.duration,
+		StdDev:      t.ExpectedDurationStdDev,
+		TTL:         simulatedDurationTTL,
+		CollectedAt: time.Now(),
+	}
+	return t
+}
+
+// projectUsage returns how much host time each project has used on the
+// distro in the fair-share usage window.
+func (s *schedulerSimulation) projectUsage(d *simulatedDistro, now time.Time) map[string]time.Duration {
+	window := s.window
+	if window <= 0 {
+		window = defaultFairShareUsageWindow
+	}
+	since := now.Add(-window)
+	usage := map[string]time.Duration{}
+	for _, st := range s.tasks {
+		if st.task.DistroId != d.distro.Id || utility.IsZeroTime(st.startAt) {
+			continue
+		}
+		startAt, finishAt := st.startAt, st.finishAt
+		if startAt.Before(since) {
+			startAt = since
+		}
+		if finishAt.After(now) {
+			finishAt = now
+		}
+		if finishAt.After(startAt) {
+			usage[st.task.Project] += finishAt.Sub(startAt)
+		}
+	}
+	return usage
+}
+
+// runDistro runs one step of the scheduler for the distro.
+func (s *schedulerSimulation) runDistro(ctx context.Context, d *simulatedDistro, now time.Time) error {
+	runnable := s.runnableTasks(d, now)
+	byID := make(map[string]*simulatedTask, len(runnable))
+	tasks := make([]task.Task, 0, len(runnable))
+	for _, st := range runnable {
+		byID[st.task.Id] = st
+		tasks = append(tasks, simulatedPlanningTask(st, now))
+	}
+
+	plan := PrepareTasksForPlanning(&d.distro, tasks).Export()
+	if d.planner == evergreen.PlannerVersionFairShare {
+		plan = FairShareOrder(plan, s.projectUsage(d, now), s.weights)
+	}
+	plannerOpts := TaskPlannerOptions{
+		StartedAt:            now,
+		ID:                   fmt.Sprintf("simulation-%s", now),
+		IncludesDependencies: d.distro.DispatcherSettings.Version == evergreen.DispatcherVersionRevisedWithDependencies,
+	}
+	info := GetDistroQueueInfo(d.distro.Id, plan, d.distro.GetTargetTime(), plannerOpts)
+
+	existingHosts := make([]host.Host, 0, len(d.hosts))
+	runningTasks := map[string]task.Task{}
+	for _, h := range d.hosts {
+		status := evergreen.HostRunning
+		if h.readyAt.After(now) {
+			status = evergreen.HostStarting
+		}
+		existing := host.Host{Id: h.id, Distro: d.distro, Status: status}
+		if h.busyUntil.After(now) && h.runningTask != "" {
+			existing.RunningTask = h.runningTask
+			if st, ok := s.tasks[h.runningTask]; ok {
+				runningTasks[h.runningTask] = simulatedPlanningTask(st, now)
+			}
+		}
+		existingHosts = append(existingHosts, existing)
+	}
+
+	numNewHosts, _, err := GetHostAllocator(d.allocator)(ctx, &HostAllocatorData{
+		Distro:          d.distro,
+		ExistingHosts:   existingHosts,
+		DistroQueueInfo: info,
+		DemandForecast:  &d.forecast,
+		AllocationTime:  now,
+		RunningTasks:    runningTasks,
+	})
+	if err != nil {
+		return errors.Wrap(err, "allocating hosts")
+	}
+	for i := 0; i < numNewHosts; i++ {
+		d.numCreated++
+		d.hosts = append(d.hosts, &simulatedSchedulerHost{
+			id:        fmt.Sprintf("simulated-%s-%d", d.distro.Id, d.numCreated),
+			createdAt: now,
+			readyAt:   now.Add(s.opts.HostStartupTime),
+		})
+	}
+	d.result.HostsRequested += numNewHosts
+
+	durations := make([]time.Duration, 0, len(plan))
+	for _, t := range plan {
+		durations = append(durations, byID[t.Id].duration)
+	}
+	timesToFree := make([]time.Duration, 0, len(d.hosts))
+	for _, h := range d.hosts {
+		timesToFree = append(timesToFree, h.freeAt().Sub(now))
+	}
+	for i, dispatch := range model.SimulateTaskQueueDispatch(durations, timesToFree, s.opts.Step) {
+		st := byID[plan[i].Id]
+		h := d.hosts[dispatch.HostIndex]
+		st.dispatched = true
+		st.startAt = now.Add(dispatch.Start)
+		st.finishAt = st.startAt.Add(st.duration)
+		h.runningTask = st.task.Id
+		h.busyUntil = st.finishAt
+	}
+
+	// Terminate hosts that have been idle for too long, as long as the distro
+	// keeps its minimum hosts.
+	numUp := len(d.hosts)
+	remaining := make([]*simulatedSchedulerHost, 0, len(d.hosts))
+	for _, h := range d.hosts {
+		freeAt := h.freeAt()
+		if numUp > d.distro.HostAllocatorSettings.MinimumHosts && !freeAt.After(now) && now.Sub(freeAt) > d.idleTime {
+			numUp--
+			d.hostHours += now.Sub(h.createdAt).Hours()
+			continue
+		}
+		remaining = append(remaining, h)
+	}
+	d.hosts = remaining
+	if len(d.hosts) > d.result.PeakHosts {
+		d.result.PeakHosts = len(d.hosts)
+	}
+
+	return nil
+}
+
+// result summarizes the simulation from its start until its end.
+func (s *schedulerSimulation) result(start, end time.Time) *SchedulerSimulationResult {
+	res := &SchedulerSimulationResult{SimulatedDuration: end.Sub(start)}
+	for _, d := range s.distros {
+		dr := d.result
+		dr.HostHours = d.hostHours
+		for _, h := range d.hosts {
+			dr.HostHours += end.Sub(h.createdAt).Hours()
+		}
+
+		var latencies []time.Duration
+		for _, st := range s.tasks {
+			if st.task.DistroId != d.distro.Id || utility.IsZeroTime(st.startAt) || !st.startAt.Before(end) {
+				if st.waiting && st.task.DistroId == d.distro.Id {
+					dr.TasksNotDispatched++
+				}
+				continue
+			}
+			startAt, finishAt := st.startAt, st.finishAt
+			if startAt.Before(start) {
+				startAt = start
+			}
+			if finishAt.After(end) {
+				finishAt = end
+			}
+			if finishAt.After(startAt) {
+				dr.BusyHostHours += finishAt.Sub(startAt).Hours()
+			}
+			if st.waiting {
+				latencies = append(latencies, st.startAt.Sub(st.runnableAt))
+			}
+		}
+		dr.TasksDispatched = len(latencies)
+		if dr.HostHours > 0 {
+			dr.Utilization = dr.BusyHostHours / dr.HostHours
+		}
+		if len(latencies) > 0 {
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			var total time.Duration
+			for _, l := range latencies {
+				total += l
+			}
+			dr.MeanQueueLatency = total / time.Duration(len(latencies))
+			dr.P50QueueLatency = latencyPercentile(latencies, 50)
+			dr.P90QueueLatency = latencyPercentile(latencies, 90)
+			dr.MaxQueueLatency = latencies[len(latencies)-1]
+		}
+
+		res.Distros = append(res.Distros, dr)
+	}
+	return res
+}
+
+// latencyPercentile returns the nearest-rank percentile of the sorted
+// latencies.
+func latencyPercentile(sorted []time.Duration, percentile int) time.Duration {
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/distro"
+	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateScheduler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	capturedAt := time.Now().Truncate(time.Minute)
+	makeDistro := func(maxHosts int) distro.Distro {
+		return distro.Distro{
+			Id:       "distro",
+			Provider: evergreen.ProviderNameEc2Fleet,
+			HostAllocatorSettings: distro.HostAllocatorSettings{
+				Version:                evergreen.HostAllocatorUtilization,
+				MaximumHosts:           maxHosts,
+				AcceptableHostIdleTime: 5 * time.Minute,
+			},
+			PlannerSettings: distro.PlannerSettings{
+				Version: evergreen.PlannerVersionTunable,
+			},
+		}
+	}
+	makeTasks := func(n int, duration time.Duration) []task.Task {
+		var tasks []task.Task
+		for i := 0; i < n; i++ {
+			tasks = append(tasks, task.Task{
+				Id:               fmt.Sprintf("task%d", i),
+				DistroId:         "distro",
+				Project:          "project",
+				Version:          "version",
+				Status:           evergreen.TaskUndispatched,
+				Activated:        true,
+				ActivatedTime:    capturedAt,
+				ExpectedDuration: duration,
+			})
+		}
+		return tasks
+	}
+	makeHosts := func(d distro.Distro, n int) []host.Host {
+		var hosts []host.Host
+		for i := 0; i < n; i++ {
+			hosts = append(hosts, host.Host{
+				Id:     fmt.Sprintf("host%d", i),
+				Distro: d,
+				Status: evergreen.HostRunning,
+			})
+		}
+		return hosts
+	}
+
+	for tName, tCase := range map[string]func(t *testing.T){
+		"DispatchesQueueToFreeHosts": func(t *testing.T) {
+			d := makeDistro(2)
+			res, err := SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot: SchedulerSnapshot{
+					CapturedAt: capturedAt,
+					Distros:    []distro.Distro{d},
+					Hosts:      makeHosts(d, 2),
+					Tasks:      makeTasks(4, 15*time.Minute),
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 30*time.Minute, res.SimulatedDuration)
+			require.Len(t, res.Distros, 1)
+
+			dr := res.Distros[0]
+			assert.Equal(t, d.Id, dr.Distro)
+			assert.Equal(t, 4, dr.TasksDispatched)
+			assert.Zero(t, dr.TasksNotDispatched)
+			assert.Equal(t, 7*time.Minute+30*time.Second, dr.MeanQueueLatency)
+			assert.Equal(t, 15*time.Minute, dr.MaxQueueLatency)
+			assert.Zero(t, dr.HostsRequested)
+			assert.Equal(t, 2, dr.PeakHosts)
+			assert.InDelta(t, 1, dr.HostHours, 0.001)
+			assert.InDelta(t, 1, dr.BusyHostHours, 0.001)
+			assert.InDelta(t, 1, dr.Utilization, 0.001)
+		},
+		"WaitsForDependencies": func(t *testing.T) {
+			d := makeDistro(1)
+			tasks := makeTasks(2, 10*time.Minute)
+			tasks[1].DependsOn = []task.Dependency{{TaskId: tasks[0].Id, Status: evergreen.TaskSucceeded}}
+			res, err := SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot: SchedulerSnapshot{
+					CapturedAt: capturedAt,
+					Distros:    []distro.Distro{d},
+					Hosts:      makeHosts(d, 1),
+					Tasks:      tasks,
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 20*time.Minute, res.SimulatedDuration)
+			require.Len(t, res.Distros, 1)
+			assert.Equal(t, 2, res.Distros[0].TasksDispatched)
+			assert.Zero(t, res.Distros[0].MaxQueueLatency)
+		},
+		"FinishesRunningTasks": func(t *testing.T) {
+			d := makeDistro(1)
+			tasks := makeTasks(1, 10*time.Minute)
+			tasks = append(tasks, task.Task{
+				Id:               "running",
+				DistroId:         d.Id,
+				Status:           evergreen.TaskStarted,
+				StartTime:        capturedAt.Add(-5 * time.Minute),
+				ExpectedDuration: 10 * time.Minute,
+			})
+			hosts := makeHosts(d, 1)
+			hosts[0].RunningTask = "running"
+			res, err := SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot: SchedulerSnapshot{
+					CapturedAt: capturedAt,
+					Distros:    []distro.Distro{d},
+					Hosts:      hosts,
+					Tasks:      tasks,
+				},
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Distros, 1)
+			assert.Equal(t, 1, res.Distros[0].TasksDispatched)
+			assert.Equal(t, 5*time.Minute, res.Distros[0].MaxQueueLatency)
+			assert.InDelta(t, 0.25, res.Distros[0].BusyHostHours, 0.001)
+		},
+		"RequestsHostsForQueue": func(t *testing.T) {
+			d := makeDistro(5)
+			res, err := SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot: SchedulerSnapshot{
+					CapturedAt: capturedAt,
+					Distros:    []distro.Distro{d},
+					Tasks:      makeTasks(10, 30*time.Minute),
+				},
+				HostStartupTime: 3 * time.Minute,
+			})
+			require.NoError(t, err)
+			require.Len(t, res.Distros, 1)
+			dr := res.Distros[0]
+			assert.Equal(t, 10, dr.TasksDispatched)
+			assert.NotZero(t, dr.HostsRequested)
+			assert.True(t, dr.PeakHosts <= 5)
+			assert.True(t, dr.MeanQueueLatency >= 3*time.Minute)
+			assert.True(t, dr.Utilization > 0 && dr.Utilization <= 1)
+		},
+		"StopsAtMaxDuration": func(t *testing.T) {
+			d := makeDistro(0)
+			res, err := SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot: SchedulerSnapshot{
+					CapturedAt: capturedAt,
+					Distros:    []distro.Distro{d},
+					Tasks:      makeTasks(3, time.Minute),
+				},
+				MaxDuration: time.Hour,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, time.Hour, res.SimulatedDuration)
+			require.Len(t, res.Distros, 1)
+			assert.Zero(t, res.Distros[0].TasksDispatched)
+			assert.Equal(t, 3, res.Distros[0].TasksNotDispatched)
+		},
+		"FailsWithInvalidOptions": func(t *testing.T) {
+			_, err := SimulateScheduler(ctx, SchedulerSimulationOptions{})
+			assert.Error(t, err)
+
+			_, err = SimulateScheduler(ctx, SchedulerSimulationOptions{
+				Snapshot:    SchedulerSnapshot{CapturedAt: capturedAt, Distros: []distro.Distro{makeDistro(1)}},
+				Step:        time.Hour,
+				MaxDuration: time.Minute,
+			})
+			assert.Error(t, err)
+		},
+	} {
+		t.Run(tName, tCase)
+	}
+}
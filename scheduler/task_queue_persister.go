@@ -5,6 +5,8 @@ import (
 
 	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
 )
 
@@ -20,21 +22,22 @@ func PersistTaskQueue(distro string, tasks []task.Task, distroQueueInfo model.Di
 			dependencies = append(dependencies, d.TaskId)
 		}
 		taskQueue = append(taskQueue, model.TaskQueueItem{
-			Id:                  t.Id,
-			DisplayName:         t.DisplayName,
-			BuildVariant:        t.BuildVariant,
-			RevisionOrderNumber: t.RevisionOrderNumber,
-			Requester:           t.Requester,
-			Revision:            t.Revision,
-			Project:             t.Project,
-			ExpectedDuration:    t.ExpectedDuration,
-			Priority:            t.Priority,
-			Group:               t.TaskGroup,
-			GroupMaxHosts:       t.TaskGroupMaxHosts,
-			GroupIndex:          t.TaskGroupOrder,
-			Version:             t.Version,
-			ActivatedBy:         t.ActivatedBy,
-			Dependencies:        dependencies,
+			Id:                    t.Id,
+			DisplayName:           t.DisplayName,
+			BuildVariant:          t.BuildVariant,
+			RevisionOrderNumber:   t.RevisionOrderNumber,
+			Requester:             t.Requester,
+			Revision:              t.Revision,
+			Project:               t.Project,
+			ExpectedDuration:      t.ExpectedDuration,
+			Priority:              t.Priority,
+			Group:                 t.TaskGroup,
+			GroupMaxHosts:         t.TaskGroupMaxHosts,
+			GroupIndex:            t.TaskGroupOrder,
+			Version:               t.Version,
+			ActivatedBy:           t.ActivatedBy,
+			Dependencies:          dependencies,
+			SortingValueBreakdown: t.SortingValueBreakdown,
 		})
 	}
 
@@ -44,6 +47,16 @@ func PersistTaskQueue(distro string, tasks []task.Task, distroQueueInfo model.Di
 		return errors.WithStack(err)
 	}
 
+	// The predictive host allocator forecasts demand for the distro's hosts
+	// from the history of its primary queue.
+	if !distroQueueInfo.SecondaryQueue {
+		grip.Warning(message.WrapError(model.RecordTaskQueueHistory(distro, distroQueueInfo, startAt), message.Fields{
+			"message": "could not record task queue history",
+			"distro":  distro,
+			"runner":  RunnerName,
+		}))
+	}
+
 	// track scheduled time for prioritized tasks
 	if err := task.SetTasksScheduledTime(tasks, startAt); err != nil {
 		return errors.Wrapf(err, "error setting scheduled time for prioritized tasks for distro '%s'", distro)
@@ -21,6 +21,9 @@ import (
 type TaskGroupData struct {
 	Hosts []host.Host
 	Info  model.TaskGroupInfo
+	// RunningTasks are the tasks running on the hosts, by ID. If it is nil,
+	// the running tasks are looked up in the database.
+	RunningTasks map[string]task.Task
 }
 
 func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostAllocatorData) (int, int, error) {
@@ -79,6 +82,7 @@ func UtilizationBasedHostAllocator(ctx context.Context, hostAllocatorData *HostA
 		infoSliceIdx[info.Name] = idx
 	}
 	for name, taskGroupData := range taskGroupDatas {
+		taskGroupData.RunningTasks = hostAllocatorData.RunningTasks
 		var maxHosts int
 		if name == "" {
 			maxHosts = distro.HostAllocatorSettings.MaximumHosts
@@ -169,7 +173,7 @@ func evalHostUtilization(ctx context.Context, d distro.Distro, taskGroupData Tas
 
 	// determine how many free hosts we have that are already up
 	startAt := time.Now()
-	numFreeHosts, err := calcExistingFreeHosts(existingHosts, futureHostFraction, maxDurationThreshold)
+	numFreeHosts, err := calcExistingFreeHosts(existingHosts, taskGroupData.RunningTasks, futureHostFraction, maxDurationThreshold)
 	if err != nil {
 		return numNewHosts, numFreeHosts, err
 	}
@@ -328,7 +332,7 @@ func calcNewHostsNeeded(scheduledDuration, maxDurationPerHost time.Duration, num
 
 // calcExistingFreeHosts returns the number of hosts that are not running a task,
 // plus hosts that will soon be free scaled by some fraction
-func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64, maxDurationPerHost time.Duration) (int, error) {
+func calcExistingFreeHosts(existingHosts []host.Host, runningTasks map[string]task.Task, futureHostFactor float64, maxDurationPerHost time.Duration) (int, error) {
 	numFreeHosts := 0
 	if futureHostFactor > 1 {
 		return numFreeHosts, errors.New("future host factor cannot be greater than 1")
@@ -340,7 +344,7 @@ func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64,
 		}
 	}
 
-	soonToBeFree, err := getSoonToBeFreeHosts(existingHosts, futureHostFactor, maxDurationPerHost)
+	soonToBeFree, err := getSoonToBeFreeHosts(existingHosts, runningTasks, futureHostFactor, maxDurationPerHost)
 	if err != nil {
 		return 0, err
 	}
@@ -351,8 +355,9 @@ func calcExistingFreeHosts(existingHosts []host.Host, futureHostFactor float64,
 // getSoonToBeFreeHosts calculates a fractional number of hosts that are expected
 // to be free for some fraction of the next maxDurationPerHost interval
 // the final value is scaled by some fraction representing how confident we are that
-// the hosts will actually be free in the expected amount of time
-func getSoonToBeFreeHosts(existingHosts []host.Host, futureHostFraction float64, maxDurationPerHost time.Duration) (float64, error) {
+// the hosts will actually be free in the expected amount of time. If the running
+// tasks are not given, they're looked up in the database.
+func getSoonToBeFreeHosts(existingHosts []host.Host, runningTasks map[string]task.Task, futureHostFraction float64, maxDurationPerHost time.Duration) (float64, error) {
 	runningTaskIds := []string{}
 
 	for _, existingDistroHost := range existingHosts {
@@ -365,14 +370,24 @@ func getSoonToBeFreeHosts(existingHosts []host.Host, futureHostFraction float64,
 		return 0.0, nil
 	}
 
-	runningTasks, err := task.Find(task.ByIds(runningTaskIds))
-	if err != nil {
-		return 0.0, err
+	var tasks []task.Task
+	if runningTasks != nil {
+		for _, id := range runningTaskIds {
+			if t, ok := runningTasks[id]; ok {
+				tasks = append(tasks, t)
+			}
+		}
+	} else {
+		var err error
+		tasks, err = task.Find(task.ByIds(runningTaskIds))
+		if err != nil {
+			return 0.0, err
+		}
 	}
 
-	nums := make(chan float64, len(runningTasks))
-	source := make(chan task.Task, len(runningTasks))
-	for _, t := range runningTasks {
+	nums := make(chan float64, len(tasks))
+	source := make(chan task.Task, len(tasks))
+	for _, t := range tasks {
 		source <- t
 	}
 	close(source)
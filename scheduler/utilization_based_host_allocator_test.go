@@ -215,7 +215,7 @@ func (s *UtilizationAllocatorSuite) TestCalcExistingFreeHosts() {
 	}
 	s.NoError(t3.Insert())
 
-	freeHosts, err := calcExistingFreeHosts([]host.Host{h1, h2, h3, h4, h5}, 1, evergreen.MaxDurationPerDistroHost)
+	freeHosts, err := calcExistingFreeHosts([]host.Host{h1, h2, h3, h4, h5}, nil, 1, evergreen.MaxDurationPerDistroHost)
 	s.NoError(err)
 	s.Equal(3, freeHosts)
 }
@@ -103,9 +103,11 @@ func PlanDistro(ctx context.Context, conf Configuration, s *evergreen.Settings)
 
 	planningPhaseBegins := time.Now()
 	prioritizedTasks, err := PrioritizeTasks(distro, tasks, TaskPlannerOptions{
-		StartedAt:        taskFindingBegins,
-		ID:               schedulerInstanceID,
-		IsSecondaryQueue: false,
+		StartedAt:            taskFindingBegins,
+		ID:                   schedulerInstanceID,
+		IsSecondaryQueue:     false,
+		ProjectWeights:       s.Scheduler.GetFairShareProjectWeights(),
+		FairShareUsageWindow: time.Duration(s.Scheduler.FairShareUsageWindowSeconds) * time.Second,
 	})
 	if err != nil {
 		return errors.WithStack(err)
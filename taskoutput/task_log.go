@@ -21,7 +21,8 @@ const (
 	TaskLogTypeTask   TaskLogType = "task_log"
 )
 
-func (t TaskLogType) validate() error {
+// Validate checks that the task log type is recognized.
+func (t TaskLogType) Validate() error {
 	switch t {
 	case TaskLogTypeAll, TaskLogTypeAgent, TaskLogTypeSystem, TaskLogTypeTask:
 		return nil
@@ -49,37 +50,118 @@ type TaskLogGetOptions struct {
 	// Start is the start time (inclusive) of the time range filter,
 	// represented as a Unix timestamp in nanoseconds. Optional.
 	Start int64
+	// LogStarts overrides the start time of the time range filter for
+	// individual logs, keyed by the log name of the lines returned. Cedar
+	// Buildlogger logs do not support per-log filtering and use the
+	// earliest of the start times instead. Optional.
+	LogStarts map[string]int64
 	// End is the end time (inclusive) of the time range filter,
 	// represented as a Unix timestamp in nanoseconds. Optional.
 	End int64
-	// LineLimit limits the number of lines read from the log. Optional.
+	// LineLimit limits the number of lines read from the log. When
+	// searching, it instead limits the number of matching lines returned,
+	// unless the search specifies its own limit. Optional.
 	LineLimit int
 	// TailN is the number of lines to read from the tail of the log.
 	// Optional.
 	TailN int
+	// Attributes filters the log lines to those with all of the given
+	// attribute key-value pairs. Optional.
+	Attributes map[string]string
+	// Search filters the log lines to those matching the search options.
+	// Optional.
+	Search *log.SearchOptions
 }
 
 // Get returns task logs belonging to the specified task run.
 func (o TaskLogOutput) Get(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, getOpts TaskLogGetOptions) (log.LogIterator, error) {
-	if err := getOpts.LogType.validate(); err != nil {
+	if err := getOpts.LogType.Validate(); err != nil {
 		return nil, err
 	}
 
-	if o.Version == 0 {
-		return o.getBuildloggerLogs(ctx, env, taskOpts, getOpts)
+	if getOpts.Search != nil {
+		if err := getOpts.Search.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid search options")
+		}
+
+		// When searching, the line limit caps the number of matching
+		// lines returned rather than the number of lines read.
+		search := *getOpts.Search
+		if search.Limit == 0 {
+			search.Limit = getOpts.LineLimit
+		}
+		getOpts.Search = &search
+		getOpts.LineLimit = 0
 	}
 
-	svc, err := o.getLogService(ctx)
+	if o.Version == TaskOutputVersionCedar {
+		it, err := o.getBuildloggerLogs(ctx, env, taskOpts, getOpts)
+		if err != nil {
+			return nil, err
+		}
+		if len(getOpts.Attributes) > 0 {
+			it = log.NewAttributeFilterIterator(it, getOpts.Attributes)
+		}
+		if getOpts.Search == nil {
+			return it, nil
+		}
+
+		return log.NewSearchIterator(it, *getOpts.Search)
+	}
+
+	svc, err := o.getLogService(ctx, env)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting log service")
 	}
 
 	return svc.Get(ctx, log.GetOptions{
-		LogNames:  []string{o.getLogName(taskOpts, getOpts.LogType)},
-		Start:     getOpts.Start,
-		End:       getOpts.End,
-		LineLimit: getOpts.LineLimit,
-		TailN:     getOpts.TailN,
+		LogNames:   []string{o.getLogName(taskOpts, getOpts.LogType)},
+		Start:      getOpts.Start,
+		LogStarts:  getOpts.LogStarts,
+		End:        getOpts.End,
+		LineLimit:  getOpts.LineLimit,
+		TailN:      getOpts.TailN,
+		Attributes: getOpts.Attributes,
+		Search:     getOpts.Search,
+	})
+}
+
+// Append appends the given lines, which must be in timestamp order, to the
+// task logs of the given type belonging to the specified task run. Only task
+// logs stored with the Evergreen log service can be appended to.
+func (o TaskLogOutput) Append(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, logType TaskLogType, lines []log.LogLine) error {
+	if err := logType.Validate(); err != nil {
+		return err
+	}
+	if logType == TaskLogTypeAll {
+		return errors.New("must specify a single task log type to append to")
+	}
+
+	svc, err := o.getLogService(ctx, env)
+	if err != nil {
+		return errors.Wrap(err, "getting log service")
+	}
+
+	return svc.Append(ctx, o.getLogName(taskOpts, logType), lines)
+}
+
+// Compact merges adjacent chunks of the task logs belonging to the specified
+// task run into chunks of up to maxChunkLines lines.
+func (o TaskLogOutput) Compact(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, maxChunkLines int, dryRun bool) (log.CompactResult, error) {
+	if o.Version == TaskOutputVersionCedar {
+		// Cedar Buildlogger manages the storage of its logs.
+		return log.CompactResult{}, nil
+	}
+
+	svc, err := o.getLogService(ctx, env)
+	if err != nil {
+		return log.CompactResult{}, errors.Wrap(err, "getting log service")
+	}
+
+	return svc.Compact(ctx, log.CompactOptions{
+		LogNames:      []string{o.getLogName(taskOpts, TaskLogTypeAll)},
+		MaxChunkLines: maxChunkLines,
+		DryRun:        dryRun,
 	})
 }
 
@@ -101,22 +183,24 @@ func (o TaskLogOutput) getLogName(taskOpts TaskOptions, logType TaskLogType) str
 	return fmt.Sprintf("%s/%s", prefix, logTypePrefix)
 }
 
-func (o TaskLogOutput) getLogService(ctx context.Context) (log.LogService, error) {
-	b, err := newBucket(ctx, o.BucketConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	return log.NewLogServiceV0(b), nil
+func (o TaskLogOutput) getLogService(ctx context.Context, env evergreen.Environment) (log.LogService, error) {
+	return newLogService(ctx, env, o.Version, o.BucketConfig)
 }
 
 // getBuildloggerLogs makes request to Cedar Buildlogger for logs.
 func (o TaskLogOutput) getBuildloggerLogs(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, getOpts TaskLogGetOptions) (log.LogIterator, error) {
+	start := getOpts.Start
+	for _, logStart := range getOpts.LogStarts {
+		if start == 0 || logStart < start {
+			start = logStart
+		}
+	}
+
 	opts := apimodels.GetBuildloggerLogsOptionsV2{
 		BaseURL:   env.Settings().Cedar.BaseURL,
 		TaskID:    taskOpts.TaskID,
 		Execution: utility.ToIntPtr(taskOpts.Execution),
-		Start:     getOpts.Start,
+		Start:     start,
 		End:       getOpts.End,
 		Limit:     getOpts.LineLimit,
 		Tail:      getOpts.TailN,
@@ -1,6 +1,25 @@
 package taskoutput
 
-import "github.com/evergreen-ci/evergreen"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// TaskOutputVersionCedar stores task output with Cedar Buildlogger.
+	TaskOutputVersionCedar = 0
+	// TaskOutputVersionLogServiceV0 stores task output with the v0
+	// Evergreen log service, which persists plain text log lines.
+	TaskOutputVersionLogServiceV0 = 1
+	// TaskOutputVersionLogServiceV1 stores task output with the v1
+	// Evergreen log service, which also persists log line attributes.
+	TaskOutputVersionLogServiceV1 = 2
+)
 
 // TaskOutput is the versioned entry point for coordinating persistent storage
 // of a task run's output data.
@@ -20,14 +39,70 @@ type TaskOptions struct {
 	Execution int `bson:"-" json:"-"`
 }
 
-// InitializeTaskOutput initializes the task output for a new task run.
+// InitializeTaskOutput initializes the task output for a new task run. Task
+// logs are stored with the task output version configured in the admin
+// settings, as long as a log bucket is configured. Test logs are always
+// stored with Cedar Buildlogger.
 func InitializeTaskOutput(env evergreen.Environment, opts TaskOptions) *TaskOutput {
-	return &TaskOutput{
+	output := &TaskOutput{
 		TaskLogs: TaskLogOutput{
-			Version: 0,
+			Version: TaskOutputVersionCedar,
 		},
 		TestLogs: TestLogOutput{
-			Version: 0,
+			Version: TaskOutputVersionCedar,
 		},
 	}
+
+	settings := env.Settings()
+	if settings == nil || settings.Buckets.LogBucket.Name == "" {
+		return output
+	}
+	if version := settings.Buckets.TaskOutputVersion; version != TaskOutputVersionCedar {
+		output.TaskLogs.Version = version
+		output.TaskLogs.BucketConfig = settings.Buckets.LogBucket
+	}
+
+	return output
+}
+
+// CompactLogs merges adjacent chunks of the task and test logs belonging to
+// the specified task run into chunks of up to maxChunkLines lines. If dryRun
+// is true, the result is computed without modifying any stored logs.
+func (o TaskOutput) CompactLogs(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, maxChunkLines int, dryRun bool) (log.CompactResult, error) {
+	var result log.CompactResult
+
+	taskLogsResult, err := o.TaskLogs.Compact(ctx, env, taskOpts, maxChunkLines, dryRun)
+	if err != nil {
+		return result, errors.Wrap(err, "compacting task logs")
+	}
+	result.Add(taskLogsResult)
+
+	testLogsResult, err := o.TestLogs.Compact(ctx, env, taskOpts, maxChunkLines, dryRun)
+	if err != nil {
+		return result, errors.Wrap(err, "compacting test logs")
+	}
+	result.Add(testLogsResult)
+
+	return result, nil
+}
+
+// DeleteProjectLogsBefore deletes the task and test logs of every task run
+// in the given project, stored in the given bucket by the Evergreen log
+// service, that were written before the cutoff. If dryRun is true, the result
+// is computed without deleting any stored logs.
+func DeleteProjectLogsBefore(ctx context.Context, env evergreen.Environment, bucketConfig evergreen.BucketConfig, projectID string, before time.Time, dryRun bool) (log.DeleteBeforeResult, error) {
+	if projectID == "" {
+		return log.DeleteBeforeResult{}, errors.New("must specify a project ID")
+	}
+
+	b, err := newBucket(ctx, env, bucketConfig)
+	if err != nil {
+		return log.DeleteBeforeResult{}, err
+	}
+
+	return log.NewLogServiceV0(b).DeleteBefore(ctx, log.DeleteBeforeOptions{
+		LogNames: []string{fmt.Sprintf("%s/", projectID)},
+		Before:   before.UnixNano(),
+		DryRun:   dryRun,
+	})
 }
@@ -42,11 +42,11 @@ type TestLogGetOptions struct {
 
 // Get returns test logs belonging to the specified task run.
 func (o TestLogOutput) Get(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, getOpts TestLogGetOptions) (log.LogIterator, error) {
-	if o.Version == 0 {
+	if o.Version == TaskOutputVersionCedar {
 		return o.getBuildloggerLogs(ctx, env, taskOpts, getOpts)
 	}
 
-	svc, err := o.getLogService(ctx)
+	svc, err := o.getLogService(ctx, env)
 	if err != nil {
 		return nil, errors.Wrap(err, "getting log service")
 	}
@@ -60,6 +60,26 @@ func (o TestLogOutput) Get(ctx context.Context, env evergreen.Environment, taskO
 	})
 }
 
+// Compact merges adjacent chunks of the test logs belonging to the specified
+// task run into chunks of up to maxChunkLines lines.
+func (o TestLogOutput) Compact(ctx context.Context, env evergreen.Environment, taskOpts TaskOptions, maxChunkLines int, dryRun bool) (log.CompactResult, error) {
+	if o.Version == TaskOutputVersionCedar {
+		// Cedar Buildlogger manages the storage of its logs.
+		return log.CompactResult{}, nil
+	}
+
+	svc, err := o.getLogService(ctx, env)
+	if err != nil {
+		return log.CompactResult{}, errors.Wrap(err, "getting log service")
+	}
+
+	return svc.Compact(ctx, log.CompactOptions{
+		LogNames:      []string{fmt.Sprintf("%s/%s/%d/%s", taskOpts.ProjectID, taskOpts.TaskID, taskOpts.Execution, o.ID())},
+		MaxChunkLines: maxChunkLines,
+		DryRun:        dryRun,
+	})
+}
+
 func (o TestLogOutput) getLogNames(taskOpts TaskOptions, logPaths []string) []string {
 	prefix := fmt.Sprintf("%s/%s/%d/%s", taskOpts.ProjectID, taskOpts.TaskID, taskOpts.Execution, o.ID())
 
@@ -71,13 +91,8 @@ func (o TestLogOutput) getLogNames(taskOpts TaskOptions, logPaths []string) []st
 	return logNames
 }
 
-func (o TestLogOutput) getLogService(ctx context.Context) (log.LogService, error) {
-	b, err := newBucket(ctx, o.BucketConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	return log.NewLogServiceV0(b), nil
+func (o TestLogOutput) getLogService(ctx context.Context, env evergreen.Environment) (log.LogService, error) {
+	return newLogService(ctx, env, o.Version, o.BucketConfig)
 }
 
 // getBuildloggerLogs makes request to Cedar Buildlogger for logs.
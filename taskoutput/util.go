@@ -2,15 +2,16 @@ package taskoutput
 
 import (
 	"context"
+	"os"
 
 	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/log"
 	"github.com/evergreen-ci/pail"
 	"github.com/evergreen-ci/utility"
 	"github.com/pkg/errors"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func newBucket(ctx context.Context, config evergreen.BucketConfig) (pail.Bucket, error) {
+func newBucket(ctx context.Context, env evergreen.Environment, config evergreen.BucketConfig) (pail.Bucket, error) {
 	switch config.Type {
 	case evergreen.BucketTypeS3:
 		return pail.NewS3Bucket(pail.S3Options{
@@ -21,18 +22,40 @@ func newBucket(ctx context.Context, config evergreen.BucketConfig) (pail.Bucket,
 			Compress:    true,
 		})
 	case evergreen.BucketTypeGridFS:
-		client, err := mongo.Connect(ctx)
-		if err != nil {
-			return nil, errors.Wrap(err, "connecting to the GridFS DB")
-		}
-
-		return pail.NewGridFSBucketWithClient(ctx, client, pail.GridFSOptions{
+		return pail.NewGridFSBucketWithClient(ctx, env.Client(), pail.GridFSOptions{
 			Name:     config.Name,
 			Database: config.DBName,
 		})
 	case evergreen.BucketTypeLocal:
+		if err := os.MkdirAll(config.Name, 0755); err != nil {
+			return nil, errors.Wrapf(err, "creating local bucket directory '%s'", config.Name)
+		}
+
 		return pail.NewLocalBucket(pail.LocalOptions{Path: config.Name})
 	default:
 		return nil, errors.Errorf("unrecognized bucket type '%s'", config.Type)
 	}
 }
+
+// newLogService returns the Evergreen log service for the given task output
+// version. Task output stored with Cedar Buildlogger has no log service.
+func newLogService(ctx context.Context, env evergreen.Environment, version int, config evergreen.BucketConfig) (log.LogService, error) {
+	var newService func(pail.Bucket) log.LogService
+	switch version {
+	case TaskOutputVersionLogServiceV0:
+		newService = func(b pail.Bucket) log.LogService { return log.NewLogServiceV0(b) }
+	case TaskOutputVersionLogServiceV1:
+		newService = func(b pail.Bucket) log.LogService { return log.NewLogServiceV1(b) }
+	case TaskOutputVersionCedar:
+		return nil, errors.New("task output stored with Cedar Buildlogger does not use the Evergreen log service")
+	default:
+		return nil, errors.Errorf("unrecognized task output version %d", version)
+	}
+
+	b, err := newBucket(ctx, env, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newService(b), nil
+}
@@ -198,6 +198,15 @@ func MockConfig() *evergreen.Settings {
 				},
 			},
 		},
+		Cost: evergreen.CostConfig{
+			DefaultHourlyCost: 0.1,
+			InstanceTypeHourlyCosts: []evergreen.InstanceTypeHourlyCost{
+				{
+					InstanceType: "m5.xlarge",
+					HourlyCost:   0.192,
+				},
+			},
+		},
 		Credentials: map[string]string{"k1": "v1"},
 		DataPipes: evergreen.DataPipesConfig{
 			Host:         "url",
@@ -368,6 +377,20 @@ func MockConfig() *evergreen.Settings {
 				Username: "vsphere",
 				Password: "vsphere_pass",
 			},
+			Libvirt: evergreen.LibvirtConfig{
+				URI:         "qemu+ssh://evergreen@kvm.example.com/system",
+				StoragePool: "evergreen",
+			},
+			Kubernetes: evergreen.KubernetesConfig{
+				APIServerURL: "https://kubernetes.example.com",
+				Namespace:    "evergreen",
+				Token:        "kubernetes_token",
+				ContainerPools: []evergreen.KubernetesContainerPool{{
+					Name:         "pool",
+					Namespace:    "evergreen-pool",
+					NodeSelector: map[string]string{"pool": "pool"},
+				}},
+			},
 		},
 		RepoTracker: evergreen.RepoTrackerConfig{
 			NumNewRepoRevisionsToFetch: 10,
@@ -376,6 +399,10 @@ func MockConfig() *evergreen.Settings {
 		},
 		Scheduler: evergreen.SchedulerConfig{
 			TaskFinder: "legacy",
+			FairShareProjectWeights: []evergreen.FairShareProjectWeight{
+				{ProjectID: "mci", Weight: 2},
+			},
+			FairShareUsageWindowSeconds: 3600,
 		},
 		ServiceFlags: evergreen.ServiceFlags{
 			TaskDispatchDisabled:           true,
@@ -402,6 +429,7 @@ func MockConfig() *evergreen.Settings {
 			CloudCleanupDisabled:           true,
 			LegacyUIPublicAccessDisabled:   true,
 			UnsetFunctionVarsDisabled:      true,
+			TaskLogCompactionDryRun:        true,
 		},
 		SSHKeyDirectory: "/ssh_key_directory",
 		SSHKeyPairs: []evergreen.SSHKeyPair{
@@ -0,0 +1,87 @@
+package trigger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeProjectBudget, event.EventProjectBudgetExceeded, makeProjectBudgetTriggers)
+}
+
+type projectBudgetTriggers struct {
+	event      *event.EventLogEntry
+	data       *event.ProjectBudgetEventData
+	projectRef *model.ProjectRef
+	uiConfig   evergreen.UIConfig
+
+	base
+}
+
+func makeProjectBudgetTriggers() eventHandler {
+	t := &projectBudgetTriggers{}
+	t.base.triggers = map[string]trigger{
+		event.TriggerBudgetExceeded: t.budgetExceeded,
+	}
+
+	return t
+}
+
+func (t *projectBudgetTriggers) Fetch(ctx context.Context, e *event.EventLogEntry) error {
+	var ok bool
+	var err error
+	t.data, ok = e.Data.(*event.ProjectBudgetEventData)
+	if !ok {
+		return errors.Errorf("expected project budget event data, got %T", e.Data)
+	}
+
+	t.projectRef, err = model.FindMergedProjectRef(e.ResourceId, "", false)
+	if err != nil {
+		return errors.Wrapf(err, "finding project '%s'", e.ResourceId)
+	}
+	if t.projectRef == nil {
+		return errors.Errorf("project '%s' not found", e.ResourceId)
+	}
+
+	if err = t.uiConfig.Get(ctx); err != nil {
+		return errors.Wrap(err, "fetching UI config")
+	}
+
+	t.event = e
+	return nil
+}
+
+func (t *projectBudgetTriggers) Attributes() event.Attributes {
+	return event.Attributes{
+		ID:      []string{t.projectRef.Id},
+		Object:  []string{event.ObjectProject},
+		Project: []string{t.projectRef.Id},
+	}
+}
+
+func (t *projectBudgetTriggers) budgetExceeded(sub *event.Subscription) (*notification.Notification, error) {
+	data := &commonTemplateData{
+		ID:              t.projectRef.Id,
+		EventID:         t.event.ID,
+		SubscriptionID:  sub.ID,
+		DisplayName:     t.projectRef.Identifier,
+		Object:          event.ObjectProject,
+		Project:         t.projectRef.Identifier,
+		URL:             fmt.Sprintf("%s/project/%s/settings", t.uiConfig.UIv2Url, t.projectRef.Identifier),
+		PastTenseStatus: fmt.Sprintf("exceeded its monthly budget of $%.2f with an estimated spend of $%.2f for %s", t.data.Budget, t.data.Spend, t.data.Month.Format("January 2006")),
+		apiModel:        t.data,
+	}
+
+	payload, err := makeCommonPayload(sub, t.Attributes(), data)
+	if err != nil {
+		return nil, errors.Wrap(err, "building notification")
+	}
+
+	return notification.New(t.event.ID, sub.Trigger, &sub.Subscriber, payload)
+}
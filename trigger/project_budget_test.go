@@ -0,0 +1,82 @@
+package trigger
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectBudgetExceeded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.Implements(t, (*eventHandler)(nil), &projectBudgetTriggers{})
+
+	require.NoError(t, db.ClearCollections(event.EventCollection, event.SubscriptionsCollection, model.ProjectRefCollection))
+	defer func() {
+		assert.NoError(t, db.ClearCollections(event.EventCollection, event.SubscriptionsCollection, model.ProjectRefCollection))
+	}()
+
+	pRef := model.ProjectRef{
+		Id:            "project_id",
+		Identifier:    "project_identifier",
+		Enabled:       true,
+		MonthlyBudget: 100,
+	}
+	require.NoError(t, pRef.Insert())
+
+	uiConfig := &evergreen.UIConfig{
+		Url:     "https://evergreen.mongodb.com",
+		UIv2Url: "https://spruce.mongodb.com",
+	}
+	require.NoError(t, uiConfig.Set(ctx))
+
+	e := &event.EventLogEntry{
+		ID:           "e0",
+		ResourceType: event.ResourceTypeProjectBudget,
+		EventType:    event.EventProjectBudgetExceeded,
+		ResourceId:   pRef.Id,
+		Data: &event.ProjectBudgetEventData{
+			Month:  time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC),
+			Budget: 100,
+			Spend:  123.45,
+		},
+	}
+
+	t.Run("Fetch", func(t *testing.T) {
+		triggers := makeProjectBudgetTriggers().(*projectBudgetTriggers)
+		require.NoError(t, triggers.Fetch(ctx, e))
+		assert.Equal(t, pRef.Id, triggers.projectRef.Id)
+		assert.Equal(t, 123.45, triggers.data.Spend)
+
+		attributes := triggers.Attributes()
+		assert.Equal(t, []string{event.ObjectProject}, attributes.Object)
+		assert.Equal(t, []string{pRef.Id}, attributes.Project)
+	})
+	t.Run("NotificationsFromEvent", func(t *testing.T) {
+		require.NoError(t, db.Clear(event.SubscriptionsCollection))
+		sub := event.NewSubscriptionByID(event.ResourceTypeProjectBudget, event.TriggerBudgetExceeded, pRef.Id, event.Subscriber{
+			Type:   event.SlackSubscriberType,
+			Target: "#channel",
+		})
+		require.NoError(t, sub.Upsert())
+
+		n, err := NotificationsFromEvent(ctx, e)
+		require.NoError(t, err)
+		require.Len(t, n, 1)
+
+		payload, ok := n[0].Payload.(*notification.SlackPayload)
+		require.True(t, ok)
+		assert.Contains(t, payload.Body, "project_identifier")
+		assert.Contains(t, payload.Body, "exceeded its monthly budget of $100.00 with an estimated spend of $123.45 for May 2023")
+		assert.Contains(t, payload.Body, "https://spruce.mongodb.com/project/project_identifier/settings")
+	})
+}
@@ -154,6 +154,11 @@ func (t *taskTriggers) Process(sub *event.Subscription) (*notification.Notificat
 	if t.task.Aborted {
 		return nil, nil
 	}
+	// Only the final outcome of a task that's automatically retried is
+	// notified.
+	if t.task.AutomaticallyRetried {
+		return nil, nil
+	}
 	return t.base.Process(sub)
 }
 
@@ -0,0 +1,54 @@
+package trigger
+
+import (
+	"fmt"
+
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeTask, event.TaskTestFlaky, makeTaskFlakyTestTriggers)
+}
+
+const triggerTaskTestFlaky = "test-flaky"
+
+// makeTaskFlakyTestTriggers returns the handler for events indicating that a
+// test has become flaky. It shares the task triggers' data and attributes, so
+// subscriptions can select the tasks whose tests they are interested in the
+// same way as for other task triggers.
+func makeTaskFlakyTestTriggers() eventHandler {
+	t := &taskTriggers{
+		oldTestResults: map[string]*testresult.TestResult{},
+	}
+	t.base.triggers = map[string]trigger{
+		triggerTaskTestFlaky: t.taskTestFlaky,
+	}
+
+	return t
+}
+
+func (t *taskTriggers) taskTestFlaky(sub *event.Subscription) (*notification.Notification, error) {
+	if t.data.TestName == "" {
+		return nil, nil
+	}
+
+	match, err := testMatchesRegex(t.data.TestName, sub)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"source":  "test-trigger",
+			"message": "bad regex in db",
+			"task":    t.task.Id,
+			"project": t.task.Project,
+		}))
+		return nil, nil
+	}
+	if !match {
+		return nil, nil
+	}
+
+	return t.generate(sub, fmt.Sprintf("has a flaky test (flip rate %.0f%%)", t.data.FlipRate*100), t.data.TestName)
+}
@@ -0,0 +1,51 @@
+package trigger
+
+import (
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/notification"
+	"github.com/evergreen-ci/evergreen/model/testresult"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+)
+
+func init() {
+	registry.registerEventHandler(event.ResourceTypeTask, event.TaskStepbackCulprit, makeTaskStepbackCulpritTriggers)
+}
+
+const triggerTaskStepbackCulprit = "stepback-culprit"
+
+// makeTaskStepbackCulpritTriggers returns the handler for events indicating
+// that stepback found the commit that introduced a failure. Subscribing to it
+// with a JIRA issue subscriber files a ticket for each culprit.
+func makeTaskStepbackCulpritTriggers() eventHandler {
+	t := &taskTriggers{
+		oldTestResults: map[string]*testresult.TestResult{},
+	}
+	t.base.triggers = map[string]trigger{
+		triggerTaskStepbackCulprit: t.taskStepbackCulprit,
+	}
+
+	return t
+}
+
+func (t *taskTriggers) taskStepbackCulprit(sub *event.Subscription) (*notification.Notification, error) {
+	if t.data.TestName == "" {
+		return t.generate(sub, "introduced a failure", "")
+	}
+
+	match, err := testMatchesRegex(t.data.TestName, sub)
+	if err != nil {
+		grip.Error(message.WrapError(err, message.Fields{
+			"source":  "test-trigger",
+			"message": "bad regex in db",
+			"task":    t.task.Id,
+			"project": t.task.Project,
+		}))
+		return nil, nil
+	}
+	if !match {
+		return nil, nil
+	}
+
+	return t.generate(sub, "introduced a test failure", t.data.TestName)
+}
@@ -454,6 +454,22 @@ func (s *taskSuite) TestAbortedTaskDoesNotNotify() {
 	s.Empty(n)
 }
 
+func (s *taskSuite) TestAutomaticallyRetriedTaskDoesNotNotify() {
+	n, err := NotificationsFromEvent(s.ctx, &s.event)
+	s.NoError(err)
+	s.NotEmpty(n)
+
+	s.task.AutomaticallyRetried = true
+	s.NoError(db.Update(task.Collection, bson.M{"_id": s.task.Id}, &s.task))
+
+	// works even if the task is archived
+	s.NoError(s.task.Archive())
+
+	n, err = NotificationsFromEvent(s.ctx, &s.event)
+	s.NoError(err)
+	s.Empty(n)
+}
+
 func (s *taskSuite) TestExecutionTask() {
 	t := task.Task{
 		Id:             "dt",
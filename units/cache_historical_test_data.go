@@ -0,0 +1,157 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/taskstats"
+	"github.com/evergreen-ci/evergreen/model/teststats"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const cacheHistoricalTestDataName = "cache-historical-test-data"
+
+func init() {
+	registry.AddJobType(cacheHistoricalTestDataName,
+		func() amboy.Job { return makeCacheHistoricalTestDataJob() })
+}
+
+type cacheHistoricalTestDataJob struct {
+	ProjectID  string   `bson:"project_id" json:"project_id" yaml:"project_id"`
+	Requesters []string `bson:"requesters" json:"requesters" yaml:"requesters"`
+	job.Base   `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+// NewCacheHistoricalTestDataJob returns a job that pre-computes the daily
+// test stats of the project's mainline tasks that finished since the last
+// time the job ran.
+func NewCacheHistoricalTestDataJob(env evergreen.Environment, id, projectID string) amboy.Job {
+	j := makeCacheHistoricalTestDataJob()
+	j.env = env
+	j.ProjectID = projectID
+	j.Requesters = []string{evergreen.RepotrackerVersionRequester}
+	j.SetID(fmt.Sprintf("%s.%s.%s", cacheHistoricalTestDataName, projectID, id))
+	return j
+}
+
+func makeCacheHistoricalTestDataJob() *cacheHistoricalTestDataJob {
+	j := &cacheHistoricalTestDataJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    cacheHistoricalTestDataName,
+				Version: 0,
+			},
+		},
+	}
+	return j
+}
+
+func (j *cacheHistoricalTestDataJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	startAt := time.Now()
+	timingMsg := message.Fields{
+		"job_id":       j.ID(),
+		"project":      j.ProjectID,
+		"job_type":     j.Type().Name,
+		"message":      "timing-info",
+		"run_start_at": startAt,
+	}
+	defer func() {
+		timingMsg["has_errors"] = j.HasErrors()
+		timingMsg["aborted"] = ctx.Err() != nil
+		timingMsg["total"] = time.Since(startAt).Seconds()
+		timingMsg["run_end_at"] = time.Now()
+		grip.Info(timingMsg)
+	}()
+
+	flags, err := evergreen.GetServiceFlags(ctx)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "getting service flags"))
+		return
+	}
+	if flags.CacheStatsJobDisabled {
+		j.AddError(errors.New("cache stats job is disabled"))
+		return
+	}
+
+	var statsStatus taskstats.StatsStatus
+	timingMsg["status_check"] = reportTiming(func() {
+		statsStatus, err = teststats.GetStatsStatus(j.ProjectID)
+		j.AddError(errors.Wrap(err, "getting daily test stats status"))
+	}).Seconds()
+	if j.HasErrors() {
+		return
+	}
+
+	// As with the task stats, the window of finished tasks to process
+	// starts at ProcessedTasksUntil and is capped at 24 hours.
+	updateWindowStart := statsStatus.ProcessedTasksUntil
+	updateWindowEnd := time.Now()
+	if max := updateWindowStart.Add(24 * time.Hour); updateWindowEnd.After(max) {
+		updateWindowEnd = max
+	}
+	timingMsg["stats_update_window_start"] = updateWindowStart
+	timingMsg["stats_update_window_end"] = updateWindowEnd
+
+	var statsToUpdate []taskstats.StatsToUpdate
+	timingMsg["find_test_stats_to_update"] = reportTiming(func() {
+		statsToUpdate, err = taskstats.FindStatsToUpdate(taskstats.FindStatsToUpdateOptions{
+			ProjectID:  j.ProjectID,
+			Requesters: j.Requesters,
+			Start:      updateWindowStart,
+			End:        updateWindowEnd,
+		})
+		j.AddError(errors.Wrap(err, "finding daily test stats to update"))
+	}).Seconds()
+	if j.HasErrors() {
+		return
+	}
+
+	timingMsg["update_daily_test_stats"] = reportTiming(func() {
+		for _, toUpdate := range statsToUpdate {
+			if len(toUpdate.Tasks) == 0 {
+				continue
+			}
+
+			err := errors.Wrap(teststats.GenerateStats(ctx, j.env, taskstats.GenerateStatsOptions{
+				ProjectID: j.ProjectID,
+				Requester: toUpdate.Requester,
+				Date:      toUpdate.Day,
+				Tasks:     toUpdate.Tasks,
+			}), "generating daily test stats")
+			grip.Warning(message.WrapError(err, message.Fields{
+				"job_id":         j.ID(),
+				"project":        j.ProjectID,
+				"job_type":       j.Type().Name,
+				"job_start_time": startAt,
+				"task_date":      utility.GetUTCDay(toUpdate.Day),
+			}))
+			if err != nil {
+				j.AddError(err)
+				return
+			}
+		}
+	}).Seconds()
+	if j.HasErrors() {
+		return
+	}
+
+	timingMsg["save_stats_status"] = reportTiming(func() {
+		j.AddError(errors.Wrap(teststats.UpdateStatsStatus(j.ProjectID, startAt, updateWindowEnd, time.Since(startAt)), "updating daily test stats status"))
+	}).Seconds()
+}
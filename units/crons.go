@@ -863,7 +863,7 @@ func PopulateBackgroundStatsJobs(env evergreen.Environment, part int) amboy.Queu
 		if flags.BackgroundStatsDisabled {
 			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
 				"message": "background stats collection disabled",
-				"impact":  "host, pod, task, latency, amboy, and notification stats disabled",
+				"impact":  "host, pod, task, task log compaction, latency, amboy, and notification stats disabled",
 				"mode":    "degraded",
 			})
 			return nil
@@ -878,6 +878,7 @@ func PopulateBackgroundStatsJobs(env evergreen.Environment, part int) amboy.Queu
 		catcher.Wrap(queue.Put(ctx, NewTaskStatsCollector(ts)), "enqueueing task stats collector job")
 		catcher.Wrap(queue.Put(ctx, NewNotificationStatsCollector(ts)), "enqueueing notification stats collector job")
 		catcher.Wrap(queue.Put(ctx, NewQueueStatsCollector(ts)), "enqueueing task queue stats collector job")
+		catcher.Wrap(queue.Put(ctx, NewTaskLogCompactionStatsCollector(ts)), "enqueueing task log compaction stats collector job")
 
 		return catcher.Resolve()
 	}
@@ -936,6 +937,121 @@ func PopulateCacheHistoricalTaskDataJob(part int) amboy.QueueOperation {
 	}
 }
 
+// PopulateCacheHistoricalTestDataJob enqueues the jobs to pre-compute the
+// daily test stats of each enabled project.
+func PopulateCacheHistoricalTestDataJob(env evergreen.Environment, part int) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags(ctx)
+		if err != nil {
+			return errors.Wrap(err, "getting service flags")
+		}
+		if flags.CacheStatsJobDisabled {
+			grip.InfoWhen(sometimes.Percent(evergreen.DegradedLoggingPercent), message.Fields{
+				"message": "cache historical test data job is disabled",
+				"impact":  "pre-computed test stats are not updated",
+				"mode":    "degraded",
+			})
+			return nil
+		}
+
+		projects, err := model.FindAllMergedTrackedProjectRefs()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		ts := utility.RoundPartOfDay(part).Format(TSFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, project := range projects {
+			if !project.Enabled || project.IsStatsCacheDisabled() {
+				continue
+			}
+
+			catcher.Wrapf(queue.Put(ctx, NewCacheHistoricalTestDataJob(env, ts, project.Id)), "enqueueing cache historical test data job for project '%s'", project.Identifier)
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateTaskLogCompactionJobs enqueues the jobs to compact and expire the
+// task logs of each enabled project. The jobs only report what they would
+// change if task log compaction is set to dry run in the service flags.
+func PopulateTaskLogCompactionJobs(env evergreen.Environment, part int) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		flags, err := evergreen.GetServiceFlags(ctx)
+		if err != nil {
+			return errors.Wrap(err, "getting service flags")
+		}
+
+		projects, err := model.FindAllMergedTrackedProjectRefs()
+		if err != nil {
+			return errors.Wrap(err, "finding tracked projects")
+		}
+
+		ts := utility.RoundPartOfDay(part).Format(TSFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, project := range projects {
+			if !project.Enabled {
+				continue
+			}
+
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewTaskLogCompactionJob(env, project.Id, ts, flags.TaskLogCompactionDryRun)), "enqueueing task log compaction job for project '%s'", project.Identifier)
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateTestFlakinessJobs enqueues the jobs to calculate the flakiness of
+// each enabled project's tests.
+func PopulateTestFlakinessJobs(env evergreen.Environment, part int) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		projects, err := model.FindAllMergedTrackedProjectRefs()
+		if err != nil {
+			return errors.Wrap(err, "finding tracked projects")
+		}
+
+		ts := utility.RoundPartOfDay(part).Format(TSFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, project := range projects {
+			if !project.Enabled {
+				continue
+			}
+
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewTestFlakinessJob(env, project.Id, ts)), "enqueueing test flakiness job for project '%s'", project.Identifier)
+		}
+
+		return catcher.Resolve()
+	}
+}
+
+// PopulateProjectBudgetJobs enqueues the jobs to check if each enabled project
+// with a monthly budget has exceeded it.
+func PopulateProjectBudgetJobs(env evergreen.Environment) amboy.QueueOperation {
+	return func(ctx context.Context, queue amboy.Queue) error {
+		projects, err := model.FindAllMergedTrackedProjectRefs()
+		if err != nil {
+			return errors.Wrap(err, "finding tracked projects")
+		}
+
+		ts := utility.RoundPartOfHour(0).Format(TSFormat)
+
+		catcher := grip.NewBasicCatcher()
+		for _, project := range projects {
+			if !project.Enabled || project.MonthlyBudget <= 0 {
+				continue
+			}
+
+			catcher.Wrapf(amboy.EnqueueUniqueJob(ctx, queue, NewProjectBudgetJob(env, project.Id, ts)), "enqueueing project budget job for project '%s'", project.Identifier)
+		}
+
+		return catcher.Resolve()
+	}
+}
+
 func PopulateSpawnhostExpirationCheckJob() amboy.QueueOperation {
 	return func(ctx context.Context, queue amboy.Queue) error {
 		hosts, err := host.FindSpawnhostsWithNoExpirationToExtend(ctx)
@@ -1265,6 +1381,10 @@ func podDefinitionCreationJobs(ctx context.Context, ts time.Time) ([]amboy.Job,
 	env := evergreen.GetEnvironment()
 	jobs := make([]amboy.Job, 0, len(pods))
 	for _, p := range pods {
+		if p.IsKubernetes() {
+			// Kubernetes pods don't use pod definitions.
+			continue
+		}
 		jobs = append(jobs, NewPodDefinitionCreationJob(env.Settings().Providers.AWS.Pod.ECS, p.TaskContainerCreationOpts, ts.Format(TSFormat)))
 	}
 
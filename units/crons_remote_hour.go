@@ -47,6 +47,10 @@ func (j *cronsRemoteHourJob) Run(ctx context.Context) {
 
 	ops := []amboy.QueueOperation{
 		PopulateCacheHistoricalTaskDataJob(2),
+		PopulateCacheHistoricalTestDataJob(j.env, 2),
+		PopulateTaskLogCompactionJobs(j.env, 1),
+		PopulateTestFlakinessJobs(j.env, 2),
+		PopulateProjectBudgetJobs(j.env),
 		PopulateHostProvisioningConversionJobs(j.env),
 		PopulateHostRestartJasperJobs(j.env),
 		PopulateSpawnhostExpirationCheckJob(),
@@ -142,6 +142,9 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		return
 	}
 	upHosts := existingHosts.Uphosts()
+	// Hosts in the warm pool don't count as task capacity until they're taken
+	// out of the pool.
+	taskHosts, warmPoolHosts := upHosts.PartitionWarmPool()
 
 	distroQueueInfo, err := model.GetDistroQueueInfo(j.DistroID)
 	if err != nil {
@@ -151,15 +154,29 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 
 	hostAllocationBegins := time.Now()
 
-	hostAllocator := scheduler.GetHostAllocator(config.Scheduler.HostAllocator)
+	hostAllocatorName := scheduler.GetHostAllocatorName(config.Scheduler, *distro)
+	hostAllocator := scheduler.GetHostAllocator(hostAllocatorName)
 
 	hostAllocatorData := scheduler.HostAllocatorData{
 		Distro:          *distro,
-		ExistingHosts:   upHosts,
+		ExistingHosts:   taskHosts,
 		UsesContainers:  (containerPool != nil),
 		ContainerPool:   containerPool,
 		DistroQueueInfo: distroQueueInfo,
 	}
+	if hostAllocatorName == evergreen.HostAllocatorPredictive {
+		forecast, err := model.GetDistroDemandForecast(j.DistroID, hostAllocationBegins)
+		if err != nil {
+			// Without a forecast, the predictive host allocator only
+			// allocates hosts for the current queue.
+			grip.Warning(message.WrapError(err, message.Fields{
+				"message": "could not get demand forecast for distro",
+				"distro":  j.DistroID,
+				"job":     j.ID(),
+			}))
+		}
+		hostAllocatorData.DemandForecast = forecast
+	}
 
 	// nHosts is the number of additional hosts desired.
 	nHosts, nHostsFree, err := hostAllocator(ctx, &hostAllocatorData)
@@ -177,6 +194,28 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		"duration_secs": time.Since(hostAllocationBegins).Seconds(),
 	})
 
+	///////////////////
+	// warm-pool phase
+	///////////////////
+
+	// Use the hosts that are ready in the warm pool before spawning new
+	// ones, since they can run tasks right away.
+	claimedHostIDs, err := host.ClaimWarmPoolHosts(ctx, distro.Id, nHosts)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "taking hosts out of the warm pool"))
+		return
+	}
+	nHosts -= len(claimedHostIDs)
+	if len(warmPoolHosts) > 0 {
+		// Warm pool hosts still count toward the distro's maximum hosts.
+		if maxNewHosts := distro.HostAllocatorSettings.MaximumHosts - len(upHosts); nHosts > maxNewHosts {
+			nHosts = maxNewHosts
+		}
+		if nHosts < 0 {
+			nHosts = 0
+		}
+	}
+
 	//////////////////////
 	// host-spawning phase
 	//////////////////////
@@ -201,6 +240,12 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		j.AddError(errors.Wrapf(err, "enqueueing host create jobs"))
 	}
 
+	var warmPoolHostsSpawned []host.Host
+	if containerPool == nil {
+		warmPoolHostsSpawned, err = j.refillWarmPool(ctx, distro, len(upHosts)+len(hostsSpawned), len(warmPoolHosts)-len(claimedHostIDs))
+		j.AddError(errors.Wrap(err, "refilling warm pool"))
+	}
+
 	// ignoring all the tasks that will take longer than the threshold to run,
 	// and the hosts allocated for them,
 	// how long will it take the current fleet of hosts, plus the ones we spawned, to chew through
@@ -224,7 +269,7 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 	scheduledDuration := correctedExpectedDuration - correctedDurationOverThreshold
 	durationOverThreshNoTaskGroups := distroQueueInfo.CountDurationOverThreshold - countDurationOverThresholdInTaskGroups
 
-	correctedHostsSpawned := len(hostsSpawned) - requiredInTaskGroups
+	correctedHostsSpawned := len(hostsSpawned) + len(claimedHostIDs) - requiredInTaskGroups
 	hostsAvail := (nHostsFree - freeInTaskGroups) + correctedHostsSpawned - durationOverThreshNoTaskGroups
 
 	var timeToEmpty, timeToEmptyNoSpawns time.Duration
@@ -254,10 +299,10 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 	const lowRatioThresh = float32(.25)
 	terminationOn := distro.HostAllocatorSettings.HostsOverallocatedRule == evergreen.HostsOverallocatedTerminate
 	terminatableDistro := utility.StringSliceContains(evergreen.ProviderSpawnable, distro.Provider)
-	if terminationOn && terminatableDistro && hostQueueRatio < lowRatioThresh && len(upHosts) > 0 {
-		distroIsByHour := cloud.UsesHourlyBilling(&upHosts[0].Distro)
+	if terminationOn && terminatableDistro && hostQueueRatio < lowRatioThresh && len(taskHosts) > 0 {
+		distroIsByHour := cloud.UsesHourlyBilling(&taskHosts[0].Distro)
 		if !distroIsByHour {
-			j.setTargetAndTerminate(ctx, len(upHosts), hostQueueRatio, distro)
+			j.setTargetAndTerminate(ctx, len(taskHosts), len(warmPoolHosts), hostQueueRatio, distro)
 		}
 	}
 
@@ -268,9 +313,12 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		"provider":                     distro.Provider,
 		"max_hosts":                    distro.HostAllocatorSettings.MaximumHosts,
 		"num_new_hosts":                len(hostsSpawned),
+		"num_warm_pool_hosts_claimed":  len(claimedHostIDs),
+		"num_warm_pool_hosts_spawned":  len(warmPoolHostsSpawned),
+		"warm_pool_size":               distro.HostAllocatorSettings.WarmPoolSize,
 		"pool_info":                    existingHosts.Stats(),
 		"task_queue_length":            distroQueueInfo.Length,
-		"num_hosts_running":            len(upHosts),
+		"num_hosts_running":            len(taskHosts),
 		"overdue_tasks":                distroQueueInfo.CountWaitOverThreshold,
 		"overdue_tasks_in_groups":      totalOverdueInTaskGroups,
 		"total_runtime":                distroQueueInfo.ExpectedDuration.String(),
@@ -290,7 +338,9 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 		attribute.String(evergreen.DistroIDOtelAttribute, distro.Id),
 		attribute.Int(fmt.Sprintf("%s.hosts_requested", hostAllocatorAttributePrefix), len(hostsSpawned)),
 		attribute.Int(fmt.Sprintf("%s.hosts_free", hostAllocatorAttributePrefix), nHostsFree),
-		attribute.Int(fmt.Sprintf("%s.hosts_running", hostAllocatorAttributePrefix), len(upHosts)),
+		attribute.Int(fmt.Sprintf("%s.hosts_running", hostAllocatorAttributePrefix), len(taskHosts)),
+		attribute.Int(fmt.Sprintf("%s.hosts_warm_pool", hostAllocatorAttributePrefix), len(warmPoolHosts)-len(claimedHostIDs)),
+		attribute.Int(fmt.Sprintf("%s.hosts_warm_pool_claimed", hostAllocatorAttributePrefix), len(claimedHostIDs)),
 		attribute.Int(fmt.Sprintf("%s.hosts_active", hostAllocatorAttributePrefix), existingHosts.Stats().Active),
 		attribute.Int(fmt.Sprintf("%s.hosts_idle", hostAllocatorAttributePrefix), existingHosts.Stats().Idle),
 		attribute.Int(fmt.Sprintf("%s.hosts_provisioning", hostAllocatorAttributePrefix), existingHosts.Stats().Provisioning),
@@ -303,7 +353,7 @@ func (j *hostAllocatorJob) Run(ctx context.Context) {
 	)
 }
 
-func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts int, hostQueueRatio float32, distro *distro.Distro) {
+func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts, numWarmPoolHosts int, hostQueueRatio float32, distro *distro.Distro) {
 	var killableHosts, newCapTarget int
 	if hostQueueRatio == 0 {
 		killableHosts = numUpHosts
@@ -320,8 +370,10 @@ func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts
 	if killableHosts > lowCountFloor {
 
 		drawdownInfo := DrawdownInfo{
-			DistroID:     distro.Id,
-			NewCapTarget: newCapTarget,
+			DistroID: distro.Id,
+			// The drawdown counts all running hosts, but it never
+			// terminates hosts in the warm pool.
+			NewCapTarget: newCapTarget + numWarmPoolHosts,
 		}
 		err := amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), NewHostDrawdownJob(j.env, drawdownInfo, utility.RoundPartOfMinute(1).Format(TSFormat)))
 		if err != nil {
@@ -335,3 +387,30 @@ func (j *hostAllocatorJob) setTargetAndTerminate(ctx context.Context, numUpHosts
 	}
 
 }
+
+// refillWarmPool spawns hosts to bring the distro's warm pool back up to its
+// size without exceeding the distro's maximum hosts. If the warm pool is
+// larger than its size, the extra hosts are taken out of the pool so that
+// they can either run tasks or be terminated once they're idle.
+func (j *hostAllocatorJob) refillWarmPool(ctx context.Context, d *distro.Distro, numUpHosts, numWarmPoolHosts int) ([]host.Host, error) {
+	size := d.HostAllocatorSettings.WarmPoolSize
+	if numWarmPoolHosts > size {
+		_, err := host.ClaimWarmPoolHosts(ctx, d.Id, numWarmPoolHosts-size)
+		return nil, errors.Wrap(err, "taking extra hosts out of the warm pool")
+	}
+
+	numToSpawn := size - numWarmPoolHosts
+	if maxNewHosts := d.HostAllocatorSettings.MaximumHosts - numUpHosts; numToSpawn > maxNewHosts {
+		numToSpawn = maxNewHosts
+	}
+	if numToSpawn <= 0 {
+		return nil, nil
+	}
+
+	hostsSpawned, err := scheduler.SpawnWarmPoolHosts(ctx, *d, numToSpawn)
+	if err != nil {
+		return nil, errors.Wrap(err, "spawning warm pool hosts")
+	}
+
+	return hostsSpawned, errors.Wrap(EnqueueHostCreateJobs(ctx, j.env, hostsSpawned), "enqueueing warm pool host create jobs")
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
+	"github.com/evergreen-ci/evergreen/model"
 	"github.com/evergreen-ci/evergreen/model/distro"
 	"github.com/evergreen-ci/evergreen/model/host"
+	"github.com/evergreen-ci/evergreen/scheduler"
 	"github.com/evergreen-ci/utility"
 	"github.com/mongodb/amboy"
 	"github.com/mongodb/amboy/job"
@@ -122,7 +124,7 @@ func (j *idleHostJob) Run(ctx context.Context) {
 	}
 
 	for _, info := range distroHosts {
-		minimumHostsForDistro := distrosMap[info.DistroID].HostAllocatorSettings.MinimumHosts
+		minimumHostsForDistro := getMinimumHostsForDistro(schedulerConfig, distrosMap[info.DistroID])
 		minNumHostsToEvaluate := getMinNumHostsToEvaluate(info, minimumHostsForDistro)
 
 		currentDistro := distrosMap[info.DistroID]
@@ -140,6 +142,45 @@ func (j *idleHostJob) Run(ctx context.Context) {
 	}
 }
 
+// getMinimumHostsForDistro returns the number of hosts that should be kept
+// running for the distro. For distros using the predictive host allocator,
+// this includes hosts that were pre-spun for forecasted demand so that they
+// are not terminated before the forecasted peak.
+func getMinimumHostsForDistro(schedulerConfig evergreen.SchedulerConfig, d distro.Distro) int {
+	minimumHosts := d.HostAllocatorSettings.MinimumHosts
+	if scheduler.GetHostAllocatorName(schedulerConfig, d) != evergreen.HostAllocatorPredictive {
+		return minimumHosts
+	}
+
+	now := time.Now()
+	forecast, err := model.GetDistroDemandForecast(d.Id, now)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "could not get demand forecast for distro, falling back to minimum hosts",
+			"distro":  d.Id,
+			"job":     idleHostJobName,
+		}))
+		return minimumHosts
+	}
+	// Use the same max duration threshold that the host allocator used to
+	// pre-spin hosts so that they're not immediately terminated as excess.
+	var maxDurationThreshold time.Duration
+	distroQueueInfo, err := model.GetDistroQueueInfo(d.Id)
+	if err != nil {
+		grip.Warning(message.WrapError(err, message.Fields{
+			"message": "could not get distro queue info, falling back to default max duration threshold",
+			"distro":  d.Id,
+			"job":     idleHostJobName,
+		}))
+	} else {
+		maxDurationThreshold = distroQueueInfo.MaxDurationThreshold
+	}
+	if numForecastedHosts := scheduler.NumForecastedHosts(d, *forecast, now, maxDurationThreshold); numForecastedHosts > minimumHosts {
+		return numForecastedHosts
+	}
+	return minimumHosts
+}
+
 func getMinNumHostsToEvaluate(info host.IdleHostsByDistroID, minimumHosts int) int {
 	totalRunningHosts := info.RunningHostsCount
 	numIdleHosts := len(info.IdleHosts)
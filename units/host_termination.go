@@ -8,6 +8,7 @@ import (
 	"github.com/evergreen-ci/evergreen"
 	"github.com/evergreen-ci/evergreen/cloud"
 	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/cost"
 	"github.com/evergreen-ci/evergreen/model/event"
 	"github.com/evergreen-ci/evergreen/model/host"
 	"github.com/evergreen-ci/evergreen/model/task"
@@ -294,6 +295,16 @@ func (j *hostTerminationJob) Run(ctx context.Context) {
 
 	j.AddError(j.incrementIdleTime(ctx))
 
+	if j.host.Distro.Provider != evergreen.ProviderNameStatic {
+		grip.Warning(message.WrapError(cost.AllocateHostCost(ctx, j.env.Settings().Cost, j.host), message.Fields{
+			"message":  "could not allocate host cost to its tasks",
+			"host_id":  j.host.Id,
+			"distro":   j.host.Distro.Id,
+			"job_type": j.Type().Name,
+			"job":      j.ID(),
+		}))
+	}
+
 	terminationMessage := message.Fields{
 		"message":            "host successfully terminated",
 		"host_id":            j.host.Id,
@@ -299,5 +299,7 @@ func (j *podAllocatorJob) getIntentPodOptions(ctx context.Context) (*pod.TaskInt
 		WorkingDir:          j.task.ContainerOpts.WorkingDir,
 		PodSecretExternalID: podSecretExternalID,
 		PodSecretValue:      podSecret,
+		Platform:            j.pRef.PodPlatform,
+		ContainerPool:       j.pRef.PodContainerPool,
 	}, nil
 }
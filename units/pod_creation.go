@@ -19,6 +19,7 @@ import (
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -40,6 +41,8 @@ type podCreationJob struct {
 	ecsClient     cocoa.ECSClient
 	ecsPod        cocoa.ECSPod
 	ecsPodCreator cocoa.ECSPodCreator
+	k8sClient     kubernetes.Interface
+	smClient      cocoa.SecretsManagerClient
 	env           evergreen.Environment
 }
 
@@ -78,6 +81,9 @@ func (j *podCreationJob) Run(ctx context.Context) {
 		if j.ecsClient != nil {
 			j.AddError(errors.Wrap(j.ecsClient.Close(ctx), "closing ECS client"))
 		}
+		if j.smClient != nil {
+			j.AddError(errors.Wrap(j.smClient.Close(ctx), "closing Secrets Manager client"))
+		}
 
 		if j.pod != nil && j.pod.Status == pod.StatusInitializing && (j.RetryInfo().GetRemainingAttempts() == 0 || !j.RetryInfo().ShouldRetry()) {
 			j.AddError(errors.Wrap(j.pod.UpdateStatus(pod.StatusDecommissioned, "pod failed to start and will not retry"), "updating pod status to decommissioned after pod failed to start"))
@@ -110,6 +116,16 @@ func (j *podCreationJob) Run(ctx context.Context) {
 
 	switch j.pod.Status {
 	case pod.StatusInitializing:
+		if j.pod.IsKubernetes() {
+			res, err := j.createKubernetesPod(ctx, &settings)
+			if err != nil {
+				j.AddRetryableError(errors.Wrap(err, "starting Kubernetes pod"))
+				return
+			}
+			j.markPodStarting(*res)
+			return
+		}
+
 		execOpts, err := cloud.ExportECSPodExecutionOptions(settings.Providers.AWS.Pod.ECS, j.pod.TaskContainerCreationOpts)
 		if err != nil {
 			j.AddError(errors.Wrap(err, "getting pod execution options"))
@@ -132,27 +148,51 @@ func (j *podCreationJob) Run(ctx context.Context) {
 
 		j.ecsPod = p
 
-		res := p.Resources()
-		if err := j.pod.UpdateResources(cloud.ImportECSPodResources(res)); err != nil {
-			j.AddError(errors.Wrap(err, "updating pod resources"))
-		}
+		j.markPodStarting(cloud.ImportECSPodResources(p.Resources()))
+	default:
+		j.AddError(errors.Errorf("not starting pod because pod status is '%s'", j.pod.Status))
+	}
+}
 
-		// Bump the last communication time to ensure that the pod has a
-		// sufficient grace period to start up.
-		if err := j.pod.UpdateLastCommunicated(); err != nil {
-			j.AddError(errors.Wrap(err, "updating pod last communication time"))
-		}
+// markPodStarting records the resources for the pod that was just created in
+// the container service and marks it as starting up.
+func (j *podCreationJob) markPodStarting(res pod.ResourceInfo) {
+	if err := j.pod.UpdateResources(res); err != nil {
+		j.AddError(errors.Wrap(err, "updating pod resources"))
+	}
 
-		if err := j.pod.UpdateStatus(pod.StatusStarting, "pod successfully started"); err != nil {
-			j.AddError(errors.Wrap(err, "marking pod as starting"))
-		}
+	// Bump the last communication time to ensure that the pod has a
+	// sufficient grace period to start up.
+	if err := j.pod.UpdateLastCommunicated(); err != nil {
+		j.AddError(errors.Wrap(err, "updating pod last communication time"))
+	}
+
+	if err := j.pod.UpdateStatus(pod.StatusStarting, "pod successfully started"); err != nil {
+		j.AddError(errors.Wrap(err, "marking pod as starting"))
+	}
 
-		if err := j.logTaskTimingStats(); err != nil {
-			j.AddError(errors.Wrap(err, "logging task timing stats"))
+	if err := j.logTaskTimingStats(); err != nil {
+		j.AddError(errors.Wrap(err, "logging task timing stats"))
+	}
+}
+
+// createKubernetesPod creates the pod in the Kubernetes cluster. Unlike ECS
+// pods, Kubernetes pods are created directly from the container options, so
+// there's no pod definition to wait for.
+func (j *podCreationJob) createKubernetesPod(ctx context.Context, settings *evergreen.Settings) (*pod.ResourceInfo, error) {
+	var repoCreds string
+	if id := j.pod.TaskContainerCreationOpts.RepoCredsExternalID; id != "" {
+		vault, err := cloud.MakeSecretsManagerVault(j.smClient)
+		if err != nil {
+			return nil, errors.Wrap(err, "initializing Secrets Manager vault")
+		}
+		repoCreds, err = vault.GetValue(ctx, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting repository credentials '%s'", id)
 		}
-	default:
-		j.AddError(errors.Errorf("not starting pod because pod status is '%s'", j.pod.Status))
 	}
+
+	return cloud.NewKubernetesPodManager(j.k8sClient, settings).CreatePod(ctx, j.pod, repoCreds)
 }
 
 func (j *podCreationJob) populateIfUnset(ctx context.Context) error {
@@ -177,6 +217,24 @@ func (j *podCreationJob) populateIfUnset(ctx context.Context) error {
 
 	settings := j.env.Settings()
 
+	if j.pod.IsKubernetes() {
+		if j.k8sClient == nil {
+			client, err := cloud.MakeKubernetesClient(settings)
+			if err != nil {
+				return errors.Wrap(err, "initializing Kubernetes client")
+			}
+			j.k8sClient = client
+		}
+		if j.smClient == nil && j.pod.TaskContainerCreationOpts.RepoCredsExternalID != "" {
+			client, err := cloud.MakeSecretsManagerClient(ctx, settings)
+			if err != nil {
+				return errors.Wrap(err, "initializing Secrets Manager client")
+			}
+			j.smClient = client
+		}
+		return nil
+	}
+
 	if j.ecsClient == nil {
 		client, err := cloud.MakeECSClient(ctx, settings)
 		if err != nil {
@@ -18,6 +18,8 @@ import (
 	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNewPodCreationJob(t *testing.T) {
@@ -200,3 +202,58 @@ func TestPodCreationJob(t *testing.T) {
 		})
 	}
 }
+
+func TestPodCreationJobKubernetes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = testutil.TestSpan(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(pod.Collection, dispatcher.Collection, event.EventCollection))
+	}()
+	require.NoError(t, db.ClearCollections(pod.Collection, dispatcher.Collection, event.EventCollection))
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx))
+	env.EvergreenSettings.Providers.Kubernetes.Namespace = "evergreen"
+
+	p, err := pod.NewTaskIntentPod(evergreen.ECSConfig{AllowedImages: []string{"image"}}, pod.TaskIntentPodOptions{
+		MemoryMB:            256,
+		CPU:                 512,
+		OS:                  pod.OSLinux,
+		Arch:                pod.ArchAMD64,
+		Image:               "image",
+		PodSecretExternalID: "pod_secret_external_id",
+		PodSecretValue:      "pod_secret_value",
+		Platform:            pod.PlatformKubernetes,
+	})
+	require.NoError(t, err)
+	assert.Zero(t, p.Family, "Kubernetes pod should not need a pod definition")
+	require.NoError(t, p.Insert())
+
+	pd := dispatcher.NewPodDispatcher("group_id", []string{}, []string{p.ID})
+	require.NoError(t, pd.Insert())
+
+	j, ok := NewPodCreationJob(p.ID, utility.RoundPartOfMinute(0).Format(TSFormat)).(*podCreationJob)
+	require.True(t, ok)
+	j.pod = p
+	j.env = env
+	c := fake.NewSimpleClientset()
+	j.k8sClient = c
+
+	j.Run(ctx)
+	require.NoError(t, j.Error())
+
+	dbPod, err := pod.FindOneByID(p.ID)
+	require.NoError(t, err)
+	require.NotZero(t, dbPod)
+	assert.Equal(t, pod.StatusStarting, dbPod.Status)
+	assert.Equal(t, "evergreen", dbPod.Resources.Cluster)
+	require.NotZero(t, dbPod.Resources.ExternalID)
+
+	kp, err := c.CoreV1().Pods(dbPod.Resources.Cluster).Get(ctx, dbPod.Resources.ExternalID, metav1.GetOptions{})
+	require.NoError(t, err, "should have created the Kubernetes pod")
+	require.Len(t, kp.Spec.Containers, 1)
+	assert.Equal(t, "256Mi", kp.Spec.Containers[0].Resources.Requests.Memory().String())
+	assert.Equal(t, "500m", kp.Spec.Containers[0].Resources.Requests.Cpu().String())
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/mongodb/grip/message"
 	"github.com/mongodb/grip/sometimes"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
@@ -39,6 +41,7 @@ type podHealthCheckJob struct {
 	pod       *pod.Pod
 	ecsClient cocoa.ECSClient
 	ecsPod    cocoa.ECSPod
+	k8sClient kubernetes.Interface
 }
 
 func makePodHealthCheckJob() *podHealthCheckJob {
@@ -117,6 +120,11 @@ func (j *podHealthCheckJob) Run(ctx context.Context) {
 		return
 	}
 
+	if j.pod.IsKubernetes() {
+		j.checkKubernetesPodHealth(ctx)
+		return
+	}
+
 	info, err := j.ecsPod.LatestStatusInfo(ctx)
 	if err != nil {
 		j.AddError(errors.Wrap(err, "getting cloud pod's status info"))
@@ -154,6 +162,52 @@ func (j *podHealthCheckJob) Run(ctx context.Context) {
 	}
 }
 
+// checkKubernetesPodHealth checks the pod's phase in the Kubernetes cluster and
+// terminates the pod if it has stopped running.
+func (j *podHealthCheckJob) checkKubernetesPodHealth(ctx context.Context) {
+	phase, err := cloud.NewKubernetesPodManager(j.k8sClient, j.env.Settings()).LatestPhase(ctx, j.pod)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "getting Kubernetes pod's phase"))
+		return
+	}
+
+	var reason string
+	switch phase {
+	case corev1.PodPending, corev1.PodRunning:
+		grip.Info(message.Fields{
+			"message": "cloud pod is healthy",
+			"pod":     j.PodID,
+			"phase":   phase,
+			"job":     j.ID(),
+		})
+		return
+	case corev1.PodSucceeded, corev1.PodFailed:
+		reason = fmt.Sprintf("pod health check detected Kubernetes pod phase '%s'", phase)
+	case "":
+		reason = "pod health check detected that the Kubernetes pod no longer exists"
+	default:
+		grip.Warning(message.Fields{
+			"message": "unable to determine pod health because it is in an unhandled state",
+			"pod":     j.PodID,
+			"phase":   phase,
+			"job":     j.ID(),
+		})
+		return
+	}
+
+	grip.Info(message.Fields{
+		"message": "cloud pod is unhealthy",
+		"pod":     j.PodID,
+		"phase":   phase,
+		"job":     j.ID(),
+	})
+
+	terminationJob := NewPodTerminationJob(j.PodID, reason, utility.RoundPartOfMinute(0))
+	if err := amboy.EnqueueUniqueJob(ctx, j.env.RemoteQueue(), terminationJob); err != nil {
+		j.AddError(errors.Wrap(err, "enqueueing job to terminate unhealthy pod"))
+	}
+}
+
 func (j *podHealthCheckJob) populateIfUnset(ctx context.Context) error {
 	if j.env == nil {
 		j.env = evergreen.GetEnvironment()
@@ -170,6 +224,17 @@ func (j *podHealthCheckJob) populateIfUnset(ctx context.Context) error {
 		j.pod = p
 	}
 
+	if j.pod.IsKubernetes() {
+		if j.k8sClient == nil {
+			client, err := cloud.MakeKubernetesClient(j.env.Settings())
+			if err != nil {
+				return errors.Wrap(err, "initializing Kubernetes client")
+			}
+			j.k8sClient = client
+		}
+		return nil
+	}
+
 	if j.ecsClient == nil {
 		client, err := cloud.MakeECSClient(ctx, j.env.Settings())
 		if err != nil {
@@ -13,6 +13,9 @@ import (
 	"github.com/evergreen-ci/evergreen/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestPodHealthCheckJob(t *testing.T) {
@@ -117,3 +120,93 @@ func TestPodHealthCheckJob(t *testing.T) {
 		})
 	}
 }
+
+func TestPodHealthCheckJobKubernetes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = testutil.TestSpan(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(pod.Collection))
+	}()
+
+	hasPodTerminationJob := func(ctx context.Context, j *podHealthCheckJob) bool {
+		for remoteQueueJob := range j.env.RemoteQueue().JobInfo(ctx) {
+			if remoteQueueJob.Type.Name == podTerminationJobName {
+				return true
+			}
+		}
+		return false
+	}
+	setPodPhase := func(ctx context.Context, t *testing.T, c *fake.Clientset, p *pod.Pod, phase corev1.PodPhase) {
+		kp, err := c.CoreV1().Pods(p.Resources.Cluster).Get(ctx, p.Resources.ExternalID, metav1.GetOptions{})
+		require.NoError(t, err)
+		kp.Status.Phase = phase
+		_, err = c.CoreV1().Pods(p.Resources.Cluster).UpdateStatus(ctx, kp, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, j *podHealthCheckJob, c *fake.Clientset){
+		"NoopsForRunningCloudPod": func(ctx context.Context, t *testing.T, j *podHealthCheckJob, c *fake.Clientset) {
+			setPodPhase(ctx, t, c, j.pod, corev1.PodRunning)
+
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+			assert.False(t, hasPodTerminationJob(ctx, j), "should not enqueue pod termination job for healthy pod")
+		},
+		"EnqueuesPodTerminationJobForFailedCloudPod": func(ctx context.Context, t *testing.T, j *podHealthCheckJob, c *fake.Clientset) {
+			setPodPhase(ctx, t, c, j.pod, corev1.PodFailed)
+
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+			assert.True(t, hasPodTerminationJob(ctx, j), "should enqueue pod termination job for unhealthy pod")
+		},
+		"EnqueuesPodTerminationJobForDeletedCloudPod": func(ctx context.Context, t *testing.T, j *podHealthCheckJob, c *fake.Clientset) {
+			require.NoError(t, c.CoreV1().Pods(j.pod.Resources.Cluster).Delete(ctx, j.pod.Resources.ExternalID, metav1.DeleteOptions{}))
+
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+			assert.True(t, hasPodTerminationJob(ctx, j), "should enqueue pod termination job for pod that no longer exists")
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			tctx, tcancel := context.WithTimeout(ctx, 30*time.Second)
+			defer tcancel()
+			tctx = testutil.TestSpan(tctx, t)
+
+			require.NoError(t, db.ClearCollections(pod.Collection))
+
+			env := &mock.Environment{}
+			require.NoError(t, env.Configure(tctx))
+			env.EvergreenSettings.Providers.Kubernetes.Namespace = "evergreen"
+
+			p := pod.Pod{
+				ID:       "pod_id",
+				Status:   pod.StatusRunning,
+				Platform: pod.PlatformKubernetes,
+				TimeInfo: pod.TimeInfo{
+					LastCommunicated: time.Now().Add(-time.Hour),
+				},
+				TaskContainerCreationOpts: pod.TaskContainerCreationOptions{
+					Image:    "image",
+					CPU:      2048,
+					MemoryMB: 4096,
+				},
+			}
+
+			c := fake.NewSimpleClientset()
+			res, err := cloud.NewKubernetesPodManager(c, env.Settings()).CreatePod(tctx, &p, "")
+			require.NoError(t, err)
+			p.Resources = *res
+			require.NoError(t, p.Insert())
+
+			j, ok := NewPodHealthCheckJob(p.ID, time.Now()).(*podHealthCheckJob)
+			require.True(t, ok)
+			j.env = env
+			j.pod = &p
+			j.k8sClient = c
+
+			tCase(tctx, t, j, c)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/mongodb/grip"
 	"github.com/mongodb/grip/message"
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 )
 
 const podTerminationJobName = "pod-termination"
@@ -36,6 +37,7 @@ type podTerminationJob struct {
 	pod       *pod.Pod
 	ecsClient cocoa.ECSClient
 	ecsPod    cocoa.ECSPod
+	k8sClient kubernetes.Interface
 	env       evergreen.Environment
 }
 
@@ -98,7 +100,12 @@ func (j *podTerminationJob) Run(ctx context.Context) {
 			"job":                j.ID(),
 		})
 	case pod.StatusStarting, pod.StatusRunning, pod.StatusDecommissioned:
-		if j.ecsPod != nil {
+		if j.pod.IsKubernetes() && j.k8sClient != nil {
+			if err := cloud.NewKubernetesPodManager(j.k8sClient, j.env.Settings()).DeletePod(ctx, j.pod); err != nil {
+				j.AddError(errors.Wrap(err, "deleting Kubernetes pod resources"))
+				return
+			}
+		} else if j.ecsPod != nil {
 			if err := j.ecsPod.Delete(ctx); err != nil {
 				j.AddError(errors.Wrap(err, "deleting pod resources"))
 				return
@@ -140,7 +147,7 @@ func (j *podTerminationJob) populateIfUnset(ctx context.Context) error {
 		j.env = evergreen.GetEnvironment()
 	}
 
-	if j.ecsPod != nil && j.pod != nil {
+	if (j.ecsPod != nil || j.k8sClient != nil) && j.pod != nil {
 		return nil
 	}
 
@@ -164,6 +171,17 @@ func (j *podTerminationJob) populateIfUnset(ctx context.Context) error {
 
 	settings := j.env.Settings()
 
+	if j.pod.IsKubernetes() {
+		if j.k8sClient == nil {
+			client, err := cloud.MakeKubernetesClient(settings)
+			if err != nil {
+				return errors.Wrap(err, "initializing Kubernetes client")
+			}
+			j.k8sClient = client
+		}
+		return nil
+	}
+
 	if j.ecsClient == nil {
 		client, err := cloud.MakeECSClient(ctx, settings)
 		if err != nil {
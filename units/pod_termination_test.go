@@ -23,6 +23,8 @@ import (
 	"github.com/evergreen-ci/utility"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNewPodTerminationJob(t *testing.T) {
@@ -421,3 +423,65 @@ func generateTestingECSPod(ctx context.Context, t *testing.T, client cocoa.ECSCl
 	require.NoError(t, err)
 	return ecsPod
 }
+
+func TestPodTerminationJobKubernetes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = testutil.TestSpan(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(pod.Collection, dispatcher.Collection, event.EventCollection))
+	}()
+	require.NoError(t, db.ClearCollections(pod.Collection, dispatcher.Collection, event.EventCollection))
+
+	env := &mock.Environment{}
+	require.NoError(t, env.Configure(ctx))
+	env.EvergreenSettings.Providers.Kubernetes.Namespace = "evergreen"
+
+	p := pod.Pod{
+		ID:       "id",
+		Status:   pod.StatusRunning,
+		Platform: pod.PlatformKubernetes,
+		TaskContainerCreationOpts: pod.TaskContainerCreationOptions{
+			Image:      "image",
+			MemoryMB:   128,
+			CPU:        128,
+			OS:         pod.OSLinux,
+			Arch:       pod.ArchAMD64,
+			EnvSecrets: map[string]pod.Secret{pod.PodSecretEnvVar: {Value: "secret"}},
+		},
+	}
+	c := fake.NewSimpleClientset()
+	res, err := cloud.NewKubernetesPodManager(c, env.Settings()).CreatePod(ctx, &p, "")
+	require.NoError(t, err)
+	p.Resources = *res
+	require.NoError(t, p.Insert())
+	countResources := func() (numPods, numSecrets int) {
+		pods, err := c.CoreV1().Pods(res.Cluster).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		secrets, err := c.CoreV1().Secrets(res.Cluster).List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return len(pods.Items), len(secrets.Items)
+	}
+	numPods, numSecrets := countResources()
+	require.NotZero(t, numPods)
+	require.NotZero(t, numSecrets)
+
+	j, ok := NewPodTerminationJob(p.ID, "reason", utility.RoundPartOfMinute(0)).(*podTerminationJob)
+	require.True(t, ok)
+	j.env = env
+	j.pod = &p
+	j.k8sClient = c
+
+	j.Run(ctx)
+	require.NoError(t, j.Error())
+
+	numPods, numSecrets = countResources()
+	assert.Zero(t, numPods, "should have deleted the Kubernetes pod")
+	assert.Zero(t, numSecrets, "should have deleted the Kubernetes pod's secrets")
+
+	dbPod, err := pod.FindOneByID(p.ID)
+	require.NoError(t, err)
+	require.NotZero(t, dbPod)
+	assert.Equal(t, pod.StatusTerminated, dbPod.Status)
+}
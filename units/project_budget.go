@@ -0,0 +1,128 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/cost"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const projectBudgetJobName = "project-budget"
+
+func init() {
+	registry.AddJobType(projectBudgetJobName,
+		func() amboy.Job { return makeProjectBudgetJob() })
+}
+
+type projectBudgetJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+// NewProjectBudgetJob returns a job that checks if the project's estimated
+// spend for the current month has exceeded its monthly budget and, the first
+// time it does in a month, logs an event so that subscribers are notified.
+func NewProjectBudgetJob(env evergreen.Environment, projectID, ts string) amboy.Job {
+	j := makeProjectBudgetJob()
+	j.env = env
+	j.ProjectID = projectID
+	j.SetID(fmt.Sprintf("%s.%s.%s", projectBudgetJobName, projectID, ts))
+
+	return j
+}
+
+func makeProjectBudgetJob() *projectBudgetJob {
+	return &projectBudgetJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    projectBudgetJobName,
+				Version: 0,
+			},
+		},
+	}
+}
+
+func (j *projectBudgetJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	pRef, err := model.FindMergedProjectRef(j.ProjectID, "", false)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "finding project '%s'", j.ProjectID))
+		return
+	}
+	if pRef == nil {
+		j.AddError(errors.Errorf("project '%s' not found", j.ProjectID))
+		return
+	}
+	if pRef.MonthlyBudget <= 0 {
+		return
+	}
+
+	now := time.Now()
+	month := cost.MonthStart(now)
+	existing, err := cost.FindBudgetAlert(pRef.Id, month)
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if existing != nil {
+		return
+	}
+
+	projectCost, err := cost.GetProjectCost(pRef.Id, month, now)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "getting cost for project '%s'", pRef.Id))
+		return
+	}
+	if projectCost.TotalCost <= pRef.MonthlyBudget {
+		return
+	}
+
+	recorded, err := cost.RecordBudgetAlert(cost.BudgetAlert{
+		ID: cost.BudgetAlertID{
+			ProjectID: pRef.Id,
+			Month:     month,
+		},
+		Budget:    pRef.MonthlyBudget,
+		Spend:     projectCost.TotalCost,
+		CreatedAt: now,
+	})
+	if err != nil {
+		j.AddError(err)
+		return
+	}
+	if !recorded {
+		return
+	}
+
+	event.LogProjectBudgetExceeded(pRef.Id, event.ProjectBudgetEventData{
+		Month:  month,
+		Budget: pRef.MonthlyBudget,
+		Spend:  projectCost.TotalCost,
+	})
+
+	grip.Info(message.Fields{
+		"message":  "project exceeded its monthly budget",
+		"job_id":   j.ID(),
+		"job_type": j.Type().Name,
+		"project":  pRef.Id,
+		"budget":   pRef.MonthlyBudget,
+		"spend":    projectCost.TotalCost,
+	})
+}
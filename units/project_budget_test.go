@@ -0,0 +1,106 @@
+package units
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/cost"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectBudgetJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	env := testutil.NewEnvironment(ctx, t)
+
+	defer func() {
+		assert.NoError(t, db.ClearCollections(model.ProjectRefCollection, task.Collection, task.OldCollection, cost.BudgetAlertCollection, event.EventCollection))
+	}()
+
+	countBudgetEvents := func(t *testing.T) int {
+		events, err := event.Find(db.Query(event.ResourceTypeKeyIs(event.ResourceTypeProjectBudget)))
+		require.NoError(t, err)
+		return len(events)
+	}
+
+	for tName, tCase := range map[string]func(ctx context.Context, t *testing.T, pRef model.ProjectRef){
+		"LogsEventWhenBudgetIsExceeded": func(ctx context.Context, t *testing.T, pRef model.ProjectRef) {
+			j := NewProjectBudgetJob(env, pRef.Id, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Equal(t, 1, countBudgetEvents(t))
+			alert, err := cost.FindBudgetAlert(pRef.Id, time.Now())
+			require.NoError(t, err)
+			require.NotNil(t, alert)
+			assert.Equal(t, 100.0, alert.Budget)
+			assert.Equal(t, 150.0, alert.Spend)
+		},
+		"OnlyLogsEventOncePerMonth": func(ctx context.Context, t *testing.T, pRef model.ProjectRef) {
+			for _, ts := range []string{"ts0", "ts1"} {
+				j := NewProjectBudgetJob(env, pRef.Id, ts)
+				j.Run(ctx)
+				require.NoError(t, j.Error())
+			}
+
+			assert.Equal(t, 1, countBudgetEvents(t))
+		},
+		"NoopsWithinBudget": func(ctx context.Context, t *testing.T, pRef model.ProjectRef) {
+			pRef.MonthlyBudget = 200
+			require.NoError(t, pRef.Upsert())
+
+			j := NewProjectBudgetJob(env, pRef.Id, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Zero(t, countBudgetEvents(t))
+		},
+		"NoopsWithoutBudget": func(ctx context.Context, t *testing.T, pRef model.ProjectRef) {
+			pRef.MonthlyBudget = 0
+			require.NoError(t, pRef.Upsert())
+
+			j := NewProjectBudgetJob(env, pRef.Id, "ts")
+			j.Run(ctx)
+			require.NoError(t, j.Error())
+
+			assert.Zero(t, countBudgetEvents(t))
+		},
+	} {
+		t.Run(tName, func(t *testing.T) {
+			tctx, tcancel := context.WithCancel(ctx)
+			defer tcancel()
+
+			require.NoError(t, db.ClearCollections(model.ProjectRefCollection, task.Collection, task.OldCollection, cost.BudgetAlertCollection, event.EventCollection))
+
+			pRef := model.ProjectRef{
+				Id:            "project",
+				Identifier:    "project",
+				Enabled:       true,
+				MonthlyBudget: 100,
+			}
+			require.NoError(t, pRef.Insert())
+
+			finishTime := time.Now().Add(-time.Minute)
+			if cost.MonthStart(finishTime).Before(cost.MonthStart(time.Now())) {
+				finishTime = time.Now()
+			}
+			for _, tsk := range []task.Task{
+				{Id: "t1", Project: pRef.Id, FinishTime: finishTime, Cost: 100},
+				{Id: "t2", Project: pRef.Id, FinishTime: finishTime, Cost: 50},
+				{Id: "t3", Project: pRef.Id, FinishTime: cost.MonthStart(time.Now()).Add(-time.Hour), Cost: 1000},
+			} {
+				require.NoError(t, tsk.Insert())
+			}
+
+			tCase(tctx, t, pRef)
+		})
+	}
+}
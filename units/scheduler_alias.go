@@ -83,10 +83,13 @@ func (j *distroAliasSchedulerJob) Run(ctx context.Context) {
 	if d == nil {
 		return
 	}
+	schedulerConfig := evergreen.GetEnvironment().Settings().Scheduler
 	plan, err := scheduler.PrioritizeTasks(d, tasks, scheduler.TaskPlannerOptions{
-		StartedAt:        startAt,
-		ID:               j.ID(),
-		IsSecondaryQueue: true,
+		StartedAt:            startAt,
+		ID:                   j.ID(),
+		IsSecondaryQueue:     true,
+		ProjectWeights:       schedulerConfig.GetFairShareProjectWeights(),
+		FairShareUsageWindow: time.Duration(schedulerConfig.FairShareUsageWindowSeconds) * time.Second,
 	})
 	if err != nil {
 		j.AddError(err)
@@ -77,10 +77,11 @@ func (j *hostStatsCollector) Run(ctx context.Context) {
 }
 
 type hostCountStats struct {
-	hosts  *host.DistroStats
-	tasks  int
-	count  int
-	excess int
+	hosts    *host.DistroStats
+	tasks    int
+	count    int
+	excess   int
+	warmPool int
 }
 
 func collectHostCountStats() (*hostCountStats, error) {
@@ -92,9 +93,11 @@ func collectHostCountStats() (*hostCountStats, error) {
 	tasks := 0
 	count := 0
 	excess := 0
+	warmPool := 0
 	for _, h := range hosts {
 		count += h.Count
 		tasks += h.NumTasks
+		warmPool += h.NumWarmPool
 
 		overage := -1 * (h.MaxHosts - h.Count)
 		if overage > 0 {
@@ -103,10 +106,11 @@ func collectHostCountStats() (*hostCountStats, error) {
 	}
 
 	stats := &hostCountStats{
-		hosts:  &hosts,
-		tasks:  tasks,
-		count:  count,
-		excess: excess,
+		hosts:    &hosts,
+		tasks:    tasks,
+		count:    count,
+		excess:   excess,
+		warmPool: warmPool,
 	}
 
 	return stats, nil
@@ -121,10 +125,11 @@ func (j *hostStatsCollector) statsByDistro() error {
 	}
 
 	j.logger.Info(message.Fields{
-		"report":        "host stats by distro",
-		"hosts_total":   stats.count,
-		"running_tasks": stats.tasks,
-		"data":          stats.hosts,
+		"report":          "host stats by distro",
+		"hosts_total":     stats.count,
+		"running_tasks":   stats.tasks,
+		"warm_pool_hosts": stats.warmPool,
+		"data":            stats.hosts,
 	})
 
 	j.logger.WarningWhen(stats.excess > 0, message.Fields{
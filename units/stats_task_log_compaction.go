@@ -0,0 +1,83 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	taskLogCompactionStatsCollectorJobName  = "task-log-compaction-stats-collector"
+	taskLogCompactionStatsCollectorInterval = time.Minute
+)
+
+func init() {
+	registry.AddJobType(taskLogCompactionStatsCollectorJobName,
+		func() amboy.Job { return makeTaskLogCompactionStatsCollector() })
+}
+
+type taskLogCompactionStatsCollector struct {
+	job.Base `bson:"job_base" json:"job_base" yaml:"job_base"`
+	logger   grip.Journaler
+}
+
+// NewTaskLogCompactionStatsCollector captures a report of each task log
+// compaction job that finished in the last minute.
+func NewTaskLogCompactionStatsCollector(id string) amboy.Job {
+	j := makeTaskLogCompactionStatsCollector()
+	j.SetID(fmt.Sprintf("%s-%s", taskLogCompactionStatsCollectorJobName, id))
+	return j
+}
+
+func makeTaskLogCompactionStatsCollector() *taskLogCompactionStatsCollector {
+	return &taskLogCompactionStatsCollector{
+		logger: logging.MakeGrip(grip.GetSender()),
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    taskLogCompactionStatsCollectorJobName,
+				Version: 0,
+			},
+		},
+	}
+}
+
+func (j *taskLogCompactionStatsCollector) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.logger == nil {
+		j.logger = logging.MakeGrip(grip.GetSender())
+	}
+
+	stats, err := model.FindTaskLogCompactionStatsFinishedAfter(time.Now().Add(-taskLogCompactionStatsCollectorInterval))
+	if err != nil {
+		j.AddError(errors.Wrap(err, "finding recent task log compaction stats"))
+		return
+	}
+
+	for _, s := range stats {
+		j.logger.Info(message.Fields{
+			"message":            "task log compaction stats",
+			"stats":              "task_log_compaction",
+			"job_id":             s.JobID,
+			"project_id":         s.ProjectID,
+			"dry_run":            s.DryRun,
+			"retention_days":     s.RetentionDays,
+			"num_tasks":          s.NumTasks,
+			"num_chunks_merged":  s.ChunksMerged,
+			"num_chunks_created": s.ChunksCreated,
+			"num_lines_merged":   s.LinesMerged,
+			"num_chunks_deleted": s.ChunksDeleted,
+			"num_lines_deleted":  s.LinesDeleted,
+			"has_errors":         s.HasErrors,
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const (
+	taskLogCompactionJobName = "task-log-compaction"
+
+	// taskLogCompactionMaxChunkLines is the maximum number of lines in a
+	// compacted log chunk.
+	taskLogCompactionMaxChunkLines = 10000
+	// taskLogCompactionLookback is how far back to look for finished
+	// tasks to compact. This is slightly longer than a day to account for
+	// jitter in when the daily job runs.
+	taskLogCompactionLookback = 26 * time.Hour
+)
+
+func init() {
+	registry.AddJobType(taskLogCompactionJobName,
+		func() amboy.Job { return makeTaskLogCompactionJob() })
+}
+
+type taskLogCompactionJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	DryRun    bool   `bson:"dry_run" json:"dry_run" yaml:"dry_run"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+// NewTaskLogCompactionJob returns a job that merges the small log chunks of
+// the project's recently finished tasks into larger chunks and deletes the
+// project's logs that are older than its log retention period. If dryRun is
+// true, the job only reports what it would change.
+func NewTaskLogCompactionJob(env evergreen.Environment, projectID, ts string, dryRun bool) amboy.Job {
+	j := makeTaskLogCompactionJob()
+	j.env = env
+	j.ProjectID = projectID
+	j.DryRun = dryRun
+	j.SetID(fmt.Sprintf("%s.%s.%t.%s", taskLogCompactionJobName, projectID, dryRun, ts))
+
+	return j
+}
+
+func makeTaskLogCompactionJob() *taskLogCompactionJob {
+	return &taskLogCompactionJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    taskLogCompactionJobName,
+				Version: 0,
+			},
+		},
+	}
+}
+
+func (j *taskLogCompactionJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	projectRef, err := model.FindMergedProjectRef(j.ProjectID, "", false)
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "finding project ref '%s'", j.ProjectID))
+		return
+	}
+	if projectRef == nil {
+		j.AddError(errors.Errorf("project ref '%s' not found", j.ProjectID))
+		return
+	}
+
+	now := time.Now()
+	compactResult, numTasks, err := j.compactFinishedTaskLogs(ctx, now)
+	if err != nil {
+		j.AddError(errors.Wrap(err, "compacting finished task logs"))
+	}
+
+	var deleteResult log.DeleteBeforeResult
+	if cutoff := projectRef.GetTaskLogRetentionCutoff(now); !cutoff.IsZero() {
+		deleteResult, err = j.deleteExpiredLogs(ctx, cutoff)
+		if err != nil {
+			j.AddError(errors.Wrap(err, "deleting expired logs"))
+		}
+	}
+
+	stats := model.TaskLogCompactionStats{
+		JobID:         j.ID(),
+		ProjectID:     j.ProjectID,
+		DryRun:        j.DryRun,
+		RetentionDays: projectRef.TaskLogRetentionDays,
+		NumTasks:      numTasks,
+		ChunksMerged:  compactResult.ChunksMerged,
+		ChunksCreated: compactResult.ChunksCreated,
+		LinesMerged:   compactResult.LinesMerged,
+		ChunksDeleted: deleteResult.ChunksDeleted,
+		LinesDeleted:  deleteResult.LinesDeleted,
+		HasErrors:     j.HasErrors(),
+		FinishTime:    time.Now(),
+	}
+	// The stats are reported by the task log compaction stats collector
+	// along with the other background stats.
+	j.AddError(errors.Wrap(stats.Upsert(), "storing task log compaction stats"))
+}
+
+// deleteExpiredLogs deletes the project's logs written before the cutoff
+// from the log service bucket configured in the admin settings, which is
+// where all tasks store their logs.
+func (j *taskLogCompactionJob) deleteExpiredLogs(ctx context.Context, cutoff time.Time) (log.DeleteBeforeResult, error) {
+	bucketConfig := j.env.Settings().Buckets.LogBucket
+	if bucketConfig.Name == "" {
+		return log.DeleteBeforeResult{}, nil
+	}
+
+	result, err := taskoutput.DeleteProjectLogsBefore(ctx, j.env, bucketConfig, j.ProjectID, cutoff, j.DryRun)
+	return result, errors.Wrapf(err, "deleting expired logs from bucket '%s'", bucketConfig.Name)
+}
+
+// compactFinishedTaskLogs compacts the logs of the project's tasks that
+// finished since the lookback period.
+func (j *taskLogCompactionJob) compactFinishedTaskLogs(ctx context.Context, now time.Time) (log.CompactResult, int, error) {
+	var result log.CompactResult
+
+	tasks, err := task.FindFinishedWithLogServiceOutput(j.ProjectID, now.Add(-taskLogCompactionLookback))
+	if err != nil {
+		return result, 0, errors.Wrap(err, "finding finished tasks")
+	}
+
+	catcher := grip.NewBasicCatcher()
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			catcher.Add(ctx.Err())
+			break
+		}
+		if t.TaskOutputInfo == nil {
+			continue
+		}
+
+		taskOpts := taskoutput.TaskOptions{
+			ProjectID: t.Project,
+			TaskID:    t.Id,
+			Execution: t.Execution,
+		}
+		taskResult, err := t.TaskOutputInfo.CompactLogs(ctx, j.env, taskOpts, taskLogCompactionMaxChunkLines, j.DryRun)
+		if err != nil {
+			catcher.Wrapf(err, "compacting logs for task '%s'", t.Id)
+			continue
+		}
+		result.Add(taskResult)
+
+		grip.InfoWhen(j.DryRun && taskResult.ChunksMerged > 0, message.Fields{
+			"message":    "task log compaction dry run",
+			"job_id":     j.ID(),
+			"project_id": j.ProjectID,
+			"task_id":    t.Id,
+			"execution":  t.Execution,
+			"stats":      taskResult,
+		})
+	}
+
+	return result, len(tasks), catcher.Resolve()
+}
@@ -0,0 +1,186 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/db"
+	"github.com/evergreen-ci/evergreen/model"
+	"github.com/evergreen-ci/evergreen/model/log"
+	"github.com/evergreen-ci/evergreen/model/task"
+	"github.com/evergreen-ci/evergreen/taskoutput"
+	"github.com/evergreen-ci/evergreen/testutil"
+	"github.com/evergreen-ci/pail"
+	"github.com/evergreen-ci/utility"
+	"github.com/mongodb/grip/level"
+	"github.com/mongodb/grip/logging"
+	"github.com/mongodb/grip/message"
+	"github.com/mongodb/grip/send"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogCompactionJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	env := testutil.NewEnvironment(ctx, t)
+
+	originalLogBucket := env.Settings().Buckets.LogBucket
+	defer func() {
+		env.Settings().Buckets.LogBucket = originalLogBucket
+		assert.NoError(t, db.ClearCollections(task.Collection, model.ProjectRefCollection, model.TaskLogCompactionStatsCollection))
+	}()
+
+	const projectID = "project"
+	now := time.Now()
+	makeBucket := func(t *testing.T, dir string) pail.Bucket {
+		bucket, err := pail.NewLocalBucket(pail.LocalOptions{Path: dir})
+		require.NoError(t, err)
+		return bucket
+	}
+	countChunks := func(t *testing.T, dir, logName string) int {
+		it, err := makeBucket(t, dir).List(ctx, logName)
+		require.NoError(t, err)
+
+		var count int
+		for it.Next(ctx) {
+			count++
+		}
+		require.NoError(t, it.Err())
+
+		return count
+	}
+
+	recentLogName := fmt.Sprintf("%s/recent/0/task_logs/task", projectID)
+	expiredLogName := fmt.Sprintf("%s/expired/0/task_logs/task", projectID)
+	// setup inserts a task that recently finished with uncompacted logs and
+	// expired logs from an older task in the log service bucket.
+	setup := func(t *testing.T) (bucketDir string) {
+		require.NoError(t, db.ClearCollections(task.Collection, model.ProjectRefCollection, model.TaskLogCompactionStatsCollection))
+
+		projectRef := model.ProjectRef{
+			Id:                   projectID,
+			Identifier:           projectID,
+			TaskLogRetentionDays: 1,
+		}
+		require.NoError(t, projectRef.Insert())
+
+		bucketDir = t.TempDir()
+		bucketConfig := evergreen.BucketConfig{
+			Name: bucketDir,
+			Type: evergreen.BucketTypeLocal,
+		}
+		env.Settings().Buckets.LogBucket = bucketConfig
+
+		recentTask := task.Task{
+			Id:         "recent",
+			Project:    projectID,
+			Status:     evergreen.TaskSucceeded,
+			FinishTime: now,
+			TaskOutputInfo: &taskoutput.TaskOutput{
+				TaskLogs: taskoutput.TaskLogOutput{
+					Version:      1,
+					BucketConfig: bucketConfig,
+				},
+			},
+		}
+		require.NoError(t, recentTask.Insert())
+		svc := log.NewLogServiceV0(makeBucket(t, bucketDir))
+		for i := 0; i < 3; i++ {
+			require.NoError(t, svc.Append(ctx, recentLogName, []log.LogLine{
+				{Priority: level.Info, Timestamp: now.UnixNano() + int64(i), Data: fmt.Sprintf("line %d", i)},
+			}))
+		}
+		require.NoError(t, svc.Append(ctx, expiredLogName, []log.LogLine{
+			{Priority: level.Info, Timestamp: now.AddDate(0, 0, -3).UnixNano(), Data: "expired line 0"},
+			{Priority: level.Info, Timestamp: now.AddDate(0, 0, -3).UnixNano() + 1, Data: "expired line 1"},
+		}))
+
+		return bucketDir
+	}
+	runJob := func(t *testing.T, dryRun bool) model.TaskLogCompactionStats {
+		j, ok := NewTaskLogCompactionJob(env, projectID, utility.RoundPartOfDay(0).Format(TSFormat), dryRun).(*taskLogCompactionJob)
+		require.True(t, ok)
+		j.Run(ctx)
+		require.NoError(t, j.Error())
+
+		stats, err := model.FindTaskLogCompactionStatsFinishedAfter(now.Add(-time.Minute))
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, j.ID(), stats[0].JobID)
+		assert.Equal(t, projectID, stats[0].ProjectID)
+
+		return stats[0]
+	}
+
+	t.Run("CompactsRecentLogsAndDeletesExpiredLogs", func(t *testing.T) {
+		bucketDir := setup(t)
+
+		stats := runJob(t, false)
+		assert.False(t, stats.DryRun)
+		assert.Equal(t, 1, stats.RetentionDays)
+		assert.Equal(t, 1, stats.NumTasks)
+		assert.Equal(t, 3, stats.ChunksMerged)
+		assert.Equal(t, 1, stats.ChunksCreated)
+		assert.Equal(t, 3, stats.LinesMerged)
+		assert.Equal(t, 1, stats.ChunksDeleted)
+		assert.Equal(t, 2, stats.LinesDeleted)
+		assert.False(t, stats.HasErrors)
+
+		assert.Equal(t, 1, countChunks(t, bucketDir, recentLogName))
+		assert.Zero(t, countChunks(t, bucketDir, expiredLogName))
+	})
+	t.Run("DryRunReportsWithoutModifyingLogs", func(t *testing.T) {
+		bucketDir := setup(t)
+
+		stats := runJob(t, true)
+		assert.True(t, stats.DryRun)
+		assert.Equal(t, 3, stats.ChunksMerged)
+		assert.Equal(t, 1, stats.ChunksDeleted)
+
+		assert.Equal(t, 3, countChunks(t, bucketDir, recentLogName))
+		assert.Equal(t, 1, countChunks(t, bucketDir, expiredLogName))
+	})
+	t.Run("StatsCollectorReportsRecentJobs", func(t *testing.T) {
+		setup(t)
+		stats := runJob(t, false)
+
+		sender := send.MakeInternalLogger()
+		j, ok := NewTaskLogCompactionStatsCollector("id").(*taskLogCompactionStatsCollector)
+		require.True(t, ok)
+		j.logger = logging.MakeGrip(sender)
+		j.Run(ctx)
+		require.NoError(t, j.Error())
+
+		msg, ok := sender.GetMessageSafe()
+		require.True(t, ok)
+		fields, ok := msg.Message.Raw().(message.Fields)
+		require.True(t, ok)
+		assert.Equal(t, "task_log_compaction", fields["stats"])
+		assert.Equal(t, stats.JobID, fields["job_id"])
+		assert.Equal(t, projectID, fields["project_id"])
+		assert.Equal(t, 3, fields["num_chunks_merged"])
+		assert.Equal(t, 2, fields["num_lines_deleted"])
+		assert.False(t, sender.HasMessage())
+	})
+	t.Run("StatsCollectorIgnoresOldJobs", func(t *testing.T) {
+		require.NoError(t, db.ClearCollections(model.TaskLogCompactionStatsCollection))
+		old := model.TaskLogCompactionStats{
+			JobID:      "old",
+			ProjectID:  projectID,
+			FinishTime: now.Add(-time.Hour),
+		}
+		require.NoError(t, old.Upsert())
+
+		sender := send.MakeInternalLogger()
+		j, ok := NewTaskLogCompactionStatsCollector("id").(*taskLogCompactionStatsCollector)
+		require.True(t, ok)
+		j.logger = logging.MakeGrip(sender)
+		j.Run(ctx)
+		require.NoError(t, j.Error())
+		assert.False(t, sender.HasMessage())
+	})
+}
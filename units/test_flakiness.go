@@ -0,0 +1,82 @@
+package units
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evergreen-ci/evergreen"
+	"github.com/evergreen-ci/evergreen/model/event"
+	"github.com/evergreen-ci/evergreen/model/flakytest"
+	"github.com/mongodb/amboy"
+	"github.com/mongodb/amboy/job"
+	"github.com/mongodb/amboy/registry"
+	"github.com/mongodb/grip"
+	"github.com/mongodb/grip/message"
+	"github.com/pkg/errors"
+)
+
+const testFlakinessJobName = "test-flakiness"
+
+func init() {
+	registry.AddJobType(testFlakinessJobName,
+		func() amboy.Job { return makeTestFlakinessJob() })
+}
+
+type testFlakinessJob struct {
+	ProjectID string `bson:"project_id" json:"project_id" yaml:"project_id"`
+	job.Base  `bson:"job_base" json:"job_base" yaml:"job_base"`
+
+	env evergreen.Environment
+}
+
+// NewTestFlakinessJob returns a job that calculates the flakiness of the
+// project's tests across its recent mainline task executions and logs an
+// event for each test that has become flaky.
+func NewTestFlakinessJob(env evergreen.Environment, projectID, ts string) amboy.Job {
+	j := makeTestFlakinessJob()
+	j.env = env
+	j.ProjectID = projectID
+	j.SetID(fmt.Sprintf("%s.%s.%s", testFlakinessJobName, projectID, ts))
+
+	return j
+}
+
+func makeTestFlakinessJob() *testFlakinessJob {
+	return &testFlakinessJob{
+		Base: job.Base{
+			JobType: amboy.JobType{
+				Name:    testFlakinessJobName,
+				Version: 0,
+			},
+		},
+	}
+}
+
+func (j *testFlakinessJob) Run(ctx context.Context) {
+	defer j.MarkComplete()
+
+	if j.env == nil {
+		j.env = evergreen.GetEnvironment()
+	}
+
+	startAt := time.Now()
+	newlyFlaky, err := flakytest.CalculateProjectFlakiness(ctx, j.env, flakytest.CalculateOptions{ProjectID: j.ProjectID})
+	if err != nil {
+		j.AddError(errors.Wrapf(err, "calculating test flakiness for project '%s'", j.ProjectID))
+		return
+	}
+
+	for _, f := range newlyFlaky {
+		event.LogTaskTestFlaky(f.LastTaskID, f.LastTaskExecution, f.ID.TestName, f.FlipRate)
+	}
+
+	grip.Info(message.Fields{
+		"message":     "calculated test flakiness",
+		"job_id":      j.ID(),
+		"job_type":    j.Type().Name,
+		"project":     j.ProjectID,
+		"newly_flaky": len(newlyFlaky),
+		"duration":    time.Since(startAt).Seconds(),
+	})
+}
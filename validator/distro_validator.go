@@ -40,6 +40,7 @@ var distroSyntaxValidators = []distroValidator{
 	ensureHasValidFinderSettings,
 	ensureHasValidDispatcherSettings,
 	ensureHasValidVirtualWorkstationSettings,
+	ensureValidLibvirtSettings,
 }
 
 // CheckDistro checks if the distro configuration syntax is valid. Returns
@@ -434,6 +435,25 @@ func ensureHasValidHostAllocatorSettings(ctx context.Context, d *distro.Distro,
 			Level:   Error,
 		})
 	}
+	if settings.WarmPoolSize < 0 {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("invalid host_allocator_settings.warm_pool_size value of %d for distro '%s' - its value must be a non-negative integer", settings.WarmPoolSize, d.Id),
+			Level:   Error,
+		})
+	} else if settings.WarmPoolSize > 0 {
+		if settings.WarmPoolSize > settings.MaximumHosts {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("invalid host_allocator_settings.warm_pool_size value of %d for distro '%s' - its value must not exceed maximum_hosts (%d)", settings.WarmPoolSize, d.Id, settings.MaximumHosts),
+				Level:   Error,
+			})
+		}
+		if !utility.StringSliceContains(evergreen.ProviderSpawnable, d.Provider) || d.ContainerPool != "" || evergreen.IsDockerProvider(d.Provider) {
+			errs = append(errs, ValidationError{
+				Message: fmt.Sprintf("distro '%s' cannot have a warm pool because its hosts are not dynamically spawned", d.Id),
+				Level:   Error,
+			})
+		}
+	}
 
 	return errs
 }
@@ -564,6 +584,32 @@ func ensureHasValidVirtualWorkstationSettings(ctx context.Context, d *distro.Dis
 	return errs
 }
 
+// ensureValidLibvirtSettings checks that libvirt distros can connect to a
+// hypervisor and are bootstrapped with a method that libvirt supports.
+func ensureValidLibvirtSettings(ctx context.Context, d *distro.Distro, s *evergreen.Settings) ValidationErrors {
+	if d.Provider != evergreen.ProviderNameLibvirt {
+		return nil
+	}
+	var errs ValidationErrors
+	var uri string
+	if len(d.ProviderSettingsList) != 0 {
+		uri, _ = d.ProviderSettingsList[0].Lookup("uri").StringValueOK()
+	}
+	if uri == "" && s.Providers.Libvirt.URI == "" {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("libvirt distro '%s' must specify a connection URI because there is no default URI in the admin settings", d.Id),
+			Level:   Error,
+		})
+	}
+	if d.BootstrapSettings.Method == distro.BootstrapMethodUserData {
+		errs = append(errs, ValidationError{
+			Message: fmt.Sprintf("libvirt distro '%s' cannot be bootstrapped with user data", d.Id),
+			Level:   Error,
+		})
+	}
+	return errs
+}
+
 func validateAliases(d *distro.Distro, allDistroAliases []string) ValidationErrors {
 	var validationErrs ValidationErrors
 	// Parent and container distros do not support aliases.
@@ -670,6 +670,60 @@ func TestEnsureValidStaticBootstrapSettings(t *testing.T) {
 	assert.NotNil(t, ensureValidStaticBootstrapSettings(ctx, &d, &evergreen.Settings{}))
 }
 
+func TestEnsureValidLibvirtSettings(t *testing.T) {
+	ctx := context.Background()
+	settings := &evergreen.Settings{}
+	d := distro.Distro{
+		Id:                "distro",
+		Provider:          evergreen.ProviderNameLibvirt,
+		BootstrapSettings: distro.BootstrapSettings{Method: distro.BootstrapMethodSSH},
+	}
+	assert.NotNil(t, ensureValidLibvirtSettings(ctx, &d, settings), "should require a connection URI")
+
+	d.ProviderSettingsList = []*birch.Document{birch.NewDocument(birch.EC.String("uri", "qemu:///system"))}
+	assert.Nil(t, ensureValidLibvirtSettings(ctx, &d, settings))
+
+	d.ProviderSettingsList = nil
+	settings.Providers.Libvirt.URI = "qemu:///system"
+	assert.Nil(t, ensureValidLibvirtSettings(ctx, &d, settings), "should use the default connection URI")
+
+	d.BootstrapSettings.Method = distro.BootstrapMethodUserData
+	assert.NotNil(t, ensureValidLibvirtSettings(ctx, &d, settings))
+
+	assert.Nil(t, ensureValidLibvirtSettings(ctx, &distro.Distro{Provider: evergreen.ProviderNameStatic}, &evergreen.Settings{}))
+}
+
+func TestEnsureHasValidHostAllocatorSettingsWarmPool(t *testing.T) {
+	ctx := context.Background()
+	d := distro.Distro{
+		Id:       "distro",
+		Provider: evergreen.ProviderNameEc2Fleet,
+		HostAllocatorSettings: distro.HostAllocatorSettings{
+			Version:                evergreen.HostAllocatorUtilization,
+			RoundingRule:           evergreen.HostAllocatorRoundDown,
+			FeedbackRule:           evergreen.HostAllocatorNoFeedback,
+			HostsOverallocatedRule: evergreen.HostsOverallocatedIgnore,
+			MaximumHosts:           10,
+			WarmPoolSize:           5,
+		},
+	}
+	assert.Empty(t, ensureHasValidHostAllocatorSettings(ctx, &d, &evergreen.Settings{}))
+
+	d.HostAllocatorSettings.WarmPoolSize = -1
+	assert.NotEmpty(t, ensureHasValidHostAllocatorSettings(ctx, &d, &evergreen.Settings{}))
+
+	d.HostAllocatorSettings.WarmPoolSize = 11
+	assert.NotEmpty(t, ensureHasValidHostAllocatorSettings(ctx, &d, &evergreen.Settings{}), "warm pool should not exceed maximum hosts")
+
+	d.HostAllocatorSettings.WarmPoolSize = 5
+	d.Provider = evergreen.ProviderNameStatic
+	assert.NotEmpty(t, ensureHasValidHostAllocatorSettings(ctx, &d, &evergreen.Settings{}), "static distros should not have a warm pool")
+
+	d.Provider = evergreen.ProviderNameEc2Fleet
+	d.ContainerPool = "pool"
+	assert.NotEmpty(t, ensureHasValidHostAllocatorSettings(ctx, &d, &evergreen.Settings{}), "container distros should not have a warm pool")
+}
+
 func TestEnsureValidCloneMethod(t *testing.T) {
 	ctx := context.Background()
 	assert.NotNil(t, ensureValidCloneMethod(ctx, &distro.Distro{}, &evergreen.Settings{}))
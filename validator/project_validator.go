@@ -124,11 +124,13 @@ var projectErrorValidators = []projectValidator{
 	validateBVFields,
 	validateDependencyGraph,
 	validatePluginCommands,
+	validateFunctionParameters,
 	validateProjectFields,
 	validateTaskDependencies,
 	validateTaskNames,
 	validateBVNames,
 	validateBVBatchTimes,
+	validateRetryPolicies,
 	validateDisplayTaskNames,
 	validateBVTaskNames,
 	validateAllDependenciesSpec,
@@ -1112,6 +1114,39 @@ func validateBVTaskNames(project *model.Project) ValidationErrors {
 	return errs
 }
 
+// validateRetryPolicies checks that the retry policies defined for tasks,
+// build variants and build variant tasks are valid.
+func validateRetryPolicies(project *model.Project) ValidationErrors {
+	errs := ValidationErrors{}
+	// Build variant tasks share the policy of their task or build variant if
+	// they don't define their own, so each policy is only checked once.
+	checked := map[*model.RetryPolicy]bool{}
+	checkPolicy := func(policy *model.RetryPolicy, location string) {
+		if policy == nil || checked[policy] {
+			return
+		}
+		checked[policy] = true
+		if err := policy.Validate(); err != nil {
+			errs = append(errs, ValidationError{
+				Message: errors.Wrapf(err, "invalid retry policy for %s", location).Error(),
+				Level:   Error,
+			})
+		}
+	}
+
+	for _, t := range project.Tasks {
+		checkPolicy(t.Retry, fmt.Sprintf("task '%s'", t.Name))
+	}
+	for _, bv := range project.BuildVariants {
+		checkPolicy(bv.Retry, fmt.Sprintf("build variant '%s'", bv.Name))
+		for _, bvt := range bv.Tasks {
+			checkPolicy(bvt.Retry, fmt.Sprintf("task '%s' in build variant '%s'", bvt.Name, bv.Name))
+		}
+	}
+
+	return errs
+}
+
 func validateBVBatchTimes(project *model.Project) ValidationErrors {
 	errs := ValidationErrors{}
 	for _, buildVariant := range project.BuildVariants {
@@ -1319,6 +1354,83 @@ func validatePluginCommands(project *model.Project) ValidationErrors {
 	return errs
 }
 
+// validateFunctionParameters checks that the parameters declared by functions
+// are valid and that every call to a function passes vars that match its
+// declared parameters.
+func validateFunctionParameters(project *model.Project) ValidationErrors {
+	errs := ValidationErrors{}
+
+	funcNames := make([]string, 0, len(project.Functions))
+	for funcName := range project.Functions {
+		funcNames = append(funcNames, funcName)
+	}
+	sort.Strings(funcNames)
+	for _, funcName := range funcNames {
+		fn := project.Functions[funcName]
+		if fn == nil {
+			continue
+		}
+		seen := map[string]bool{}
+		for _, param := range fn.Parameters {
+			if err := param.Validate(); err != nil {
+				errs = append(errs, ValidationError{
+					Message: errors.Wrapf(err, "function '%s' has invalid parameter '%s'", funcName, param.Name).Error(),
+					Level:   Error,
+				})
+			}
+			if seen[param.Name] {
+				errs = append(errs, ValidationError{
+					Message: fmt.Sprintf("function '%s' declares parameter '%s' more than once", funcName, param.Name),
+					Level:   Error,
+				})
+			}
+			seen[param.Name] = true
+		}
+	}
+
+	validateCalls := func(section string, cmds *model.YAMLCommandSet) {
+		if cmds == nil {
+			return
+		}
+		for _, cmd := range cmds.List() {
+			fn := project.Functions[cmd.Function]
+			if cmd.Function == "" || fn == nil {
+				continue
+			}
+			if err := fn.ValidateCall(cmd.Vars); err != nil {
+				errs = append(errs, ValidationError{
+					Message: errors.Wrapf(err, "%s calls function '%s' with invalid vars", section, cmd.Function).Error(),
+					Level:   Error,
+				})
+			}
+		}
+	}
+
+	validateCalls("pre", project.Pre)
+	validateCalls("post", project.Post)
+	validateCalls("timeout", project.Timeout)
+	for _, t := range project.Tasks {
+		validateCalls(fmt.Sprintf("task '%s'", t.Name), &model.YAMLCommandSet{MultiCommand: t.Commands})
+	}
+	taskGroups := project.TaskGroups
+	for _, bv := range project.BuildVariants {
+		for _, t := range bv.Tasks {
+			if t.TaskGroup != nil {
+				taskGroups = append(taskGroups, *t.TaskGroup)
+			}
+		}
+	}
+	for _, tg := range taskGroups {
+		validateCalls(fmt.Sprintf("setup group for task group '%s'", tg.Name), tg.SetupGroup)
+		validateCalls(fmt.Sprintf("setup task for task group '%s'", tg.Name), tg.SetupTask)
+		validateCalls(fmt.Sprintf("teardown task for task group '%s'", tg.Name), tg.TeardownTask)
+		validateCalls(fmt.Sprintf("teardown group for task group '%s'", tg.Name), tg.TeardownGroup)
+		validateCalls(fmt.Sprintf("timeout for task group '%s'", tg.Name), tg.Timeout)
+	}
+
+	return errs
+}
+
 // Ensures there aren't any duplicate task names for this project
 func validateProjectTaskNames(project *model.Project) ValidationErrors {
 	errs := ValidationErrors{}
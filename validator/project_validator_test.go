@@ -1703,6 +1703,61 @@ func TestValidateBVBatchTimes(t *testing.T) {
 
 }
 
+func TestValidateRetryPolicies(t *testing.T) {
+	taskPolicy := &model.RetryPolicy{MaxAttempts: 3, FailureTypes: []string{model.RetryFailureTypeSystem}}
+	bvPolicy := &model.RetryPolicy{MaxAttempts: 2, FailureTypes: []string{model.RetryFailureTypeTest}, TestPatterns: []string{"^TestFlaky"}, BackoffSecs: 60}
+	p := &model.Project{
+		Tasks: []model.ProjectTask{
+			{Name: "t1", Retry: taskPolicy},
+			{Name: "t2"},
+		},
+		BuildVariants: []model.BuildVariant{
+			{
+				Name:  "linux",
+				Retry: bvPolicy,
+				Tasks: []model.BuildVariantTaskUnit{
+					{Name: "t1", Variant: "linux", Retry: taskPolicy},
+					{Name: "t2", Variant: "linux", Retry: bvPolicy},
+				},
+			},
+		},
+	}
+	assert.Empty(t, validateRetryPolicies(p))
+
+	// Policies shared by build variant tasks are only reported once.
+	taskPolicy.MaxAttempts = 1
+	errs := validateRetryPolicies(p)
+	require.Len(t, errs, 1)
+	assert.Equal(t, Error, errs[0].Level)
+	assert.Contains(t, errs[0].Message, "task 't1'")
+	taskPolicy.MaxAttempts = evergreen.MaxTaskExecution + 2
+	assert.Len(t, validateRetryPolicies(p), 1)
+	taskPolicy.MaxAttempts = 3
+
+	taskPolicy.FailureTypes = nil
+	assert.Len(t, validateRetryPolicies(p), 1)
+	taskPolicy.FailureTypes = []string{"flaky"}
+	assert.Len(t, validateRetryPolicies(p), 1)
+	taskPolicy.FailureTypes = []string{model.RetryFailureTypeSystem}
+
+	// Test patterns must be valid and require retrying test failures.
+	bvPolicy.TestPatterns = []string{"["}
+	assert.Len(t, validateRetryPolicies(p), 1)
+	bvPolicy.TestPatterns = []string{"^TestFlaky"}
+	bvPolicy.FailureTypes = []string{model.RetryFailureTypeTimeout}
+	assert.Len(t, validateRetryPolicies(p), 1)
+	bvPolicy.FailureTypes = []string{model.RetryFailureTypeTest}
+
+	bvPolicy.BackoffSecs = -1
+	assert.Len(t, validateRetryPolicies(p), 1)
+	bvPolicy.BackoffSecs = 60
+
+	p.BuildVariants[0].Tasks[1].Retry = &model.RetryPolicy{MaxAttempts: 2}
+	errs = validateRetryPolicies(p)
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "task 't2' in build variant 'linux'")
+}
+
 func TestCheckBVsContainTasks(t *testing.T) {
 	Convey("When validating a project's build variants", t, func() {
 		Convey("if any build variant contains no tasks an error should be returned", func() {
@@ -3758,6 +3813,113 @@ func TestValidateCreateHosts(t *testing.T) {
 	assert.Len(errs, 1)
 }
 
+func TestValidateFunctionParameters(t *testing.T) {
+	ctx := context.Background()
+	loadProject := func(t *testing.T, yml string) *model.Project {
+		var p model.Project
+		_, err := model.LoadProjectInto(ctx, []byte(yml), nil, "id", &p)
+		require.NoError(t, err)
+		return &p
+	}
+	const functions = `
+functions:
+  run tests:
+    parameters:
+    - name: suite
+      required: true
+    - name: jobs
+      type: int
+      default: "4"
+    - name: verbose
+      type: bool
+    commands:
+    - command: shell.exec
+      params:
+        script: echo ${suite}
+  untyped:
+    - command: shell.exec
+      params:
+        script: echo ${anything}
+`
+
+	t.Run("PassesWithValidCalls", func(t *testing.T) {
+		p := loadProject(t, functions+`
+pre:
+- func: run tests
+  vars:
+    suite: pre
+tasks:
+- name: t1
+  commands:
+  - func: run tests
+    vars:
+      suite: unit
+      jobs: ${num_jobs}
+      verbose: "true"
+  - func: untyped
+    vars:
+      anything: goes
+`)
+		fn := p.Functions["run tests"]
+		require.NotNil(t, fn)
+		require.Len(t, fn.Parameters, 3)
+		assert.Equal(t, model.FunctionParameterTypeString, fn.Parameters[0].GetType())
+		assert.True(t, fn.Parameters[0].Required)
+		assert.Equal(t, "4", fn.Parameters[1].Default)
+		assert.Len(t, fn.List(), 1)
+
+		assert.Empty(t, validateFunctionParameters(p))
+	})
+	t.Run("FailsWithInvalidCalls", func(t *testing.T) {
+		p := loadProject(t, functions+`
+tasks:
+- name: t1
+  commands:
+  - func: run tests
+    vars:
+      jobs: four
+      verbose: "yes"
+      extra: value
+task_groups:
+- name: tg
+  setup_group:
+  - func: run tests
+  tasks:
+  - t1
+`)
+		errs := validateFunctionParameters(p)
+		require.Len(t, errs, 2)
+		for _, err := range errs {
+			assert.Equal(t, Error, err.Level)
+		}
+		assert.Contains(t, errs[0].Message, "task 't1'")
+		assert.Contains(t, errs[0].Message, "missing required parameter 'suite'")
+		assert.Contains(t, errs[0].Message, "'four' is not an int")
+		assert.Contains(t, errs[0].Message, "'yes' is not a bool")
+		assert.Contains(t, errs[0].Message, "'extra' is not a declared parameter")
+		assert.Contains(t, errs[1].Message, "setup group for task group 'tg'")
+	})
+	t.Run("FailsWithInvalidDeclarations", func(t *testing.T) {
+		p := loadProject(t, `
+functions:
+  f:
+    parameters:
+    - name: a
+      type: float
+    - name: b
+      type: int
+      default: one
+    - name: c
+      required: true
+      default: c
+    - name: c
+    commands:
+    - command: shell.exec
+`)
+		assert.Len(t, validateFunctionParameters(p), 4)
+	})
+}
+
 func TestValidateParameters(t *testing.T) {
 	p := &model.Project{
 		Parameters: []model.ParameterInfo{